@@ -22,6 +22,9 @@ const (
 	VolumeAPIName                // Volume API Name
 	VolumeType                   // Volume Type
 
+	// EC2 fields
+	PhysicalProcessor // Physical Processor
+
 	// EFSFileSystem
 	StorageClass    // Storage Class
 	AccessType      // Access Type
@@ -45,10 +48,19 @@ const (
 	// CloudWatch Alarm fields
 	AlarmType // Alarm Type
 
+	// MQ fields
+	BrokerEngine // Broker Engine
+
+	// Directory Service fields
+	DirectorySize // Directory Size
+	DirectoryType // Directory Type
+
 	///// Price Attributes /////
 	Currency      // Currency
+	RateCode      // RateCode
 	PricePerUnit  // PricePerUnit
 	StartingRange // StartingRange
+	EndingRange   // EndingRange
 	TermType      // TermType
 	Unit          // Unit
 )
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _FieldName = "SKUCapacityStatusGroupInstance TypeLocationOperating SystemPre Installed S/WProduct FamilyserviceCodeTenancyusageTypeVolume API NameVolume TypeStorage ClassAccess TypeThroughput ClassCache EngineDatabase EngineDatabase EditionDeployment OptionLicense ModelFile system typeStorage typeThroughput capacityDeployment optionAlarm TypeCurrencyPricePerUnitStartingRangeTermTypeUnit"
+const _FieldName = "SKUCapacityStatusGroupInstance TypeLocationOperating SystemPre Installed S/WProduct FamilyserviceCodeTenancyusageTypeVolume API NameVolume TypePhysical ProcessorStorage ClassAccess TypeThroughput ClassCache EngineDatabase EngineDatabase EditionDeployment OptionLicense ModelFile system typeStorage typeThroughput capacityDeployment optionAlarm TypeBroker EngineDirectory SizeDirectory TypeCurrencyRateCodePricePerUnitStartingRangeEndingRangeTermTypeUnit"
 
-var _FieldIndex = [...]uint16{0, 3, 17, 22, 35, 43, 59, 76, 90, 101, 108, 117, 132, 143, 156, 167, 183, 195, 210, 226, 243, 256, 272, 284, 303, 320, 330, 338, 350, 363, 371, 375}
+var _FieldIndex = [...]uint16{0, 3, 17, 22, 35, 43, 59, 76, 90, 101, 108, 117, 132, 143, 161, 174, 185, 201, 213, 228, 244, 261, 274, 290, 302, 321, 338, 348, 361, 375, 389, 397, 405, 417, 430, 441, 449, 453}
 
-const _FieldLowerName = "skucapacitystatusgroupinstance typelocationoperating systempre installed s/wproduct familyservicecodetenancyusagetypevolume api namevolume typestorage classaccess typethroughput classcache enginedatabase enginedatabase editiondeployment optionlicense modelfile system typestorage typethroughput capacitydeployment optionalarm typecurrencypriceperunitstartingrangetermtypeunit"
+const _FieldLowerName = "skucapacitystatusgroupinstance typelocationoperating systempre installed s/wproduct familyservicecodetenancyusagetypevolume api namevolume typephysical processorstorage classaccess typethroughput classcache enginedatabase enginedatabase editiondeployment optionlicense modelfile system typestorage typethroughput capacitydeployment optionalarm typebroker enginedirectory sizedirectory typecurrencyratecodepriceperunitstartingrangeendingrangetermtypeunit"
 
 func (i Field) String() string {
 	if i >= Field(len(_FieldIndex)-1) {
@@ -37,27 +37,33 @@ func _FieldNoOp() {
 	_ = x[UsageType-(10)]
 	_ = x[VolumeAPIName-(11)]
 	_ = x[VolumeType-(12)]
-	_ = x[StorageClass-(13)]
-	_ = x[AccessType-(14)]
-	_ = x[ThroughputClass-(15)]
-	_ = x[CacheEngine-(16)]
-	_ = x[DatabaseEngine-(17)]
-	_ = x[DatabaseEdition-(18)]
-	_ = x[DatabaseDeploymentOption-(19)]
-	_ = x[LicenseModel-(20)]
-	_ = x[FileSystemType-(21)]
-	_ = x[StorageType-(22)]
-	_ = x[ThroughputCapacity-(23)]
-	_ = x[FileSystemDeploymentOption-(24)]
-	_ = x[AlarmType-(25)]
-	_ = x[Currency-(26)]
-	_ = x[PricePerUnit-(27)]
-	_ = x[StartingRange-(28)]
-	_ = x[TermType-(29)]
-	_ = x[Unit-(30)]
+	_ = x[PhysicalProcessor-(13)]
+	_ = x[StorageClass-(14)]
+	_ = x[AccessType-(15)]
+	_ = x[ThroughputClass-(16)]
+	_ = x[CacheEngine-(17)]
+	_ = x[DatabaseEngine-(18)]
+	_ = x[DatabaseEdition-(19)]
+	_ = x[DatabaseDeploymentOption-(20)]
+	_ = x[LicenseModel-(21)]
+	_ = x[FileSystemType-(22)]
+	_ = x[StorageType-(23)]
+	_ = x[ThroughputCapacity-(24)]
+	_ = x[FileSystemDeploymentOption-(25)]
+	_ = x[AlarmType-(26)]
+	_ = x[BrokerEngine-(27)]
+	_ = x[DirectorySize-(28)]
+	_ = x[DirectoryType-(29)]
+	_ = x[Currency-(30)]
+	_ = x[RateCode-(31)]
+	_ = x[PricePerUnit-(32)]
+	_ = x[StartingRange-(33)]
+	_ = x[EndingRange-(34)]
+	_ = x[TermType-(35)]
+	_ = x[Unit-(36)]
 }
 
-var _FieldValues = []Field{SKU, CapacityStatus, Group, InstanceType, Location, OperatingSystem, PreInstalledSW, ProductFamily, ServiceCode, Tenancy, UsageType, VolumeAPIName, VolumeType, StorageClass, AccessType, ThroughputClass, CacheEngine, DatabaseEngine, DatabaseEdition, DatabaseDeploymentOption, LicenseModel, FileSystemType, StorageType, ThroughputCapacity, FileSystemDeploymentOption, AlarmType, Currency, PricePerUnit, StartingRange, TermType, Unit}
+var _FieldValues = []Field{SKU, CapacityStatus, Group, InstanceType, Location, OperatingSystem, PreInstalledSW, ProductFamily, ServiceCode, Tenancy, UsageType, VolumeAPIName, VolumeType, PhysicalProcessor, StorageClass, AccessType, ThroughputClass, CacheEngine, DatabaseEngine, DatabaseEdition, DatabaseDeploymentOption, LicenseModel, FileSystemType, StorageType, ThroughputCapacity, FileSystemDeploymentOption, AlarmType, BrokerEngine, DirectorySize, DirectoryType, Currency, RateCode, PricePerUnit, StartingRange, EndingRange, TermType, Unit}
 
 var _FieldNameToValueMap = map[string]Field{
 	_FieldName[0:3]:          SKU,
@@ -86,42 +92,54 @@ var _FieldNameToValueMap = map[string]Field{
 	_FieldLowerName[117:132]: VolumeAPIName,
 	_FieldName[132:143]:      VolumeType,
 	_FieldLowerName[132:143]: VolumeType,
-	_FieldName[143:156]:      StorageClass,
-	_FieldLowerName[143:156]: StorageClass,
-	_FieldName[156:167]:      AccessType,
-	_FieldLowerName[156:167]: AccessType,
-	_FieldName[167:183]:      ThroughputClass,
-	_FieldLowerName[167:183]: ThroughputClass,
-	_FieldName[183:195]:      CacheEngine,
-	_FieldLowerName[183:195]: CacheEngine,
-	_FieldName[195:210]:      DatabaseEngine,
-	_FieldLowerName[195:210]: DatabaseEngine,
-	_FieldName[210:226]:      DatabaseEdition,
-	_FieldLowerName[210:226]: DatabaseEdition,
-	_FieldName[226:243]:      DatabaseDeploymentOption,
-	_FieldLowerName[226:243]: DatabaseDeploymentOption,
-	_FieldName[243:256]:      LicenseModel,
-	_FieldLowerName[243:256]: LicenseModel,
-	_FieldName[256:272]:      FileSystemType,
-	_FieldLowerName[256:272]: FileSystemType,
-	_FieldName[272:284]:      StorageType,
-	_FieldLowerName[272:284]: StorageType,
-	_FieldName[284:303]:      ThroughputCapacity,
-	_FieldLowerName[284:303]: ThroughputCapacity,
-	_FieldName[303:320]:      FileSystemDeploymentOption,
-	_FieldLowerName[303:320]: FileSystemDeploymentOption,
-	_FieldName[320:330]:      AlarmType,
-	_FieldLowerName[320:330]: AlarmType,
-	_FieldName[330:338]:      Currency,
-	_FieldLowerName[330:338]: Currency,
-	_FieldName[338:350]:      PricePerUnit,
-	_FieldLowerName[338:350]: PricePerUnit,
-	_FieldName[350:363]:      StartingRange,
-	_FieldLowerName[350:363]: StartingRange,
-	_FieldName[363:371]:      TermType,
-	_FieldLowerName[363:371]: TermType,
-	_FieldName[371:375]:      Unit,
-	_FieldLowerName[371:375]: Unit,
+	_FieldName[143:161]:      PhysicalProcessor,
+	_FieldLowerName[143:161]: PhysicalProcessor,
+	_FieldName[161:174]:      StorageClass,
+	_FieldLowerName[161:174]: StorageClass,
+	_FieldName[174:185]:      AccessType,
+	_FieldLowerName[174:185]: AccessType,
+	_FieldName[185:201]:      ThroughputClass,
+	_FieldLowerName[185:201]: ThroughputClass,
+	_FieldName[201:213]:      CacheEngine,
+	_FieldLowerName[201:213]: CacheEngine,
+	_FieldName[213:228]:      DatabaseEngine,
+	_FieldLowerName[213:228]: DatabaseEngine,
+	_FieldName[228:244]:      DatabaseEdition,
+	_FieldLowerName[228:244]: DatabaseEdition,
+	_FieldName[244:261]:      DatabaseDeploymentOption,
+	_FieldLowerName[244:261]: DatabaseDeploymentOption,
+	_FieldName[261:274]:      LicenseModel,
+	_FieldLowerName[261:274]: LicenseModel,
+	_FieldName[274:290]:      FileSystemType,
+	_FieldLowerName[274:290]: FileSystemType,
+	_FieldName[290:302]:      StorageType,
+	_FieldLowerName[290:302]: StorageType,
+	_FieldName[302:321]:      ThroughputCapacity,
+	_FieldLowerName[302:321]: ThroughputCapacity,
+	_FieldName[321:338]:      FileSystemDeploymentOption,
+	_FieldLowerName[321:338]: FileSystemDeploymentOption,
+	_FieldName[338:348]:      AlarmType,
+	_FieldLowerName[338:348]: AlarmType,
+	_FieldName[348:361]:      BrokerEngine,
+	_FieldLowerName[348:361]: BrokerEngine,
+	_FieldName[361:375]:      DirectorySize,
+	_FieldLowerName[361:375]: DirectorySize,
+	_FieldName[375:389]:      DirectoryType,
+	_FieldLowerName[375:389]: DirectoryType,
+	_FieldName[389:397]:      Currency,
+	_FieldLowerName[389:397]: Currency,
+	_FieldName[397:405]:      RateCode,
+	_FieldLowerName[397:405]: RateCode,
+	_FieldName[405:417]:      PricePerUnit,
+	_FieldLowerName[405:417]: PricePerUnit,
+	_FieldName[417:430]:      StartingRange,
+	_FieldLowerName[417:430]: StartingRange,
+	_FieldName[430:441]:      EndingRange,
+	_FieldLowerName[430:441]: EndingRange,
+	_FieldName[441:449]:      TermType,
+	_FieldLowerName[441:449]: TermType,
+	_FieldName[449:453]:      Unit,
+	_FieldLowerName[449:453]: Unit,
 }
 
 var _FieldNames = []string{
@@ -138,24 +156,30 @@ var _FieldNames = []string{
 	_FieldName[108:117],
 	_FieldName[117:132],
 	_FieldName[132:143],
-	_FieldName[143:156],
-	_FieldName[156:167],
-	_FieldName[167:183],
-	_FieldName[183:195],
-	_FieldName[195:210],
-	_FieldName[210:226],
-	_FieldName[226:243],
-	_FieldName[243:256],
-	_FieldName[256:272],
-	_FieldName[272:284],
-	_FieldName[284:303],
-	_FieldName[303:320],
-	_FieldName[320:330],
-	_FieldName[330:338],
-	_FieldName[338:350],
-	_FieldName[350:363],
-	_FieldName[363:371],
-	_FieldName[371:375],
+	_FieldName[143:161],
+	_FieldName[161:174],
+	_FieldName[174:185],
+	_FieldName[185:201],
+	_FieldName[201:213],
+	_FieldName[213:228],
+	_FieldName[228:244],
+	_FieldName[244:261],
+	_FieldName[261:274],
+	_FieldName[274:290],
+	_FieldName[290:302],
+	_FieldName[302:321],
+	_FieldName[321:338],
+	_FieldName[338:348],
+	_FieldName[348:361],
+	_FieldName[361:375],
+	_FieldName[375:389],
+	_FieldName[389:397],
+	_FieldName[397:405],
+	_FieldName[405:417],
+	_FieldName[417:430],
+	_FieldName[430:441],
+	_FieldName[441:449],
+	_FieldName[449:453],
 }
 
 // FieldString retrieves an enum value from the enum constants string name.
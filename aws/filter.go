@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"strings"
+
+	"github.com/cycloidio/terracost/aws/region"
 	"github.com/cycloidio/terracost/price"
 )
 
@@ -16,6 +19,10 @@ func DefaultFilter(_ *price.WithProduct) bool {
 // MinimalFilter only ingests the supported records, skipping those that would never be used.
 func MinimalFilter(pp *price.WithProduct) bool {
 	switch pp.Product.Service {
+	case "AmazonApiGateway":
+		return true // is minimal already
+	case "AmazonCloudFront":
+		return minimalFilterCloudFront(pp)
 	case "AmazonCloudWatch":
 		return minimalFilterCloudWatch(pp)
 	case "AmazonEC2":
@@ -28,12 +35,28 @@ func MinimalFilter(pp *price.WithProduct) bool {
 		return true // is minimal already
 	case "AmazonFSx":
 		return true
+	case "AmazonMQ":
+		return true // is minimal already
+	case "AmazonMSK":
+		return true // is minimal already
 	case "AmazonRDS":
 		return minimalFilterRDS(pp)
+	case "AmazonRoute53":
+		return minimalFilterRoute53(pp)
 	case "AmazonS3":
 		return minimalFilterS3Bucket(pp)
+	case "AmazonSageMaker":
+		return minimalFilterSageMaker(pp)
+	case "AmazonSNS":
+		return minimalFilterSNS(pp)
+	case "AmazonVPC":
+		return minimalFilterVPC(pp)
+	case "AWSBackup":
+		return true // is minimal already
 	case "AWSDataTransfer":
 		return true
+	case "AWSDirectoryService":
+		return true // is minimal already
 	case "AWSELB":
 		return true // is minimal already
 	case "awskms":
@@ -42,11 +65,77 @@ func MinimalFilter(pp *price.WithProduct) bool {
 		return true // is minimal already
 	case "AWSSecretsManager":
 		return true // is minimal already
+	case "AWSTransfer":
+		return true // is minimal already
+	case "awswaf":
+		return true // is minimal already
 	default:
 		return false
 	}
 }
 
+// AllFilters returns an IngestionFilter that ingests the records accepted by all the filters.
+func AllFilters(filters ...IngestionFilter) IngestionFilter {
+	return func(pp *price.WithProduct) bool {
+		for _, f := range filters {
+			if !f(pp) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyFilter returns an IngestionFilter that ingests the records accepted by at least one of the filters.
+func AnyFilter(filters ...IngestionFilter) IngestionFilter {
+	return func(pp *price.WithProduct) bool {
+		for _, f := range filters {
+			if f(pp) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FamilyFilter only ingests the records of the product families (ex: "Compute Instance").
+func FamilyFilter(families ...string) IngestionFilter {
+	return func(pp *price.WithProduct) bool {
+		return isValueAllowed(pp.Product.Family, families)
+	}
+}
+
+// LocationFilter only ingests the records of the regions, the global
+// records (ex: Route53) are only ingested if region.Global is one of them.
+func LocationFilter(regions ...region.Code) IngestionFilter {
+	return func(pp *price.WithProduct) bool {
+		for _, r := range regions {
+			if pp.Product.Location == r.String() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// InstanceTypeFilter only ingests the instances with a type starting by one of the
+// prefixes (ex: "m" and "c5." for all the M families and the C5 instances), the
+// records without instance type (ex: storage) are always ingested.
+func InstanceTypeFilter(prefixes ...string) IngestionFilter {
+	return func(pp *price.WithProduct) bool {
+		it, ok := pp.Product.Attributes["InstanceType"]
+		if !ok || it == "" {
+			return true
+		}
+		for _, p := range prefixes {
+			if strings.HasPrefix(it, p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // minimalFilterEC2 only ingests storage-related records as well as compute records that match supported attributes.
 func minimalFilterEC2(pp *price.WithProduct) bool {
 	switch pp.Product.Family {
@@ -55,7 +144,7 @@ func minimalFilterEC2(pp *price.WithProduct) bool {
 			"CapacityStatus":  {"Used"},
 			"OperatingSystem": {"Linux"},
 			"PreInstalledSW":  {"NA"},
-			"Tenancy":         {"Shared", "Dedicated"},
+			"Tenancy":         {"Shared", "Dedicated", "Host"},
 		}
 		for k, vals := range allowedProductAttrs {
 			if !isValueAllowed(pp.Product.Attributes[k], vals) {
@@ -63,7 +152,7 @@ func minimalFilterEC2(pp *price.WithProduct) bool {
 			}
 		}
 		return true
-	case "Storage", "System Operation", "NAT Gateway":
+	case "Storage", "System Operation", "NAT Gateway", "CPU Credits", "Dedicated Host", "Storage Snapshot":
 		return true
 	default:
 		return false
@@ -73,7 +162,7 @@ func minimalFilterEC2(pp *price.WithProduct) bool {
 // minimalFilterCloudWatch only ingests records of supported product families.
 func minimalFilterCloudWatch(pp *price.WithProduct) bool {
 	switch pp.Product.Family {
-	case "Data Payload", "Storage Snapshot", "Alarm":
+	case "Data Payload", "Storage Snapshot", "Alarm", "Metric":
 		return true
 	default:
 		return false
@@ -90,6 +179,47 @@ func minimalFilterRDS(pp *price.WithProduct) bool {
 	}
 }
 
+// minimalFilterCloudFront only ingests the data transfer and requests records.
+func minimalFilterCloudFront(pp *price.WithProduct) bool {
+	switch pp.Product.Family {
+	case "Data Transfer", "Request":
+		return true
+	default:
+		return false
+	}
+}
+
+// minimalFilterVPC only ingests the VPC endpoints (PrivateLink) records.
+func minimalFilterVPC(pp *price.WithProduct) bool {
+	return pp.Product.Family == "VpcEndpoint"
+}
+
+// minimalFilterRoute53 only ingests the hosted zones and standard queries records.
+func minimalFilterRoute53(pp *price.WithProduct) bool {
+	switch pp.Product.Family {
+	case "DNS Zone", "DNS Query":
+		return true
+	default:
+		return false
+	}
+}
+
+// minimalFilterSNS only ingests the requests and notifications delivery records.
+func minimalFilterSNS(pp *price.WithProduct) bool {
+	switch pp.Product.Family {
+	case "API Request", "Message Delivery":
+		return true
+	default:
+		return false
+	}
+}
+
+// minimalFilterSageMaker only ingests the records of the real-time endpoints and notebook instances.
+func minimalFilterSageMaker(pp *price.WithProduct) bool {
+	ut := pp.Product.Attributes["UsageType"]
+	return strings.Contains(ut, "Host:") || strings.Contains(ut, "Notebk:")
+}
+
 func minimalFilterS3Bucket(pp *price.WithProduct) bool {
 	switch pp.Product.Family {
 	case "Storage", "API Request", "Fee":
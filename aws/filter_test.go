@@ -12,6 +12,8 @@ import (
 func TestMinimalFilter(t *testing.T) {
 	t.Run("Allowed", func(t *testing.T) {
 		pps := []*price.WithProduct{
+			{Product: &product.Product{Service: "AmazonCloudFront", Family: "Data Transfer"}},
+			{Product: &product.Product{Service: "AmazonCloudFront", Family: "Request"}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "Storage"}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "System Operation"}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance", Attributes: map[string]string{
@@ -26,10 +28,25 @@ func TestMinimalFilter(t *testing.T) {
 				"PreInstalledSW":  "NA",
 				"Tenancy":         "Dedicated",
 			}}},
+			{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance", Attributes: map[string]string{
+				"CapacityStatus":  "Used",
+				"OperatingSystem": "Linux",
+				"PreInstalledSW":  "NA",
+				"Tenancy":         "Host",
+			}}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "NAT Gateway"}},
+			{Product: &product.Product{Service: "AmazonEC2", Family: "CPU Credits"}},
+			{Product: &product.Product{Service: "AmazonEC2", Family: "Dedicated Host"}},
+			{Product: &product.Product{Service: "AmazonVPC", Family: "VpcEndpoint"}},
 			{Product: &product.Product{Service: "AmazonRDS", Family: "Database Instance"}},
 			{Product: &product.Product{Service: "AmazonRDS", Family: "Database Storage"}},
 			{Product: &product.Product{Service: "AmazonRDS", Family: "Provisioned IOPS"}},
+			{Product: &product.Product{Service: "AmazonRoute53", Family: "DNS Zone"}},
+			{Product: &product.Product{Service: "AmazonRoute53", Family: "DNS Query"}},
+			{Product: &product.Product{Service: "AmazonSNS", Family: "API Request"}},
+			{Product: &product.Product{Service: "AmazonSNS", Family: "Message Delivery"}},
+			{Product: &product.Product{Service: "AmazonSageMaker", Family: "ML Instance", Attributes: map[string]string{"UsageType": "EUW3-Host:ml.m5.large"}}},
+			{Product: &product.Product{Service: "AmazonSageMaker", Family: "ML Instance", Attributes: map[string]string{"UsageType": "EUW3-Notebk:ml.t3.medium"}}},
 		}
 
 		for i, pp := range pps {
@@ -39,14 +56,9 @@ func TestMinimalFilter(t *testing.T) {
 
 	t.Run("Skipped", func(t *testing.T) {
 		pps := []*price.WithProduct{
+			{Product: &product.Product{Service: "AmazonCloudFront", Family: "Invalidations"}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance (bare metal)"}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "Fee"}},
-			{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance", Attributes: map[string]string{
-				"CapacityStatus":  "Used",
-				"OperatingSystem": "Linux",
-				"PreInstalledSW":  "NA",
-				"Tenancy":         "Host",
-			}}},
 			{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance", Attributes: map[string]string{
 				"CapacityStatus":  "Used",
 				"OperatingSystem": "SUSE",
@@ -69,6 +81,10 @@ func TestMinimalFilter(t *testing.T) {
 			{Product: &product.Product{Service: "AmazonRDS", Family: "Performance Insights"}},
 			{Product: &product.Product{Service: "AmazonRDS", Family: "RDSProxy"}},
 			{Product: &product.Product{Service: "AmazonRDS", Family: "Serverless"}},
+			{Product: &product.Product{Service: "AmazonRoute53", Family: "DNS Health Check"}},
+			{Product: &product.Product{Service: "AmazonVPC", Family: "VPN Connection"}},
+			{Product: &product.Product{Service: "AmazonSNS", Family: "SMS"}},
+			{Product: &product.Product{Service: "AmazonSageMaker", Family: "ML Instance", Attributes: map[string]string{"UsageType": "EUW3-Train:ml.m5.large"}}},
 		}
 
 		for i, pp := range pps {
@@ -76,3 +92,49 @@ func TestMinimalFilter(t *testing.T) {
 		}
 	})
 }
+
+func TestFilters(t *testing.T) {
+	m5 := &price.WithProduct{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance", Location: "eu-west-3", Attributes: map[string]string{"InstanceType": "m5.xlarge"}}}
+	t3 := &price.WithProduct{Product: &product.Product{Service: "AmazonEC2", Family: "Compute Instance", Location: "eu-west-3", Attributes: map[string]string{"InstanceType": "t3.micro"}}}
+	gp2 := &price.WithProduct{Product: &product.Product{Service: "AmazonEC2", Family: "Storage", Location: "us-east-1", Attributes: map[string]string{"VolumeAPIName": "gp2"}}}
+	zone := &price.WithProduct{Product: &product.Product{Service: "AmazonRoute53", Family: "DNS Zone", Location: "global"}}
+
+	t.Run("FamilyFilter", func(t *testing.T) {
+		f := FamilyFilter("Compute Instance", "DNS Zone")
+		assert.True(t, f(m5))
+		assert.True(t, f(zone))
+		assert.False(t, f(gp2))
+	})
+
+	t.Run("LocationFilter", func(t *testing.T) {
+		f := LocationFilter("eu-west-3")
+		assert.True(t, f(m5))
+		assert.False(t, f(gp2))
+		assert.False(t, f(zone))
+	})
+
+	t.Run("InstanceTypeFilter", func(t *testing.T) {
+		f := InstanceTypeFilter("m", "c")
+		assert.True(t, f(m5))
+		assert.False(t, f(t3))
+		assert.True(t, f(gp2))
+	})
+
+	t.Run("AllFilters", func(t *testing.T) {
+		f := AllFilters(MinimalFilter, LocationFilter("eu-west-3"), InstanceTypeFilter("m"))
+		m5.Product.Attributes["CapacityStatus"] = "Used"
+		m5.Product.Attributes["OperatingSystem"] = "Linux"
+		m5.Product.Attributes["PreInstalledSW"] = "NA"
+		m5.Product.Attributes["Tenancy"] = "Shared"
+		assert.True(t, f(m5))
+		assert.False(t, f(t3))
+		assert.False(t, f(gp2))
+	})
+
+	t.Run("AnyFilter", func(t *testing.T) {
+		f := AnyFilter(LocationFilter("global"), InstanceTypeFilter("t"))
+		assert.True(t, f(zone))
+		assert.True(t, f(t3))
+		assert.False(t, f(m5))
+	})
+}
@@ -4,13 +4,16 @@ import (
 	"bufio"
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/machinebox/progress"
 	"github.com/shopspring/decimal"
+	"golang.org/x/time/rate"
 
 	"github.com/cycloidio/terracost/aws/field"
 	"github.com/cycloidio/terracost/aws/region"
@@ -23,16 +26,30 @@ const ProviderName = "aws"
 
 const (
 	defaultPricingURL = "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws"
+	// chinaPricingURL is the pricing URL of the China partition, the GovCloud
+	// regions are on the offer files of the defaultPricingURL
+	chinaPricingURL   = "https://pricing.cn-north-1.amazonaws.com.cn/offers/v1.0/cn"
 	defaultBufferSize = 100 * 1024 * 1024 // 100 MiB
+
+	// pricingService is the name of the service the signed requests are for
+	// and the signing regions are the ones of the default pricing URLs
+	pricingService       = "pricing"
+	defaultSigningRegion = "us-east-1"
+	chinaSigningRegion   = "cn-north-1"
 )
 
 // Ingester is used to load the pricing data from AWS offer files into a database. It is one-use only and
 // should be discarded after the ingestion is complete.
 type Ingester struct {
 	httpClient HTTPClient
+	limiter    *rate.Limiter
 	pricingURL string
 	bufferSize uint
 
+	// signer is only set by WithAWSConfig when credentials are given
+	signer        *v4.Signer
+	signingRegion string
+
 	service string
 	region  string
 
@@ -41,24 +58,35 @@ type Ingester struct {
 
 	ingestionFilter IngestionFilter
 
+	// incremental is set by SetSince, since is the offer file version
+	// of the previous ingestion and mark the one ingested
+	incremental bool
+	since       string
+	mark        string
+
 	err error
 }
 
 // NewIngester returns a new Ingester using the given options. Only the repositories must be provided using the
 // WithRepositories function, other configuration options will use their default values.
 // The service must be a valid AWS service name that is supported by Terracost, otherwise this function will
-// return an error.
-func NewIngester(service, region string, options ...Option) (*Ingester, error) {
+// return an error. The regions of the China partition (ex: cn-north-1) are ingested from the China pricing URL.
+func NewIngester(service, rgn string, options ...Option) (*Ingester, error) {
 	if !IsServiceSupported(service) {
 		return nil, fmt.Errorf("service not supported: %s", service)
 	}
 
+	pricingURL := defaultPricingURL
+	if region.Code(rgn).Partition() == region.PartitionChina {
+		pricingURL = chinaPricingURL
+	}
+
 	ing := &Ingester{
 		httpClient:      http.DefaultClient,
-		pricingURL:      defaultPricingURL,
+		pricingURL:      pricingURL,
 		bufferSize:      defaultBufferSize,
 		service:         service,
-		region:          region,
+		region:          rgn,
 		progressCh:      nil,
 		ingestionFilter: DefaultFilter,
 	}
@@ -77,6 +105,28 @@ func (ing *Ingester) Ingest(ctx context.Context, chSize int) <-chan *price.WithP
 	go func() {
 		defer close(results)
 
+		if ing.incremental {
+			version, err := ing.currentVersion(ctx)
+			if err != nil {
+				ing.err = err
+				return
+			}
+			// AWS does not publish the changes between the versions of
+			// an offer file so it's fully ingested if it has changed
+			if version == ing.since {
+				ing.mark = version
+				if ing.progressCh != nil {
+					close(ing.progressCh)
+				}
+				return
+			}
+			defer func() {
+				if ing.err == nil {
+					ing.mark = version
+				}
+			}()
+		}
+
 		url := ing.pricingURL + "/" + ing.service + "/current/" + ing.region + "/index.csv"
 		rc, size, err := ing.download(ctx, url)
 		if err != nil {
@@ -164,6 +214,50 @@ func (ing *Ingester) Err() error {
 	return ing.err
 }
 
+// MarkKey returns the key of the ingested offer file, see terracost.IncrementalIngester.
+func (ing *Ingester) MarkKey() string {
+	return ProviderName + "/" + ing.service + "/" + ing.region
+}
+
+// SetSince sets the version of the offer file of the previous ingestion, the offer file is
+// only ingested if its current version is a different one, see terracost.IncrementalIngester.
+func (ing *Ingester) SetSince(mark string) {
+	ing.incremental = true
+	ing.since = mark
+}
+
+// Mark returns the version of the offer file ingested, see terracost.IncrementalIngester.
+func (ing *Ingester) Mark() string {
+	return ing.mark
+}
+
+// regionIndex is the index of the current offer files of a service by region
+type regionIndex struct {
+	Regions map[string]struct {
+		CurrentVersionURL string `json:"currentVersionUrl"`
+	} `json:"regions"`
+}
+
+// currentVersion returns the URL of the current version of the offer file of the service
+// and region (ex: /offers/v1.0/aws/AmazonEC2/20240207192422/eu-west-3/index.json)
+func (ing *Ingester) currentVersion(ctx context.Context) (string, error) {
+	rc, _, err := ing.download(ctx, ing.pricingURL+"/"+ing.service+"/current/region_index.json")
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	var ri regionIndex
+	if err := json.NewDecoder(rc).Decode(&ri); err != nil {
+		return "", fmt.Errorf("failed to decode the region index: %w", err)
+	}
+	r, ok := ri.Regions[ing.region]
+	if !ok || r.CurrentVersionURL == "" {
+		return "", fmt.Errorf("no offer file for the region %s", ing.region)
+	}
+	return r.CurrentVersionURL, nil
+}
+
 // download is a helper that performs an HTTP GET request and returns the body of the response. The returned
 // io.ReadCloser must be manually closed after use.
 func (ing *Ingester) download(ctx context.Context, url string) (io.ReadCloser, int64, error) {
@@ -171,6 +265,16 @@ func (ing *Ingester) download(ctx context.Context, url string) (io.ReadCloser, i
 	if err != nil {
 		return nil, 0, err
 	}
+	if ing.limiter != nil {
+		if err := ing.limiter.Wait(ctx); err != nil {
+			return nil, 0, err
+		}
+	}
+	if ing.signer != nil {
+		if _, err := ing.signer.Sign(req, nil, pricingService, ing.signingRegion, time.Now()); err != nil {
+			return nil, 0, fmt.Errorf("failed to sign the request: %w", err)
+		}
+	}
 	resp, err := ing.httpClient.Do(req)
 	if err != nil {
 		return nil, 0, err
@@ -178,6 +282,22 @@ func (ing *Ingester) download(ctx context.Context, url string) (io.ReadCloser, i
 	return resp.Body, resp.ContentLength, nil
 }
 
+// pricingEndpoint returns the endpoint of the pricing service of the rgn.
+func pricingEndpoint(rgn string) string {
+	if region.Code(rgn).Partition() == region.PartitionChina {
+		return "https://pricing." + rgn + ".amazonaws.com.cn"
+	}
+	return "https://pricing." + rgn + ".amazonaws.com"
+}
+
+// offersPath returns the path of the offer files on the pricing endpoint of the rgn.
+func offersPath(rgn string) string {
+	if region.Code(rgn).Partition() == region.PartitionChina {
+		return "/offers/v1.0/cn"
+	}
+	return "/offers/v1.0/aws"
+}
+
 // readColumnPositions maps column names to their position in the CSV file.
 func readColumnPositions(values []string) map[string]int {
 	columns := make(map[string]int)
@@ -201,6 +321,9 @@ var columnProductToIngest = map[field.Field]string{
 	field.VolumeAPIName:   "VolumeAPIName",
 	field.VolumeType:      "VolumeType",
 
+	// EC2 attributes
+	field.PhysicalProcessor: "PhysicalProcessor",
+
 	// EFS attributes
 	field.StorageClass:    "StorageClass",
 	field.AccessType:      "AccessType",
@@ -223,13 +346,22 @@ var columnProductToIngest = map[field.Field]string{
 
 	// Cloudwatch alarms
 	field.AlarmType: "AlarmType",
+
+	// MQ fields
+	field.BrokerEngine: "BrokerEngine",
+
+	// Directory Service fields
+	field.DirectorySize: "DirectorySize",
+	field.DirectoryType: "DirectoryType",
 }
 
 // columnPriceToIngest is a mapping from column title to the price.Price attribute name under which the value will
 // be stored.
 var columnPriceToIngest = map[field.Field]string{
 	field.StartingRange: "StartingRange",
+	field.EndingRange:   "EndingRange",
 	field.TermType:      "TermType",
+	field.RateCode:      price.RateCodeAttribute,
 }
 
 func newPriceWithProduct(values map[field.Field]string) (*price.WithProduct, error) {
@@ -277,5 +409,12 @@ func newProduct(values map[field.Field]string) *product.Product {
 		Location:   region.NewFromName(values[field.Location]).String(),
 		Attributes: attributes,
 	}
+
+	// The CloudFront locations are the edge regions (ex: Europe) which are
+	// not AWS regions, they are part of the UsageType (ex: EU-Requests-Tier1)
+	if prod.Service == "AmazonCloudFront" {
+		prod.Location = region.Global.String()
+	}
+
 	return prod
 }
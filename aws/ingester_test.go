@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -109,6 +112,256 @@ func TestIngester_Ingest(t *testing.T) {
 		assert.False(t, ok, "Results channel should be closed")
 		assert.NoError(t, ing.Err())
 	})
+
+	t.Run("IngestionFilter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock.NewHTTPClient(ctrl)
+		ing, err := NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client), WithIngestionFilter(FamilyFilter("Compute Instance"), InstanceTypeFilter("m", "c")))
+		require.NoError(t, err)
+
+		content := makeCSV([][]string{
+			{"SKU", "Product Family", "serviceCode", "TermType", "Location", "Unit", "Currency", "PricePerUnit", "Tenancy", "Instance Type", "Operating System", "Volume API Name"},
+			{"prod1", "Compute Instance", "AmazonEC2", "OnDemand", "EU (Paris)", "Hrs", "USD", "1.234", "Shared", "m5.xlarge", "Linux", ""},
+			{"prod2", "Compute Instance", "AmazonEC2", "OnDemand", "EU (Paris)", "Hrs", "USD", "0.123", "Shared", "t3.micro", "Linux", ""},
+			{"prod3", "Compute Instance", "AmazonEC2", "OnDemand", "EU (Paris)", "Hrs", "USD", "0.987", "Shared", "c5.large", "Linux", ""},
+			{"prod4", "Storage", "AmazonEC2", "OnDemand", "EU (Paris)", "GB-Mo", "USD", "0.456", "", "", "", "gp2"},
+		})
+		rd := strings.NewReader(content)
+		res := &http.Response{Body: ioutil.NopCloser(rd)}
+
+		client.EXPECT().Do(gomock.Any()).Return(res, nil)
+
+		results := ing.Ingest(context.Background(), 1)
+
+		skus := make([]string, 0)
+		for pp := range results {
+			skus = append(skus, pp.Product.SKU)
+		}
+		assert.Equal(t, []string{"prod1", "prod3"}, skus)
+		assert.NoError(t, ing.Err())
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		ecs := func() *http.Response {
+			content := makeCSV([][]string{
+				{"SKU", "Product Family", "serviceCode", "TermType", "Location", "Unit", "Currency", "PricePerUnit", "Tenancy", "Instance Type", "Operating System", "Volume API Name"},
+				{"prod1", "Compute Instance", "AmazonEC2", "OnDemand", "EU (Paris)", "Hrs", "USD", "1.234", "Shared", "m5.xlarge", "Linux", ""},
+			})
+			return &http.Response{Body: ioutil.NopCloser(strings.NewReader(content))}
+		}
+
+		t.Run("Success", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock.NewHTTPClient(ctrl)
+			client.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) { return ecs(), nil }).Times(4)
+
+			// The limit is shared by the ingesters given the same Option
+			rateLimit := WithRateLimit(5)
+
+			start := time.Now()
+			for n := 0; n < 4; n++ {
+				ing, err := NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client), rateLimit)
+				require.NoError(t, err)
+
+				for range ing.Ingest(context.Background(), 1) {
+				}
+				require.NoError(t, ing.Err())
+			}
+
+			// The first download is done right away and the 3 next each 200ms
+			assert.GreaterOrEqual(t, time.Since(start), 600*time.Millisecond)
+		})
+
+		t.Run("Canceled", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock.NewHTTPClient(ctrl)
+			client.EXPECT().Do(gomock.Any()).Return(ecs(), nil)
+
+			rateLimit := WithRateLimit(1)
+			ctx, cancel := context.WithCancel(context.Background())
+
+			ing, err := NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client), rateLimit)
+			require.NoError(t, err)
+			for range ing.Ingest(ctx, 1) {
+			}
+			require.NoError(t, ing.Err())
+
+			// The next download would have to wait 1s but the context is canceled
+			cancel()
+			ing, err = NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client), rateLimit)
+			require.NoError(t, err)
+			for range ing.Ingest(ctx, 1) {
+			}
+			assert.Error(t, ing.Err())
+		})
+
+		t.Run("Ignored", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock.NewHTTPClient(ctrl)
+			client.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) { return ecs(), nil }).Times(2)
+
+			// A limit of 0 would block all the downloads after the first one
+			rateLimit := WithRateLimit(0)
+			for n := 0; n < 2; n++ {
+				ing, err := NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client), rateLimit)
+				require.NoError(t, err)
+				assert.Nil(t, ing.limiter)
+
+				for range ing.Ingest(context.Background(), 1) {
+				}
+				require.NoError(t, ing.Err())
+			}
+		})
+	})
+
+	t.Run("Incremental", func(t *testing.T) {
+		const version = "/offers/v1.0/aws/AmazonEC2/20240207192422/eu-west-3/index.json"
+		regionIndex := func() *http.Response {
+			body := `{"regions": {"eu-west-3": {"regionCode": "eu-west-3", "currentVersionUrl": "` + version + `"}}}`
+			return &http.Response{Body: ioutil.NopCloser(strings.NewReader(body))}
+		}
+
+		t.Run("Unchanged", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock.NewHTTPClient(ctrl)
+			ing, err := NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client))
+			require.NoError(t, err)
+			ing.SetSince(version)
+
+			client.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/region_index.json", req.URL.String())
+				return regionIndex(), nil
+			})
+
+			var count int
+			for range ing.Ingest(context.Background(), 1) {
+				count++
+			}
+			assert.NoError(t, ing.Err())
+			assert.Equal(t, 0, count)
+			assert.Equal(t, version, ing.Mark())
+			assert.Equal(t, "aws/AmazonEC2/eu-west-3", ing.MarkKey())
+		})
+
+		t.Run("Changed", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock.NewHTTPClient(ctrl)
+			ing, err := NewIngester("AmazonEC2", "eu-west-3", WithHTTPClient(client))
+			require.NoError(t, err)
+			ing.SetSince("/offers/v1.0/aws/AmazonEC2/20240101000000/eu-west-3/index.json")
+
+			content := makeCSV([][]string{
+				{"SKU", "Product Family", "serviceCode", "TermType", "Location", "Unit", "Currency", "PricePerUnit", "Tenancy", "Instance Type", "Operating System", "Volume API Name"},
+				{"prod1", "Compute Instance", "AmazonEC2", "OnDemand", "EU (Paris)", "Hrs", "USD", "1.234", "Shared", "m5.xlarge", "Linux", ""},
+			})
+			gomock.InOrder(
+				client.EXPECT().Do(gomock.Any()).Return(regionIndex(), nil),
+				client.EXPECT().Do(gomock.Any()).Return(&http.Response{Body: ioutil.NopCloser(strings.NewReader(content))}, nil),
+			)
+
+			var count int
+			for range ing.Ingest(context.Background(), 1) {
+				count++
+			}
+			assert.NoError(t, ing.Err())
+			assert.Equal(t, 1, count)
+			assert.Equal(t, version, ing.Mark())
+		})
+	})
+
+	t.Run("AWSConfig", func(t *testing.T) {
+		content := makeCSV([][]string{
+			{"SKU", "Product Family", "serviceCode", "TermType", "Location", "Unit", "Currency", "PricePerUnit", "Tenancy", "Instance Type", "Operating System", "Volume API Name"},
+			{"prod1", "Compute Instance", "AmazonEC2", "OnDemand", "China (Ningxia)", "Hrs", "CNY", "1.234", "Shared", "m5.xlarge", "Linux", ""},
+		})
+
+		var req *http.Request
+		client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			req = r
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(content))}, nil
+		})}
+
+		ing, err := NewIngester("AmazonEC2", "cn-northwest-1", WithAWSConfig(awssdk.Config{
+			Region:      awssdk.String("cn-northwest-1"),
+			Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			HTTPClient:  client,
+		}))
+		require.NoError(t, err)
+
+		var count int
+		for range ing.Ingest(context.Background(), 1) {
+			count++
+		}
+		require.NoError(t, ing.Err())
+		assert.Equal(t, 1, count)
+
+		require.NotNil(t, req)
+		assert.Equal(t, "https://pricing.cn-northwest-1.amazonaws.com.cn/offers/v1.0/cn/AmazonEC2/current/cn-northwest-1/index.csv", req.URL.String())
+		assert.Contains(t, req.Header.Get("Authorization"), "Credential=AKID/")
+		assert.Contains(t, req.Header.Get("Authorization"), "/cn-northwest-1/pricing/aws4_request")
+
+		t.Run("Endpoint", func(t *testing.T) {
+			ing, err := NewIngester("AmazonEC2", "eu-west-3", WithAWSConfig(awssdk.Config{
+				Endpoint:   awssdk.String("https://pricing.example.com/"),
+				HTTPClient: client,
+			}))
+			require.NoError(t, err)
+
+			for range ing.Ingest(context.Background(), 1) {
+			}
+			require.NoError(t, ing.Err())
+			assert.Equal(t, "https://pricing.example.com/offers/v1.0/aws/AmazonEC2/current/eu-west-3/index.csv", req.URL.String())
+			assert.Empty(t, req.Header.Get("Authorization"))
+		})
+	})
+
+	t.Run("Partitions", func(t *testing.T) {
+		testcases := []struct{ region, location, url string }{
+			{"us-gov-west-1", "AWS GovCloud (US-West)", "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/us-gov-west-1/index.csv"},
+			{"cn-north-1", "China (Beijing)", "https://pricing.cn-north-1.amazonaws.com.cn/offers/v1.0/cn/AmazonEC2/current/cn-north-1/index.csv"},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.region, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				client := mock.NewHTTPClient(ctrl)
+				ing, err := NewIngester("AmazonEC2", tc.region, WithHTTPClient(client))
+				require.NoError(t, err)
+
+				content := makeCSV([][]string{
+					{"SKU", "Product Family", "serviceCode", "TermType", "Location", "Unit", "Currency", "PricePerUnit", "Tenancy", "Instance Type", "Operating System", "Volume API Name"},
+					{"prod1", "Compute Instance", "AmazonEC2", "OnDemand", tc.location, "Hrs", "USD", "1.234", "Shared", "m5.xlarge", "Linux", ""},
+				})
+				rd := strings.NewReader(content)
+				res := &http.Response{Body: ioutil.NopCloser(rd)}
+
+				client.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, tc.url, req.URL.String())
+					return res, nil
+				})
+
+				locations := make([]string, 0)
+				for pp := range ing.Ingest(context.Background(), 1) {
+					locations = append(locations, pp.Product.Location)
+				}
+				assert.Equal(t, []string{tc.region}, locations)
+				assert.NoError(t, ing.Err())
+			})
+		}
+	})
 }
 
 func makeCSV(rows [][]string) string {
@@ -118,3 +371,10 @@ func makeCSV(rows [][]string) string {
 	}
 	return s
 }
+
+// roundTripperFunc is an http.RoundTripper calling itself
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
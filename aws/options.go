@@ -2,9 +2,15 @@ package aws
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/machinebox/progress"
+	"golang.org/x/time/rate"
+
+	"github.com/cycloidio/terracost/aws/region"
 )
 
 //go:generate mockgen -destination=../mock/http_client.go -mock_names=HTTPClient=HTTPClient -package mock github.com/cycloidio/terracost/aws HTTPClient
@@ -17,7 +23,8 @@ type HTTPClient interface {
 // Option is used to configure the Ingester.
 type Option func(ing *Ingester)
 
-// WithPricingURL sets the base AWS pricing URL, "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws" by default.
+// WithPricingURL sets the base AWS pricing URL, "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws" by default
+// and "https://pricing.cn-north-1.amazonaws.com.cn/offers/v1.0/cn" for the regions of the China partition.
 func WithPricingURL(url string) Option {
 	return func(ing *Ingester) {
 		ing.pricingURL = url
@@ -31,6 +38,37 @@ func WithHTTPClient(client HTTPClient) Option {
 	}
 }
 
+// WithAWSConfig sets the HTTP client, the pricing endpoint and the credentials of the AWS SDK cfg to be used for
+// offer file downloads. The Region of the cfg is the one of the pricing endpoint (ex: cn-northwest-1), not the
+// ingested one, and the Endpoint, if set, replaces the default one of the Region. When the cfg has Credentials
+// the requests are signed with them. The unset values of the cfg keep the current configuration of the Ingester.
+func WithAWSConfig(cfg awssdk.Config) Option {
+	return func(ing *Ingester) {
+		if cfg.HTTPClient != nil {
+			ing.httpClient = cfg.HTTPClient
+		}
+
+		// Without Region the endpoint is the default one of the partition of the ingested region
+		rgn := awssdk.StringValue(cfg.Region)
+		if rgn == "" {
+			rgn = defaultSigningRegion
+			if region.Code(ing.region).Partition() == region.PartitionChina {
+				rgn = chinaSigningRegion
+			}
+		} else {
+			ing.pricingURL = pricingEndpoint(rgn) + offersPath(rgn)
+		}
+		if endpoint := awssdk.StringValue(cfg.Endpoint); endpoint != "" {
+			ing.pricingURL = strings.TrimSuffix(endpoint, "/") + offersPath(rgn)
+		}
+
+		if cfg.Credentials != nil {
+			ing.signer = v4.NewSigner(cfg.Credentials)
+			ing.signingRegion = rgn
+		}
+	}
+}
+
 // WithBufferSize sets the I/O buffer size for the downloaded file, 100 MiB by default.
 func WithBufferSize(size uint) Option {
 	return func(ing *Ingester) {
@@ -47,8 +85,27 @@ func WithProgress(progressCh chan<- progress.Progress, interval time.Duration) O
 }
 
 // WithIngestionFilter sets a custom IngestionFilter to control which pricing data records should be ingested.
-func WithIngestionFilter(filter IngestionFilter) Option {
+// If more than one is given only the records accepted by all of them are ingested, see AllFilters.
+func WithIngestionFilter(filters ...IngestionFilter) Option {
+	return func(ing *Ingester) {
+		if len(filters) == 1 {
+			ing.ingestionFilter = filters[0]
+			return
+		}
+		ing.ingestionFilter = AllFilters(filters...)
+	}
+}
+
+// WithRateLimit throttles the downloads of the offer files to rps requests per second. The limit
+// is shared by all the ingesters configured with the same Option, so a whole ingestion run stays
+// under the quota when each of its ingesters is given the same Option. A rps lower or
+// equal to 0 is ignored and the requests are not throttled.
+func WithRateLimit(rps int) Option {
+	if rps <= 0 {
+		return func(ing *Ingester) {}
+	}
+	limiter := rate.NewLimiter(rate.Limit(rps), 1)
 	return func(ing *Ingester) {
-		ing.ingestionFilter = filter
+		ing.limiter = limiter
 	}
 }
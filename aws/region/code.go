@@ -1,8 +1,24 @@
 package region
 
+import "strings"
+
 // Code represents an AWS region code.
 type Code string
 
+// Global is the location of the services that are not regional (ex: Route 53),
+// it's not a Valid region.
+const Global Code = "global"
+
+// globalName is the name of the location of the services that are not regional
+const globalName = "Global"
+
+// Partitions of AWS, each one is isolated and has its own regions
+const (
+	PartitionAWS      = "aws"
+	PartitionGovCloud = "aws-us-gov"
+	PartitionChina    = "aws-cn"
+)
+
 // NewFromZone returns the region code of the given zone or empty string if invalid.
 func NewFromZone(zone string) Code {
 	if len(zone) < 1 {
@@ -13,7 +29,25 @@ func NewFromZone(zone string) Code {
 
 // NewFromName returns the region code from its name or empty string if invalid.
 func NewFromName(name string) Code {
-	return nameToCode[name]
+	if name == globalName {
+		return Global
+	}
+	if c, ok := nameToCode[name]; ok {
+		return c
+	}
+	return normalizedToCode[normalize(name)]
+}
+
+// Parse returns the region code of s, which can be the code (ex: "eu-west-1") or the name (ex: "EU (Ireland)")
+// of the region with any casing and spacing (ex: "EU-WEST-1", "eu (ireland)"), or empty string if invalid.
+func Parse(s string) Code {
+	return normalizedToCode[normalize(s)]
+}
+
+// normalize returns s in lower case without whitespaces so the spelling variations of
+// the codes and names of the regions (ex: " EU  (Ireland)", "eu(ireland)") are equal
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), "")
 }
 
 // Valid returns true if the region exists and is supported, false otherwise.
@@ -25,6 +59,28 @@ func (c Code) Valid() bool {
 	return ok
 }
 
+// Name returns the name of the region used as location of the pricing
+// data (ex: "AWS GovCloud (US-West)") or empty string if invalid.
+func (c Code) Name() string {
+	if c == Global {
+		return globalName
+	}
+	return codeToName[c]
+}
+
+// Partition returns the AWS partition of the region, the GovCloud
+// and China regions are not on the standard "aws" partition.
+func (c Code) Partition() string {
+	switch {
+	case strings.HasPrefix(string(c), "us-gov-"):
+		return PartitionGovCloud
+	case strings.HasPrefix(string(c), "cn-"):
+		return PartitionChina
+	default:
+		return PartitionAWS
+	}
+}
+
 // String returns the code of the region as a string.
 func (c Code) String() string {
 	return string(c)
@@ -27,6 +27,11 @@ func TestNewFromName(t *testing.T) {
 		{"", ""},
 		{"US East (N. Virginia)", "us-east-1"},
 		{"EU (Paris)", "eu-west-3"},
+		{"AWS GovCloud (US-West)", "us-gov-west-1"},
+		{"China (Beijing)", "cn-north-1"},
+		{"Global", "global"},
+		{"eu (paris)", "eu-west-3"},
+		{"EU  (Paris) ", "eu-west-3"},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.in, func(t *testing.T) {
@@ -36,6 +41,25 @@ func TestNewFromName(t *testing.T) {
 	}
 }
 
+func TestParse(t *testing.T) {
+	testcases := []struct{ in, out string }{
+		{"", ""},
+		{"eu-west-1", "eu-west-1"},
+		{"EU-WEST-1", "eu-west-1"},
+		{" eu-west-1 ", "eu-west-1"},
+		{"EU (Ireland)", "eu-west-1"},
+		{"eu (ireland)", "eu-west-1"},
+		{"EU(Ireland)", "eu-west-1"},
+		{"US East (N. Virginia)", "us-east-1"},
+		{"us-invalid-42", ""},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.out, region.Parse(tc.in).String())
+		})
+	}
+}
+
 func TestCode_Valid(t *testing.T) {
 	testcases := []struct {
 		in  string
@@ -51,3 +75,38 @@ func TestCode_Valid(t *testing.T) {
 		})
 	}
 }
+
+func TestCode_Name(t *testing.T) {
+	testcases := []struct{ in, out string }{
+		{"", ""},
+		{"eu-west-3", "EU (Paris)"},
+		{"us-gov-west-1", "AWS GovCloud (US-West)"},
+		{"cn-northwest-1", "China (Ningxia)"},
+		{"global", "Global"},
+		{"us-invalid-42", ""},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			code := region.Code(tc.in)
+			assert.Equal(t, tc.out, code.Name())
+			if tc.out != "" {
+				// The name has to return the same code
+				assert.Equal(t, code, region.NewFromName(code.Name()))
+			}
+		})
+	}
+}
+
+func TestCode_Partition(t *testing.T) {
+	testcases := []struct{ in, out string }{
+		{"us-east-1", region.PartitionAWS},
+		{"us-gov-west-1", region.PartitionGovCloud},
+		{"us-gov-east-1", region.PartitionGovCloud},
+		{"cn-north-1", region.PartitionChina},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.out, region.Code(tc.in).Partition())
+		})
+	}
+}
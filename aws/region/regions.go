@@ -81,11 +81,18 @@ var codeToShortName = map[string]string{
 	"me-central-1":    "MEC1",
 }
 
-var codeToName = make(map[Code]string)
+var (
+	codeToName = make(map[Code]string)
+
+	// normalizedToCode are the codes keyed by their normalized code and name, see normalize
+	normalizedToCode = make(map[string]Code)
+)
 
 func init() {
 	for name, code := range nameToCode {
 		codeToName[code] = name
+		normalizedToCode[normalize(name)] = code
+		normalizedToCode[normalize(string(code))] = code
 	}
 }
 
@@ -6,19 +6,31 @@ import (
 
 // SupportedServices is a list of all AWS services that are supported by Terracost.
 var supportedServices = map[string]struct{}{
-	"AmazonCloudWatch":  {},
-	"AmazonEC2":         {},
-	"AmazonEFS":         {},
-	"AmazonEKS":         {},
-	"AmazonElastiCache": {},
-	"AmazonFSx":         {},
-	"AmazonRDS":         {},
-	"AmazonS3":          {},
-	"AWSDataTransfer":   {},
-	"AWSELB":            {},
-	"awskms":            {},
-	"AWSQueueService":   {},
-	"AWSSecretsManager": {},
+	"AmazonApiGateway":    {},
+	"AmazonCloudFront":    {},
+	"AmazonCloudWatch":    {},
+	"AmazonEC2":           {},
+	"AmazonEFS":           {},
+	"AmazonEKS":           {},
+	"AmazonElastiCache":   {},
+	"AmazonFSx":           {},
+	"AmazonMQ":            {},
+	"AmazonMSK":           {},
+	"AmazonRDS":           {},
+	"AmazonRoute53":       {},
+	"AmazonS3":            {},
+	"AmazonSageMaker":     {},
+	"AmazonSNS":           {},
+	"AmazonVPC":           {},
+	"AWSBackup":           {},
+	"AWSDataTransfer":     {},
+	"AWSDirectoryService": {},
+	"AWSELB":              {},
+	"awskms":              {},
+	"AWSQueueService":     {},
+	"AWSSecretsManager":   {},
+	"AWSTransfer":         {},
+	"awswaf":              {},
 }
 
 // IsServiceSupported returns true if the AWS service is valid and supported by Terracost (e.g. for ingestion.)
@@ -0,0 +1,137 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// APIGatewayRestAPI represents an AWS API Gateway REST API that can be cost-estimated.
+type APIGatewayRestAPI struct {
+	providerKey string
+	region      region.Code
+
+	// Usage
+	monthlyRequests       decimal.Decimal
+	monthlyOutboundDataGB decimal.Decimal
+}
+
+type apiGatewayRestAPIValues struct {
+	Usage struct {
+		MonthlyRequests       float64 `mapstructure:"monthly_requests"`
+		MonthlyOutboundDataGB float64 `mapstructure:"monthly_outbound_data_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeAPIGatewayRestAPIValues(tfVals map[string]interface{}) (apiGatewayRestAPIValues, error) {
+	var v apiGatewayRestAPIValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newAPIGatewayRestAPI creates a new APIGatewayRestAPI from apiGatewayRestAPIValues.
+func (p *Provider) newAPIGatewayRestAPI(vals apiGatewayRestAPIValues) *APIGatewayRestAPI {
+	return &APIGatewayRestAPI{
+		providerKey: p.key,
+		region:      p.region,
+
+		// From Usage
+		monthlyRequests:       decimal.NewFromFloat(vals.Usage.MonthlyRequests),
+		monthlyOutboundDataGB: decimal.NewFromFloat(vals.Usage.MonthlyOutboundDataGB),
+	}
+}
+
+// Components returns the price component queries that make up the APIGatewayRestAPI.
+// The requests are charged per request with tiers (first 333 million, next 667 million...) and the
+// data transferred out as the EC2 one, the cache is charged on the stages (see APIGatewayStage).
+func (a *APIGatewayRestAPI) Components() []query.Component {
+	return []query.Component{
+		apiGatewayComponent(a.providerKey, a.region, "Requests", []string{"REST"}, a.monthlyRequests, "Requests", "ApiGatewayRequest"),
+		dataTransferOutComponent(a.providerKey, a.region, a.monthlyOutboundDataGB),
+	}
+}
+
+// apiGatewayComponent returns the component of the API Gateway usage type (without its region prefix),
+// it's tiered as the requests and messages are, the other usage types have a single tier
+func apiGatewayComponent(providerKey string, r region.Code, name string, details []string, quantity decimal.Decimal, unit, usageType string) query.Component {
+	return query.Component{
+		Name:            name,
+		Details:         details,
+		MonthlyQuantity: quantity,
+		Usage:           true,
+		Unit:            unit,
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(providerKey),
+			Service:  util.StringPtr("AmazonApiGateway"),
+			Location: util.StringPtr(r.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("^([A-Z0-9]+-)?%s$", usageType))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// dataTransferOutComponent returns the component of the data transferred out to the internet from
+// the region, which is charged with tiers
+func dataTransferOutComponent(providerKey string, r region.Code, outboundGB decimal.Decimal) query.Component {
+	usageType := "DataTransfer-Out-Bytes"
+	// us-east-1 has no region prefix on the UsageType
+	if shortRegion := region.GetRegionToShortName(r.String()); shortRegion != "" && r.String() != "us-east-1" {
+		usageType = fmt.Sprintf("%s-DataTransfer-Out-Bytes", shortRegion)
+	}
+
+	return query.Component{
+		Name:            "Outbound data transfer",
+		Details:         []string{"Outbound"},
+		MonthlyQuantity: outboundGB,
+		Usage:           true,
+		Unit:            "GB",
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(providerKey),
+			Service:  util.StringPtr("AWSDataTransfer"),
+			Family:   util.StringPtr("Data Transfer"),
+			Location: util.StringPtr(""),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", Value: util.StringPtr(usageType)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("GB"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// apiGatewayRestAPIExample returns the ResourceExample of the aws_api_gateway_rest_api, see Provider.ResourceExamples
+func (p *Provider) apiGatewayRestAPIExample() terraform.ResourceExample {
+	return p.example("aws_api_gateway_rest_api", map[string]interface{}{})
+}
@@ -0,0 +1,106 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// APIGatewayStage represents an AWS API Gateway REST API stage that can be cost-estimated,
+// only the stages with a cache cluster have a cost.
+type APIGatewayStage struct {
+	providerKey string
+	region      region.Code
+
+	// cacheClusterSize is the size in GB of the cache cluster (ex: 0.5),
+	// it's empty when the cache cluster is not enabled
+	cacheClusterSize string
+}
+
+type apiGatewayStageValues struct {
+	CacheClusterEnabled bool   `mapstructure:"cache_cluster_enabled"`
+	CacheClusterSize    string `mapstructure:"cache_cluster_size"`
+}
+
+func decodeAPIGatewayStageValues(tfVals map[string]interface{}) (apiGatewayStageValues, error) {
+	var v apiGatewayStageValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newAPIGatewayStage creates a new APIGatewayStage from apiGatewayStageValues.
+func (p *Provider) newAPIGatewayStage(vals apiGatewayStageValues) *APIGatewayStage {
+	inst := &APIGatewayStage{
+		providerKey: p.key,
+		region:      p.region,
+	}
+
+	if vals.CacheClusterEnabled {
+		inst.cacheClusterSize = vals.CacheClusterSize
+		// The cache cluster size is 0.5 GB by default
+		if inst.cacheClusterSize == "" {
+			inst.cacheClusterSize = "0.5"
+		}
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the APIGatewayStage.
+// The cache cluster is charged per hour by its size.
+func (s *APIGatewayStage) Components() []query.Component {
+	if s.cacheClusterSize == "" {
+		return []query.Component{}
+	}
+
+	return []query.Component{
+		{
+			Name:           "Cache cluster",
+			Details:        []string{fmt.Sprintf("%s GB", s.cacheClusterSize)},
+			HourlyQuantity: decimal.NewFromInt(1),
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(s.providerKey),
+				Service:  util.StringPtr("AmazonApiGateway"),
+				Location: util.StringPtr(s.region.String()),
+				AttributeFilters: []*product.AttributeFilter{
+					// The UsageType has the size of the cache (ex: EUW3-ApiGatewayCacheUsage:0.5GB)
+					{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("^([A-Z0-9]+-)?ApiGatewayCacheUsage:%sGB$", regexp.QuoteMeta(s.cacheClusterSize)))},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+	}
+}
+
+// apiGatewayStageExample returns the ResourceExample of the aws_api_gateway_stage, see Provider.ResourceExamples
+func (p *Provider) apiGatewayStageExample() terraform.ResourceExample {
+	return p.example("aws_api_gateway_stage", map[string]interface{}{
+		"cache_cluster_enabled": true,
+		"cache_cluster_size":    "0.5",
+	})
+}
@@ -0,0 +1,106 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestAPIGateway_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	component := func(name, detail string, quantity decimal.Decimal, unit, usageType string) query.Component {
+		return query.Component{
+			Name:            name,
+			Details:         []string{detail},
+			MonthlyQuantity: quantity,
+			Usage:           true,
+			Unit:            unit,
+			Tiered:          true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonApiGateway"),
+				Location: util.StringPtr("eu-west-3"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		}
+	}
+
+	resource := func(rt string, values map[string]interface{}) terraform.Resource {
+		values[usage.Key] = usage.Default.GetUsage(rt)
+		return terraform.Resource{
+			Address:      rt + ".test",
+			Type:         rt,
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       values,
+		}
+	}
+
+	t.Run("REST", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource("aws_api_gateway_rest_api", map[string]interface{}{}))
+		require.Len(t, actual, 2)
+		assert.Equal(t, component("Requests", "REST", decimal.NewFromFloat(1000000), "Requests", "^([A-Z0-9]+-)?ApiGatewayRequest$"), actual[0])
+		assert.Equal(t, "Outbound data transfer", actual[1].Name)
+		assert.Equal(t, decimal.NewFromFloat(10), actual[1].MonthlyQuantity)
+		assert.True(t, actual[1].Tiered)
+		assert.Equal(t, []*product.AttributeFilter{
+			{Key: "UsageType", Value: util.StringPtr("EUW3-DataTransfer-Out-Bytes")},
+		}, actual[1].ProductFilter.AttributeFilters)
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource("aws_apigatewayv2_api", map[string]interface{}{
+			"protocol_type": "HTTP",
+		}))
+		require.Len(t, actual, 2)
+		assert.Equal(t, component("Requests", "HTTP", decimal.NewFromFloat(1000000), "Requests", "^([A-Z0-9]+-)?ApiGatewayHttpRequest$"), actual[0])
+		assert.Equal(t, "Outbound data transfer", actual[1].Name)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("WebSocket", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource("aws_apigatewayv2_api", map[string]interface{}{
+			"protocol_type": "WEBSOCKET",
+		}))
+		require.Len(t, actual, 3)
+		assert.Equal(t, component("Messages", "WebSocket", decimal.NewFromFloat(1000000), "Messages", "^([A-Z0-9]+-)?ApiGatewayMessage$"), actual[0])
+		assert.Equal(t, component("Connection minutes", "WebSocket", decimal.NewFromFloat(100000), "Minutes", "^([A-Z0-9]+-)?ApiGatewayMinute$"), actual[1])
+		assert.Equal(t, "Outbound data transfer", actual[2].Name)
+	})
+
+	t.Run("StageCache", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource("aws_api_gateway_stage", map[string]interface{}{
+			"cache_cluster_enabled": true,
+			"cache_cluster_size":    "1.6",
+		}))
+		require.Len(t, actual, 1)
+		assert.Equal(t, "Cache cluster", actual[0].Name)
+		assert.Equal(t, decimal.NewFromInt(1), actual[0].HourlyQuantity)
+		assert.Equal(t, []*product.AttributeFilter{
+			{Key: "UsageType", ValueRegex: util.StringPtr(`^([A-Z0-9]+-)?ApiGatewayCacheUsage:1\.6GB$`)},
+		}, actual[0].ProductFilter.AttributeFilters)
+	})
+
+	t.Run("StageNoCache", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource("aws_api_gateway_stage", map[string]interface{}{}))
+		assert.Empty(t, actual)
+	})
+}
@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+)
+
+// APIGatewayV2API represents an AWS API Gateway (v2) HTTP or WebSocket API that can be cost-estimated.
+type APIGatewayV2API struct {
+	providerKey string
+	region      region.Code
+
+	// protocolType is HTTP or WEBSOCKET
+	protocolType string
+
+	// Usage
+	monthlyRequests          decimal.Decimal
+	monthlyMessages          decimal.Decimal
+	monthlyConnectionMinutes decimal.Decimal
+	monthlyOutboundDataGB    decimal.Decimal
+}
+
+type apiGatewayV2APIValues struct {
+	ProtocolType string `mapstructure:"protocol_type"`
+
+	Usage struct {
+		MonthlyRequests          float64 `mapstructure:"monthly_requests"`
+		MonthlyMessages          float64 `mapstructure:"monthly_messages"`
+		MonthlyConnectionMinutes float64 `mapstructure:"monthly_connection_minutes"`
+		MonthlyOutboundDataGB    float64 `mapstructure:"monthly_outbound_data_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeAPIGatewayV2APIValues(tfVals map[string]interface{}) (apiGatewayV2APIValues, error) {
+	var v apiGatewayV2APIValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newAPIGatewayV2API creates a new APIGatewayV2API from apiGatewayV2APIValues.
+func (p *Provider) newAPIGatewayV2API(vals apiGatewayV2APIValues) *APIGatewayV2API {
+	inst := &APIGatewayV2API{
+		providerKey:  p.key,
+		region:       p.region,
+		protocolType: vals.ProtocolType,
+
+		// From Usage
+		monthlyRequests:          decimal.NewFromFloat(vals.Usage.MonthlyRequests),
+		monthlyMessages:          decimal.NewFromFloat(vals.Usage.MonthlyMessages),
+		monthlyConnectionMinutes: decimal.NewFromFloat(vals.Usage.MonthlyConnectionMinutes),
+		monthlyOutboundDataGB:    decimal.NewFromFloat(vals.Usage.MonthlyOutboundDataGB),
+	}
+
+	if inst.protocolType != "HTTP" && inst.protocolType != "WEBSOCKET" {
+		p.warnf("protocol_type %q is not supported, the API is estimated as HTTP", inst.protocolType)
+		inst.protocolType = "HTTP"
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the APIGatewayV2API.
+// The HTTP APIs are charged per request (metered by 512 KB) with tiers (first 300 million...), the WebSocket
+// APIs per message (metered by 32 KB) with tiers and per connection minute, and both of them for the data
+// transferred out as the EC2 one.
+func (a *APIGatewayV2API) Components() []query.Component {
+	if a.protocolType == "WEBSOCKET" {
+		return []query.Component{
+			apiGatewayComponent(a.providerKey, a.region, "Messages", []string{"WebSocket"}, a.monthlyMessages, "Messages", "ApiGatewayMessage"),
+			apiGatewayComponent(a.providerKey, a.region, "Connection minutes", []string{"WebSocket"}, a.monthlyConnectionMinutes, "Minutes", "ApiGatewayMinute"),
+			dataTransferOutComponent(a.providerKey, a.region, a.monthlyOutboundDataGB),
+		}
+	}
+
+	return []query.Component{
+		apiGatewayComponent(a.providerKey, a.region, "Requests", []string{"HTTP"}, a.monthlyRequests, "Requests", "ApiGatewayHttpRequest"),
+		dataTransferOutComponent(a.providerKey, a.region, a.monthlyOutboundDataGB),
+	}
+}
+
+// apiGatewayV2APIExample returns the ResourceExample of the aws_apigatewayv2_api, see Provider.ResourceExamples
+func (p *Provider) apiGatewayV2APIExample() terraform.ResourceExample {
+	return p.example("aws_apigatewayv2_api", map[string]interface{}{
+		"protocol_type": "HTTP",
+	})
+}
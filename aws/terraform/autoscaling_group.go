@@ -39,9 +39,15 @@ type autoscalingGroupValues struct {
 
 	MinSize         int64 `mapstructure:"min_size"`
 	DesiredCapacity int64 `mapstructure:"desired_capacity"`
+
+	Usage struct {
+		// AverageCapacity is the expected average number of instances
+		// running, if defined it has precedence over the DesiredCapacity
+		AverageCapacity float64 `mapstructure:"average_capacity"`
+	} `mapstructure:"tc_usage"`
 }
 
-// decodeAutoscalingGroupValues decodes and returns instanceValues from a Terraform values map.
+// decodeAutoscalingGroupValues decodes and returns autoscalingGroupValues from a Terraform values map.
 func decodeAutoscalingGroupValues(tfVals map[string]interface{}) (autoscalingGroupValues, error) {
 	var v autoscalingGroupValues
 	config := &mapstructure.DecoderConfig{
@@ -60,7 +66,8 @@ func decodeAutoscalingGroupValues(tfVals map[string]interface{}) (autoscalingGro
 	return v, nil
 }
 
-// newInstance creates a new Instance from instanceValues.
+// newAutoscalingGroup creates a new Instance from autoscalingGroupValues resolving the
+// referenced launch template or configuration, the instanceCount is the desired capacity.
 func (p *Provider) newAutoscalingGroup(rss map[string]terraform.Resource, vals autoscalingGroupValues) *Instance {
 
 	inst := &Instance{
@@ -88,6 +95,10 @@ func (p *Provider) newAutoscalingGroup(rss map[string]terraform.Resource, vals a
 		instanceCount = 1
 	}
 	inst.instanceCount = decimal.NewFromInt(instanceCount)
+	if vals.Usage.AverageCapacity > 0 {
+		inst.instanceCount = decimal.NewFromFloat(vals.Usage.AverageCapacity)
+	}
+
 	// ASG use LaunchConfiguration
 	if len(vals.LaunchConfiguration) > 0 {
 		lc, err := decodeLaunchConfigurationValues(rss[vals.LaunchConfiguration].Values)
@@ -196,6 +207,8 @@ func (p *Provider) newAutoscalingGroup(rss map[string]terraform.Resource, vals a
 		}
 	}
 
+	inst.arm64 = isGravitonInstanceType(inst.instanceType)
+
 	// Override provider region by the one defined in LC/LT
 	if reg := region.NewFromZone(availabilityZone); reg.Valid() {
 		inst.region = reg
@@ -203,3 +216,14 @@ func (p *Provider) newAutoscalingGroup(rss map[string]terraform.Resource, vals a
 
 	return inst
 }
+
+// autoscalingGroupExample returns the ResourceExample of the aws_autoscaling_group, see Provider.ResourceExamples
+func (p *Provider) autoscalingGroupExample() terraform.ResourceExample {
+	return p.example("aws_autoscaling_group", map[string]interface{}{
+		"min_size":         1,
+		"desired_capacity": 2,
+		"launch_template":  []interface{}{map[string]interface{}{"id": "aws_launch_template.example"}},
+	}, p.resource("aws_launch_template", map[string]interface{}{
+		"instance_type": "m5.large",
+	}))
+}
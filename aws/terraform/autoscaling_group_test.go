@@ -13,6 +13,7 @@ import (
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -99,27 +100,6 @@ func TestAutoscalingGroup_Components(t *testing.T) {
 					},
 				},
 			},
-			{
-				Name:           "CPUCreditCost",
-				Details:        []string{"Linux", "on-demand", "m5.xlarge"},
-				HourlyQuantity: decimal.NewFromInt(2),
-				ProductFilter: &product.Filter{
-					Provider: util.StringPtr("aws"),
-					Service:  util.StringPtr("AmazonEC2"),
-					Family:   util.StringPtr("CPU Credits"),
-					Location: util.StringPtr("eu-west-1"),
-					AttributeFilters: []*product.AttributeFilter{
-						{Key: "OperatingSystem", Value: util.StringPtr("Linux")},
-						{Key: "UsageType", Value: util.StringPtr(fmt.Sprintf("%s-CPUCredits:%s", "EU", "m5"))},
-					},
-				},
-				PriceFilter: &price.Filter{
-					Unit: util.StringPtr("vCPU-Hours"),
-					AttributeFilters: []*price.AttributeFilter{
-						{Key: "TermType", Value: util.StringPtr("OnDemand")},
-					},
-				},
-			},
 			{
 				Name:            "EC2 detailed monitoring",
 				Details:         []string{"on-demand", "monitoring"},
@@ -364,4 +344,38 @@ func TestAutoscalingGroup_Components(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("AverageCapacityUsage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_autoscaling_group.lc",
+			Type:         "aws_autoscaling_group",
+			Name:         "lc",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"desired_capacity":     4,
+				"launch_configuration": "aws_launch_configuration.test",
+				usage.Key: map[string]interface{}{
+					"average_capacity": 2.5,
+				},
+			},
+		}
+
+		rss := map[string]terraform.Resource{
+			"aws_launch_configuration.test": terraform.Resource{
+				Address:      "aws_launch_configuration.test",
+				Type:         "aws_launch_configuration",
+				Name:         "test",
+				ProviderName: "aws",
+				Values: map[string]interface{}{
+					"instance_type": "m6g.large",
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.NotEmpty(t, actual)
+		assert.Equal(t, "Compute", actual[0].Name)
+		assert.True(t, decimal.NewFromFloat(2.5).Equal(actual[0].HourlyQuantity))
+		assert.Contains(t, actual[0].ProductFilter.AttributeFilters, &product.AttributeFilter{Key: "InstanceType", Value: util.StringPtr("m6g.large")})
+		assert.Contains(t, actual[0].Details, "arm64")
+	})
 }
@@ -0,0 +1,122 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// BackupVault represents an AWS Backup vault that can be cost-estimated, the backups
+// of the plans (aws_backup_plan) are charged on the vault they are stored on.
+type BackupVault struct {
+	providerKey string
+	region      region.Code
+
+	// Usage
+	resourceType  string
+	warmStorageGB decimal.Decimal
+	coldStorageGB decimal.Decimal
+	restoreGB     decimal.Decimal
+}
+
+type backupVaultValues struct {
+	Usage struct {
+		// ResourceType is the type of the resources backed up (ex: EFS, EBS, RDS, DynamoDB)
+		// as the backup storage is priced by resource type, it's EFS by default
+		ResourceType     string  `mapstructure:"resource_type"`
+		WarmStorageGB    float64 `mapstructure:"warm_storage_gb"`
+		ColdStorageGB    float64 `mapstructure:"cold_storage_gb"`
+		MonthlyRestoreGB float64 `mapstructure:"monthly_restore_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeBackupVaultValues(tfVals map[string]interface{}) (backupVaultValues, error) {
+	var v backupVaultValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newBackupVault creates a new BackupVault from backupVaultValues.
+func (p *Provider) newBackupVault(vals backupVaultValues) *BackupVault {
+	v := &BackupVault{
+		providerKey: p.key,
+		region:      p.region,
+
+		// From Usage
+		resourceType:  vals.Usage.ResourceType,
+		warmStorageGB: decimal.NewFromFloat(vals.Usage.WarmStorageGB),
+		coldStorageGB: decimal.NewFromFloat(vals.Usage.ColdStorageGB),
+		restoreGB:     decimal.NewFromFloat(vals.Usage.MonthlyRestoreGB),
+	}
+	if v.resourceType == "" {
+		v.resourceType = "EFS"
+	}
+	return v
+}
+
+// Components returns the price component queries that make up the BackupVault.
+// The backups are charged by the GB-month stored on the warm storage and, once transitioned,
+// on the cold storage, and the restores by the GB restored. All of them depend on the
+// resource type backed up and can't be known from the configuration so they are usage.
+func (v *BackupVault) Components() []query.Component {
+	components := []query.Component{
+		v.backupComponent("Warm storage", v.warmStorageGB, "GB-Mo", "WarmStorage-ByteHrs"),
+	}
+	if v.coldStorageGB.IsPositive() {
+		components = append(components, v.backupComponent("Cold storage", v.coldStorageGB, "GB-Mo", "ColdStorage-ByteHrs"))
+	}
+	if v.restoreGB.IsPositive() {
+		components = append(components, v.backupComponent("Restores", v.restoreGB, "GB", "Restore-Bytes"))
+	}
+	return components
+}
+
+// backupComponent returns the component of the usage type (without its region prefix nor resource type suffix)
+func (v *BackupVault) backupComponent(name string, quantity decimal.Decimal, unit, usageType string) query.Component {
+	return query.Component{
+		Name:            name,
+		Details:         []string{v.resourceType},
+		MonthlyQuantity: quantity,
+		Usage:           true,
+		Unit:            unit,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.providerKey),
+			Service:  util.StringPtr("AWSBackup"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("^([A-Z0-9]+-)?%s-%s$", usageType, v.resourceType))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr(unit),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// backupVaultExample returns the ResourceExample of the aws_backup_vault, see Provider.ResourceExamples
+func (p *Provider) backupVaultExample() terraform.ResourceExample {
+	return p.example("aws_backup_vault", map[string]interface{}{})
+}
@@ -0,0 +1,57 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestBackupVault_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	resource := func(u map[string]interface{}) terraform.Resource {
+		return terraform.Resource{
+			Address:      "aws_backup_vault.test",
+			Mode:         "managed",
+			Type:         "aws_backup_vault",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       map[string]interface{}{usage.Key: u},
+		}
+	}
+
+	t.Run("DefaultUsage", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(usage.Default.GetUsage("aws_backup_vault")))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "Warm storage", actual[0].Name)
+		assert.Equal(t, []string{"EFS"}, actual[0].Details)
+		assert.Equal(t, "100", actual[0].MonthlyQuantity.String())
+		assert.True(t, actual[0].Usage)
+		assert.Equal(t, util.StringPtr("AWSBackup"), actual[0].ProductFilter.Service)
+		assert.Equal(t, util.StringPtr("^([A-Z0-9]+-)?WarmStorage-ByteHrs-EFS$"), actual[0].ProductFilter.AttributeFilters[0].ValueRegex)
+	})
+
+	t.Run("ColdStorageAndRestores", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"resource_type":      "EBS",
+			"warm_storage_gb":    50,
+			"cold_storage_gb":    500,
+			"monthly_restore_gb": 20,
+		}))
+
+		require.Len(t, actual, 3)
+		assert.Equal(t, "Cold storage", actual[1].Name)
+		assert.Equal(t, "500", actual[1].MonthlyQuantity.String())
+		assert.Equal(t, util.StringPtr("^([A-Z0-9]+-)?ColdStorage-ByteHrs-EBS$"), actual[1].ProductFilter.AttributeFilters[0].ValueRegex)
+		assert.Equal(t, "Restores", actual[2].Name)
+		assert.Equal(t, util.StringPtr("GB"), actual[2].PriceFilter.Unit)
+		assert.Equal(t, util.StringPtr("^([A-Z0-9]+-)?Restore-Bytes-EBS$"), actual[2].ProductFilter.AttributeFilters[0].ValueRegex)
+	})
+}
@@ -0,0 +1,317 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+)
+
+// cloudFormationResources maps the supported CloudFormation resource types to the Terraform resource
+// type estimated and the function returning its Terraform values from the CloudFormation properties
+var cloudFormationResources = map[string]struct {
+	Type   string
+	Values func(props map[string]interface{}) map[string]interface{}
+}{
+	"AWS::EC2::Instance": {"aws_instance", func(props map[string]interface{}) map[string]interface{} {
+		vals := cloudFormationValues(props, map[string]string{
+			"InstanceType":     "instance_type",
+			"Tenancy":          "tenancy",
+			"AvailabilityZone": "availability_zone",
+			"EbsOptimized":     "ebs_optimized",
+			"Monitoring":       "monitoring",
+		})
+		// The first block device with an EBS volume is the root one
+		if bdms, ok := props["BlockDeviceMappings"].([]interface{}); ok {
+			for _, bdm := range bdms {
+				m, _ := bdm.(map[string]interface{})
+				if ebs, ok := m["Ebs"].(map[string]interface{}); ok {
+					vals["root_block_device"] = []interface{}{cloudFormationValues(ebs, map[string]string{
+						"VolumeType": "volume_type",
+						"VolumeSize": "volume_size",
+						"Iops":       "iops",
+					})}
+					break
+				}
+			}
+		}
+		return vals
+	}},
+	"AWS::EC2::Volume": {"aws_ebs_volume", func(props map[string]interface{}) map[string]interface{} {
+		return cloudFormationValues(props, map[string]string{
+			"AvailabilityZone": "availability_zone",
+			"VolumeType":       "type",
+			"Size":             "size",
+			"Iops":             "iops",
+		})
+	}},
+	"AWS::EC2::NatGateway": {"aws_nat_gateway", func(props map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{}
+	}},
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": {"aws_lb", func(props map[string]interface{}) map[string]interface{} {
+		vals := cloudFormationValues(props, map[string]string{"Type": "load_balancer_type"})
+		// The Type is application by default as for the aws_lb
+		if _, ok := vals["load_balancer_type"]; !ok {
+			vals["load_balancer_type"] = "application"
+		}
+		return vals
+	}},
+	"AWS::RDS::DBInstance": {"aws_db_instance", func(props map[string]interface{}) map[string]interface{} {
+		return cloudFormationValues(props, map[string]string{
+			"DBInstanceClass":  "instance_class",
+			"AvailabilityZone": "availability_zone",
+			"Engine":           "engine",
+			"LicenseModel":     "license_model",
+			"MultiAZ":          "multi_az",
+			"AllocatedStorage": "allocated_storage",
+			"StorageType":      "storage_type",
+			"Iops":             "iops",
+		})
+	}},
+}
+
+type cloudFormationStackValues struct {
+	TemplateBody string            `mapstructure:"template_body"`
+	TemplateURL  string            `mapstructure:"template_url"`
+	Parameters   map[string]string `mapstructure:"parameters"`
+}
+
+func decodeCloudFormationStackValues(tfVals map[string]interface{}) (cloudFormationStackValues, error) {
+	var v cloudFormationStackValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// cloudFormationTemplate is the part of a CloudFormation template used to estimate its resources
+type cloudFormationTemplate struct {
+	Parameters map[string]struct {
+		Default interface{} `mapstructure:"Default"`
+	} `mapstructure:"Parameters"`
+
+	Resources map[string]struct {
+		Type       string                 `mapstructure:"Type"`
+		Properties map[string]interface{} `mapstructure:"Properties"`
+	} `mapstructure:"Resources"`
+}
+
+// cloudFormationStackComponents returns the components of the supported resources of the template of the
+// aws_cloudformation_stack tfRes, each of them is estimated as the Terraform resource of the same kind with
+// the usage of the stack and its components are prefixed with the logical ID of the resource
+func (p *Provider) cloudFormationStackComponents(rss map[string]terraform.Resource, tfRes terraform.Resource, vals cloudFormationStackValues) []query.Component {
+	if vals.TemplateBody == "" {
+		if vals.TemplateURL != "" {
+			p.warnf("the template_url is not supported, only the stacks with a template_body are estimated")
+		}
+		return []query.Component{}
+	}
+
+	tpl, err := parseCloudFormationTemplate(vals.TemplateBody)
+	if err != nil {
+		p.warnf("invalid template_body, the stack is not estimated: %s", err)
+		return []query.Component{}
+	}
+
+	// The parameters of the stack have precedence over the Default of the template ones
+	params := make(map[string]interface{}, len(tpl.Parameters))
+	for name, param := range tpl.Parameters {
+		if param.Default != nil {
+			params[name] = param.Default
+		}
+	}
+	for name, value := range vals.Parameters {
+		params[name] = value
+	}
+
+	ids := make([]string, 0, len(tpl.Resources))
+	for id := range tpl.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	components := make([]query.Component, 0)
+	unsupported := make(map[string]struct{})
+	for _, id := range ids {
+		res := tpl.Resources[id]
+		cfr, ok := cloudFormationResources[res.Type]
+		if !ok {
+			unsupported[res.Type] = struct{}{}
+			continue
+		}
+
+		props, _ := resolveCloudFormationRefs(res.Properties, params).(map[string]interface{})
+		values := cfr.Values(props)
+		values[usage.Key] = tfRes.Values[usage.Key]
+
+		nested := terraform.Resource{
+			Address:      fmt.Sprintf("%s.%s", tfRes.Address, id),
+			Mode:         tfRes.Mode,
+			Type:         cfr.Type,
+			Name:         id,
+			ProviderName: tfRes.ProviderName,
+			Values:       values,
+		}
+		for _, comp := range p.resourceComponents(rss, nested) {
+			comp.Name = fmt.Sprintf("%s: %s", id, comp.Name)
+			components = append(components, comp)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		types := make([]string, 0, len(unsupported))
+		for t := range unsupported {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		p.warnf("the nested resources of type %s are not estimated", strings.Join(types, ", "))
+	}
+
+	return components
+}
+
+// parseCloudFormationTemplate parses the JSON or YAML CloudFormation template body, the short
+// form of the intrinsic functions (ex: !Ref) are converted to their full form (ex: {"Ref": ...})
+func parseCloudFormationTemplate(body string) (cloudFormationTemplate, error) {
+	var tpl cloudFormationTemplate
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &node); err != nil {
+		return tpl, err
+	}
+	v, err := cloudFormationNodeValue(&node)
+	if err != nil {
+		return tpl, err
+	}
+
+	if err := mapstructure.Decode(v, &tpl); err != nil {
+		return tpl, err
+	}
+	return tpl, nil
+}
+
+// cloudFormationNodeValue returns the value of the YAML node n
+func cloudFormationNodeValue(n *yaml.Node) (interface{}, error) {
+	var v interface{}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return cloudFormationNodeValue(n.Content[0])
+	case yaml.AliasNode:
+		return cloudFormationNodeValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			value, err := cloudFormationNodeValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = value
+		}
+		v = m
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			value, err := cloudFormationNodeValue(c)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, value)
+		}
+		v = s
+	default:
+		if isCloudFormationTag(n.Tag) {
+			v = n.Value
+		} else if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+	}
+
+	// The short form of the intrinsic functions are the tags (ex: !GetAtt)
+	if isCloudFormationTag(n.Tag) {
+		fn := strings.TrimPrefix(n.Tag, "!")
+		if fn != "Ref" && fn != "Condition" {
+			fn = "Fn::" + fn
+		}
+		return map[string]interface{}{fn: v}, nil
+	}
+	return v, nil
+}
+
+// isCloudFormationTag returns true if the YAML tag is not a standard one (ex: !!str) but a CloudFormation one
+func isCloudFormationTag(tag string) bool {
+	return strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!")
+}
+
+// resolveCloudFormationRefs returns v with the references (Ref) to the params replaced by their value,
+// the other intrinsic functions (and references to resources) can't be resolved so they are removed
+func resolveCloudFormationRefs(v interface{}, params map[string]interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if len(vv) == 1 {
+			for k, arg := range vv {
+				if k == "Ref" {
+					name, _ := arg.(string)
+					return params[name]
+				}
+				if strings.HasPrefix(k, "Fn::") {
+					return nil
+				}
+			}
+		}
+		m := make(map[string]interface{}, len(vv))
+		for k, e := range vv {
+			if r := resolveCloudFormationRefs(e, params); r != nil {
+				m[k] = r
+			}
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			if r := resolveCloudFormationRefs(e, params); r != nil {
+				s = append(s, r)
+			}
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// cloudFormationValues returns the Terraform values from the CloudFormation properties
+// with the keys of attrs renamed to their value, the other properties are ignored
+func cloudFormationValues(props map[string]interface{}, attrs map[string]string) map[string]interface{} {
+	vals := make(map[string]interface{})
+	for prop, attr := range attrs {
+		if v, ok := props[prop]; ok {
+			vals[attr] = v
+		}
+	}
+	return vals
+}
+
+// cloudFormationStackExample returns the ResourceExample of the aws_cloudformation_stack, see Provider.ResourceExamples
+func (p *Provider) cloudFormationStackExample() terraform.ResourceExample {
+	return p.example("aws_cloudformation_stack", map[string]interface{}{
+		"template_body": `{"Resources": {"Instance": {"Type": "AWS::EC2::Instance", "Properties": {"InstanceType": "t3.medium"}}}}`,
+	})
+}
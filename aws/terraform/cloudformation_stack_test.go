@@ -0,0 +1,122 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+const cloudFormationTemplateBody = `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  InstanceType:
+    Type: String
+    Default: t3.micro
+  VolumeSize:
+    Type: Number
+    Default: 50
+Resources:
+  Web:
+    Type: AWS::EC2::Instance
+    Properties:
+      InstanceType: !Ref InstanceType
+      SubnetId: !GetAtt Subnet.SubnetId
+      BlockDeviceMappings:
+        - DeviceName: /dev/xvda
+          Ebs:
+            VolumeSize: 20
+            VolumeType: gp2
+  Data:
+    Type: AWS::EC2::Volume
+    Properties:
+      AvailabilityZone: !Sub "${AWS::Region}a"
+      Size: !Ref VolumeSize
+      VolumeType: gp2
+  WebSecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupDescription: web
+`
+
+func TestCloudFormationStack_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		return terraform.Resource{
+			Address:      "aws_cloudformation_stack.test",
+			Mode:         "managed",
+			Type:         "aws_cloudformation_stack",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       values,
+		}
+	}
+
+	names := func(comps []query.Component) []string {
+		ns := make([]string, 0, len(comps))
+		for _, c := range comps {
+			ns = append(ns, c.Name)
+		}
+		return ns
+	}
+
+	attribute := func(comp query.Component, key string) *product.AttributeFilter {
+		for _, af := range comp.ProductFilter.AttributeFilters {
+			if af.Key == key {
+				return af
+			}
+		}
+		return nil
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"template_body": cloudFormationTemplateBody,
+			"parameters":    map[string]interface{}{"InstanceType": "m5.large"},
+		}))
+
+		assert.Equal(t, []string{"Data: Storage", "Web: Compute", "Web: Root volume: Storage"}, names(actual))
+		assert.Equal(t, &product.AttributeFilter{Key: "InstanceType", Value: util.StringPtr("m5.large")}, attribute(actual[1], "InstanceType"))
+		assert.Equal(t, "50", actual[0].MonthlyQuantity.String())
+		assert.Equal(t, "20", actual[2].MonthlyQuantity.String())
+		assert.Equal(t, []string{"the nested resources of type AWS::EC2::SecurityGroup are not estimated"}, warnings)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"template_body": `{"Resources": {"Database": {"Type": "AWS::RDS::DBInstance", "Properties": {"DBInstanceClass": "db.t3.medium", "Engine": "postgres", "AllocatedStorage": "20"}}}}`,
+		}))
+
+		require.NotEmpty(t, actual)
+		for _, c := range actual {
+			assert.Contains(t, c.Name, "Database: ")
+		}
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("TemplateURL", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"template_url": "https://s3.amazonaws.com/bucket/template.yaml",
+		}))
+
+		assert.Empty(t, actual)
+		assert.Equal(t, []string{"the template_url is not supported, only the stacks with a template_body are estimated"}, warnings)
+	})
+
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"template_body": "Resources: [",
+		}))
+
+		assert.Empty(t, actual)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "invalid template_body")
+	})
+}
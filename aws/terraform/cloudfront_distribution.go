@@ -0,0 +1,232 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// cloudFrontEdgeRegion is a geographic region of the CloudFront edge locations,
+// the data transfer and requests are charged with the rates of the region
+// the viewers are served from
+type cloudFrontEdgeRegion struct {
+	name string
+	// usageTypePrefix is the prefix of the usage types of the region, ex: EU-DataTransfer-Out-Bytes
+	usageTypePrefix string
+}
+
+// cloudFrontEdgeRegions are all the edge regions sorted by the price classes that include them
+var cloudFrontEdgeRegions = []cloudFrontEdgeRegion{
+	// PriceClass_100
+	{name: "United States", usageTypePrefix: "US"},
+	{name: "Canada", usageTypePrefix: "CA"},
+	{name: "Europe", usageTypePrefix: "EU"},
+	// PriceClass_200
+	{name: "Japan", usageTypePrefix: "JP"},
+	{name: "Asia Pacific", usageTypePrefix: "AP"},
+	{name: "India", usageTypePrefix: "IN"},
+	{name: "Middle East", usageTypePrefix: "ME"},
+	{name: "South Africa", usageTypePrefix: "ZA"},
+	// PriceClass_All
+	{name: "South America", usageTypePrefix: "SA"},
+	{name: "Australia", usageTypePrefix: "AU"},
+}
+
+// cloudFrontPriceClassRegions is the number of cloudFrontEdgeRegions included on each price class
+var cloudFrontPriceClassRegions = map[string]int{
+	"PriceClass_100": 3,
+	"PriceClass_200": 8,
+	"PriceClass_All": len(cloudFrontEdgeRegions),
+}
+
+// CloudFrontDistribution represents a CloudFront distribution definition that can be cost-estimated.
+// The distribution itself has no cost, it's the data transferred to the internet and the requests
+// of each edge region which are charged. CloudFront is a global service so the prices are not regional.
+type CloudFrontDistribution struct {
+	provider   *Provider
+	priceClass string
+
+	// Usage, keyed by the usageTypePrefix of the edge region
+	monthlyDataTransferToInternetGB map[string]decimal.Decimal
+	monthlyHTTPRequests             map[string]decimal.Decimal
+	monthlyHTTPSRequests            map[string]decimal.Decimal
+}
+
+// cloudFrontRegionsUsage is the usage of each edge region
+type cloudFrontRegionsUsage struct {
+	US           float64 `mapstructure:"us"`
+	Canada       float64 `mapstructure:"canada"`
+	Europe       float64 `mapstructure:"europe"`
+	Japan        float64 `mapstructure:"japan"`
+	AsiaPacific  float64 `mapstructure:"asia_pacific"`
+	India        float64 `mapstructure:"india"`
+	MiddleEast   float64 `mapstructure:"middle_east"`
+	SouthAfrica  float64 `mapstructure:"south_africa"`
+	SouthAmerica float64 `mapstructure:"south_america"`
+	Australia    float64 `mapstructure:"australia"`
+}
+
+// byUsageTypePrefix returns the usage keyed by the usageTypePrefix of the edge regions
+func (u cloudFrontRegionsUsage) byUsageTypePrefix() map[string]decimal.Decimal {
+	return map[string]decimal.Decimal{
+		"US": decimal.NewFromFloat(u.US),
+		"CA": decimal.NewFromFloat(u.Canada),
+		"EU": decimal.NewFromFloat(u.Europe),
+		"JP": decimal.NewFromFloat(u.Japan),
+		"AP": decimal.NewFromFloat(u.AsiaPacific),
+		"IN": decimal.NewFromFloat(u.India),
+		"ME": decimal.NewFromFloat(u.MiddleEast),
+		"ZA": decimal.NewFromFloat(u.SouthAfrica),
+		"SA": decimal.NewFromFloat(u.SouthAmerica),
+		"AU": decimal.NewFromFloat(u.Australia),
+	}
+}
+
+type cloudFrontDistributionValues struct {
+	PriceClass string `mapstructure:"price_class"`
+
+	Usage struct {
+		MonthlyDataTransferToInternetGB cloudFrontRegionsUsage `mapstructure:"monthly_data_transfer_to_internet_gb"`
+		MonthlyHTTPRequests             cloudFrontRegionsUsage `mapstructure:"monthly_http_requests"`
+		MonthlyHTTPSRequests            cloudFrontRegionsUsage `mapstructure:"monthly_https_requests"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeCloudFrontDistributionValues decodes and returns cloudFrontDistributionValues from a Terraform values map.
+func decodeCloudFrontDistributionValues(tfVals map[string]interface{}) (cloudFrontDistributionValues, error) {
+	var v cloudFrontDistributionValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newCloudFrontDistribution creates a new CloudFrontDistribution from cloudFrontDistributionValues.
+func (p *Provider) newCloudFrontDistribution(_ map[string]terraform.Resource, vals cloudFrontDistributionValues) *CloudFrontDistribution {
+	v := &CloudFrontDistribution{
+		provider:   p,
+		priceClass: "PriceClass_All",
+
+		// From Usage
+		monthlyDataTransferToInternetGB: vals.Usage.MonthlyDataTransferToInternetGB.byUsageTypePrefix(),
+		monthlyHTTPRequests:             vals.Usage.MonthlyHTTPRequests.byUsageTypePrefix(),
+		monthlyHTTPSRequests:            vals.Usage.MonthlyHTTPSRequests.byUsageTypePrefix(),
+	}
+
+	if vals.PriceClass != "" {
+		if _, ok := cloudFrontPriceClassRegions[vals.PriceClass]; ok {
+			v.priceClass = vals.PriceClass
+		} else {
+			p.warnf("unknown price_class %q, PriceClass_All is assumed", vals.PriceClass)
+		}
+	}
+
+	return v
+}
+
+// Components returns the price component queries that make up the CloudFrontDistribution.
+// The usage of the edge regions excluded from the price class is served by the edge locations
+// of the included regions and charged at the rate of the least expensive one (United States).
+func (v *CloudFrontDistribution) Components() []query.Component {
+	included := cloudFrontEdgeRegions[:cloudFrontPriceClassRegions[v.priceClass]]
+	for _, er := range cloudFrontEdgeRegions[len(included):] {
+		for _, u := range []map[string]decimal.Decimal{v.monthlyDataTransferToInternetGB, v.monthlyHTTPRequests, v.monthlyHTTPSRequests} {
+			u["US"] = u["US"].Add(u[er.usageTypePrefix])
+			u[er.usageTypePrefix] = decimal.Zero
+		}
+	}
+
+	components := make([]query.Component, 0)
+	for _, er := range included {
+		if q := v.monthlyDataTransferToInternetGB[er.usageTypePrefix]; q.IsPositive() {
+			components = append(components, v.dataTransferComponent(er, q))
+		}
+		if q := v.monthlyHTTPRequests[er.usageTypePrefix]; q.IsPositive() {
+			components = append(components, v.requestsComponent(er, "HTTP", "Requests-Tier1", q))
+		}
+		if q := v.monthlyHTTPSRequests[er.usageTypePrefix]; q.IsPositive() {
+			components = append(components, v.requestsComponent(er, "HTTPS", "Requests-Tier2-HTTPS", q))
+		}
+	}
+
+	// Without usage the distribution is still reported as estimated
+	if len(components) == 0 {
+		components = append(components, v.dataTransferComponent(included[0], decimal.Zero))
+	}
+
+	return components
+}
+
+func (v *CloudFrontDistribution) dataTransferComponent(er cloudFrontEdgeRegion, quantity decimal.Decimal) query.Component {
+	return query.Component{
+		Name:            fmt.Sprintf("Data transfer out to internet (%s)", er.name),
+		MonthlyQuantity: quantity,
+		Details:         []string{"CloudFront", v.priceClass},
+		Usage:           true,
+		Unit:            "GB",
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonCloudFront"),
+			Family:   util.StringPtr("Data Transfer"),
+			Location: util.StringPtr(region.Global.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", Value: util.StringPtr(fmt.Sprintf("%s-DataTransfer-Out-Bytes", er.usageTypePrefix))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+func (v *CloudFrontDistribution) requestsComponent(er cloudFrontEdgeRegion, protocol, usageType string, quantity decimal.Decimal) query.Component {
+	return query.Component{
+		Name:            fmt.Sprintf("%s requests (%s)", protocol, er.name),
+		MonthlyQuantity: quantity,
+		Details:         []string{"CloudFront", v.priceClass},
+		Usage:           true,
+		Unit:            "Requests",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonCloudFront"),
+			Family:   util.StringPtr("Request"),
+			Location: util.StringPtr(region.Global.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", Value: util.StringPtr(fmt.Sprintf("%s-%s", er.usageTypePrefix, usageType))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// cloudFrontDistributionExample returns the ResourceExample of the aws_cloudfront_distribution, see Provider.ResourceExamples
+func (p *Provider) cloudFrontDistributionExample() terraform.ResourceExample {
+	return p.example("aws_cloudfront_distribution", map[string]interface{}{
+		"price_class": "PriceClass_100",
+	})
+}
@@ -0,0 +1,143 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestCloudFrontDistribution_Components(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	dataTransfer := func(name, usageType, priceClass string, quantity int64) query.Component {
+		return query.Component{
+			Name:            "Data transfer out to internet (" + name + ")",
+			MonthlyQuantity: decimal.NewFromInt(quantity),
+			Details:         []string{"CloudFront", priceClass},
+			Usage:           true,
+			Unit:            "GB",
+			Tiered:          true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonCloudFront"),
+				Family:   util.StringPtr("Data Transfer"),
+				Location: util.StringPtr("global"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", Value: util.StringPtr(usageType)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		}
+	}
+	requests := func(name, usageType, priceClass string, quantity int64) query.Component {
+		return query.Component{
+			Name:            name,
+			MonthlyQuantity: decimal.NewFromInt(quantity),
+			Details:         []string{"CloudFront", priceClass},
+			Usage:           true,
+			Unit:            "Requests",
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonCloudFront"),
+				Family:   util.StringPtr("Request"),
+				Location: util.StringPtr("global"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", Value: util.StringPtr(usageType)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		}
+	}
+
+	t.Run("DefaultUsage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_cloudfront_distribution.test",
+			Type:         "aws_cloudfront_distribution",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				usage.Key: usage.Default.GetUsage("aws_cloudfront_distribution"),
+			},
+		}
+
+		expected := []query.Component{
+			dataTransfer("United States", "US-DataTransfer-Out-Bytes", "PriceClass_All", 100),
+			requests("HTTPS requests (United States)", "US-Requests-Tier2-HTTPS", "PriceClass_All", 1000000),
+			dataTransfer("Europe", "EU-DataTransfer-Out-Bytes", "PriceClass_All", 100),
+			requests("HTTPS requests (Europe)", "EU-Requests-Tier2-HTTPS", "PriceClass_All", 1000000),
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("PriceClass", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_cloudfront_distribution.test",
+			Type:         "aws_cloudfront_distribution",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"price_class": "PriceClass_100",
+				usage.Key: map[string]interface{}{
+					"monthly_data_transfer_to_internet_gb": map[string]interface{}{
+						"us":            100,
+						"japan":         50,
+						"south_america": 10,
+					},
+					"monthly_http_requests": map[string]interface{}{
+						"europe": 20000,
+					},
+				},
+			},
+		}
+
+		expected := []query.Component{
+			dataTransfer("United States", "US-DataTransfer-Out-Bytes", "PriceClass_100", 160),
+			requests("HTTP requests (Europe)", "EU-Requests-Tier1", "PriceClass_100", 20000),
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("NoUsage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_cloudfront_distribution.test",
+			Type:         "aws_cloudfront_distribution",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"price_class": "PriceClass_Unknown",
+			},
+		}
+
+		expected := []query.Component{
+			dataTransfer("United States", "US-DataTransfer-Out-Bytes", "PriceClass_All", 0),
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+		assert.Equal(t, []string{`unknown price_class "PriceClass_Unknown", PriceClass_All is assumed`}, warnings)
+	})
+}
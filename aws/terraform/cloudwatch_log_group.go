@@ -12,7 +12,7 @@ import (
 	"github.com/cycloidio/terracost/util"
 )
 
-// CloudwatchLogGroup represents an SQS queue definition that can be cost-estimated.
+// CloudwatchLogGroup represents a CloudWatch log group definition that can be cost-estimated.
 type CloudwatchLogGroup struct {
 	provider *Provider
 	region   region.Code
@@ -28,6 +28,11 @@ type cloudwatchLogGroupValues struct {
 		MonthlyDataIngestedGB        float64 `mapstructure:"monthly_data_ingested_gb"`
 		StorageGB                    float64 `mapstructure:"storage_gb"`
 		MonthlyDataScannedInsightsGB float64 `mapstructure:"monthly_data_scanned_insights_gb"`
+
+		// MonthlyLogIngestedGB and MonthlyLogStorageGB have precedence
+		// over MonthlyDataIngestedGB and StorageGB when defined
+		MonthlyLogIngestedGB float64 `mapstructure:"monthly_log_ingested_gb"`
+		MonthlyLogStorageGB  float64 `mapstructure:"monthly_log_storage_gb"`
 	} `mapstructure:"tc_usage"`
 }
 
@@ -62,6 +67,13 @@ func (p *Provider) newCloudwatchLogGroup(_ map[string]terraform.Resource, vals c
 		monthlyDataScannedInsightsGB: decimal.NewFromFloat(vals.Usage.MonthlyDataScannedInsightsGB),
 	}
 
+	if vals.Usage.MonthlyLogIngestedGB > 0 {
+		v.monthlyDataIngestedGB = decimal.NewFromFloat(vals.Usage.MonthlyLogIngestedGB)
+	}
+	if vals.Usage.MonthlyLogStorageGB > 0 {
+		v.storageGB = decimal.NewFromFloat(vals.Usage.MonthlyLogStorageGB)
+	}
+
 	return v
 }
 
@@ -150,3 +162,8 @@ func (v *CloudwatchLogGroup) cloudwatchLogGroupInsightsScannedComponent() query.
 		},
 	}
 }
+
+// cloudwatchLogGroupExample returns the ResourceExample of the aws_cloudwatch_log_group, see Provider.ResourceExamples
+func (p *Provider) cloudwatchLogGroupExample() terraform.ResourceExample {
+	return p.example("aws_cloudwatch_log_group", map[string]interface{}{})
+}
@@ -107,4 +107,29 @@ func TestCloudwatchLogGroup_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
+
+	t.Run("LogUsage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_cloudwatch_log_group.test",
+			Type:         "aws_cloudwatch_log_group",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				usage.Key: map[string]interface{}{
+					"monthly_data_ingested_gb": 10,
+					"storage_gb":               200,
+					"monthly_log_ingested_gb":  50,
+					"monthly_log_storage_gb":   500,
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.Len(t, actual, 3)
+		require.Equal(t, "Data ingested", actual[0].Name)
+		require.True(t, decimal.NewFromFloat(50).Equal(actual[0].MonthlyQuantity))
+		require.Equal(t, "Archival Storage", actual[1].Name)
+		require.True(t, decimal.NewFromFloat(500).Equal(actual[1].MonthlyQuantity))
+	})
 }
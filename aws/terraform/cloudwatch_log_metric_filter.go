@@ -0,0 +1,100 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// CloudwatchLogMetricFilter represents a CloudWatch log metric filter definition that can be cost-estimated.
+// Its metric transformation (there can only be one) publishes a custom metric which is what is charged.
+type CloudwatchLogMetricFilter struct {
+	provider *Provider
+	region   region.Code
+
+	metricsCount decimal.Decimal
+}
+
+type cloudwatchLogMetricFilterValues struct {
+	MetricTransformation []struct {
+		Name string `mapstructure:"name"`
+	} `mapstructure:"metric_transformation"`
+}
+
+// decodeCloudwatchLogMetricFilterValues decodes and returns cloudwatchLogMetricFilterValues from a Terraform values map.
+func decodeCloudwatchLogMetricFilterValues(tfVals map[string]interface{}) (cloudwatchLogMetricFilterValues, error) {
+	var v cloudwatchLogMetricFilterValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newCloudwatchLogMetricFilter creates a new CloudwatchLogMetricFilter from cloudwatchLogMetricFilterValues.
+func (p *Provider) newCloudwatchLogMetricFilter(_ map[string]terraform.Resource, vals cloudwatchLogMetricFilterValues) *CloudwatchLogMetricFilter {
+	return &CloudwatchLogMetricFilter{
+		provider:     p,
+		region:       p.region,
+		metricsCount: decimal.NewFromInt(int64(len(vals.MetricTransformation))),
+	}
+}
+
+// Components returns the price component queries that make up the CloudwatchLogMetricFilter,
+// a filter without metric transformation publishes no metric so it has none.
+func (v *CloudwatchLogMetricFilter) Components() []query.Component {
+	if v.metricsCount.IsZero() {
+		return []query.Component{}
+	}
+
+	components := []query.Component{v.customMetricsComponent()}
+	return components
+}
+
+func (v *CloudwatchLogMetricFilter) customMetricsComponent() query.Component {
+	return query.Component{
+		Name:            "Custom metrics",
+		MonthlyQuantity: v.metricsCount,
+		Details:         []string{"Custom metrics"},
+		Unit:            "metrics",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonCloudWatch"),
+			Family:   util.StringPtr("Metric"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				// The usage type has no region prefix on us-east-1
+				{Key: "UsageType", ValueRegex: util.StringPtr(".*CW:MetricMonitorUsage$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Metrics"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				{Key: "StartingRange", Value: util.StringPtr("0")},
+			},
+		},
+	}
+}
+
+// cloudwatchLogMetricFilterExample returns the ResourceExample of the aws_cloudwatch_log_metric_filter, see Provider.ResourceExamples
+func (p *Provider) cloudwatchLogMetricFilterExample() terraform.ResourceExample {
+	return p.example("aws_cloudwatch_log_metric_filter", map[string]interface{}{
+		"metric_transformation": []interface{}{map[string]interface{}{"name": "ErrorCount"}},
+	})
+}
@@ -0,0 +1,120 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestCloudwatchLogMetricFilter_Components(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-1")
+	require.NoError(t, err)
+
+	t.Run("MetricFilter", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_cloudwatch_log_metric_filter.test",
+			Type:         "aws_cloudwatch_log_metric_filter",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"metric_transformation": []interface{}{
+					map[string]interface{}{
+						"name": "ErrorCount",
+					},
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		expected := []query.Component{
+			{
+				Name:            "Custom metrics",
+				MonthlyQuantity: decimal.NewFromInt(1),
+				Unit:            "metrics",
+				Details:         []string{"Custom metrics"},
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonCloudWatch"),
+					Family:   util.StringPtr("Metric"),
+					Location: util.StringPtr("eu-west-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr(".*CW:MetricMonitorUsage$")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("Metrics"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+						{Key: "StartingRange", Value: util.StringPtr("0")},
+					},
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(rss, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("UsEast1", func(t *testing.T) {
+		p, err := awstf.NewProvider("aws", "us-east-1")
+		require.NoError(t, err)
+
+		tfres := terraform.Resource{
+			Address:      "aws_cloudwatch_log_metric_filter.test",
+			Type:         "aws_cloudwatch_log_metric_filter",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"metric_transformation": []interface{}{
+					map[string]interface{}{
+						"name": "ErrorCount",
+					},
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.Len(t, actual, 1)
+		assert.True(t, decimal.NewFromInt(1).Equal(actual[0].MonthlyQuantity))
+
+		// The usage type of us-east-1 has no region prefix
+		assert.True(t, actual[0].ProductFilter.Matches(&product.Product{
+			Provider:   "aws",
+			Service:    "AmazonCloudWatch",
+			Family:     "Metric",
+			Location:   "us-east-1",
+			Attributes: map[string]string{"UsageType": "CW:MetricMonitorUsage"},
+		}))
+		assert.False(t, actual[0].ProductFilter.Matches(&product.Product{
+			Provider:   "aws",
+			Service:    "AmazonCloudWatch",
+			Family:     "Metric",
+			Location:   "us-east-1",
+			Attributes: map[string]string{"UsageType": "CW:MetricMonitorUsage-Extra"},
+		}))
+	})
+
+	t.Run("NoMetricTransformation", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_cloudwatch_log_metric_filter.test",
+			Type:         "aws_cloudwatch_log_metric_filter",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       map[string]interface{}{},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		assert.Empty(t, actual)
+	})
+}
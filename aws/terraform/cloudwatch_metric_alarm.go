@@ -14,7 +14,7 @@ import (
 	"github.com/cycloidio/terracost/util"
 )
 
-// CloudwatchMetricAlarm represents an SQS queue definition that can be cost-estimated.
+// CloudwatchMetricAlarm represents a CloudWatch metric alarm definition that can be cost-estimated.
 type CloudwatchMetricAlarm struct {
 	provider           *Provider
 	region             region.Code
@@ -140,3 +140,11 @@ func (v *CloudwatchMetricAlarm) cloudwatchMetricAlarmComponent() query.Component
 		},
 	}
 }
+
+// cloudwatchMetricAlarmExample returns the ResourceExample of the aws_cloudwatch_metric_alarm, see Provider.ResourceExamples
+func (p *Provider) cloudwatchMetricAlarmExample() terraform.ResourceExample {
+	return p.example("aws_cloudwatch_metric_alarm", map[string]interface{}{
+		"comparison_operator": "GreaterThanOrEqualToThreshold",
+		"period":              60,
+	})
+}
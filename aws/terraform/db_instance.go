@@ -10,6 +10,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -219,3 +220,13 @@ func (inst *DBInstance) iopsComponent() query.Component {
 		},
 	}
 }
+
+// dbInstanceExample returns the ResourceExample of the aws_db_instance, see Provider.ResourceExamples
+func (p *Provider) dbInstanceExample() terraform.ResourceExample {
+	return p.example("aws_db_instance", map[string]interface{}{
+		"instance_class":    "db.t3.medium",
+		"engine":            "mysql",
+		"allocated_storage": float64(20),
+		"storage_type":      "gp2",
+	})
+}
@@ -0,0 +1,100 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// DBSnapshot represents a manual RDS snapshot that can be cost-estimated.
+type DBSnapshot struct {
+	providerKey string
+	region      region.Code
+	storageGB   decimal.Decimal
+
+	// storageUsage is set when the storageGB is the one of the usage
+	storageUsage bool
+}
+
+type dbSnapshotValues struct {
+	AllocatedStorage float64 `mapstructure:"allocated_storage"`
+
+	Usage struct {
+		// StorageGB is the size of the snapshot, the allocated_storage is used without it
+		StorageGB float64 `mapstructure:"storage_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeDBSnapshotValues(tfVals map[string]interface{}) (dbSnapshotValues, error) {
+	var v dbSnapshotValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newDBSnapshot creates a new DBSnapshot from dbSnapshotValues.
+func (p *Provider) newDBSnapshot(vals dbSnapshotValues) *DBSnapshot {
+	v := &DBSnapshot{
+		providerKey: p.key,
+		region:      p.region,
+		storageGB:   decimal.NewFromFloat(vals.AllocatedStorage),
+	}
+	if vals.Usage.StorageGB > 0 {
+		v.storageGB = decimal.NewFromFloat(vals.Usage.StorageGB)
+		v.storageUsage = true
+	}
+	return v
+}
+
+// Components returns the price component queries that make up the DBSnapshot.
+// The manual snapshots are charged by the GB-month stored as the backup storage over the free allowance.
+func (v *DBSnapshot) Components() []query.Component {
+	return []query.Component{
+		{
+			Name:            "Snapshot storage",
+			Details:         []string{"Snapshot"},
+			MonthlyQuantity: v.storageGB,
+			Usage:           v.storageUsage,
+			Unit:            "GB-Mo",
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(v.providerKey),
+				Service:  util.StringPtr("AmazonRDS"),
+				Family:   util.StringPtr("Storage Snapshot"),
+				Location: util.StringPtr(v.region.String()),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?RDS:ChargedBackupUsage$")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("GB-Mo"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+	}
+}
+
+// dbSnapshotExample returns the ResourceExample of the aws_db_snapshot, see Provider.ResourceExamples
+func (p *Provider) dbSnapshotExample() terraform.ResourceExample {
+	return p.example("aws_db_snapshot", map[string]interface{}{
+		"allocated_storage": float64(20),
+	})
+}
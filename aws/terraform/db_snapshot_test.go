@@ -0,0 +1,51 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestDBSnapshot_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		return terraform.Resource{
+			Address:      "aws_db_snapshot.test",
+			Mode:         "managed",
+			Type:         "aws_db_snapshot",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       values,
+		}
+	}
+
+	t.Run("AllocatedStorage", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"allocated_storage": 20,
+		}))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "20", actual[0].MonthlyQuantity.String())
+		assert.False(t, actual[0].Usage)
+		assert.Equal(t, util.StringPtr("AmazonRDS"), actual[0].ProductFilter.Service)
+		assert.Equal(t, util.StringPtr("^([A-Z0-9]+-)?RDS:ChargedBackupUsage$"), actual[0].ProductFilter.AttributeFilters[0].ValueRegex)
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"allocated_storage": 20,
+			usage.Key:           map[string]interface{}{"storage_gb": 8},
+		}))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "8", actual[0].MonthlyQuantity.String())
+		assert.True(t, actual[0].Usage)
+	})
+}
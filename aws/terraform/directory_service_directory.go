@@ -0,0 +1,139 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// directoryTypeMap is the Directory Type of the pricing data of each type of directory
+var directoryTypeMap = map[string]string{
+	"SimpleAD":    "Simple AD",
+	"ADConnector": "AD Connector",
+	"MicrosoftAD": "Microsoft AD",
+}
+
+// DirectoryServiceDirectory represents an AWS Directory Service directory that can be cost-estimated.
+type DirectoryServiceDirectory struct {
+	providerKey string
+	region      region.Code
+
+	// directoryType is the type on the pricing data (ex: "Microsoft AD")
+	directoryType string
+
+	// size is the Small/Large size of the Simple AD and AD Connector
+	// or the Standard/Enterprise edition of the Microsoft AD
+	size string
+
+	// domainControllers is the number of domain controllers of the Microsoft AD,
+	// which are charged each, the other directories are charged as one
+	domainControllers decimal.Decimal
+}
+
+type directoryServiceDirectoryValues struct {
+	Type    string `mapstructure:"type"`
+	Size    string `mapstructure:"size"`
+	Edition string `mapstructure:"edition"`
+
+	Usage struct {
+		AdditionalDomainControllers int64 `mapstructure:"additional_domain_controllers"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeDirectoryServiceDirectoryValues(tfVals map[string]interface{}) (directoryServiceDirectoryValues, error) {
+	var v directoryServiceDirectoryValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newDirectoryServiceDirectory creates a new DirectoryServiceDirectory from directoryServiceDirectoryValues.
+func (p *Provider) newDirectoryServiceDirectory(vals directoryServiceDirectoryValues) *DirectoryServiceDirectory {
+	inst := &DirectoryServiceDirectory{
+		providerKey:       p.key,
+		region:            p.region,
+		directoryType:     directoryTypeMap["SimpleAD"],
+		size:              vals.Size,
+		domainControllers: decimal.NewFromInt(1),
+	}
+
+	if vals.Type != "" {
+		dt, ok := directoryTypeMap[vals.Type]
+		if !ok {
+			p.warnf("unknown directory type %q, it's estimated as SimpleAD", vals.Type)
+		} else {
+			inst.directoryType = dt
+		}
+	}
+
+	if inst.directoryType == directoryTypeMap["MicrosoftAD"] {
+		// The Microsoft AD are deployed with 2 domain controllers
+		// and the Enterprise edition is the default one
+		inst.size = vals.Edition
+		if inst.size == "" {
+			inst.size = "Enterprise"
+		}
+		inst.domainControllers = decimal.NewFromInt(2 + vals.Usage.AdditionalDomainControllers)
+	} else if inst.size == "" {
+		p.warnf("no size, the directory is estimated as Small")
+		inst.size = "Small"
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the DirectoryServiceDirectory.
+func (inst *DirectoryServiceDirectory) Components() []query.Component {
+	name := "Directory"
+	if inst.directoryType == directoryTypeMap["MicrosoftAD"] {
+		name = "Domain controllers"
+	}
+
+	return []query.Component{
+		{
+			Name:           name,
+			Details:        []string{inst.directoryType, inst.size},
+			HourlyQuantity: inst.domainControllers,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(inst.providerKey),
+				Service:  util.StringPtr("AWSDirectoryService"),
+				Family:   util.StringPtr("AWS Directory Service"),
+				Location: util.StringPtr(inst.region.String()),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "DirectoryType", Value: util.StringPtr(inst.directoryType)},
+					{Key: "DirectorySize", Value: util.StringPtr(inst.size)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+	}
+}
+
+// directoryServiceDirectoryExample returns the ResourceExample of the aws_directory_service_directory, see Provider.ResourceExamples
+func (p *Provider) directoryServiceDirectoryExample() terraform.ResourceExample {
+	return p.example("aws_directory_service_directory", map[string]interface{}{
+		"type":    "MicrosoftAD",
+		"edition": "Standard",
+	})
+}
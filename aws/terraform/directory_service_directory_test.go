@@ -0,0 +1,96 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestDirectoryServiceDirectory_Components(t *testing.T) {
+	p, err := NewProvider("aws", "us-east-1")
+	require.NoError(t, err)
+
+	component := func(name, directoryType, size string, quantity int64) query.Component {
+		return query.Component{
+			Name:           name,
+			Details:        []string{directoryType, size},
+			HourlyQuantity: decimal.NewFromInt(quantity),
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AWSDirectoryService"),
+				Family:   util.StringPtr("AWS Directory Service"),
+				Location: util.StringPtr("us-east-1"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "DirectoryType", Value: util.StringPtr(directoryType)},
+					{Key: "DirectorySize", Value: util.StringPtr(size)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		}
+	}
+
+	t.Run("SimpleAD", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_directory_service_directory.test",
+			Type:         "aws_directory_service_directory",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"size": "Large",
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, []query.Component{component("Directory", "Simple AD", "Large", 1)}, actual)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("MicrosoftAD", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_directory_service_directory.test",
+			Type:         "aws_directory_service_directory",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"type":    "MicrosoftAD",
+				"edition": "Standard",
+				usage.Key: map[string]interface{}{
+					"additional_domain_controllers": 1,
+				},
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, []query.Component{component("Domain controllers", "Microsoft AD", "Standard", 3)}, actual)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("ADConnectorNoSize", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_directory_service_directory.test",
+			Type:         "aws_directory_service_directory",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"type": "ADConnector",
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, []query.Component{component("Directory", "AD Connector", "Small", 1)}, actual)
+		assert.Equal(t, []string{"no size, the directory is estimated as Small"}, warnings)
+	})
+}
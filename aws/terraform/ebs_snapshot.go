@@ -0,0 +1,109 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// EBSSnapshot represents an EBS snapshot that can be cost-estimated.
+type EBSSnapshot struct {
+	providerKey string
+	region      region.Code
+	storageTier string
+	storageGB   decimal.Decimal
+
+	// storageUsage is set when the storageGB is the one of the usage
+	storageUsage bool
+}
+
+type ebsSnapshotValues struct {
+	VolumeSize  float64 `mapstructure:"volume_size"`
+	StorageTier string  `mapstructure:"storage_tier"`
+
+	Usage struct {
+		// StorageGB is the size of the snapshot, which is incremental so it's
+		// usually smaller than the volume, the volume_size is used without it
+		StorageGB float64 `mapstructure:"storage_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeEBSSnapshotValues(tfVals map[string]interface{}) (ebsSnapshotValues, error) {
+	var v ebsSnapshotValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newEBSSnapshot creates a new EBSSnapshot from ebsSnapshotValues.
+func (p *Provider) newEBSSnapshot(vals ebsSnapshotValues) *EBSSnapshot {
+	v := &EBSSnapshot{
+		providerKey: p.key,
+		region:      p.region,
+		storageTier: vals.StorageTier,
+		storageGB:   decimal.NewFromFloat(vals.VolumeSize),
+	}
+	if vals.Usage.StorageGB > 0 {
+		v.storageGB = decimal.NewFromFloat(vals.Usage.StorageGB)
+		v.storageUsage = true
+	}
+	return v
+}
+
+// Components returns the price component queries that make up the EBSSnapshot.
+// The snapshots are charged by the GB-month stored, on the standard or the archive tier.
+func (v *EBSSnapshot) Components() []query.Component {
+	name, usageType := "Storage", "EBS:SnapshotUsage"
+	if v.storageTier == "archive" {
+		name, usageType = "Archive storage", "EBS:SnapshotArchiveStorage"
+	}
+
+	return []query.Component{
+		{
+			Name:            name,
+			Details:         []string{"Snapshot"},
+			MonthlyQuantity: v.storageGB,
+			Usage:           v.storageUsage,
+			Unit:            "GB-Mo",
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(v.providerKey),
+				Service:  util.StringPtr("AmazonEC2"),
+				Family:   util.StringPtr("Storage Snapshot"),
+				Location: util.StringPtr(v.region.String()),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?" + usageType + "$")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("GB-Mo"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+	}
+}
+
+// ebsSnapshotExample returns the ResourceExample of the aws_ebs_snapshot, see Provider.ResourceExamples
+func (p *Provider) ebsSnapshotExample() terraform.ResourceExample {
+	return p.example("aws_ebs_snapshot", map[string]interface{}{
+		"volume_size": float64(100),
+	})
+}
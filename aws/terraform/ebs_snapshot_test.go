@@ -0,0 +1,55 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestEBSSnapshot_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		return terraform.Resource{
+			Address:      "aws_ebs_snapshot.test",
+			Mode:         "managed",
+			Type:         "aws_ebs_snapshot",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       values,
+		}
+	}
+
+	t.Run("VolumeSize", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"volume_size": 100,
+		}))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "Storage", actual[0].Name)
+		assert.Equal(t, "100", actual[0].MonthlyQuantity.String())
+		assert.False(t, actual[0].Usage)
+		assert.Equal(t, util.StringPtr("Storage Snapshot"), actual[0].ProductFilter.Family)
+		assert.Equal(t, util.StringPtr("^([A-Z0-9]+-)?EBS:SnapshotUsage$"), actual[0].ProductFilter.AttributeFilters[0].ValueRegex)
+	})
+
+	t.Run("ArchiveWithUsage", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"volume_size":  100,
+			"storage_tier": "archive",
+			usage.Key:      map[string]interface{}{"storage_gb": 30},
+		}))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "Archive storage", actual[0].Name)
+		assert.Equal(t, "30", actual[0].MonthlyQuantity.String())
+		assert.True(t, actual[0].Usage)
+		assert.Equal(t, util.StringPtr("^([A-Z0-9]+-)?EBS:SnapshotArchiveStorage$"), actual[0].ProductFilter.AttributeFilters[0].ValueRegex)
+	})
+}
@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// EC2Host represents an EC2 Dedicated Host definition that can be cost-estimated.
+// The host is charged by the hour for its instance family, the instances placed
+// on it (with 'host' tenancy) have no compute cost.
+type EC2Host struct {
+	providerKey string
+	region      region.Code
+
+	// instanceFamily is the family of the instances that the host supports (ex: m5)
+	instanceFamily string
+}
+
+// ec2HostValues represents the structure of Terraform values for aws_ec2_host resource.
+type ec2HostValues struct {
+	AvailabilityZone string `mapstructure:"availability_zone"`
+	InstanceType     string `mapstructure:"instance_type"`
+	InstanceFamily   string `mapstructure:"instance_family"`
+}
+
+// decodeEC2HostValues decodes and returns ec2HostValues from a Terraform values map.
+func decodeEC2HostValues(tfVals map[string]interface{}) (ec2HostValues, error) {
+	var v ec2HostValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newEC2Host creates a new EC2Host from ec2HostValues.
+func (p *Provider) newEC2Host(vals ec2HostValues) *EC2Host {
+	inst := &EC2Host{
+		providerKey:    p.key,
+		region:         p.region,
+		instanceFamily: vals.InstanceFamily,
+	}
+
+	if reg := region.NewFromZone(vals.AvailabilityZone); reg.Valid() {
+		inst.region = reg
+	}
+
+	// Only one of instance_type or instance_family can be set,
+	// the host is charged by the family of the instance type
+	if inst.instanceFamily == "" && vals.InstanceType != "" {
+		inst.instanceFamily = strings.Split(vals.InstanceType, ".")[0]
+	}
+	if inst.instanceFamily == "" {
+		p.warnf("no instance_family or instance_type, the dedicated host is not estimated")
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this EC2Host.
+func (inst *EC2Host) Components() []query.Component {
+	if inst.instanceFamily == "" {
+		return []query.Component{}
+	}
+
+	return []query.Component{inst.hostComponent()}
+}
+
+func (inst *EC2Host) hostComponent() query.Component {
+	return query.Component{
+		Name:           "Dedicated host",
+		Details:        []string{"on-demand", inst.instanceFamily},
+		HourlyQuantity: decimal.NewFromInt(1),
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AmazonEC2"),
+			Family:   util.StringPtr("Dedicated Host"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				// The UsageType has a region prefix (ex: EUW3-HostUsage:m5) except on us-east-1
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("HostUsage:%s$", inst.instanceFamily))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Hrs"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// ec2HostExample returns the ResourceExample of the aws_ec2_host, see Provider.ResourceExamples
+func (p *Provider) ec2HostExample() terraform.ResourceExample {
+	return p.example("aws_ec2_host", map[string]interface{}{
+		"instance_family": "m5",
+	})
+}
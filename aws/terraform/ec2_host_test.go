@@ -0,0 +1,97 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestEC2Host_Components(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "us-east-1")
+	require.NoError(t, err)
+
+	expected := []query.Component{
+		{
+			Name:           "Dedicated host",
+			Details:        []string{"on-demand", "m5"},
+			HourlyQuantity: decimal.NewFromInt(1),
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonEC2"),
+				Family:   util.StringPtr("Dedicated Host"),
+				Location: util.StringPtr("eu-west-3"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr("HostUsage:m5$")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("Hrs"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+	}
+
+	t.Run("InstanceType", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_ec2_host.test",
+			Type:         "aws_ec2_host",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"availability_zone": "eu-west-3a",
+				"instance_type":     "m5.large",
+			},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("InstanceFamily", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_ec2_host.test",
+			Type:         "aws_ec2_host",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"availability_zone": "eu-west-3a",
+				"instance_family":   "m5",
+			},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_ec2_host.test",
+			Type:         "aws_ec2_host",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"availability_zone": "eu-west-3a",
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Empty(t, actual)
+		assert.Equal(t, []string{"no instance_family or instance_type, the dedicated host is not estimated"}, warnings)
+
+		tfres.Values["instance_family"] = "m5"
+		_, warnings = p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Empty(t, warnings)
+	})
+}
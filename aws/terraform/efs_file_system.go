@@ -7,6 +7,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
@@ -18,6 +19,7 @@ type EFSFileSystem struct {
 	provider                     *Provider
 	region                       region.Code
 	availabilityZoneName         string
+	performanceMode              string
 	throughputMode               string
 	provisionedThroughputInMibps decimal.Decimal
 
@@ -27,6 +29,8 @@ type EFSFileSystem struct {
 	infrequentAccessStorageGB      decimal.Decimal
 	monthlyInfrequentAccessReadGB  decimal.Decimal
 	monthlyInfrequentAccessWriteGB decimal.Decimal
+	monthlyElasticReadGB           decimal.Decimal
+	monthlyElasticWriteGB          decimal.Decimal
 }
 
 // efsFileSystemValues represents the structure of Terraform values for aws_efs_file_system resource.
@@ -36,6 +40,8 @@ type efsFileSystemValues struct {
 		TransitionToIa                  string `mapstructure:"transition_to_ia"`
 		TransitionToPrimaryStorageClass string `mapstructure:"transition_to_primary_storage_class"`
 	} `mapstructure:"lifecycle_policy"`
+	PerformanceMode string `mapstructure:"performance_mode"`
+	// bursting, provisioned or elastic
 	ThroughputMode string `mapstructure:"throughput_mode"`
 	// only available if ThroughputMode=provisioned
 	ProvisionedThroughputInMibps float64 `mapstructure:"provisioned_throughput_in_mibps"`
@@ -45,6 +51,9 @@ type efsFileSystemValues struct {
 		InfrequentAccessStorageGB      float64 `mapstructure:"infrequent_access_storage_gb"`
 		MonthlyInfrequentAccessReadGB  float64 `mapstructure:"monthly_infrequent_access_read_gb"`
 		MonthlyInfrequentAccessWriteGB float64 `mapstructure:"monthly_infrequent_access_write_gb"`
+		// only used if ThroughputMode=elastic
+		MonthlyElasticReadGB  float64 `mapstructure:"monthly_elastic_read_gb"`
+		MonthlyElasticWriteGB float64 `mapstructure:"monthly_elastic_write_gb"`
 	} `mapstructure:"tc_usage"`
 }
 
@@ -70,9 +79,10 @@ func decodeEFSFileSystemValues(tfVals map[string]interface{}) (efsFileSystemValu
 // newEFSFileSystem creates a new EFSFileSystem from efsFileSystemValues.
 func (p *Provider) newEFSFileSystem(_ map[string]terraform.Resource, vals efsFileSystemValues) *EFSFileSystem {
 	v := &EFSFileSystem{
-		provider:       p,
-		region:         p.region,
-		throughputMode: "bursting",
+		provider:        p,
+		region:          p.region,
+		performanceMode: "generalPurpose",
+		throughputMode:  "bursting",
 		// only available if ThroughputMode=provisioned
 		provisionedThroughputInMibps: decimal.NewFromFloat(0),
 		hasLifecyclePolicy:           false,
@@ -81,6 +91,8 @@ func (p *Provider) newEFSFileSystem(_ map[string]terraform.Resource, vals efsFil
 		infrequentAccessStorageGB:      decimal.NewFromFloat(vals.Usage.InfrequentAccessStorageGB),
 		monthlyInfrequentAccessReadGB:  decimal.NewFromFloat(vals.Usage.MonthlyInfrequentAccessReadGB),
 		monthlyInfrequentAccessWriteGB: decimal.NewFromFloat(vals.Usage.MonthlyInfrequentAccessWriteGB),
+		monthlyElasticReadGB:           decimal.NewFromFloat(vals.Usage.MonthlyElasticReadGB),
+		monthlyElasticWriteGB:          decimal.NewFromFloat(vals.Usage.MonthlyElasticWriteGB),
 	}
 
 	if reg := region.NewFromZone(vals.AvailabilityZoneName); reg.Valid() {
@@ -92,6 +104,10 @@ func (p *Provider) newEFSFileSystem(_ map[string]terraform.Resource, vals efsFil
 		v.hasLifecyclePolicy = true
 	}
 
+	if vals.PerformanceMode != "" {
+		v.performanceMode = vals.PerformanceMode
+	}
+
 	if vals.ThroughputMode != "" {
 		v.throughputMode = vals.ThroughputMode
 	}
@@ -118,16 +134,29 @@ func (v *EFSFileSystem) calculateProvisionedThroughput(storageGB decimal.Decimal
 }
 
 // Components returns the price component queries that make up the EFSFileSystem.
+// The standard storage is always charged, the Infrequent Access storage and requests only
+// with a lifecycle policy and the throughput depends on the throughput mode: the provisioned
+// one above the baseline of the storage and the elastic one by GB read and written.
 func (v *EFSFileSystem) Components() []query.Component {
 	usagetype := ".*-TimedStorage-ByteHrs"
 	if v.availabilityZoneName != "" {
 		usagetype = ".*-TimedStorage-Z-ByteHrs"
 	}
 
-	components := []query.Component{v.efsFileSystemComponent(usagetype, v.storageGB)}
+	components := []query.Component{v.efsFileSystemComponent("Standard storage", usagetype, v.storageGB)}
 
-	if v.provisionedThroughputInMibps.GreaterThan(decimal.NewFromInt(0)) {
-		components = append(components, v.provisionedThroughputComponent())
+	switch v.throughputMode {
+	case "provisioned":
+		if v.provisionedThroughputInMibps.IsPositive() {
+			components = append(components, v.provisionedThroughputComponent())
+		}
+	case "elastic":
+		if v.monthlyElasticReadGB.IsPositive() {
+			components = append(components, v.elasticThroughputComponent("Read", v.monthlyElasticReadGB))
+		}
+		if v.monthlyElasticWriteGB.IsPositive() {
+			components = append(components, v.elasticThroughputComponent("Write", v.monthlyElasticWriteGB))
+		}
 	}
 
 	if v.hasLifecyclePolicy {
@@ -136,29 +165,28 @@ func (v *EFSFileSystem) Components() []query.Component {
 			usagetype = ".*-IATimedStorage-Z-ByteHrs"
 		}
 
-		if v.infrequentAccessStorageGB.GreaterThan(decimal.NewFromInt(0)) {
-			components = append(components, v.efsFileSystemComponent(usagetype, v.infrequentAccessStorageGB))
+		if v.infrequentAccessStorageGB.IsPositive() {
+			components = append(components, v.efsFileSystemComponent("Infrequent Access storage", usagetype, v.infrequentAccessStorageGB))
 		}
 
-		if v.monthlyInfrequentAccessReadGB.GreaterThan(decimal.NewFromInt(0)) {
+		if v.monthlyInfrequentAccessReadGB.IsPositive() {
 			components = append(components, v.requestsComponent("Read"))
 		}
 
-		if v.monthlyInfrequentAccessWriteGB.GreaterThan(decimal.NewFromInt(0)) {
+		if v.monthlyInfrequentAccessWriteGB.IsPositive() {
 			components = append(components, v.requestsComponent("Write"))
 		}
-
 	}
 
 	return components
 }
 
-func (v *EFSFileSystem) efsFileSystemComponent(usagetype string, storageGB decimal.Decimal) query.Component {
+func (v *EFSFileSystem) efsFileSystemComponent(name, usagetype string, storageGB decimal.Decimal) query.Component {
 	return query.Component{
-		Name:            fmt.Sprintf("Storage %s", usagetype),
+		Name:            name,
 		MonthlyQuantity: storageGB,
 		Unit:            "GB",
-		Details:         []string{"EFS storage", usagetype},
+		Details:         []string{"EFS storage", v.performanceMode},
 		Usage:           true,
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
@@ -177,7 +205,7 @@ func (v *EFSFileSystem) provisionedThroughputComponent() query.Component {
 		Name:            "Provisioned throughput",
 		MonthlyQuantity: v.provisionedThroughputInMibps,
 		Unit:            "MBps",
-		Details:         []string{"Througput"},
+		Details:         []string{"Throughput", "provisioned"},
 		Usage:           true,
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
@@ -191,6 +219,31 @@ func (v *EFSFileSystem) provisionedThroughputComponent() query.Component {
 	}
 }
 
+func (v *EFSFileSystem) elasticThroughputComponent(accessType string, gb decimal.Decimal) query.Component {
+	return query.Component{
+		Name:            fmt.Sprintf("Elastic throughput %s", accessType),
+		MonthlyQuantity: gb,
+		Unit:            "GB",
+		Details:         []string{"Throughput", "elastic", accessType},
+		Usage:           true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonEFS"),
+			Family:   util.StringPtr("Throughput"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "ThroughputClass", Value: util.StringPtr("Elastic")},
+				{Key: "AccessType", Value: util.StringPtr(accessType)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
 func (v *EFSFileSystem) requestsComponent(accessType string) query.Component {
 	var requestsGB decimal.Decimal
 	if accessType == "Read" {
@@ -217,3 +270,10 @@ func (v *EFSFileSystem) requestsComponent(accessType string) query.Component {
 		},
 	}
 }
+
+// efsFileSystemExample returns the ResourceExample of the aws_efs_file_system, see Provider.ResourceExamples
+func (p *Provider) efsFileSystemExample() terraform.ResourceExample {
+	return p.example("aws_efs_file_system", map[string]interface{}{
+		"throughput_mode": "bursting",
+	})
+}
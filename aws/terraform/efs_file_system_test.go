@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
@@ -31,10 +32,10 @@ func TestEFSFileSystem_Components(t *testing.T) {
 
 		expected := []query.Component{
 			{
-				Name:            "Storage .*-TimedStorage-ByteHrs",
+				Name:            "Standard storage",
 				MonthlyQuantity: decimal.NewFromFloat(180),
 				Unit:            "GB",
-				Details:         []string{"EFS storage", ".*-TimedStorage-ByteHrs"},
+				Details:         []string{"EFS storage", "generalPurpose"},
 				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
@@ -73,10 +74,10 @@ func TestEFSFileSystem_Components(t *testing.T) {
 
 		expected := []query.Component{
 			{
-				Name:            "Storage .*-TimedStorage-ByteHrs",
+				Name:            "Standard storage",
 				MonthlyQuantity: decimal.NewFromFloat(180),
 				Unit:            "GB",
-				Details:         []string{"EFS storage", ".*-TimedStorage-ByteHrs"},
+				Details:         []string{"EFS storage", "generalPurpose"},
 				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
@@ -92,7 +93,7 @@ func TestEFSFileSystem_Components(t *testing.T) {
 				Name:            "Provisioned throughput",
 				MonthlyQuantity: decimal.NewFromFloat(11),
 				Unit:            "MBps",
-				Details:         []string{"Througput"},
+				Details:         []string{"Throughput", "provisioned"},
 				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
@@ -105,10 +106,11 @@ func TestEFSFileSystem_Components(t *testing.T) {
 				},
 			},
 			{
-				Name:            "Storage .*-IATimedStorage-ByteHrs",
+				Name:            "Infrequent Access storage",
 				MonthlyQuantity: decimal.NewFromFloat(10),
-				Unit:            "GB", Details: []string{"EFS storage", ".*-IATimedStorage-ByteHrs"},
-				Usage: true,
+				Unit:            "GB",
+				Details:         []string{"EFS storage", "generalPurpose"},
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("AmazonEFS"),
@@ -160,4 +162,87 @@ func TestEFSFileSystem_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
+
+	t.Run("Elastic", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_efs_file_system.test",
+			Type:         "aws_efs_file_system",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"availability_zone_name": "eu-west-3a",
+				"performance_mode":       "maxIO",
+				"throughput_mode":        "elastic",
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		expected := []query.Component{
+			{
+				Name:            "Standard storage",
+				MonthlyQuantity: decimal.NewFromFloat(180),
+				Unit:            "GB",
+				Details:         []string{"EFS storage", "maxIO"},
+				Usage:           true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonEFS"),
+					Family:   util.StringPtr("Storage"),
+					Location: util.StringPtr("eu-west-3"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr(".*-TimedStorage-Z-ByteHrs")},
+					},
+				},
+			},
+			{
+				Name:            "Elastic throughput Read",
+				MonthlyQuantity: decimal.NewFromFloat(100),
+				Unit:            "GB",
+				Details:         []string{"Throughput", "elastic", "Read"},
+				Usage:           true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonEFS"),
+					Family:   util.StringPtr("Throughput"),
+					Location: util.StringPtr("eu-west-3"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "ThroughputClass", Value: util.StringPtr("Elastic")},
+						{Key: "AccessType", Value: util.StringPtr("Read")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+			{
+				Name:            "Elastic throughput Write",
+				MonthlyQuantity: decimal.NewFromFloat(20),
+				Unit:            "GB",
+				Details:         []string{"Throughput", "elastic", "Write"},
+				Usage:           true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonEFS"),
+					Family:   util.StringPtr("Throughput"),
+					Location: util.StringPtr("eu-west-3"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "ThroughputClass", Value: util.StringPtr("Elastic")},
+						{Key: "AccessType", Value: util.StringPtr("Write")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+
+		us := usage.Default.GetUsage("aws_efs_file_system")
+		tfres.Values[usage.Key] = us
+		actual := p.ResourceComponents(rss, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
 }
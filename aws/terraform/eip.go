@@ -8,6 +8,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -100,3 +101,8 @@ func (inst *ElasticIP) elasticIPInstanceComponent() query.Component {
 		},
 	}
 }
+
+// elasticIPExample returns the ResourceExample of the aws_eip, see Provider.ResourceExamples
+func (p *Provider) elasticIPExample() terraform.ResourceExample {
+	return p.example("aws_eip", map[string]interface{}{})
+}
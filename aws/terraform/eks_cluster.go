@@ -11,6 +11,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -103,3 +104,8 @@ func (inst *EKSCluster) eKSClusterInstanceComponent() query.Component {
 		},
 	}
 }
+
+// eksClusterExample returns the ResourceExample of the aws_eks_cluster, see Provider.ResourceExamples
+func (p *Provider) eksClusterExample() terraform.ResourceExample {
+	return p.example("aws_eks_cluster", map[string]interface{}{})
+}
@@ -20,6 +20,9 @@ type eksNodeGroupValues struct {
 	InstanceTypes []string `mapstructure:"instance_types"`
 	DiskSize      float64  `mapstructure:"disk_size"`
 
+	// CapacityType is ON_DEMAND (default) or SPOT
+	CapacityType string `mapstructure:"capacity_type"`
+
 	LaunchTemplate []struct {
 		ID      string `mapstructure:"id"`
 		Name    string `mapstructure:"name"`
@@ -74,6 +77,11 @@ func (p *Provider) newEKSNodeGroup(rss map[string]terraform.Resource, vals eksNo
 	}
 	inst.instanceCount = decimal.NewFromInt(instanceCount)
 
+	if vals.CapacityType == "SPOT" {
+		inst.spot = true
+		p.warnf("the Spot prices are not part of the pricing data, the SPOT capacity is estimated with the on-demand price")
+	}
+
 	if len(vals.LaunchTemplate) > 0 {
 		// If LT defined
 		var ltref string
@@ -135,6 +143,7 @@ func (p *Provider) newEKSNodeGroup(rss map[string]terraform.Resource, vals eksNo
 			inst.instanceType = vals.InstanceTypes[0]
 		} else {
 			inst.instanceType = defaultEKSInstanceType
+			p.warnf("no instance_types, the default %s is assumed", defaultEKSInstanceType)
 		}
 
 		// Set the default Linux size
@@ -156,3 +165,12 @@ func (p *Provider) newEKSNodeGroup(rss map[string]terraform.Resource, vals eksNo
 
 	return inst
 }
+
+// eksNodeGroupExample returns the ResourceExample of the aws_eks_node_group, see Provider.ResourceExamples
+func (p *Provider) eksNodeGroupExample() terraform.ResourceExample {
+	return p.example("aws_eks_node_group", map[string]interface{}{
+		"instance_types": []interface{}{"t3.medium"},
+		"disk_size":      float64(20),
+		"scaling_config": []interface{}{map[string]interface{}{"min_size": 1, "desired_size": 2}},
+	})
+}
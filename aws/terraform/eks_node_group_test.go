@@ -185,4 +185,31 @@ func TestEKSNodeGroup_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("EKSNodeGroupSpot", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "module.test.aws_eks_node_group.spot",
+			Type:         "aws_eks_node_group",
+			Name:         "spot",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"scaling_config": []interface{}{map[string]interface{}{
+					"desired_size": 4,
+					"min_size":     1,
+					"max_size":     5,
+				}},
+				"capacity_type":  "SPOT",
+				"instance_types": []string{"m5.large"},
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(nil, tfres)
+		require.Len(t, actual, 2)
+		assert.Equal(t, "Compute", actual[0].Name)
+		assert.Equal(t, []string{"Linux", "spot", "m5.large"}, actual[0].Details)
+		assert.Equal(t, decimal.NewFromInt(4), actual[0].HourlyQuantity)
+		// The Spot prices are not ingested so it's the on-demand price
+		assert.Equal(t, []*price.AttributeFilter{{Key: "TermType", Value: util.StringPtr("OnDemand")}}, actual[0].PriceFilter.AttributeFilters)
+		assert.Len(t, warnings, 1)
+	})
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -30,6 +31,9 @@ type ElastiCache struct {
 	replicationGroupID string
 
 	snapshotRetentionLimit decimal.Decimal
+
+	// Usage
+	snapshotSizeGB decimal.Decimal
 }
 
 type elastiCacheValues struct {
@@ -39,6 +43,10 @@ type elastiCacheValues struct {
 	ReplicationGroupID     string `mapstructure:"replication_group_id"`
 	NumCacheNodes          int64  `mapstructure:"num_cache_nodes"`
 	SnapshotRetentionLimit int64  `mapstructure:"snapshot_retention_limit"`
+
+	Usage struct {
+		SnapshotSizeGB float64 `mapstructure:"snapshot_size_gb"`
+	} `mapstructure:"tc_usage"`
 }
 
 var cacheTypeMap = map[string]string{
@@ -76,6 +84,7 @@ func (p *Provider) newElastiCache(vals elastiCacheValues) *ElastiCache {
 		numCacheNodes:          decimal.NewFromInt(vals.NumCacheNodes),
 		replicationGroupID:     vals.ReplicationGroupID,
 		snapshotRetentionLimit: decimal.NewFromInt(vals.SnapshotRetentionLimit),
+		snapshotSizeGB:         decimal.NewFromFloat(vals.Usage.SnapshotSizeGB),
 	}
 
 	if reg := region.NewFromZone(vals.AvailabilityZone); reg.Valid() {
@@ -129,16 +138,14 @@ func (inst *ElastiCache) elastiCacheInstanceComponent() query.Component {
 }
 
 func (inst *ElastiCache) backupStorageComponent() query.Component {
-	// MonthlyQuantity = snapshotRetentionLimit * backupSnapshotSize
-	// TODO: If/When usage estimation will be supported, backupSnapshotSize might have a different value from 0
-
-	backupSnapshotSize := decimal.NewFromInt(0)
-	monthlyQuantityTotal := backupSnapshotSize.Mul(inst.snapshotRetentionLimit)
+	// MonthlyQuantity = snapshotRetentionLimit * snapshotSizeGB
+	monthlyQuantityTotal := inst.snapshotSizeGB.Mul(inst.snapshotRetentionLimit)
 
 	return query.Component{
 		Name:            "Backup storage",
 		Details:         []string{monthlyQuantityTotal.String()},
 		MonthlyQuantity: monthlyQuantityTotal,
+		Usage:           true,
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(inst.providerKey),
 			Service:  util.StringPtr("AmazonElastiCache"),
@@ -153,3 +160,12 @@ func (inst *ElastiCache) backupStorageComponent() query.Component {
 		},
 	}
 }
+
+// elastiCacheExample returns the ResourceExample of the aws_elasticache_cluster, see Provider.ResourceExamples
+func (p *Provider) elastiCacheExample() terraform.ResourceExample {
+	return p.example("aws_elasticache_cluster", map[string]interface{}{
+		"node_type":       "cache.t3.medium",
+		"engine":          "redis",
+		"num_cache_nodes": 1,
+	})
+}
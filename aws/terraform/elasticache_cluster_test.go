@@ -101,6 +101,7 @@ func TestElastiCache_Components(t *testing.T) {
 				Name:            "Backup storage",
 				Details:         []string{"0"},
 				MonthlyQuantity: decimal.NewFromInt(0),
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("AmazonElastiCache"),
@@ -120,6 +121,31 @@ func TestElastiCache_Components(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("RedisSnapshotSizeUsage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_elasticache_cluster.test",
+			Type:         "aws_elasticache_cluster",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"node_type":                "cache.m4.large",
+				"engine":                   "redis",
+				"num_cache_nodes":          1,
+				"snapshot_retention_limit": 5,
+				"tc_usage": map[string]interface{}{
+					"snapshot_size_gb": 10,
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.Len(t, actual, 2)
+		assert.Equal(t, "Backup storage", actual[1].Name)
+		assert.Equal(t, []string{"50"}, actual[1].Details)
+		assert.True(t, decimal.NewFromInt(50).Equal(actual[1].MonthlyQuantity))
+	})
+
 	t.Run("RedisReplicationGroupID", func(t *testing.T) {
 		tfres := terraform.Resource{
 			Address:      "aws_elasticache_cluster.test",
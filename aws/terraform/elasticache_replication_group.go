@@ -8,6 +8,7 @@ import (
 
 	"github.com/cycloidio/terracost/aws/region"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 )
 
 // ElastiCacheReplication represents an ElastiCacheReplication instance definition that can be cost-estimated.
@@ -26,6 +27,9 @@ type ElastiCacheReplication struct {
 	snapshotRetentionLimit decimal.Decimal
 
 	globalReplicationGroupID string
+
+	// Usage
+	snapshotSizeGB decimal.Decimal
 }
 
 type elastiCacheReplicationValues struct {
@@ -42,6 +46,10 @@ type elastiCacheReplicationValues struct {
 	NumberCacheClusters      int64  `mapstructure:"num_cache_clusters"`
 	SnapshotRetentionLimit   int64  `mapstructure:"snapshot_retention_limit"`
 	GlobalReplicationGroupID string `mapstructure:"global_replication_group_id"`
+
+	Usage struct {
+		SnapshotSizeGB float64 `mapstructure:"snapshot_size_gb"`
+	} `mapstructure:"tc_usage"`
 }
 
 func decodeElastiCacheReplicationValues(tfVals map[string]interface{}) (elastiCacheReplicationValues, error) {
@@ -90,6 +98,7 @@ func (p *Provider) newElastiCacheReplication(vals elastiCacheReplicationValues)
 		numCacheNodes:            numCacheNodes,
 		snapshotRetentionLimit:   decimal.NewFromInt(vals.SnapshotRetentionLimit),
 		globalReplicationGroupID: vals.GlobalReplicationGroupID,
+		snapshotSizeGB:           decimal.NewFromFloat(vals.Usage.SnapshotSizeGB),
 	}
 
 	if len(vals.AvailabilityZones) > 0 {
@@ -145,7 +154,17 @@ func (inst *ElastiCacheReplication) backupStorageComponent() query.Component {
 		cacheEngine:            inst.cacheEngine,
 		numCacheNodes:          inst.numCacheNodes,
 		snapshotRetentionLimit: inst.snapshotRetentionLimit,
+		snapshotSizeGB:         inst.snapshotSizeGB,
 	}
 
 	return elastiCacheInst.backupStorageComponent()
 }
+
+// elastiCacheReplicationExample returns the ResourceExample of the aws_elasticache_replication_group, see Provider.ResourceExamples
+func (p *Provider) elastiCacheReplicationExample() terraform.ResourceExample {
+	return p.example("aws_elasticache_replication_group", map[string]interface{}{
+		"node_type":          "cache.t3.medium",
+		"engine":             "redis",
+		"num_cache_clusters": 2,
+	})
+}
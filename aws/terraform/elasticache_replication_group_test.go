@@ -122,6 +122,7 @@ func TestElastiCacheReplication_Components(t *testing.T) {
 				Name:            "Backup storage",
 				Details:         []string{"0"},
 				MonthlyQuantity: decimal.NewFromInt(0),
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("AmazonElastiCache"),
@@ -65,6 +65,11 @@ func (p *Provider) newFSxLustreFileSystem(_ map[string]terraform.Resource, vals
 		deploymentType = "PERSISTENT_1"
 	}
 
+	// The storage type has to be known to default the throughput
+	if len(vals.StorageType) > 0 {
+		v.storageType = vals.StorageType
+	}
+
 	if vals.PerUnitStorageThroughput > 0 {
 		v.throughputCapacity = decimal.NewFromFloat(vals.PerUnitStorageThroughput)
 	} else {
@@ -83,13 +88,19 @@ func (p *Provider) newFSxLustreFileSystem(_ map[string]terraform.Resource, vals
 		}
 	}
 
-	if len(vals.StorageType) > 0 {
-		v.storageType = vals.StorageType
-	}
-
 	if vals.AutomaticBackupRetentionDays > 0 {
 		v.automaticBackupRetentionDays = decimal.NewFromFloat(vals.AutomaticBackupRetentionDays)
 	}
 
 	return v
 }
+
+// fsxLustreFileSystemExample returns the ResourceExample of the aws_fsx_lustre_file_system, see Provider.ResourceExamples
+func (p *Provider) fsxLustreFileSystemExample() terraform.ResourceExample {
+	return p.example("aws_fsx_lustre_file_system", map[string]interface{}{
+		"storage_capacity":                float64(1200),
+		"deployment_type":                 "PERSISTENT_2",
+		"per_unit_storage_throughput":     float64(125),
+		"automatic_backup_retention_days": float64(7),
+	})
+}
@@ -93,3 +93,13 @@ func (p *Provider) newFSxOntapFileSystem(_ map[string]terraform.Resource, vals f
 
 	return v
 }
+
+// fsxOntapFileSystemExample returns the ResourceExample of the aws_fsx_ontap_file_system, see Provider.ResourceExamples
+func (p *Provider) fsxOntapFileSystemExample() terraform.ResourceExample {
+	return p.example("aws_fsx_ontap_file_system", map[string]interface{}{
+		"storage_capacity":                float64(1024),
+		"deployment_type":                 "MULTI_AZ_1",
+		"throughput_capacity":             float64(512),
+		"automatic_backup_retention_days": float64(7),
+	})
+}
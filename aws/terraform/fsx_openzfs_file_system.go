@@ -93,3 +93,13 @@ func (p *Provider) newFSxOpenzfsFileSystem(_ map[string]terraform.Resource, vals
 
 	return v
 }
+
+// fsxOpenzfsFileSystemExample returns the ResourceExample of the aws_fsx_openzfs_file_system, see Provider.ResourceExamples
+func (p *Provider) fsxOpenzfsFileSystemExample() terraform.ResourceExample {
+	return p.example("aws_fsx_openzfs_file_system", map[string]interface{}{
+		"storage_capacity":                float64(1024),
+		"deployment_type":                 "SINGLE_AZ_1",
+		"throughput_capacity":             float64(64),
+		"automatic_backup_retention_days": float64(7),
+	})
+}
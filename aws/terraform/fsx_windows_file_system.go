@@ -88,3 +88,13 @@ func (p *Provider) newFSxWindowsFileSystem(_ map[string]terraform.Resource, vals
 
 	return v
 }
+
+// fsxWindowsFileSystemExample returns the ResourceExample of the aws_fsx_windows_file_system, see Provider.ResourceExamples
+func (p *Provider) fsxWindowsFileSystemExample() terraform.ResourceExample {
+	return p.example("aws_fsx_windows_file_system", map[string]interface{}{
+		"storage_capacity":                float64(300),
+		"deployment_type":                 "MULTI_AZ_1",
+		"throughput_capacity":             float64(32),
+		"automatic_backup_retention_days": float64(7),
+	})
+}
@@ -113,9 +113,16 @@ func (v *FSxFileSystem) fsxFileSystemBackupGBCostComponent() query.Component {
 		}
 	}
 
+	// Without the backup_storage_gb usage the backups are assumed
+	// to be a full copy of the storage capacity
+	backupStorage := v.backupStorage
+	if !backupStorage.IsPositive() {
+		backupStorage = v.storageCapacity
+	}
+
 	return query.Component{
 		Name:            fmt.Sprintf("%s Backup storage", v.fsxType),
-		MonthlyQuantity: v.storageCapacity,
+		MonthlyQuantity: backupStorage,
 		Unit:            "GB-Mo",
 		Details:         []string{"Storage", v.fsxType},
 		Usage:           true,
@@ -62,6 +62,47 @@ func TestFSXXFileSystem_Components(t *testing.T) {
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
 
+	t.Run("LustreFileSystemHDD", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_fsx_lustre_file_system.test",
+			Type:         "aws_fsx_lustre_file_system",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"storage_capacity": float64(6000),
+				"storage_type":     "HDD",
+				"deployment_type":  "PERSISTENT_1",
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		expected := []query.Component{
+			{
+				Name:            "Lustre Storage HDD",
+				MonthlyQuantity: decimal.NewFromFloat(6000),
+				Unit:            "GB-Mo",
+				Details:         []string{"Storage", "Lustre"},
+				Usage:           false,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonFSx"),
+					Family:   util.StringPtr("Storage"),
+					Location: util.StringPtr("eu-west-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "Deployment_option", Value: util.StringPtr("Persistent")},
+						{Key: "FileSystemType", Value: util.StringPtr("Lustre")},
+						{Key: "StorageType", Value: util.StringPtr("HDD")},
+						{Key: "ThroughputCapacity", Value: util.StringPtr("12")},
+					},
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.Len(t, actual, 1)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
 	t.Run("OntapFileSystem", func(t *testing.T) {
 		tfres := terraform.Resource{
 			Address:      "aws_fsx_ontap_file_system.test",
@@ -269,7 +310,7 @@ func TestFSXXFileSystem_Components(t *testing.T) {
 			},
 			{
 				Name:            "Windows Backup storage",
-				MonthlyQuantity: decimal.NewFromFloat(300),
+				MonthlyQuantity: decimal.NewFromFloat(1024),
 				Unit:            "GB-Mo",
 				Details:         []string{"Storage", "Windows"},
 				Usage:           true,
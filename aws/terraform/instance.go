@@ -11,6 +11,8 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -22,7 +24,8 @@ type Instance struct {
 
 	// tenancy describes the tenancy of an instance.
 	// Valid values include: Shared, Dedicated, Host.
-	// Note: only "Shared" and "Dedicated" are supported at the moment.
+	// Note: the instances with "Host" tenancy have no cost as
+	// the cost is the one of the Dedicated Host they run on.
 	tenancy string
 
 	// operatingSystem denotes the OS that the instance is using that may affect pricing.
@@ -40,7 +43,16 @@ type Instance struct {
 	// Note: only "NA" (no pre-installed software) is supported at the moment.
 	preInstalledSW string
 
-	// Credit option for CPU usage. Valid values include standard or unlimited
+	// arm64 is set when the instanceType is from an AWS Graviton family (ex: m6g, t4g)
+	// which have different pricing from the x86 equivalents
+	arm64 bool
+
+	// spot is set when the instance runs on Spot capacity, as the Spot prices are not
+	// on the offer files it's estimated with the on-demand price (upper bound)
+	spot bool
+
+	// cpuCredits is set when the instance is on the 'unlimited' credit
+	// option, so the surplus credits of burstable instances are charged
 	cpuCredits bool
 
 	ebsOptimized     bool
@@ -51,6 +63,19 @@ type Instance struct {
 	instanceCount decimal.Decimal
 
 	rootVolume *Volume
+
+	// Usage
+	// monthlyHours is the number of hours the instance runs per month,
+	// if not set it's considered to be always running
+	monthlyHours decimal.Decimal
+
+	// runningShare is the share of the time the instance runs with its hours_per_week
+	// or schedule, it's only used if the monthlyHours are not set
+	runningShare decimal.Decimal
+
+	// monthlyCPUCreditHours is the number of vCPU-hours of surplus
+	// credits used per month when the cpuCredits are unlimited
+	monthlyCPUCreditHours decimal.Decimal
 }
 
 // instanceValues represents the structure of Terraform values for aws_instance resource.
@@ -70,6 +95,16 @@ type instanceValues struct {
 		VolumeSize float64 `mapstructure:"volume_size"`
 		IOPS       float64 `mapstructure:"iops"`
 	} `mapstructure:"root_block_device"`
+
+	Usage struct {
+		MonthlyHours          float64 `mapstructure:"monthly_hours"`
+		MonthlyCPUCreditHours float64 `mapstructure:"monthly_cpu_credit_hours"`
+
+		// HoursPerWeek and Schedule (ex: "Mon-Fri 08:00-20:00") are the
+		// weekly runtime used if the MonthlyHours are not set
+		HoursPerWeek float64 `mapstructure:"hours_per_week"`
+		Schedule     string  `mapstructure:"schedule"`
+	} `mapstructure:"tc_usage"`
 }
 
 // decodeInstanceValues decodes and returns instanceValues from a Terraform values map.
@@ -106,14 +141,30 @@ func (p *Provider) newInstance(vals instanceValues) *Instance {
 		instanceCount:   decimal.NewFromInt(1),
 
 		instanceType: vals.InstanceType,
+		arm64:        isGravitonInstanceType(vals.InstanceType),
+
+		// From Usage
+		monthlyHours:          decimal.NewFromFloat(vals.Usage.MonthlyHours),
+		monthlyCPUCreditHours: decimal.NewFromFloat(vals.Usage.MonthlyCPUCreditHours),
+	}
+
+	if !inst.monthlyHours.IsPositive() {
+		share, err := usage.RunningShare(vals.Usage.HoursPerWeek, vals.Usage.Schedule)
+		if err != nil {
+			p.warnf("%s, the instance is estimated as always running", err)
+		}
+		inst.runningShare = decimal.NewFromFloat(share)
 	}
 
 	if reg := region.NewFromZone(vals.AvailabilityZone); reg.Valid() {
 		inst.region = reg
 	}
 
-	if vals.Tenancy == "dedicated" {
+	switch vals.Tenancy {
+	case "dedicated":
 		inst.tenancy = "Dedicated"
+	case "host":
+		inst.tenancy = "Host"
 	}
 
 	if vals.EBSOptimized {
@@ -145,8 +196,10 @@ func (p *Provider) newInstance(vals instanceValues) *Instance {
 
 // Components returns the price component queries that make up this Instance.
 func (inst *Instance) Components() []query.Component {
-	components := []query.Component{inst.computeComponent()}
+	components := []query.Component{inst.runtimeComponent(inst.computeComponent())}
 
+	// The volumes persist when the instance is stopped
+	// so they are not affected by the monthlyHours
 	if inst.rootVolume != nil {
 		for _, comp := range inst.rootVolume.Components() {
 			comp.Name = "Root volume: " + comp.Name
@@ -154,7 +207,7 @@ func (inst *Instance) Components() []query.Component {
 		}
 	}
 
-	if inst.cpuCredits {
+	if inst.cpuCredits && isBurstableInstanceType(inst.instanceType) {
 		components = append(components, inst.cpuCreditCostComponent())
 	}
 
@@ -163,22 +216,39 @@ func (inst *Instance) Components() []query.Component {
 	}
 
 	if inst.ebsOptimized {
-		components = append(components, inst.ebsOptimizedCostComponent())
+		components = append(components, inst.runtimeComponent(inst.ebsOptimizedCostComponent()))
 	}
 
 	return components
 }
 
-func (inst *Instance) cpuCreditCostComponent() query.Component {
+// runtimeComponent returns the hourly priced component only for the monthlyHours or the
+// runningShare of the month the instance runs, if they are set, instead of the full month
+func (inst *Instance) runtimeComponent(comp query.Component) query.Component {
+	switch {
+	case inst.monthlyHours.IsPositive():
+		comp.MonthlyQuantity = comp.HourlyQuantity.Mul(inst.monthlyHours)
+		comp.HourlyQuantity = decimal.Zero
+	case inst.runningShare.IsPositive():
+		comp.HourlyQuantity = comp.HourlyQuantity.Mul(inst.runningShare)
+	default:
+		return comp
+	}
+	comp.Usage = true
+	return comp
+}
 
-	// Used to generate the UsageType
-	region := strings.ToUpper(strings.Split(inst.region.String(), "-")[0])
-	instType := strings.Split(inst.instanceType, ".")[0]
+// cpuCreditCostComponent returns the surplus CPU credits used by an 'unlimited' burstable
+// instance, the quantity is the monthlyCPUCreditHours usage per instance
+func (inst *Instance) cpuCreditCostComponent() query.Component {
+	family := strings.Split(inst.instanceType, ".")[0]
 
 	return query.Component{
-		Name:           "CPUCreditCost",
-		Details:        []string{"Linux", "on-demand", inst.instanceType},
-		HourlyQuantity: inst.instanceCount,
+		Name:            "CPUCreditCost",
+		Details:         []string{"Linux", "unlimited", inst.instanceType},
+		MonthlyQuantity: inst.monthlyCPUCreditHours.Mul(inst.instanceCount),
+		Unit:            "vCPU-Hours",
+		Usage:           true,
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(inst.provider.key),
 			Service:  util.StringPtr("AmazonEC2"),
@@ -186,7 +256,8 @@ func (inst *Instance) cpuCreditCostComponent() query.Component {
 			Location: util.StringPtr(inst.region.String()),
 			AttributeFilters: []*product.AttributeFilter{
 				{Key: "OperatingSystem", Value: util.StringPtr(inst.operatingSystem)},
-				{Key: "UsageType", Value: util.StringPtr(fmt.Sprintf("%s-CPUCredits:%s", region, instType))},
+				// The UsageType has a region prefix (ex: EUW3-CPUCredits:t3) except on us-east-1
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("CPUCredits:%s$", family))},
 			},
 		},
 		PriceFilter: &price.Filter{
@@ -251,22 +322,35 @@ func (inst *Instance) ebsOptimizedCostComponent() query.Component {
 }
 
 func (inst *Instance) computeComponent() query.Component {
+	purchaseOption := "on-demand"
+	if inst.spot {
+		purchaseOption = "spot"
+	}
+	details := []string{"Linux", purchaseOption, inst.instanceType}
+	attrFilters := []*product.AttributeFilter{
+		{Key: "CapacityStatus", Value: util.StringPtr(inst.capacityStatus)},
+		{Key: "InstanceType", Value: util.StringPtr(inst.instanceType)},
+		{Key: "Tenancy", Value: util.StringPtr(inst.tenancy)},
+		{Key: "OperatingSystem", Value: util.StringPtr(inst.operatingSystem)},
+		{Key: "PreInstalledSW", Value: util.StringPtr(inst.preInstalledSW)},
+	}
+
+	// The InstanceType already identifies the Graviton products
+	// so the processor is only part of the details
+	if inst.arm64 {
+		details = append(details, "arm64")
+	}
+
 	return query.Component{
 		Name:           "Compute",
-		Details:        []string{"Linux", "on-demand", inst.instanceType},
+		Details:        details,
 		HourlyQuantity: inst.instanceCount,
 		ProductFilter: &product.Filter{
-			Provider: util.StringPtr(inst.provider.key),
-			Service:  util.StringPtr("AmazonEC2"),
-			Family:   util.StringPtr("Compute Instance"),
-			Location: util.StringPtr(inst.region.String()),
-			AttributeFilters: []*product.AttributeFilter{
-				{Key: "CapacityStatus", Value: util.StringPtr(inst.capacityStatus)},
-				{Key: "InstanceType", Value: util.StringPtr(inst.instanceType)},
-				{Key: "Tenancy", Value: util.StringPtr(inst.tenancy)},
-				{Key: "OperatingSystem", Value: util.StringPtr(inst.operatingSystem)},
-				{Key: "PreInstalledSW", Value: util.StringPtr(inst.preInstalledSW)},
-			},
+			Provider:         util.StringPtr(inst.provider.key),
+			Service:          util.StringPtr("AmazonEC2"),
+			Family:           util.StringPtr("Compute Instance"),
+			Location:         util.StringPtr(inst.region.String()),
+			AttributeFilters: attrFilters,
 		},
 		PriceFilter: &price.Filter{
 			Unit: util.StringPtr("Hrs"),
@@ -276,3 +360,34 @@ func (inst *Instance) computeComponent() query.Component {
 		},
 	}
 }
+
+// isBurstableInstanceType returns true if the instance type belongs to a burstable
+// performance family (T2, T3, T3a, T4g) which can use the 'unlimited' CPU credits.
+func isBurstableInstanceType(it string) bool {
+	return strings.HasPrefix(it, "t") && !strings.HasPrefix(it, "trn")
+}
+
+// isGravitonInstanceType returns true if the instance type belongs to an AWS Graviton (arm64) family.
+// The families are identified by having a 'g' on the attributes after the generation,
+// ex: t4g, m6g, m6gd, c7gn, im4gn, but not g4dn or m6i, except for the first Graviton
+// family a1 which has no attributes.
+func isGravitonInstanceType(it string) bool {
+	family := strings.Split(it, ".")[0]
+	if family == "a1" {
+		return true
+	}
+	i := strings.IndexAny(family, "0123456789")
+	if i == -1 {
+		return false
+	}
+	attrs := strings.TrimLeft(family[i:], "0123456789")
+	return strings.Contains(attrs, "g")
+}
+
+// instanceExample returns the ResourceExample of the aws_instance, see Provider.ResourceExamples
+func (p *Provider) instanceExample() terraform.ResourceExample {
+	return p.example("aws_instance", map[string]interface{}{
+		"instance_type":     "t3.medium",
+		"root_block_device": []interface{}{map[string]interface{}{"volume_type": "gp3", "volume_size": float64(20)}},
+	})
+}
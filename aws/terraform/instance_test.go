@@ -2,6 +2,7 @@ package terraform_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -13,6 +14,7 @@ import (
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -145,27 +147,6 @@ func TestInstance_Components(t *testing.T) {
 					},
 				},
 			},
-			{
-				Name:           "CPUCreditCost",
-				Details:        []string{"Linux", "on-demand", "m5.xlarge"},
-				HourlyQuantity: decimal.NewFromInt(1),
-				ProductFilter: &product.Filter{
-					Provider: util.StringPtr("aws"),
-					Service:  util.StringPtr("AmazonEC2"),
-					Family:   util.StringPtr("CPU Credits"),
-					Location: util.StringPtr("eu-west-1"),
-					AttributeFilters: []*product.AttributeFilter{
-						{Key: "OperatingSystem", Value: util.StringPtr("Linux")},
-						{Key: "UsageType", Value: util.StringPtr(fmt.Sprintf("%s-CPUCredits:%s", "EU", "m5"))},
-					},
-				},
-				PriceFilter: &price.Filter{
-					Unit: util.StringPtr("vCPU-Hours"),
-					AttributeFilters: []*price.AttributeFilter{
-						{Key: "TermType", Value: util.StringPtr("OnDemand")},
-					},
-				},
-			},
 			{
 				Name:            "EC2 detailed monitoring",
 				Details:         []string{"on-demand", "monitoring"},
@@ -211,4 +192,195 @@ func TestInstance_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("Graviton", func(t *testing.T) {
+		rss := map[string]terraform.Resource{}
+		compute := func(it string) query.Component {
+			tfres := terraform.Resource{
+				Address:      "aws_instance.test",
+				Type:         "aws_instance",
+				Name:         "test",
+				ProviderName: "aws",
+				Values: map[string]interface{}{
+					"instance_type": it,
+				},
+			}
+			actual := p.ResourceComponents(rss, tfres)
+			require.NotEmpty(t, actual)
+			assert.Equal(t, "Compute", actual[0].Name)
+			return actual[0]
+		}
+
+		attrFilters := func(it string) []*product.AttributeFilter {
+			return []*product.AttributeFilter{
+				{Key: "CapacityStatus", Value: util.StringPtr("Used")},
+				{Key: "InstanceType", Value: util.StringPtr(it)},
+				{Key: "Tenancy", Value: util.StringPtr("Shared")},
+				{Key: "OperatingSystem", Value: util.StringPtr("Linux")},
+				{Key: "PreInstalledSW", Value: util.StringPtr("NA")},
+			}
+		}
+
+		// The products are distinguished by the InstanceType
+		m6g := compute("m6g.large")
+		assert.Equal(t, []string{"Linux", "on-demand", "m6g.large", "arm64"}, m6g.Details)
+		assert.Equal(t, attrFilters("m6g.large"), m6g.ProductFilter.AttributeFilters)
+
+		m6i := compute("m6i.large")
+		assert.Equal(t, []string{"Linux", "on-demand", "m6i.large"}, m6i.Details)
+		assert.Equal(t, attrFilters("m6i.large"), m6i.ProductFilter.AttributeFilters)
+
+		assert.Contains(t, compute("t4g.micro").Details, "arm64")
+		assert.Contains(t, compute("c7gn.xlarge").Details, "arm64")
+		assert.Contains(t, compute("a1.medium").Details, "arm64")
+		assert.NotContains(t, compute("g4dn.xlarge").Details, "arm64")
+	})
+
+	t.Run("MonthlyHours", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_instance.test",
+			Type:         "aws_instance",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"instance_type": "m5.xlarge",
+				"ebs_optimized": true,
+				usage.Key: map[string]interface{}{
+					"monthly_hours": 12.5,
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.Len(t, actual, 3)
+		for _, c := range actual {
+			if strings.HasPrefix(c.Name, "Root volume") {
+				assert.False(t, c.Usage, c.Name)
+				assert.True(t, c.MonthlyQuantity.Equal(decimal.NewFromInt(8)), c.Name)
+				continue
+			}
+			assert.True(t, c.Usage, c.Name)
+			assert.True(t, c.HourlyQuantity.IsZero(), c.Name)
+			assert.True(t, c.MonthlyQuantity.Equal(decimal.NewFromFloat(12.5)), c.Name)
+		}
+	})
+
+	t.Run("Schedule", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_instance.test",
+			Type:         "aws_instance",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"instance_type": "m5.xlarge",
+				usage.Key: map[string]interface{}{
+					// 84 hours per week
+					"schedule": "Mon-Fri 07:00-19:00; Sat-Sun 09:00-21:00",
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		require.Len(t, actual, 2)
+		assert.Equal(t, "Compute", actual[0].Name)
+		assert.True(t, actual[0].Usage)
+		// The hourly quantity is kept so it's converted with the hours per month of the estimation
+		assert.True(t, actual[0].MonthlyQuantity.IsZero())
+		assert.Equal(t, "0.5", actual[0].HourlyQuantity.String())
+		// The volumes are always charged for the full month
+		assert.False(t, actual[1].Usage)
+
+		tfres.Values[usage.Key] = map[string]interface{}{"hours_per_week": 84, "schedule": "invalid"}
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, "0.5", actual[0].HourlyQuantity.String())
+		assert.Empty(t, warnings)
+
+		tfres.Values[usage.Key] = map[string]interface{}{"schedule": "Mon-Fri 8h-18h"}
+		actual, warnings = p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.True(t, actual[0].MonthlyQuantity.IsZero())
+		assert.Equal(t, decimal.NewFromInt(1), actual[0].HourlyQuantity)
+		assert.Len(t, warnings, 1)
+
+		tfres.Values[usage.Key] = map[string]interface{}{"hours_per_week": 200}
+		actual, warnings = p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, decimal.NewFromInt(1), actual[0].HourlyQuantity)
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("CPUCredits", func(t *testing.T) {
+		rss := map[string]terraform.Resource{}
+		creditComponents := func(it, cpuCredits string) []query.Component {
+			tfres := terraform.Resource{
+				Address:      "aws_instance.test",
+				Type:         "aws_instance",
+				Name:         "test",
+				ProviderName: "aws",
+				Values: map[string]interface{}{
+					"instance_type": it,
+					"credit_specification": []interface{}{
+						map[string]interface{}{"cpu_credits": cpuCredits},
+					},
+					usage.Key: map[string]interface{}{
+						"monthly_cpu_credit_hours": 120,
+					},
+				},
+			}
+			comps := make([]query.Component, 0)
+			for _, c := range p.ResourceComponents(rss, tfres) {
+				if c.Name == "CPUCreditCost" {
+					comps = append(comps, c)
+				}
+			}
+			return comps
+		}
+
+		expected := []query.Component{
+			{
+				Name:            "CPUCreditCost",
+				Details:         []string{"Linux", "unlimited", "t3.micro"},
+				MonthlyQuantity: decimal.NewFromFloat(120),
+				Unit:            "vCPU-Hours",
+				Usage:           true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonEC2"),
+					Family:   util.StringPtr("CPU Credits"),
+					Location: util.StringPtr("eu-west-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "OperatingSystem", Value: util.StringPtr("Linux")},
+						{Key: "UsageType", ValueRegex: util.StringPtr("CPUCredits:t3$")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("vCPU-Hours"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+
+		assert.Equal(t, expected, creditComponents("t3.micro", "unlimited"))
+		assert.Empty(t, creditComponents("t3.micro", "standard"))
+		assert.Empty(t, creditComponents("m5.xlarge", "unlimited"))
+	})
+
+	t.Run("HostTenancy", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_instance.test",
+			Type:         "aws_instance",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"instance_type": "m5.xlarge",
+				"tenancy":       "host",
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		actual := p.ResourceComponents(rss, tfres)
+		require.NotEmpty(t, actual)
+		assert.Contains(t, actual[0].ProductFilter.AttributeFilters, &product.AttributeFilter{Key: "Tenancy", Value: util.StringPtr("Host")})
+	})
 }
@@ -12,15 +12,26 @@ import (
 	"github.com/cycloidio/terracost/util"
 )
 
-// KMSKey represents an SQS queue definition that can be cost-estimated.
+// KMSKey represents a KMS customer managed key definition that can be cost-estimated.
 type KMSKey struct {
 	provider              *Provider
 	region                region.Code
 	customerMasterKeySpec string
+
+	// Usage
+	monthlyRequests                       decimal.Decimal
+	monthlyECCGenerateDataKeyPairRequests decimal.Decimal
+	monthlyRSAGenerateDataKeyPairRequests decimal.Decimal
 }
 
 type kmsKeyValues struct {
 	CustomerMasterKeySpec string `mapstructure:"customer_master_key_spec"`
+
+	Usage struct {
+		MonthlyRequests                       float64 `mapstructure:"monthly_requests"`
+		MonthlyECCGenerateDataKeyPairRequests float64 `mapstructure:"monthly_ecc_generate_data_key_pair_requests"`
+		MonthlyRSAGenerateDataKeyPairRequests float64 `mapstructure:"monthly_rsa_generate_data_key_pair_requests"`
+	} `mapstructure:"tc_usage"`
 }
 
 // decodeKMSKeyValues decodes and returns kmsKeyValues from a Terraform values map.
@@ -48,6 +59,11 @@ func (p *Provider) newKMSKey(_ map[string]terraform.Resource, vals kmsKeyValues)
 		provider:              p,
 		region:                p.region,
 		customerMasterKeySpec: "SYMMETRIC_DEFAULT",
+
+		// From Usage
+		monthlyRequests:                       decimal.NewFromFloat(vals.Usage.MonthlyRequests),
+		monthlyECCGenerateDataKeyPairRequests: decimal.NewFromFloat(vals.Usage.MonthlyECCGenerateDataKeyPairRequests),
+		monthlyRSAGenerateDataKeyPairRequests: decimal.NewFromFloat(vals.Usage.MonthlyRSAGenerateDataKeyPairRequests),
 	}
 
 	if vals.CustomerMasterKeySpec != "" {
@@ -63,7 +79,7 @@ func (v *KMSKey) Components() []query.Component {
 
 	switch v.customerMasterKeySpec {
 	case "RSA_2048":
-		components = append(components, v.kmsKeyRequestComponent("Requests (RSA 2048)", ".*KMS-Requests-Asymmetric-RSA_2048$", ""))
+		components = append(components, v.kmsKeyRequestComponent("Requests (RSA 2048)", ".*KMS-Requests-Asymmetric-RSA_2048$", "", v.monthlyRequests))
 	case
 		"RSA_3072",
 		"RSA_4096",
@@ -71,11 +87,11 @@ func (v *KMSKey) Components() []query.Component {
 		"ECC_NIST_P384",
 		"ECC_NIST_P521",
 		"ECC_SECG_P256K1":
-		components = append(components, v.kmsKeyRequestComponent("Requests (asymmetric)", ".*KMS-Requests-Asymmetric$", ""))
+		components = append(components, v.kmsKeyRequestComponent("Requests (asymmetric)", ".*KMS-Requests-Asymmetric$", "", v.monthlyRequests))
 	default:
-		components = append(components, v.kmsKeyRequestComponent("Requests", ".*KMS-Requests$", "API Request"))
-		components = append(components, v.kmsKeyRequestComponent("ECC GenerateDataKeyPair requests", ".*KMS-Requests-GenerateDatakeyPair-ECC$", ""))
-		components = append(components, v.kmsKeyRequestComponent("RSA GenerateDataKeyPair requests", ".*KMS-Requests-GenerateDatakeyPair-RSA$", ""))
+		components = append(components, v.kmsKeyRequestComponent("Requests", ".*KMS-Requests$", "API Request", v.monthlyRequests))
+		components = append(components, v.kmsKeyRequestComponent("ECC GenerateDataKeyPair requests", ".*KMS-Requests-GenerateDatakeyPair-ECC$", "", v.monthlyECCGenerateDataKeyPairRequests))
+		components = append(components, v.kmsKeyRequestComponent("RSA GenerateDataKeyPair requests", ".*KMS-Requests-GenerateDatakeyPair-RSA$", "", v.monthlyRSAGenerateDataKeyPairRequests))
 	}
 
 	return components
@@ -107,12 +123,14 @@ func (v *KMSKey) kmsKeyComponent() query.Component {
 	}
 }
 
-func (v *KMSKey) kmsKeyRequestComponent(name string, usageType string, family string) query.Component {
+// kmsKeyRequestComponent returns the component of the requests of the usageType,
+// the quantity is the monthly number of requests from the usage
+func (v *KMSKey) kmsKeyRequestComponent(name string, usageType string, family string, quantity decimal.Decimal) query.Component {
 	return query.Component{
 		Name:            name,
-		MonthlyQuantity: decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
 		Details:         []string{"Request"},
-		Usage:           false,
+		Usage:           true,
 		Unit:            "Requests",
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
@@ -132,3 +150,10 @@ func (v *KMSKey) kmsKeyRequestComponent(name string, usageType string, family st
 		},
 	}
 }
+
+// kmsKeyExample returns the ResourceExample of the aws_kms_key, see Provider.ResourceExamples
+func (p *Provider) kmsKeyExample() terraform.ResourceExample {
+	return p.example("aws_kms_key", map[string]interface{}{
+		"customer_master_key_spec": "SYMMETRIC_DEFAULT",
+	})
+}
@@ -58,10 +58,10 @@ func TestKMSKey_Components(t *testing.T) {
 			},
 			{
 				Name:            "Requests",
-				MonthlyQuantity: decimal.NewFromFloat(1),
+				MonthlyQuantity: decimal.NewFromFloat(100000),
 				Unit:            "Requests",
 				Details:         []string{"Request"},
-				Usage:           false,
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("awskms"),
@@ -81,10 +81,10 @@ func TestKMSKey_Components(t *testing.T) {
 			},
 			{
 				Name:            "ECC GenerateDataKeyPair requests",
-				MonthlyQuantity: decimal.NewFromFloat(1),
+				MonthlyQuantity: decimal.Zero,
 				Unit:            "Requests",
 				Details:         []string{"Request"},
-				Usage:           false,
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("awskms"),
@@ -104,10 +104,10 @@ func TestKMSKey_Components(t *testing.T) {
 			},
 			{
 				Name:            "RSA GenerateDataKeyPair requests",
-				MonthlyQuantity: decimal.NewFromFloat(1),
+				MonthlyQuantity: decimal.Zero,
 				Unit:            "Requests",
 				Details:         []string{"Request"},
-				Usage:           false,
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("awskms"),
@@ -127,7 +127,7 @@ func TestKMSKey_Components(t *testing.T) {
 			},
 		}
 
-		us := usage.Default.GetUsage("aws_cloudwatch_metric_alarm")
+		us := usage.Default.GetUsage("aws_kms_key")
 		tfres.Values[usage.Key] = us
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
@@ -171,10 +171,10 @@ func TestKMSKey_Components(t *testing.T) {
 			},
 			{
 				Name:            "Requests (asymmetric)",
-				MonthlyQuantity: decimal.NewFromFloat(1),
+				MonthlyQuantity: decimal.NewFromFloat(100000),
 				Unit:            "Requests",
 				Details:         []string{"Request"},
-				Usage:           false,
+				Usage:           true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("awskms"),
@@ -194,7 +194,7 @@ func TestKMSKey_Components(t *testing.T) {
 			},
 		}
 
-		us := usage.Default.GetUsage("aws_cloudwatch_metric_alarm")
+		us := usage.Default.GetUsage("aws_kms_key")
 		tfres.Values[usage.Key] = us
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
@@ -4,6 +4,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -48,6 +49,11 @@ func decodeLBValues(tfVals map[string]interface{}) (lbValues, error) {
 
 // newLB created a new LB from lbValues.
 func (p *Provider) newLB(vals lbValues) *LB {
+	switch vals.LoadBalancerType {
+	case "", "application", "network", "gateway", "classic":
+	default:
+		p.warnf("unknown load_balancer_type %q, an application load balancer is assumed", vals.LoadBalancerType)
+	}
 	return &LB{
 		provider: p,
 		region:   p.region,
@@ -94,3 +100,16 @@ func (lb *LB) loadBalancerComponent() query.Component {
 		},
 	}
 }
+
+// lbExamples returns the ResourceExamples of the aws_elb, aws_lb and aws_alb, see Provider.ResourceExamples
+func (p *Provider) lbExamples() []terraform.ResourceExample {
+	return []terraform.ResourceExample{
+		p.example("aws_elb", map[string]interface{}{}),
+		p.example("aws_lb", map[string]interface{}{
+			"load_balancer_type": "application",
+		}),
+		p.example("aws_alb", map[string]interface{}{
+			"load_balancer_type": "application",
+		}),
+	}
+}
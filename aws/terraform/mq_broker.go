@@ -0,0 +1,186 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// mqDeploymentBrokers is the number of brokers of each deployment mode, the
+// Multi-AZ deployments are charged as the number of Single-AZ brokers they run
+var mqDeploymentBrokers = map[string]int64{
+	"SINGLE_INSTANCE":         1,
+	"ACTIVE_STANDBY_MULTI_AZ": 2,
+	"CLUSTER_MULTI_AZ":        3,
+}
+
+var mqEngineMap = map[string]string{
+	"activemq": "ActiveMQ",
+	"rabbitmq": "RabbitMQ",
+}
+
+// MQBroker represents an Amazon MQ broker definition that can be cost-estimated.
+type MQBroker struct {
+	providerKey string
+	region      region.Code
+
+	instanceType string
+
+	// engine can be one of "ActiveMQ" or "RabbitMQ".
+	engine string
+
+	brokers decimal.Decimal
+
+	// storageType can be one of "efs" or "ebs", only ActiveMQ supports "efs"
+	storageType string
+
+	// Usage
+	storageGB decimal.Decimal
+}
+
+type mqBrokerValues struct {
+	HostInstanceType string `mapstructure:"host_instance_type"`
+	EngineType       string `mapstructure:"engine_type"`
+	DeploymentMode   string `mapstructure:"deployment_mode"`
+	StorageType      string `mapstructure:"storage_type"`
+
+	Usage struct {
+		StorageGB float64 `mapstructure:"storage_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeMQBrokerValues(tfVals map[string]interface{}) (mqBrokerValues, error) {
+	var v mqBrokerValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newMQBroker creates a new MQBroker from mqBrokerValues.
+func (p *Provider) newMQBroker(vals mqBrokerValues) *MQBroker {
+	inst := &MQBroker{
+		providerKey:  p.key,
+		region:       p.region,
+		instanceType: vals.HostInstanceType,
+		engine:       mqEngineMap[strings.ToLower(vals.EngineType)],
+		storageType:  strings.ToLower(vals.StorageType),
+
+		// From Usage
+		storageGB: decimal.NewFromFloat(vals.Usage.StorageGB),
+	}
+
+	if inst.engine == "" {
+		p.warnf("unknown engine_type %q, the broker is estimated as ActiveMQ", vals.EngineType)
+		inst.engine = "ActiveMQ"
+	}
+
+	brokers, ok := mqDeploymentBrokers[vals.DeploymentMode]
+	if !ok {
+		brokers = 1
+		if vals.DeploymentMode != "" {
+			p.warnf("unknown deployment_mode %q, the broker is estimated as SINGLE_INSTANCE", vals.DeploymentMode)
+		}
+	}
+	inst.brokers = decimal.NewFromInt(brokers)
+
+	// The RabbitMQ brokers only have EBS and the ActiveMQ ones default to EFS
+	if inst.storageType == "" {
+		inst.storageType = "efs"
+		if inst.engine == "RabbitMQ" {
+			inst.storageType = "ebs"
+		}
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the MQBroker.
+func (inst *MQBroker) Components() []query.Component {
+	return []query.Component{
+		inst.instanceComponent(),
+		inst.storageComponent(),
+	}
+}
+
+func (inst *MQBroker) instanceComponent() query.Component {
+	return query.Component{
+		Name:           "Broker instance",
+		Details:        []string{inst.engine, inst.instanceType},
+		HourlyQuantity: inst.brokers,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AmazonMQ"),
+			Family:   util.StringPtr("Broker Instances"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "InstanceType", Value: util.StringPtr(inst.instanceType)},
+				{Key: "BrokerEngine", Value: util.StringPtr(inst.engine)},
+				{Key: "DeploymentOption", Value: util.StringPtr("Single-AZ")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Hrs"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+func (inst *MQBroker) storageComponent() query.Component {
+	usageType := "TimedStorage-ByteHrs$"
+	if inst.storageType == "ebs" {
+		usageType = "TimedStorage-EBS-ByteHrs$"
+	}
+
+	return query.Component{
+		Name:            "Storage",
+		Details:         []string{strings.ToUpper(inst.storageType)},
+		Usage:           true,
+		MonthlyQuantity: inst.storageGB.Mul(inst.brokers),
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AmazonMQ"),
+			Family:   util.StringPtr("Broker Storage"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "BrokerEngine", Value: util.StringPtr(inst.engine)},
+				{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("GB-Mo"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// mqBrokerExample returns the ResourceExample of the aws_mq_broker, see Provider.ResourceExamples
+func (p *Provider) mqBrokerExample() terraform.ResourceExample {
+	return p.example("aws_mq_broker", map[string]interface{}{
+		"host_instance_type": "mq.m5.large",
+		"engine_type":        "ActiveMQ",
+		"deployment_mode":    "ACTIVE_STANDBY_MULTI_AZ",
+	})
+}
@@ -0,0 +1,127 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestMQBroker_Components(t *testing.T) {
+	p, err := NewProvider("aws", "us-east-1")
+	require.NoError(t, err)
+
+	expectedComponents := func(engine, storageType, usageType string, brokers int64) []query.Component {
+		return []query.Component{
+			{
+				Name:           "Broker instance",
+				Details:        []string{engine, "mq.m5.large"},
+				HourlyQuantity: decimal.NewFromInt(brokers),
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonMQ"),
+					Family:   util.StringPtr("Broker Instances"),
+					Location: util.StringPtr("us-east-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "InstanceType", Value: util.StringPtr("mq.m5.large")},
+						{Key: "BrokerEngine", Value: util.StringPtr(engine)},
+						{Key: "DeploymentOption", Value: util.StringPtr("Single-AZ")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("Hrs"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+			{
+				Name:            "Storage",
+				Details:         []string{storageType},
+				Usage:           true,
+				MonthlyQuantity: decimal.NewFromFloat(20).Mul(decimal.NewFromInt(brokers)),
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonMQ"),
+					Family:   util.StringPtr("Broker Storage"),
+					Location: util.StringPtr("us-east-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "BrokerEngine", Value: util.StringPtr(engine)},
+						{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("GB-Mo"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ActiveStandby", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_mq_broker.test",
+			Type:         "aws_mq_broker",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"host_instance_type": "mq.m5.large",
+				"engine_type":        "ActiveMQ",
+				"deployment_mode":    "ACTIVE_STANDBY_MULTI_AZ",
+				usage.Key:            usage.Default.GetUsage("aws_mq_broker"),
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, expectedComponents("ActiveMQ", "EFS", "TimedStorage-ByteHrs$", 2), actual)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("RabbitMQCluster", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_mq_broker.test",
+			Type:         "aws_mq_broker",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"host_instance_type": "mq.m5.large",
+				"engine_type":        "RabbitMQ",
+				"deployment_mode":    "CLUSTER_MULTI_AZ",
+				usage.Key:            usage.Default.GetUsage("aws_mq_broker"),
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, expectedComponents("RabbitMQ", "EBS", "TimedStorage-EBS-ByteHrs$", 3), actual)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("UnknownDeploymentMode", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_mq_broker.test",
+			Type:         "aws_mq_broker",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"host_instance_type": "mq.m5.large",
+				"engine_type":        "ActiveMQ",
+				"deployment_mode":    "OTHER",
+				usage.Key:            usage.Default.GetUsage("aws_mq_broker"),
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, expectedComponents("ActiveMQ", "EFS", "TimedStorage-ByteHrs$", 1), actual)
+		assert.Equal(t, []string{`unknown deployment_mode "OTHER", the broker is estimated as SINGLE_INSTANCE`}, warnings)
+	})
+}
@@ -0,0 +1,154 @@
+package terraform
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// MSKCluster represents an MSK (Managed Streaming for Apache Kafka) cluster definition that can be cost-estimated.
+type MSKCluster struct {
+	providerKey string
+	region      region.Code
+
+	// instanceType is the broker type without the "kafka." prefix (e.g. "m5.large")
+	instanceType string
+
+	numberOfBrokerNodes decimal.Decimal
+
+	// volumeSizeGB is the EBS storage of each broker
+	volumeSizeGB decimal.Decimal
+}
+
+type mskClusterValues struct {
+	NumberOfBrokerNodes int64 `mapstructure:"number_of_broker_nodes"`
+
+	BrokerNodeGroupInfo []struct {
+		InstanceType  string  `mapstructure:"instance_type"`
+		EBSVolumeSize float64 `mapstructure:"ebs_volume_size"`
+
+		StorageInfo []struct {
+			EBSStorageInfo []struct {
+				VolumeSize float64 `mapstructure:"volume_size"`
+			} `mapstructure:"ebs_storage_info"`
+		} `mapstructure:"storage_info"`
+	} `mapstructure:"broker_node_group_info"`
+}
+
+func decodeMSKClusterValues(tfVals map[string]interface{}) (mskClusterValues, error) {
+	var v mskClusterValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newMSKCluster creates a new MSKCluster from mskClusterValues.
+func (p *Provider) newMSKCluster(vals mskClusterValues) *MSKCluster {
+	inst := &MSKCluster{
+		providerKey:         p.key,
+		region:              p.region,
+		numberOfBrokerNodes: decimal.NewFromInt(vals.NumberOfBrokerNodes),
+	}
+
+	if len(vals.BrokerNodeGroupInfo) > 0 {
+		bngi := vals.BrokerNodeGroupInfo[0]
+		inst.instanceType = strings.TrimPrefix(bngi.InstanceType, "kafka.")
+
+		// The ebs_volume_size is deprecated in favor of the storage_info
+		inst.volumeSizeGB = decimal.NewFromFloat(bngi.EBSVolumeSize)
+		if len(bngi.StorageInfo) > 0 && len(bngi.StorageInfo[0].EBSStorageInfo) > 0 {
+			inst.volumeSizeGB = decimal.NewFromFloat(bngi.StorageInfo[0].EBSStorageInfo[0].VolumeSize)
+		}
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the MSKCluster.
+// The brokers are charged by hour and their storage by GB-month.
+func (inst *MSKCluster) Components() []query.Component {
+	components := []query.Component{inst.brokerComponent()}
+
+	if inst.volumeSizeGB.IsPositive() {
+		components = append(components, inst.storageComponent())
+	}
+
+	return components
+}
+
+func (inst *MSKCluster) brokerComponent() query.Component {
+	return query.Component{
+		Name:           "Broker instance",
+		Details:        []string{"kafka." + inst.instanceType},
+		HourlyQuantity: inst.numberOfBrokerNodes,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AmazonMSK"),
+			Family:   util.StringPtr("Managed Streaming for Apache Kafka (MSK)"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("Kafka\\." + regexp.QuoteMeta(inst.instanceType) + "$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Hrs"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+func (inst *MSKCluster) storageComponent() query.Component {
+	return query.Component{
+		Name:            "Storage",
+		Details:         []string{"EBS"},
+		MonthlyQuantity: inst.volumeSizeGB.Mul(inst.numberOfBrokerNodes),
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AmazonMSK"),
+			Family:   util.StringPtr("Managed Streaming for Apache Kafka (MSK)"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("Kafka\\.Storage\\.GP2$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("GB-Mo"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// mskClusterExample returns the ResourceExample of the aws_msk_cluster, see Provider.ResourceExamples
+func (p *Provider) mskClusterExample() terraform.ResourceExample {
+	return p.example("aws_msk_cluster", map[string]interface{}{
+		"number_of_broker_nodes": 3,
+		"broker_node_group_info": []interface{}{map[string]interface{}{
+			"instance_type": "kafka.m5.large",
+			"storage_info":  []interface{}{map[string]interface{}{"ebs_storage_info": []interface{}{map[string]interface{}{"volume_size": float64(100)}}}},
+		}},
+	})
+}
@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestMSKCluster_Components(t *testing.T) {
+	p, err := NewProvider("aws", "us-east-1")
+	require.NoError(t, err)
+
+	brokerComponent := query.Component{
+		Name:           "Broker instance",
+		Details:        []string{"kafka.m5.large"},
+		HourlyQuantity: decimal.NewFromInt(3),
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr("aws"),
+			Service:  util.StringPtr("AmazonMSK"),
+			Family:   util.StringPtr("Managed Streaming for Apache Kafka (MSK)"),
+			Location: util.StringPtr("us-east-1"),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("Kafka\\.m5\\.large$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Hrs"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+
+	t.Run("StorageInfo", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_msk_cluster.test",
+			Type:         "aws_msk_cluster",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"number_of_broker_nodes": 3,
+				"broker_node_group_info": []interface{}{map[string]interface{}{
+					"instance_type": "kafka.m5.large",
+					"storage_info":  []interface{}{map[string]interface{}{"ebs_storage_info": []interface{}{map[string]interface{}{"volume_size": 100}}}},
+				}},
+			},
+		}
+
+		expected := []query.Component{
+			brokerComponent,
+			{
+				Name:            "Storage",
+				Details:         []string{"EBS"},
+				MonthlyQuantity: decimal.NewFromFloat(100).Mul(decimal.NewFromInt(3)),
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonMSK"),
+					Family:   util.StringPtr("Managed Streaming for Apache Kafka (MSK)"),
+					Location: util.StringPtr("us-east-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr("Kafka\\.Storage\\.GP2$")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("GB-Mo"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("NoStorage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_msk_cluster.test",
+			Type:         "aws_msk_cluster",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"number_of_broker_nodes": 3,
+				"broker_node_group_info": []interface{}{map[string]interface{}{
+					"instance_type": "kafka.m5.large",
+				}},
+			},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, []query.Component{brokerComponent}, actual)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -110,3 +111,8 @@ func (inst *NatGateway) natGatewayDataProcessedComponent() query.Component {
 		},
 	}
 }
+
+// natGatewayExample returns the ResourceExample of the aws_nat_gateway, see Provider.ResourceExamples
+func (p *Provider) natGatewayExample() terraform.ResourceExample {
+	return p.example("aws_nat_gateway", map[string]interface{}{})
+}
@@ -13,21 +13,174 @@ import (
 type Provider struct {
 	key    string
 	region region.Code
+
+	// defaultRegion is set when the region is not defined on the provider
+	// configuration, so the resources estimated have a warning about it
+	defaultRegion bool
+
+	// warnings of the resource being estimated, only set on the
+	// copy of the Provider made by ResourceComponentsWithWarnings
+	warnings []string
 }
 
-// NewProvider returns a new Provider with the provided default region and a query key.
+// NewProvider returns a new Provider with the provided default region and a query key. The regionCode
+// can also be the name of the region and its casing doesn't matter (ex: "EU (Ireland)", "EU-WEST-1"), see region.Parse.
 func NewProvider(key string, regionCode region.Code) (*Provider, error) {
+	if c := region.Parse(string(regionCode)); c != "" {
+		regionCode = c
+	}
 	if !regionCode.Valid() {
 		return nil, fmt.Errorf("invalid AWS region: %q", regionCode)
 	}
 	return &Provider{key: key, region: regionCode}, nil
 }
 
+// NewProviderWithDefaultRegion returns a new Provider as NewProvider for a provider configuration that
+// has no region, the regionCode is the fallback used and each resource estimated has a warning about it.
+func NewProviderWithDefaultRegion(key string, regionCode region.Code) (*Provider, error) {
+	p, err := NewProvider(key, regionCode)
+	if err != nil {
+		return nil, err
+	}
+	p.defaultRegion = true
+	return p, nil
+}
+
 // Name returns the Provider's common name.
 func (p *Provider) Name() string { return p.key }
 
+// ResourceAttributes returns the attributes of the resource type used to estimate it, see terraform.AttributesProvider.
+func (p *Provider) ResourceAttributes(resourceType string) []string {
+	v, ok := UsageValues()[resourceType]
+	if !ok {
+		return nil
+	}
+	return terraform.ValuesAttributes(v)
+}
+
+// DataSources returns the data source types estimated as the resources
+// of the same type, see terraform.DataSourcesProvider.
+func (p *Provider) DataSources() []string {
+	return []string{
+		"aws_ebs_volume",
+		"aws_eip",
+		"aws_elasticache_cluster",
+		"aws_instance",
+		"aws_lb",
+		"aws_nat_gateway",
+	}
+}
+
+// warnf adds a warning to the resource being estimated
+func (p *Provider) warnf(format string, args ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
+// ResourceExamples returns a canonical example of each supported resource type on the regionCode, see
+// terraform.ExamplesProvider. The examples are estimated by the returned Provider, on the regionCode or on
+// the region of p if it's empty.
+func (p *Provider) ResourceExamples(regionCode string) (terraform.Provider, []terraform.ResourceExample, error) {
+	rp := p
+	if regionCode != "" {
+		var err error
+		rp, err = NewProvider(p.key, region.Code(regionCode))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	examples := []terraform.ResourceExample{
+		rp.instanceExample(),
+		rp.apiGatewayRestAPIExample(),
+		rp.apiGatewayStageExample(),
+		rp.apiGatewayV2APIExample(),
+		rp.autoscalingGroupExample(),
+		rp.backupVaultExample(),
+		rp.cloudFormationStackExample(),
+		rp.cloudFrontDistributionExample(),
+		rp.cloudwatchLogGroupExample(),
+		rp.cloudwatchLogMetricFilterExample(),
+		rp.cloudwatchMetricAlarmExample(),
+		rp.dbInstanceExample(),
+		rp.dbSnapshotExample(),
+		rp.directoryServiceDirectoryExample(),
+		rp.volumeExample(),
+		rp.ebsSnapshotExample(),
+		rp.ec2HostExample(),
+		rp.efsFileSystemExample(),
+		rp.elastiCacheExample(),
+		rp.elastiCacheReplicationExample(),
+		rp.elasticIPExample(),
+		rp.eksClusterExample(),
+		rp.eksNodeGroupExample(),
+		rp.fsxLustreFileSystemExample(),
+		rp.fsxOntapFileSystemExample(),
+		rp.fsxOpenzfsFileSystemExample(),
+		rp.fsxWindowsFileSystemExample(),
+		rp.kmsKeyExample(),
+		rp.mqBrokerExample(),
+		rp.mskClusterExample(),
+		rp.natGatewayExample(),
+		rp.rdsClusterExample(),
+		rp.rdsClusterInstanceExample(),
+		rp.route53ZoneExample(),
+		rp.s3BucketExample(),
+		rp.s3BucketAnalyticsConfigurationExample(),
+		rp.s3BucketInventoryExample(),
+		rp.sageMakerEndpointConfigurationExample(),
+		rp.sageMakerNotebookInstanceExample(),
+		rp.secretsmanagerSecretExample(),
+		rp.snsTopicExample(),
+		rp.sqsQueueExample(),
+		rp.transferServerExample(),
+		rp.vpcEndpointExample(),
+		rp.wafv2WebACLExample(),
+	}
+	examples = append(examples, rp.lbExamples()...)
+	return rp, examples, nil
+}
+
+// example returns the ResourceExample of the resource type rt with the values and its references
+func (p *Provider) example(rt string, values map[string]interface{}, references ...terraform.Resource) terraform.ResourceExample {
+	return terraform.ResourceExample{
+		Resource:   p.resource(rt, values),
+		References: references,
+	}
+}
+
+// resource returns the Resource 'example' of the resource type rt with the values
+func (p *Provider) resource(rt string, values map[string]interface{}) terraform.Resource {
+	return terraform.Resource{
+		Address:      rt + ".example",
+		Mode:         "managed",
+		Type:         rt,
+		Name:         "example",
+		ProviderName: p.key,
+		Values:       values,
+	}
+}
+
 // ResourceComponents returns Component queries for a given terraform.Resource.
 func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes terraform.Resource) []query.Component {
+	components, _ := p.ResourceComponentsWithWarnings(rss, tfRes)
+	return components
+}
+
+// ResourceComponentsWithWarnings returns the Component queries of the terraform.Resource as ResourceComponents
+// and the assumptions made to estimate it, see terraform.WarningsProvider. The warnings are collected on a copy
+// of the Provider so it can be used concurrently.
+func (p *Provider) ResourceComponentsWithWarnings(rss map[string]terraform.Resource, tfRes terraform.Resource) ([]query.Component, []string) {
+	rp := *p
+	rp.warnings = nil
+	components := rp.resourceComponents(rss, tfRes)
+	if rp.defaultRegion && len(components) > 0 {
+		rp.warnf("no region on the provider configuration, the default region %q is used", rp.region)
+	}
+	return components, rp.warnings
+}
+
+// resourceComponents returns the Component queries of the terraform.Resource depending on its type
+func (p *Provider) resourceComponents(rss map[string]terraform.Resource, tfRes terraform.Resource) []query.Component {
 	switch tfRes.Type {
 	case "aws_instance":
 		vals, err := decodeInstanceValues(tfRes.Values)
@@ -35,18 +188,60 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 			return nil
 		}
 		return p.newInstance(vals).Components()
+	case "aws_api_gateway_rest_api":
+		vals, err := decodeAPIGatewayRestAPIValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newAPIGatewayRestAPI(vals).Components()
+	case "aws_api_gateway_stage":
+		vals, err := decodeAPIGatewayStageValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newAPIGatewayStage(vals).Components()
+	case "aws_apigatewayv2_api":
+		vals, err := decodeAPIGatewayV2APIValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newAPIGatewayV2API(vals).Components()
 	case "aws_autoscaling_group":
 		vals, err := decodeAutoscalingGroupValues(tfRes.Values)
 		if err != nil {
 			return nil
 		}
 		return p.newAutoscalingGroup(rss, vals).Components()
+	case "aws_backup_vault":
+		vals, err := decodeBackupVaultValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newBackupVault(vals).Components()
+	case "aws_cloudformation_stack":
+		vals, err := decodeCloudFormationStackValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.cloudFormationStackComponents(rss, tfRes, vals)
+	case "aws_cloudfront_distribution":
+		vals, err := decodeCloudFrontDistributionValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newCloudFrontDistribution(rss, vals).Components()
 	case "aws_cloudwatch_log_group":
 		vals, err := decodeCloudwatchLogGroupValues(tfRes.Values)
 		if err != nil {
 			return nil
 		}
 		return p.newCloudwatchLogGroup(rss, vals).Components()
+	case "aws_cloudwatch_log_metric_filter":
+		vals, err := decodeCloudwatchLogMetricFilterValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newCloudwatchLogMetricFilter(rss, vals).Components()
 	case "aws_cloudwatch_metric_alarm":
 		vals, err := decodeCloudwatchMetricAlarmValues(tfRes.Values)
 		if err != nil {
@@ -60,12 +255,36 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 			return nil
 		}
 		return p.newDBInstance(vals).Components()
+	case "aws_db_snapshot":
+		vals, err := decodeDBSnapshotValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newDBSnapshot(vals).Components()
+	case "aws_directory_service_directory":
+		vals, err := decodeDirectoryServiceDirectoryValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newDirectoryServiceDirectory(vals).Components()
 	case "aws_ebs_volume":
 		vals, err := decodeVolumeValues(tfRes.Values)
 		if err != nil {
 			return nil
 		}
 		return p.newVolume(vals).Components()
+	case "aws_ebs_snapshot":
+		vals, err := decodeEBSSnapshotValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newEBSSnapshot(vals).Components()
+	case "aws_ec2_host":
+		vals, err := decodeEC2HostValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newEC2Host(vals).Components()
 	case "aws_efs_file_system":
 		vals, err := decodeEFSFileSystemValues(tfRes.Values)
 		if err != nil {
@@ -142,6 +361,18 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 			return nil
 		}
 		return p.newLB(vals).Components()
+	case "aws_mq_broker":
+		vals, err := decodeMQBrokerValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newMQBroker(vals).Components()
+	case "aws_msk_cluster":
+		vals, err := decodeMSKClusterValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newMSKCluster(vals).Components()
 	case "aws_nat_gateway":
 		vals, err := decodeNatGatewayValues(tfRes.Values)
 		if err != nil {
@@ -160,6 +391,12 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 			return nil
 		}
 		return p.newRDSClusterInstance(rss, vals).Components()
+	case "aws_route53_zone":
+		vals, err := decodeRoute53ZoneValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newRoute53Zone(rss, vals).Components()
 	case "aws_s3_bucket":
 		vals, err := decodeS3BucketValues(tfRes.Values)
 		if err != nil {
@@ -178,18 +415,54 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 			return nil
 		}
 		return p.newS3BucketInventory(rss, vals).Components()
+	case "aws_sagemaker_endpoint_configuration":
+		vals, err := decodeSageMakerEndpointConfigurationValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newSageMakerEndpointConfiguration(vals).Components()
+	case "aws_sagemaker_notebook_instance":
+		vals, err := decodeSageMakerNotebookInstanceValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newSageMakerNotebookInstance(vals).Components()
 	case "aws_secretsmanager_secret":
 		vals, err := decodeSecretsmanagerSecretValues(tfRes.Values)
 		if err != nil {
 			return nil
 		}
 		return p.newSecretsmanagerSecret(rss, vals).Components()
+	case "aws_sns_topic":
+		vals, err := decodeSNSTopicValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newSNSTopic(rss, vals).Components()
 	case "aws_sqs_queue":
 		vals, err := decodeSQSQueueValues(tfRes.Values)
 		if err != nil {
 			return nil
 		}
 		return p.newSQSQueue(rss, vals).Components()
+	case "aws_transfer_server":
+		vals, err := decodeTransferServerValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newTransferServer(vals).Components()
+	case "aws_vpc_endpoint":
+		vals, err := decodeVPCEndpointValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newVPCEndpoint(vals).Components()
+	case "aws_wafv2_web_acl":
+		vals, err := decodeWAFv2WebACLValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newWAFv2WebACL(vals).Components()
 	default:
 		return nil
 	}
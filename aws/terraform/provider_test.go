@@ -0,0 +1,58 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+)
+
+func TestProvider_ResourceExamples(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-1")
+	require.NoError(t, err)
+
+	t.Run("Region", func(t *testing.T) {
+		rp, examples, err := p.ResourceExamples("eu-west-3")
+		require.NoError(t, err)
+		require.NotEmpty(t, examples)
+
+		ex := examples[0]
+		rss := map[string]terraform.Resource{ex.Resource.Address: ex.Resource}
+		ex.Resource.Values[usage.Key] = usage.Default.GetUsage(ex.Resource.Type)
+		comps := rp.ResourceComponents(rss, ex.Resource)
+		require.NotEmpty(t, comps)
+		assert.Equal(t, "eu-west-3", *comps[0].ProductFilter.Location)
+	})
+
+	t.Run("InvalidRegion", func(t *testing.T) {
+		_, _, err := p.ResourceExamples("eu-invalid-1")
+		assert.Error(t, err)
+	})
+
+	// All the resource types of the UsageValues have to have an example
+	// with components so the coverage of all of them can be verified
+	rp, exs, err := p.ResourceExamples("")
+	require.NoError(t, err)
+	examples := make(map[string]terraform.ResourceExample)
+	for _, ex := range exs {
+		examples[ex.Resource.Type] = ex
+	}
+	for rt := range awstf.UsageValues() {
+		t.Run(rt, func(t *testing.T) {
+			ex, ok := examples[rt]
+			require.True(t, ok, "missing example")
+
+			rss := map[string]terraform.Resource{ex.Resource.Address: ex.Resource}
+			for _, ref := range ex.References {
+				rss[ref.Address] = ref
+			}
+			ex.Resource.Values[usage.Key] = usage.Default.GetUsage(rt)
+
+			assert.NotEmpty(t, rp.ResourceComponents(rss, ex.Resource))
+		})
+	}
+}
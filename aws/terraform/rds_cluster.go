@@ -1,7 +1,6 @@
 package terraform
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -46,6 +45,7 @@ type rdsClusterValues struct {
 	BackupRetentionPeriod            float64 `mapstructure:"backup_retention_period"`
 	Serverlessv2ScalingConfiguration []struct {
 		MinCapacity float64 `mapstructure:"min_capacity"`
+		MaxCapacity float64 `mapstructure:"max_capacity"`
 	} `mapstructure:"serverlessv2_scaling_configuration"`
 
 	Usage struct {
@@ -142,8 +142,10 @@ func (v *RDSCluster) Components() []query.Component {
 
 	components := v.rdsClusterAuroraStorageComponent(databaseEngine, isIOOptimized)
 
-	if v.isServerless {
-		components = append(components, v.rdsClusterAuroraServerlessComponent(databaseEngine, isIOOptimized))
+	// The Serverless v2 capacity is charged on the db.serverless instances of
+	// the cluster (aws_rds_cluster_instance), only the v1 one is on the cluster
+	if v.isServerless && v.serverlessVersion == "v1" {
+		components = append(components, v.rdsClusterAuroraServerlessComponent(databaseEngine))
 	}
 
 	if v.backupRetentionPeriod.GreaterThan(decimal.NewFromFloat(1)) {
@@ -161,33 +163,21 @@ func (v *RDSCluster) Components() []query.Component {
 	return components
 }
 
-func (v *RDSCluster) rdsClusterAuroraServerlessComponent(databaseEngine string, isIOOptimized bool) query.Component {
-
-	family := "Serverless"
-	usageType := ".*Aurora:ServerlessUsage$"
-
-	if v.serverlessVersion == "v2" {
-		family = "ServerlessV2"
-		usageType = ".*Aurora:ServerlessV2Usage$"
-		if isIOOptimized {
-			usageType = ".*Aurora:ServerlessV2IOOptimizedUsage$"
-		}
-	}
-
+func (v *RDSCluster) rdsClusterAuroraServerlessComponent(databaseEngine string) query.Component {
 	return query.Component{
-		Name:            fmt.Sprintf("Aurora %s", family),
-		MonthlyQuantity: v.capacityUnitsPerHr,
-		Details:         []string{databaseEngine},
-		Usage:           true,
-		Unit:            "ACU-Hr",
+		Name:           "Aurora Serverless",
+		HourlyQuantity: v.capacityUnitsPerHr,
+		Details:        []string{databaseEngine},
+		Usage:          true,
+		Unit:           "ACU-Hr",
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
 			Service:  util.StringPtr("AmazonRDS"),
-			Family:   util.StringPtr(family),
+			Family:   util.StringPtr("Serverless"),
 			Location: util.StringPtr(v.region.String()),
 			AttributeFilters: []*product.AttributeFilter{
 				{Key: "DatabaseEngine", Value: util.StringPtr(databaseEngine)},
-				{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+				{Key: "UsageType", ValueRegex: util.StringPtr(".*Aurora:ServerlessUsage$")},
 			},
 		},
 		PriceFilter: &price.Filter{
@@ -353,3 +343,11 @@ func (v *RDSCluster) rdsClusterAuroraSnapshotExportComponent(databaseEngine stri
 		},
 	}
 }
+
+// rdsClusterExample returns the ResourceExample of the aws_rds_cluster, see Provider.ResourceExamples
+func (p *Provider) rdsClusterExample() terraform.ResourceExample {
+	return p.example("aws_rds_cluster", map[string]interface{}{
+		"engine":                  "aurora-mysql",
+		"backup_retention_period": 7,
+	})
+}
@@ -28,6 +28,9 @@ type RDSClusterInstance struct {
 	isServerless  bool
 	isIOOptimized bool
 
+	// capacityUnitsUsage is set when the capacityUnitsPerHr are the ones of the usage
+	capacityUnitsUsage bool
+
 	// Usage
 	monthlyAdditionalPerformanceInsightsRequests decimal.Decimal
 	capacityUnitsPerHr                           decimal.Decimal
@@ -71,7 +74,7 @@ func decodeRDSClusterInstanceValues(tfVals map[string]interface{}) (rdsClusterIn
 }
 
 // newRDSClusterInstance creates a new RDSClusterInstance from rdsClusterInstanceValues.
-func (p *Provider) newRDSClusterInstance(_ map[string]terraform.Resource, vals rdsClusterInstanceValues) *RDSClusterInstance {
+func (p *Provider) newRDSClusterInstance(rss map[string]terraform.Resource, vals rdsClusterInstanceValues) *RDSClusterInstance {
 	v := &RDSClusterInstance{
 		provider:                           p,
 		region:                             p.region,
@@ -81,19 +84,49 @@ func (p *Provider) newRDSClusterInstance(_ map[string]terraform.Resource, vals r
 		performanceInsightsRetentionPeriod: decimal.NewFromFloat(vals.PerformanceInsightsRetentionPeriod),
 		engine:                             vals.Engine,
 		engineVersion:                      vals.EngineVersion,
+		storageType:                        vals.StorageType,
 
 		// Usage
 		capacityUnitsPerHr:                           decimal.NewFromFloat(vals.Usage.CapacityUnitsPerHr),
+		capacityUnitsUsage:                           true,
 		monthlyAdditionalPerformanceInsightsRequests: decimal.NewFromFloat(vals.Usage.MonthlyAdditionalPerformanceInsightsRequests),
 	}
 
 	v.isServerless = strings.EqualFold(vals.InstanceClass, "db.serverless")
 
+	// The storage type and the Serverless v2 capacity range are the ones of the cluster
+	var minCapacity, maxCapacity decimal.Decimal
+	if cluster, ok := rss[vals.ClusterIdentifier]; ok {
+		if cvals, err := decodeRDSClusterValues(cluster.Values); err == nil {
+			if v.engine == "" {
+				v.engine = cvals.Engine
+			}
+			if cvals.StorageType != "" {
+				v.storageType = cvals.StorageType
+			}
+			if len(cvals.Serverlessv2ScalingConfiguration) > 0 {
+				minCapacity = decimal.NewFromFloat(cvals.Serverlessv2ScalingConfiguration[0].MinCapacity)
+				maxCapacity = decimal.NewFromFloat(cvals.Serverlessv2ScalingConfiguration[0].MaxCapacity)
+			}
+		}
+	}
+
 	switch v.storageType {
 	case "aurora-iopt1":
 		v.isIOOptimized = true
 	}
 
+	// The average ACUs of the usage can't be out of the capacity range of the cluster
+	if v.isServerless {
+		if v.capacityUnitsPerHr.LessThan(minCapacity) {
+			v.capacityUnitsPerHr = minCapacity
+			v.capacityUnitsUsage = false
+		} else if maxCapacity.IsPositive() && v.capacityUnitsPerHr.GreaterThan(maxCapacity) {
+			v.capacityUnitsPerHr = maxCapacity
+			v.capacityUnitsUsage = false
+		}
+	}
+
 	return v
 }
 
@@ -136,7 +169,7 @@ func (v *RDSClusterInstance) rdsClusterInstanceServerlessV2CostComponent(databas
 		Name:           name,
 		HourlyQuantity: v.capacityUnitsPerHr,
 		Details:        []string{name},
-		Usage:          true,
+		Usage:          v.capacityUnitsUsage,
 		Unit:           "ACU-Hr",
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
@@ -245,3 +278,11 @@ func (v *RDSClusterInstance) rdsClusterInstanceInsightsAPIRequestComponent() que
 		},
 	}
 }
+
+// rdsClusterInstanceExample returns the ResourceExample of the aws_rds_cluster_instance, see Provider.ResourceExamples
+func (p *Provider) rdsClusterInstanceExample() terraform.ResourceExample {
+	return p.example("aws_rds_cluster_instance", map[string]interface{}{
+		"engine":         "aurora-mysql",
+		"instance_class": "db.r5.large",
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	awstf "github.com/cycloidio/terracost/aws/terraform"
@@ -115,4 +116,55 @@ func TestRDSClusterInstance_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
+
+	t.Run("RDSClusterInstanceServerlessV2", func(t *testing.T) {
+		cluster := terraform.Resource{
+			Address:      "aws_rds_cluster.test",
+			Type:         "aws_rds_cluster",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"engine":       "aurora-postgresql",
+				"storage_type": "aurora-iopt1",
+				"serverlessv2_scaling_configuration": []interface{}{
+					map[string]interface{}{
+						"min_capacity": 2,
+						"max_capacity": 8,
+					},
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{cluster.Address: cluster}
+
+		instance := func(acus float64) terraform.Resource {
+			return terraform.Resource{
+				Address:      "aws_rds_cluster_instance.test",
+				Type:         "aws_rds_cluster_instance",
+				Name:         "test",
+				ProviderName: "aws",
+				Values: map[string]interface{}{
+					"cluster_identifier": cluster.Address,
+					"instance_class":     "db.serverless",
+					usage.Key:            map[string]interface{}{"capacity_units_per_hr": acus},
+				},
+			}
+		}
+
+		actual := p.ResourceComponents(rss, instance(4))
+		require.Len(t, actual, 1)
+		assert.Equal(t, "Aurora serverless v2 (I/O-optimized)", actual[0].Name)
+		assert.Equal(t, "4", actual[0].HourlyQuantity.String())
+		assert.True(t, actual[0].Usage)
+		assert.Contains(t, actual[0].ProductFilter.AttributeFilters, &product.AttributeFilter{Key: "DatabaseEngine", Value: util.StringPtr("Aurora PostgreSQL")})
+
+		// The usage is out of the capacity range of the cluster
+		actual = p.ResourceComponents(rss, instance(0.5))
+		require.Len(t, actual, 1)
+		assert.Equal(t, "2", actual[0].HourlyQuantity.String())
+		assert.False(t, actual[0].Usage)
+
+		actual = p.ResourceComponents(rss, instance(16))
+		require.Len(t, actual, 1)
+		assert.Equal(t, "8", actual[0].HourlyQuantity.String())
+	})
 }
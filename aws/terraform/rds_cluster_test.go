@@ -161,6 +161,60 @@ func TestRDSCluster_Components(t *testing.T) {
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
 
+	t.Run("RDSClusterServerlessV1", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_rds_cluster.test",
+			Type:         "aws_rds_cluster",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"engine":      "aurora-postgresql",
+				"engine_mode": "serverless",
+				usage.Key: map[string]interface{}{
+					"capacity_units_per_hr": 2,
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		// The capacity_units_per_hr are the ACUs running each hour, so
+		// the month is charged 2 ACUs x 730h = 1460 ACU-Hr
+		expected := query.Component{
+			Name:           "Aurora Serverless",
+			HourlyQuantity: decimal.NewFromFloat(2),
+			Unit:           "ACU-Hr",
+			Details:        []string{"Aurora PostgreSQL"},
+			Usage:          true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonRDS"),
+				Family:   util.StringPtr("Serverless"),
+				Location: util.StringPtr("eu-west-1"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "DatabaseEngine", Value: util.StringPtr("Aurora PostgreSQL")},
+					{Key: "UsageType", ValueRegex: util.StringPtr(".*Aurora:ServerlessUsage$")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("ACU-Hr"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					{Key: "StartingRange", Value: util.StringPtr("0")},
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(rss, tfres)
+		var found bool
+		for _, c := range actual {
+			if c.Name == expected.Name {
+				testutil.EqualQueryComponents(t, []query.Component{expected}, []query.Component{c})
+				found = true
+			}
+		}
+		require.True(t, found, "missing the Aurora Serverless component")
+	})
+
 	t.Run("RDSClusterServerlessPostgres", func(t *testing.T) {
 		tfres := terraform.Resource{
 			Address:      "aws_rds_cluster.test",
@@ -229,30 +283,6 @@ func TestRDSCluster_Components(t *testing.T) {
 					},
 				},
 			},
-			{
-				Name:            "Aurora ServerlessV2",
-				MonthlyQuantity: decimal.NewFromFloat(0.5),
-				Unit:            "ACU-Hr",
-				Details:         []string{"Aurora PostgreSQL"},
-				Usage:           true,
-				ProductFilter: &product.Filter{
-					Provider: util.StringPtr("aws"),
-					Service:  util.StringPtr("AmazonRDS"),
-					Family:   util.StringPtr("ServerlessV2"),
-					Location: util.StringPtr("eu-west-1"),
-					AttributeFilters: []*product.AttributeFilter{
-						{Key: "DatabaseEngine", Value: util.StringPtr("Aurora PostgreSQL")},
-						{Key: "UsageType", ValueRegex: util.StringPtr(".*Aurora:ServerlessV2IOOptimizedUsage$")},
-					},
-				},
-				PriceFilter: &price.Filter{
-					Unit: util.StringPtr("ACU-Hr"),
-					AttributeFilters: []*price.AttributeFilter{
-						{Key: "TermType", Value: util.StringPtr("OnDemand")},
-						{Key: "StartingRange", Value: util.StringPtr("0")},
-					},
-				},
-			},
 			{
 				Name:            "Backup storage",
 				MonthlyQuantity: decimal.NewFromFloat(840),
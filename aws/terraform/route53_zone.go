@@ -0,0 +1,124 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// Route53Zone represents a Route 53 hosted zone definition that can be cost-estimated.
+// Route 53 is a global service so the prices are not regional.
+type Route53Zone struct {
+	provider *Provider
+
+	// Usage
+	monthlyStandardQueries decimal.Decimal
+}
+
+type route53ZoneValues struct {
+	Usage struct {
+		MonthlyStandardQueries float64 `mapstructure:"monthly_standard_queries"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeRoute53ZoneValues decodes and returns route53ZoneValues from a Terraform values map.
+func decodeRoute53ZoneValues(tfVals map[string]interface{}) (route53ZoneValues, error) {
+	var v route53ZoneValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newRoute53Zone creates a new Route53Zone from route53ZoneValues.
+func (p *Provider) newRoute53Zone(_ map[string]terraform.Resource, vals route53ZoneValues) *Route53Zone {
+	v := &Route53Zone{
+		provider: p,
+
+		// From Usage
+		monthlyStandardQueries: decimal.NewFromFloat(vals.Usage.MonthlyStandardQueries),
+	}
+
+	return v
+}
+
+// Components returns the price component queries that make up the Route53Zone.
+func (v *Route53Zone) Components() []query.Component {
+	components := []query.Component{v.hostedZoneComponent()}
+
+	if v.monthlyStandardQueries.IsPositive() {
+		components = append(components, v.standardQueriesComponent())
+	}
+
+	return components
+}
+
+// hostedZoneComponent is priced with the first tier (first 25 hosted zones)
+// as we only know about this hosted zone
+func (v *Route53Zone) hostedZoneComponent() query.Component {
+	return query.Component{
+		Name:            "Hosted zone",
+		MonthlyQuantity: decimal.NewFromInt(1),
+		Unit:            "HostedZone",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonRoute53"),
+			Family:   util.StringPtr("DNS Zone"),
+			Location: util.StringPtr(region.Global.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", Value: util.StringPtr("HostedZone")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				{Key: "StartingRange", Value: util.StringPtr("0")},
+			},
+		},
+	}
+}
+
+func (v *Route53Zone) standardQueriesComponent() query.Component {
+	return query.Component{
+		Name:            "Standard queries",
+		MonthlyQuantity: v.monthlyStandardQueries,
+		Usage:           true,
+		Unit:            "Queries",
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonRoute53"),
+			Family:   util.StringPtr("DNS Query"),
+			Location: util.StringPtr(region.Global.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", Value: util.StringPtr("DNS-Queries")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// route53ZoneExample returns the ResourceExample of the aws_route53_zone, see Provider.ResourceExamples
+func (p *Provider) route53ZoneExample() terraform.ResourceExample {
+	return p.example("aws_route53_zone", map[string]interface{}{})
+}
@@ -0,0 +1,105 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestRoute53Zone_Components(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-1")
+	require.NoError(t, err)
+
+	hostedZone := query.Component{
+		Name:            "Hosted zone",
+		MonthlyQuantity: decimal.NewFromInt(1),
+		Unit:            "HostedZone",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr("aws"),
+			Service:  util.StringPtr("AmazonRoute53"),
+			Family:   util.StringPtr("DNS Zone"),
+			Location: util.StringPtr("global"),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", Value: util.StringPtr("HostedZone")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				{Key: "StartingRange", Value: util.StringPtr("0")},
+			},
+		},
+	}
+
+	t.Run("NoUsage", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_route53_zone.test",
+			Type:         "aws_route53_zone",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"name": "example.com",
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		expected := []query.Component{hostedZone}
+
+		actual := p.ResourceComponents(rss, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("StandardQueries", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_route53_zone.test",
+			Type:         "aws_route53_zone",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"name": "example.com",
+				usage.Key: map[string]interface{}{
+					"monthly_standard_queries": 2000000000,
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		expected := []query.Component{
+			hostedZone,
+			{
+				Name:            "Standard queries",
+				MonthlyQuantity: decimal.NewFromInt(2000000000),
+				Usage:           true,
+				Unit:            "Queries",
+				Tiered:          true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonRoute53"),
+					Family:   util.StringPtr("DNS Query"),
+					Location: util.StringPtr("global"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", Value: util.StringPtr("DNS-Queries")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+
+		actual := p.ResourceComponents(rss, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+}
@@ -14,7 +14,27 @@ import (
 	"github.com/cycloidio/terracost/util"
 )
 
-// S3Bucket represents an SQS queue definition that can be cost-estimated.
+// s3StorageClass is a storage class of the S3 objects, the key is the one of the storage_by_class usage
+type s3StorageClass struct {
+	key        string
+	name       string
+	usageType  string
+	volumeType string
+	tiered     bool
+}
+
+// s3StorageClasses are the storage classes that can be estimated, the Standard is the default one
+var s3StorageClasses = []s3StorageClass{
+	{key: "standard", name: "Standard", usageType: "TimedStorage-ByteHrs", volumeType: "Standard", tiered: true},
+	{key: "intelligent_tiering", name: "Intelligent-Tiering", usageType: "TimedStorage-INT-FA-ByteHrs", volumeType: "Intelligent-Tiering Frequent Access", tiered: true},
+	{key: "ia", name: "Standard-IA", usageType: "TimedStorage-SIA-ByteHrs", volumeType: "Standard - Infrequent Access"},
+	{key: "onezone_ia", name: "One Zone-IA", usageType: "TimedStorage-ZIA-ByteHrs", volumeType: "One Zone - Infrequent Access"},
+	{key: "glacier_ir", name: "Glacier Instant Retrieval", usageType: "TimedStorage-GIR-ByteHrs", volumeType: "Glacier Instant Retrieval"},
+	{key: "glacier", name: "Glacier Flexible Retrieval", usageType: "TimedStorage-GlacierByteHrs", volumeType: "Amazon Glacier"},
+	{key: "deep_archive", name: "Glacier Deep Archive", usageType: "TimedStorage-GDA-ByteHrs", volumeType: "Glacier Deep Archive"},
+}
+
+// S3Bucket represents an S3 bucket definition that can be cost-estimated.
 type S3Bucket struct {
 	provider *Provider
 	region   region.Code
@@ -22,14 +42,24 @@ type S3Bucket struct {
 	// Usage
 	monthlyOutboundDataGB decimal.Decimal
 	storageGB             decimal.Decimal
+
+	// storageByClass is the storage of each of the s3StorageClasses by key,
+	// if set it replaces the storageGB which is all on Standard
+	storageByClass                     map[string]decimal.Decimal
+	intelligentTieringMonitoredObjects decimal.Decimal
 }
 
 type s3BucketValues struct {
 
 	// Usage
 	Usage struct {
-		MonthlyOutboundDataGB float64 `mapstructure:"monthly_outbound_data_gb"`
-		StorageGB             float64 `mapstructure:"storage_gb"`
+		MonthlyOutboundDataGB float64            `mapstructure:"monthly_outbound_data_gb"`
+		StorageGB             float64            `mapstructure:"storage_gb"`
+		StorageByClass        map[string]float64 `mapstructure:"storage_by_class"`
+
+		// IntelligentTieringMonitoredObjects is the number of objects on
+		// Intelligent-Tiering, they are charged for the monitoring
+		IntelligentTieringMonitoredObjects float64 `mapstructure:"intelligent_tiering_monitored_objects"`
 	} `mapstructure:"tc_usage"`
 }
 
@@ -59,8 +89,27 @@ func (p *Provider) newS3Bucket(_ map[string]terraform.Resource, vals s3BucketVal
 		region:   p.region,
 
 		// From Usage
-		monthlyOutboundDataGB: decimal.NewFromFloat(vals.Usage.MonthlyOutboundDataGB),
-		storageGB:             decimal.NewFromFloat(vals.Usage.StorageGB),
+		monthlyOutboundDataGB:              decimal.NewFromFloat(vals.Usage.MonthlyOutboundDataGB),
+		storageGB:                          decimal.NewFromFloat(vals.Usage.StorageGB),
+		intelligentTieringMonitoredObjects: decimal.NewFromFloat(vals.Usage.IntelligentTieringMonitoredObjects),
+	}
+
+	if len(vals.Usage.StorageByClass) > 0 {
+		v.storageByClass = make(map[string]decimal.Decimal, len(vals.Usage.StorageByClass))
+		known := make(map[string]struct{}, len(s3StorageClasses))
+		for _, sc := range s3StorageClasses {
+			known[sc.key] = struct{}{}
+		}
+		for k, gb := range vals.Usage.StorageByClass {
+			if _, ok := known[k]; !ok {
+				p.warnf("unknown storage class %q on the storage_by_class usage, it's not estimated", k)
+				continue
+			}
+			v.storageByClass[k] = decimal.NewFromFloat(gb)
+		}
+	}
+	if _, ok := v.storageByClass["intelligent_tiering"]; ok && !v.intelligentTieringMonitoredObjects.IsPositive() {
+		p.warnf("no intelligent_tiering_monitored_objects usage, the Intelligent-Tiering monitoring is not estimated")
 	}
 
 	return v
@@ -70,18 +119,19 @@ func (p *Provider) newS3Bucket(_ map[string]terraform.Resource, vals s3BucketVal
 func (v *S3Bucket) Components() []query.Component {
 	components := []query.Component{}
 
-	if v.storageGB.GreaterThan(decimal.NewFromInt(512000)) {
-		extraSize := v.storageGB.Sub(decimal.NewFromInt(512000))
-		components = append(components, v.S3BucketComponent("0", decimal.NewFromInt(51200)))
-		components = append(components, v.S3BucketComponent("51200", decimal.NewFromInt(460800)))
-		components = append(components, v.S3BucketComponent("512000", extraSize))
-
-	} else if v.storageGB.GreaterThan(decimal.NewFromInt(51200)) {
-		extraSize := v.storageGB.Sub(decimal.NewFromInt(51200))
-		components = append(components, v.S3BucketComponent("0", decimal.NewFromInt(51200)))
-		components = append(components, v.S3BucketComponent("51200", extraSize))
+	if v.storageByClass == nil {
+		components = append(components, v.S3BucketComponent(v.storageGB))
 	} else {
-		components = append(components, v.S3BucketComponent("0", v.storageGB))
+		for _, sc := range s3StorageClasses {
+			gb, ok := v.storageByClass[sc.key]
+			if !ok {
+				continue
+			}
+			components = append(components, v.storageClassComponent(sc, gb))
+		}
+		if _, ok := v.storageByClass["intelligent_tiering"]; ok {
+			components = append(components, v.intelligentTieringMonitoringComponent())
+		}
 	}
 
 	if v.monthlyOutboundDataGB.GreaterThan(decimal.NewFromInt(153600)) {
@@ -106,28 +156,67 @@ func (v *S3Bucket) Components() []query.Component {
 	return components
 }
 
-func (v *S3Bucket) S3BucketComponent(startingRange string, storage decimal.Decimal) query.Component {
+// S3BucketComponent returns the Standard storage component, the price
+// is tiered so the StartingRange is not filtered
+func (v *S3Bucket) S3BucketComponent(storage decimal.Decimal) query.Component {
+	return v.storageClassComponent(s3StorageClasses[0], storage)
+}
+
+// storageClassComponent returns the storage component of the storage class sc, the
+// Standard one is named "Storage" and the others have the name of the class
+func (v *S3Bucket) storageClassComponent(sc s3StorageClass, storage decimal.Decimal) query.Component {
+	name := "Storage"
+	if sc.key != "standard" {
+		name = fmt.Sprintf("Storage (%s)", sc.name)
+	}
 	return query.Component{
-		Name:            fmt.Sprintf("Storage %s", startingRange),
+		Name:            name,
 		MonthlyQuantity: storage,
-		Details:         []string{"Standard"},
+		Details:         []string{sc.name},
 		Usage:           true,
 		Unit:            "GB-Mo",
+		Tiered:          sc.tiered,
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
 			Service:  util.StringPtr("AmazonS3"),
 			Family:   util.StringPtr("Storage"),
 			Location: util.StringPtr(v.region.String()),
 			AttributeFilters: []*product.AttributeFilter{
-				{Key: "UsageType", ValueRegex: util.StringPtr(".*TimedStorage-ByteHrs$")},
-				{Key: "VolumeType", Value: util.StringPtr("Standard")},
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf(".*%s$", sc.usageType))},
+				{Key: "VolumeType", Value: util.StringPtr(sc.volumeType)},
 			},
 		},
 		PriceFilter: &price.Filter{
 			Unit: util.StringPtr("GB-Mo"),
 			AttributeFilters: []*price.AttributeFilter{
 				{Key: "TermType", Value: util.StringPtr("OnDemand")},
-				{Key: "StartingRange", Value: util.StringPtr(startingRange)},
+			},
+		},
+	}
+}
+
+// intelligentTieringMonitoringComponent returns the monitoring and automation
+// fee of the objects on Intelligent-Tiering, it's charged by object
+func (v *S3Bucket) intelligentTieringMonitoringComponent() query.Component {
+	return query.Component{
+		Name:            "Intelligent-Tiering monitoring",
+		MonthlyQuantity: v.intelligentTieringMonitoredObjects,
+		Details:         []string{"Intelligent-Tiering"},
+		Usage:           true,
+		Unit:            "objects",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonS3"),
+			Family:   util.StringPtr("Fee"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr(".*Monitoring-Automation-INT$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Objects"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
 			},
 		},
 	}
@@ -165,3 +254,8 @@ func (v *S3Bucket) S3BucketOutboundDataTransferComponent(startingRange string, o
 		},
 	}
 }
+
+// s3BucketExample returns the ResourceExample of the aws_s3_bucket, see Provider.ResourceExamples
+func (p *Provider) s3BucketExample() terraform.ResourceExample {
+	return p.example("aws_s3_bucket", map[string]interface{}{})
+}
@@ -90,3 +90,8 @@ func (v *S3BucketAnalyticsConfiguration) s3BucketAnalyticsConfigurationComponent
 		},
 	}
 }
+
+// s3BucketAnalyticsConfigurationExample returns the ResourceExample of the aws_s3_bucket_analytics_configuration, see Provider.ResourceExamples
+func (p *Provider) s3BucketAnalyticsConfigurationExample() terraform.ResourceExample {
+	return p.example("aws_s3_bucket_analytics_configuration", map[string]interface{}{})
+}
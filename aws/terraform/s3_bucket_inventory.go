@@ -90,3 +90,8 @@ func (v *S3BucketInventory) s3BucketInventoryComponent() query.Component {
 		},
 	}
 }
+
+// s3BucketInventoryExample returns the ResourceExample of the aws_s3_bucket_inventory, see Provider.ResourceExamples
+func (p *Provider) s3BucketInventoryExample() terraform.ResourceExample {
+	return p.example("aws_s3_bucket_inventory", map[string]interface{}{})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	awstf "github.com/cycloidio/terracost/aws/terraform"
@@ -32,11 +33,12 @@ func TestS3Bucket_Components(t *testing.T) {
 
 		expected := []query.Component{
 			{
-				Name:            "Storage 0",
+				Name:            "Storage",
 				MonthlyQuantity: decimal.NewFromFloat(200),
 				Unit:            "GB-Mo",
 				Details:         []string{"Standard"},
 				Usage:           true,
+				Tiered:          true,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("AmazonS3"),
@@ -51,7 +53,6 @@ func TestS3Bucket_Components(t *testing.T) {
 					Unit: util.StringPtr("GB-Mo"),
 					AttributeFilters: []*price.AttributeFilter{
 						{Key: "TermType", Value: util.StringPtr("OnDemand")},
-						{Key: "StartingRange", Value: util.StringPtr("0")},
 					},
 				},
 			},
@@ -85,4 +86,88 @@ func TestS3Bucket_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
+
+	t.Run("StorageByClass", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_s3_bucket.test",
+			Type:         "aws_s3_bucket",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				usage.Key: map[string]interface{}{
+					"storage_gb": 200,
+					"storage_by_class": map[string]interface{}{
+						"standard":            100,
+						"ia":                  400,
+						"glacier":             2000,
+						"intelligent_tiering": 50,
+						"tape":                10,
+					},
+					"intelligent_tiering_monitored_objects": 100000,
+				},
+			},
+		}
+
+		storage := func(name, details, usageType, volumeType string, quantity int64, tiered bool) query.Component {
+			return query.Component{
+				Name:            name,
+				MonthlyQuantity: decimal.NewFromInt(quantity),
+				Unit:            "GB-Mo",
+				Details:         []string{details},
+				Usage:           true,
+				Tiered:          tiered,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonS3"),
+					Family:   util.StringPtr("Storage"),
+					Location: util.StringPtr("eu-west-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr(".*" + usageType + "$")},
+						{Key: "VolumeType", Value: util.StringPtr(volumeType)},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("GB-Mo"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			}
+		}
+
+		expected := []query.Component{
+			storage("Storage", "Standard", "TimedStorage-ByteHrs", "Standard", 100, true),
+			storage("Storage (Intelligent-Tiering)", "Intelligent-Tiering", "TimedStorage-INT-FA-ByteHrs", "Intelligent-Tiering Frequent Access", 50, true),
+			storage("Storage (Standard-IA)", "Standard-IA", "TimedStorage-SIA-ByteHrs", "Standard - Infrequent Access", 400, false),
+			storage("Storage (Glacier Flexible Retrieval)", "Glacier Flexible Retrieval", "TimedStorage-GlacierByteHrs", "Amazon Glacier", 2000, false),
+			{
+				Name:            "Intelligent-Tiering monitoring",
+				MonthlyQuantity: decimal.NewFromInt(100000),
+				Unit:            "objects",
+				Details:         []string{"Intelligent-Tiering"},
+				Usage:           true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonS3"),
+					Family:   util.StringPtr("Fee"),
+					Location: util.StringPtr("eu-west-1"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr(".*Monitoring-Automation-INT$")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("Objects"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		require.GreaterOrEqual(t, len(actual), len(expected))
+		// The outbound data transfer components are the same as without distribution
+		testutil.EqualQueryComponents(t, expected, actual[:len(expected)])
+		assert.Equal(t, []string{`unknown storage class "tape" on the storage_by_class usage, it's not estimated`}, warnings)
+	})
 }
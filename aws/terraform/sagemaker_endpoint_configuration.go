@@ -0,0 +1,139 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// SageMakerEndpointConfiguration represents a SageMaker real-time endpoint configuration that can be cost-estimated.
+// The ML instances of its production variants are charged by the hour once deployed by an aws_sagemaker_endpoint,
+// the configuration is estimated as deployed by one endpoint.
+type SageMakerEndpointConfiguration struct {
+	providerKey string
+	region      region.Code
+
+	variants []sageMakerVariant
+}
+
+// sageMakerVariant is a production variant deployed on instanceCount ML instances of instanceType
+type sageMakerVariant struct {
+	name          string
+	instanceType  string
+	instanceCount decimal.Decimal
+}
+
+// sageMakerEndpointConfigurationValues represents the structure of Terraform values for aws_sagemaker_endpoint_configuration resource.
+type sageMakerEndpointConfigurationValues struct {
+	ProductionVariant []struct {
+		VariantName          string  `mapstructure:"variant_name"`
+		InstanceType         string  `mapstructure:"instance_type"`
+		InitialInstanceCount float64 `mapstructure:"initial_instance_count"`
+
+		ServerlessConfig []struct {
+			MemorySizeInMB float64 `mapstructure:"memory_size_in_mb"`
+		} `mapstructure:"serverless_config"`
+	} `mapstructure:"production_variants"`
+}
+
+// decodeSageMakerEndpointConfigurationValues decodes and returns sageMakerEndpointConfigurationValues from a Terraform values map.
+func decodeSageMakerEndpointConfigurationValues(tfVals map[string]interface{}) (sageMakerEndpointConfigurationValues, error) {
+	var v sageMakerEndpointConfigurationValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newSageMakerEndpointConfiguration creates a new SageMakerEndpointConfiguration from sageMakerEndpointConfigurationValues.
+func (p *Provider) newSageMakerEndpointConfiguration(vals sageMakerEndpointConfigurationValues) *SageMakerEndpointConfiguration {
+	inst := &SageMakerEndpointConfiguration{
+		providerKey: p.key,
+		region:      p.region,
+	}
+
+	for _, pv := range vals.ProductionVariant {
+		if len(pv.ServerlessConfig) > 0 {
+			p.warnf("the serverless production variant %q is not estimated", pv.VariantName)
+			continue
+		}
+		if pv.InstanceType == "" {
+			continue
+		}
+		count := pv.InitialInstanceCount
+		if count <= 0 {
+			count = 1
+		}
+		inst.variants = append(inst.variants, sageMakerVariant{
+			name:          pv.VariantName,
+			instanceType:  pv.InstanceType,
+			instanceCount: decimal.NewFromFloat(count),
+		})
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this SageMakerEndpointConfiguration.
+func (inst *SageMakerEndpointConfiguration) Components() []query.Component {
+	components := make([]query.Component, 0, len(inst.variants))
+	for _, v := range inst.variants {
+		components = append(components, sageMakerInstanceComponent(inst.providerKey, inst.region, "Endpoint instances", []string{v.name, v.instanceType}, "Host", v.instanceType, v.instanceCount))
+	}
+	return components
+}
+
+// sageMakerInstanceComponent returns the component of the quantity of ML instances of instanceType of the
+// SageMaker feature, which is the prefix of the UsageType (ex: Host, Notebk), as the ML instances are priced
+// by feature (ex: EUW3-Host:ml.m5.large) and not at the EC2 rates
+func sageMakerInstanceComponent(providerKey string, rgn region.Code, name string, details []string, feature, instanceType string, quantity decimal.Decimal) query.Component {
+	return query.Component{
+		Name:           name,
+		Details:        details,
+		HourlyQuantity: quantity,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(providerKey),
+			Service:  util.StringPtr("AmazonSageMaker"),
+			Location: util.StringPtr(rgn.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("^([A-Z0-9]+-)?%s:%s$", feature, regexp.QuoteMeta(instanceType)))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Hrs"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// sageMakerEndpointConfigurationExample returns the ResourceExample of the aws_sagemaker_endpoint_configuration, see Provider.ResourceExamples
+func (p *Provider) sageMakerEndpointConfigurationExample() terraform.ResourceExample {
+	return p.example("aws_sagemaker_endpoint_configuration", map[string]interface{}{
+		"production_variants": []interface{}{map[string]interface{}{
+			"variant_name":           "primary",
+			"instance_type":          "ml.m5.large",
+			"initial_instance_count": 1,
+		}},
+	})
+}
@@ -0,0 +1,58 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestSageMakerEndpointConfiguration_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		return terraform.Resource{
+			Address:      "aws_sagemaker_endpoint_configuration.test",
+			Mode:         "managed",
+			Type:         "aws_sagemaker_endpoint_configuration",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       values,
+		}
+	}
+
+	t.Run("ProductionVariants", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"production_variants": []interface{}{
+				map[string]interface{}{"variant_name": "primary", "instance_type": "ml.m5.large", "initial_instance_count": 2},
+				map[string]interface{}{"variant_name": "canary", "instance_type": "ml.g4dn.xlarge"},
+			},
+		}))
+
+		require.Len(t, actual, 2)
+		assert.Equal(t, []string{"primary", "ml.m5.large"}, actual[0].Details)
+		assert.Equal(t, "2", actual[0].HourlyQuantity.String())
+		assert.Equal(t, util.StringPtr("AmazonSageMaker"), actual[0].ProductFilter.Service)
+		assert.Equal(t, util.StringPtr(`^([A-Z0-9]+-)?Host:ml\.m5\.large$`), actual[0].ProductFilter.AttributeFilters[0].ValueRegex)
+		assert.Equal(t, "1", actual[1].HourlyQuantity.String())
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("Serverless", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"production_variants": []interface{}{
+				map[string]interface{}{
+					"variant_name":      "serverless",
+					"serverless_config": []interface{}{map[string]interface{}{"memory_size_in_mb": 2048}},
+				},
+			},
+		}))
+
+		assert.Empty(t, actual)
+		assert.Equal(t, []string{`the serverless production variant "serverless" is not estimated`}, warnings)
+	})
+}
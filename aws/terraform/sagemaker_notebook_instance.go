@@ -0,0 +1,107 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// defaultNotebookVolumeSize is the size in GB of the ML storage volume of a notebook instance without volume_size
+const defaultNotebookVolumeSize = 5
+
+// SageMakerNotebookInstance represents a SageMaker notebook instance that can be cost-estimated.
+// It's charged by the hour for its ML instance and by the GB-month of its ML storage volume.
+type SageMakerNotebookInstance struct {
+	providerKey string
+	region      region.Code
+
+	instanceType string
+	volumeSize   decimal.Decimal
+}
+
+// sageMakerNotebookInstanceValues represents the structure of Terraform values for aws_sagemaker_notebook_instance resource.
+type sageMakerNotebookInstanceValues struct {
+	InstanceType string  `mapstructure:"instance_type"`
+	VolumeSize   float64 `mapstructure:"volume_size"`
+}
+
+// decodeSageMakerNotebookInstanceValues decodes and returns sageMakerNotebookInstanceValues from a Terraform values map.
+func decodeSageMakerNotebookInstanceValues(tfVals map[string]interface{}) (sageMakerNotebookInstanceValues, error) {
+	var v sageMakerNotebookInstanceValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newSageMakerNotebookInstance creates a new SageMakerNotebookInstance from sageMakerNotebookInstanceValues.
+func (p *Provider) newSageMakerNotebookInstance(vals sageMakerNotebookInstanceValues) *SageMakerNotebookInstance {
+	inst := &SageMakerNotebookInstance{
+		providerKey:  p.key,
+		region:       p.region,
+		instanceType: vals.InstanceType,
+		volumeSize:   decimal.NewFromFloat(vals.VolumeSize),
+	}
+	if vals.VolumeSize <= 0 {
+		inst.volumeSize = decimal.NewFromInt(defaultNotebookVolumeSize)
+	}
+	return inst
+}
+
+// Components returns the price component queries that make up this SageMakerNotebookInstance.
+func (inst *SageMakerNotebookInstance) Components() []query.Component {
+	if inst.instanceType == "" {
+		return []query.Component{}
+	}
+
+	return []query.Component{
+		sageMakerInstanceComponent(inst.providerKey, inst.region, "Notebook instance", []string{inst.instanceType}, "Notebk", inst.instanceType, decimal.NewFromInt(1)),
+		inst.storageComponent(),
+	}
+}
+
+func (inst *SageMakerNotebookInstance) storageComponent() query.Component {
+	return query.Component{
+		Name:            "Storage",
+		Details:         []string{"General Purpose SSD"},
+		MonthlyQuantity: inst.volumeSize,
+		Unit:            "GB-Mo",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AmazonSageMaker"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?Notebk:VolumeUsage.gp2$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("GB-Mo"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// sageMakerNotebookInstanceExample returns the ResourceExample of the aws_sagemaker_notebook_instance, see Provider.ResourceExamples
+func (p *Provider) sageMakerNotebookInstanceExample() terraform.ResourceExample {
+	return p.example("aws_sagemaker_notebook_instance", map[string]interface{}{
+		"instance_type": "ml.t3.medium",
+	})
+}
@@ -0,0 +1,50 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestSageMakerNotebookInstance_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		return terraform.Resource{
+			Address:      "aws_sagemaker_notebook_instance.test",
+			Mode:         "managed",
+			Type:         "aws_sagemaker_notebook_instance",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       values,
+		}
+	}
+
+	t.Run("DefaultVolume", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+		}))
+
+		require.Len(t, actual, 2)
+		assert.Equal(t, "Notebook instance", actual[0].Name)
+		assert.Equal(t, "1", actual[0].HourlyQuantity.String())
+		assert.Equal(t, util.StringPtr(`^([A-Z0-9]+-)?Notebk:ml\.t3\.medium$`), actual[0].ProductFilter.AttributeFilters[0].ValueRegex)
+		assert.Equal(t, "Storage", actual[1].Name)
+		assert.Equal(t, "5", actual[1].MonthlyQuantity.String())
+	})
+
+	t.Run("VolumeSize", func(t *testing.T) {
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, resource(map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"volume_size":   50,
+		}))
+
+		require.Len(t, actual, 2)
+		assert.Equal(t, "50", actual[1].MonthlyQuantity.String())
+	})
+}
@@ -12,16 +12,24 @@ import (
 	"github.com/cycloidio/terracost/util"
 )
 
-// SecretsmanagerSecret represents an SQS queue definition that can be cost-estimated.
+// SecretsmanagerSecret represents a Secrets Manager secret definition that can be cost-estimated.
 type SecretsmanagerSecret struct {
 	provider *Provider
 	region   region.Code
 
+	// secrets is the number of secrets charged, each replica
+	// of the secret is charged as a secret
+	secrets decimal.Decimal
+
 	// Usage
 	monthlyRequests decimal.Decimal
 }
 
 type secretsmanagerSecretValues struct {
+	Replica []struct {
+		Region string `mapstructure:"region"`
+	} `mapstructure:"replica"`
+
 	Usage struct {
 		MonthlyRequests float64 `mapstructure:"monthly_requests"`
 	} `mapstructure:"tc_usage"`
@@ -51,6 +59,7 @@ func (p *Provider) newSecretsmanagerSecret(_ map[string]terraform.Resource, vals
 	v := &SecretsmanagerSecret{
 		provider: p,
 		region:   p.region,
+		secrets:  decimal.NewFromInt(int64(1 + len(vals.Replica))),
 
 		// From Usage
 		monthlyRequests: decimal.NewFromFloat(vals.Usage.MonthlyRequests),
@@ -69,9 +78,9 @@ func (v *SecretsmanagerSecret) Components() []query.Component {
 func (v *SecretsmanagerSecret) secretsmanagerSecretComponent() query.Component {
 	return query.Component{
 		Name:            "Secret",
-		MonthlyQuantity: decimal.NewFromInt(1),
+		MonthlyQuantity: v.secrets,
 		Details:         []string{"Secret"},
-		Usage:           true,
+		Usage:           false,
 		Unit:            "Secrets",
 		ProductFilter: &product.Filter{
 			Provider: util.StringPtr(v.provider.key),
@@ -117,3 +126,8 @@ func (v *SecretsmanagerSecret) secretsmanagerSecretRequestsComponent() query.Com
 		},
 	}
 }
+
+// secretsmanagerSecretExample returns the ResourceExample of the aws_secretsmanager_secret, see Provider.ResourceExamples
+func (p *Provider) secretsmanagerSecretExample() terraform.ResourceExample {
+	return p.example("aws_secretsmanager_secret", map[string]interface{}{})
+}
@@ -36,7 +36,7 @@ func TestSecretsmanagerSecret_Components(t *testing.T) {
 				MonthlyQuantity: decimal.NewFromFloat(1),
 				Unit:            "Secrets",
 				Details:         []string{"Secret"},
-				Usage:           true,
+				Usage:           false,
 				ProductFilter: &product.Filter{
 					Provider: util.StringPtr("aws"),
 					Service:  util.StringPtr("AWSSecretsManager"),
@@ -85,4 +85,25 @@ func TestSecretsmanagerSecret_Components(t *testing.T) {
 		actual := p.ResourceComponents(rss, tfres)
 		testutil.EqualQueryComponents(t, expected, actual)
 	})
+
+	t.Run("Replicas", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_secretsmanager_secret.test",
+			Type:         "aws_secretsmanager_secret",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"replica": []interface{}{
+					map[string]interface{}{"region": "eu-west-3"},
+				},
+			},
+		}
+		rss := map[string]terraform.Resource{}
+
+		tfres.Values[usage.Key] = usage.Default.GetUsage("aws_secretsmanager_secret")
+		actual := p.ResourceComponents(rss, tfres)
+		require.Len(t, actual, 2)
+		require.Equal(t, "Secret", actual[0].Name)
+		require.True(t, decimal.NewFromInt(2).Equal(actual[0].MonthlyQuantity), actual[0].MonthlyQuantity.String())
+	})
 }
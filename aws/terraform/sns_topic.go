@@ -0,0 +1,159 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// SNSTopic represents an SNS topic definition that can be cost-estimated.
+// The topic itself has no cost, it's the published requests and the
+// notifications delivered to the subscriptions which are charged.
+type SNSTopic struct {
+	provider *Provider
+	region   region.Code
+
+	// Usage
+	monthlyRequests decimal.Decimal
+	requestSizeKB   decimal.Decimal
+	notifications   []snsNotifications
+}
+
+// snsNotifications are the notifications delivered for an endpoint type
+type snsNotifications struct {
+	name      string
+	usageType string
+	quantity  decimal.Decimal
+}
+
+type snsTopicValues struct {
+	Usage struct {
+		MonthlyRequests            float64 `mapstructure:"monthly_requests"`
+		RequestSizeKB              float64 `mapstructure:"request_size_kb"`
+		MonthlyHTTPNotifications   float64 `mapstructure:"monthly_http_notifications"`
+		MonthlyEmailNotifications  float64 `mapstructure:"monthly_email_notifications"`
+		MonthlySQSNotifications    float64 `mapstructure:"monthly_sqs_notifications"`
+		MonthlyLambdaNotifications float64 `mapstructure:"monthly_lambda_notifications"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeSNSTopicValues decodes and returns snsTopicValues from a Terraform values map.
+func decodeSNSTopicValues(tfVals map[string]interface{}) (snsTopicValues, error) {
+	var v snsTopicValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newSNSTopic creates a new SNSTopic from snsTopicValues.
+func (p *Provider) newSNSTopic(_ map[string]terraform.Resource, vals snsTopicValues) *SNSTopic {
+	v := &SNSTopic{
+		provider: p,
+		region:   p.region,
+
+		// From Usage
+		monthlyRequests: decimal.NewFromFloat(vals.Usage.MonthlyRequests),
+		requestSizeKB:   decimal.NewFromFloat(vals.Usage.RequestSizeKB),
+		notifications: []snsNotifications{
+			{name: "HTTP/S", usageType: "HTTP", quantity: decimal.NewFromFloat(vals.Usage.MonthlyHTTPNotifications)},
+			{name: "Email", usageType: "SMTP", quantity: decimal.NewFromFloat(vals.Usage.MonthlyEmailNotifications)},
+			{name: "SQS", usageType: "SQS", quantity: decimal.NewFromFloat(vals.Usage.MonthlySQSNotifications)},
+			{name: "Lambda", usageType: "LAMBDA", quantity: decimal.NewFromFloat(vals.Usage.MonthlyLambdaNotifications)},
+		},
+	}
+
+	return v
+}
+
+// Components returns the price component queries that make up the SNSTopic.
+func (v *SNSTopic) Components() []query.Component {
+	components := []query.Component{v.requestsComponent()}
+
+	for _, n := range v.notifications {
+		if n.quantity.IsPositive() {
+			components = append(components, v.notificationsComponent(n))
+		}
+	}
+
+	return components
+}
+
+func (v *SNSTopic) requestsComponent() query.Component {
+	// Each 64KB chunk of published data is billed as 1 request
+	requests := v.monthlyRequests
+	if v.requestSizeKB.IsPositive() {
+		requests = v.requestSizeKB.Div(decimal.NewFromInt(64)).Ceil().Mul(v.monthlyRequests)
+	}
+
+	return query.Component{
+		Name:            "Requests",
+		MonthlyQuantity: requests,
+		Details:         []string{"SNS topic"},
+		Usage:           true,
+		Unit:            "Requests",
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonSNS"),
+			Family:   util.StringPtr("API Request"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("Requests-Tier1$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+func (v *SNSTopic) notificationsComponent(n snsNotifications) query.Component {
+	return query.Component{
+		Name:            fmt.Sprintf("%s notifications", n.name),
+		MonthlyQuantity: n.quantity,
+		Details:         []string{"SNS topic", n.name},
+		Usage:           true,
+		Unit:            "Notifications",
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.provider.key),
+			Service:  util.StringPtr("AmazonSNS"),
+			Family:   util.StringPtr("Message Delivery"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr(fmt.Sprintf("DeliveryAttempts-%s$", n.usageType))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// snsTopicExample returns the ResourceExample of the aws_sns_topic, see Provider.ResourceExamples
+func (p *Provider) snsTopicExample() terraform.ResourceExample {
+	return p.example("aws_sns_topic", map[string]interface{}{})
+}
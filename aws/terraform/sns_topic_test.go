@@ -0,0 +1,88 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestSNSTopic_Components(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-1")
+	require.NoError(t, err)
+
+	tfres := terraform.Resource{
+		Address:      "aws_sns_topic.test",
+		Type:         "aws_sns_topic",
+		Name:         "test",
+		ProviderName: "aws",
+		Values: map[string]interface{}{
+			"name": "test",
+			usage.Key: map[string]interface{}{
+				"monthly_requests":            2000000,
+				"request_size_kb":             100,
+				"monthly_email_notifications": 5000,
+			},
+		},
+	}
+	rss := map[string]terraform.Resource{}
+
+	expected := []query.Component{
+		{
+			Name:            "Requests",
+			MonthlyQuantity: decimal.NewFromInt(4000000),
+			Details:         []string{"SNS topic"},
+			Usage:           true,
+			Unit:            "Requests",
+			Tiered:          true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonSNS"),
+				Family:   util.StringPtr("API Request"),
+				Location: util.StringPtr("eu-west-1"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr("Requests-Tier1$")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+		{
+			Name:            "Email notifications",
+			MonthlyQuantity: decimal.NewFromInt(5000),
+			Details:         []string{"SNS topic", "Email"},
+			Usage:           true,
+			Unit:            "Notifications",
+			Tiered:          true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonSNS"),
+				Family:   util.StringPtr("Message Delivery"),
+				Location: util.StringPtr("eu-west-1"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr("DeliveryAttempts-SMTP$")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		},
+	}
+
+	actual := p.ResourceComponents(rss, tfres)
+	testutil.EqualQueryComponents(t, expected, actual)
+}
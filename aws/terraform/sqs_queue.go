@@ -109,3 +109,10 @@ func (v *SQSQueue) sqsQueueComponent() query.Component {
 		},
 	}
 }
+
+// sqsQueueExample returns the ResourceExample of the aws_sqs_queue, see Provider.ResourceExamples
+func (p *Provider) sqsQueueExample() terraform.ResourceExample {
+	return p.example("aws_sqs_queue", map[string]interface{}{
+		"fifo_queue": false,
+	})
+}
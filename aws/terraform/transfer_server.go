@@ -0,0 +1,113 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// TransferServer represents an AWS Transfer Family server (SFTP, FTPS, FTP or AS2) that can be cost-estimated.
+type TransferServer struct {
+	providerKey string
+	region      region.Code
+
+	// protocols are the enabled protocols, each of them is charged as an endpoint
+	protocols []string
+
+	// Usage
+	monthlyUploadGB   decimal.Decimal
+	monthlyDownloadGB decimal.Decimal
+}
+
+type transferServerValues struct {
+	Protocols []string `mapstructure:"protocols"`
+
+	Usage struct {
+		MonthlyUploadGB   float64 `mapstructure:"monthly_upload_gb"`
+		MonthlyDownloadGB float64 `mapstructure:"monthly_download_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeTransferServerValues(tfVals map[string]interface{}) (transferServerValues, error) {
+	var v transferServerValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newTransferServer creates a new TransferServer from transferServerValues.
+func (p *Provider) newTransferServer(vals transferServerValues) *TransferServer {
+	inst := &TransferServer{
+		providerKey: p.key,
+		region:      p.region,
+		protocols:   vals.Protocols,
+
+		// From Usage
+		monthlyUploadGB:   decimal.NewFromFloat(vals.Usage.MonthlyUploadGB),
+		monthlyDownloadGB: decimal.NewFromFloat(vals.Usage.MonthlyDownloadGB),
+	}
+
+	// SFTP is the protocol enabled by default
+	if len(inst.protocols) == 0 {
+		inst.protocols = []string{"SFTP"}
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the TransferServer.
+// Each enabled protocol is charged per hour and the data uploaded and downloaded by GB.
+func (inst *TransferServer) Components() []query.Component {
+	return []query.Component{
+		inst.transferServerComponent("Protocol endpoints", inst.protocols, decimal.NewFromInt(int64(len(inst.protocols))), decimal.Zero, false, "ProtocolHours$"),
+		inst.transferServerComponent("Data uploaded", nil, decimal.Zero, inst.monthlyUploadGB, true, "UploadBytes$"),
+		inst.transferServerComponent("Data downloaded", nil, decimal.Zero, inst.monthlyDownloadGB, true, "DownloadBytes$"),
+	}
+}
+
+func (inst *TransferServer) transferServerComponent(name string, details []string, hourlyQuantity, monthlyQuantity decimal.Decimal, usage bool, usageType string) query.Component {
+	return query.Component{
+		Name:            name,
+		Details:         details,
+		HourlyQuantity:  hourlyQuantity,
+		MonthlyQuantity: monthlyQuantity,
+		Usage:           usage,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("AWSTransfer"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// transferServerExample returns the ResourceExample of the aws_transfer_server, see Provider.ResourceExamples
+func (p *Provider) transferServerExample() terraform.ResourceExample {
+	return p.example("aws_transfer_server", map[string]interface{}{
+		"protocols": []interface{}{"SFTP"},
+	})
+}
@@ -0,0 +1,80 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestTransferServer_Components(t *testing.T) {
+	p, err := NewProvider("aws", "us-east-1")
+	require.NoError(t, err)
+
+	component := func(name string, details []string, hourly, monthly decimal.Decimal, usage bool, usageType string) query.Component {
+		return query.Component{
+			Name:            name,
+			Details:         details,
+			HourlyQuantity:  hourly,
+			MonthlyQuantity: monthly,
+			Usage:           usage,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AWSTransfer"),
+				Location: util.StringPtr("us-east-1"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		}
+	}
+
+	t.Run("Protocols", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_transfer_server.test",
+			Type:         "aws_transfer_server",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"protocols": []interface{}{"SFTP", "FTPS"},
+				usage.Key:   usage.Default.GetUsage("aws_transfer_server"),
+			},
+		}
+
+		expected := []query.Component{
+			component("Protocol endpoints", []string{"SFTP", "FTPS"}, decimal.NewFromInt(2), decimal.Zero, false, "ProtocolHours$"),
+			component("Data uploaded", nil, decimal.Zero, decimal.NewFromFloat(10), true, "UploadBytes$"),
+			component("Data downloaded", nil, decimal.Zero, decimal.NewFromFloat(10), true, "DownloadBytes$"),
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("DefaultSFTP", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_transfer_server.test",
+			Type:         "aws_transfer_server",
+			Name:         "test",
+			ProviderName: "aws",
+			Values:       map[string]interface{}{},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		require.Len(t, actual, 3)
+		assert.Equal(t, component("Protocol endpoints", []string{"SFTP"}, decimal.NewFromInt(1), decimal.Zero, false, "ProtocolHours$"), actual[0])
+	})
+}
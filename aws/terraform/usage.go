@@ -0,0 +1,56 @@
+package terraform
+
+// UsageValues returns the values structs of all the supported resources keyed by
+// resource type, they are used to describe the usage fields of each resource.
+func UsageValues() map[string]interface{} {
+	return map[string]interface{}{
+		"aws_instance":                          instanceValues{},
+		"aws_api_gateway_rest_api":              apiGatewayRestAPIValues{},
+		"aws_api_gateway_stage":                 apiGatewayStageValues{},
+		"aws_apigatewayv2_api":                  apiGatewayV2APIValues{},
+		"aws_autoscaling_group":                 autoscalingGroupValues{},
+		"aws_backup_vault":                      backupVaultValues{},
+		"aws_cloudformation_stack":              cloudFormationStackValues{},
+		"aws_cloudfront_distribution":           cloudFrontDistributionValues{},
+		"aws_cloudwatch_log_group":              cloudwatchLogGroupValues{},
+		"aws_cloudwatch_log_metric_filter":      cloudwatchLogMetricFilterValues{},
+		"aws_cloudwatch_metric_alarm":           cloudwatchMetricAlarmValues{},
+		"aws_db_instance":                       dbInstanceValues{},
+		"aws_db_snapshot":                       dbSnapshotValues{},
+		"aws_directory_service_directory":       directoryServiceDirectoryValues{},
+		"aws_ebs_volume":                        volumeValues{},
+		"aws_ebs_snapshot":                      ebsSnapshotValues{},
+		"aws_ec2_host":                          ec2HostValues{},
+		"aws_efs_file_system":                   efsFileSystemValues{},
+		"aws_elasticache_cluster":               elastiCacheValues{},
+		"aws_elasticache_replication_group":     elastiCacheReplicationValues{},
+		"aws_eip":                               elasticIPValues{},
+		"aws_elb":                               lbValues{},
+		"aws_eks_cluster":                       eKSClusterValues{},
+		"aws_eks_node_group":                    eksNodeGroupValues{},
+		"aws_fsx_lustre_file_system":            fsxLustreFileSystemValues{},
+		"aws_fsx_ontap_file_system":             fsxOntapFileSystemValues{},
+		"aws_fsx_openzfs_file_system":           fsxOpenzfsFileSystemValues{},
+		"aws_fsx_windows_file_system":           fsxWindowsFileSystemValues{},
+		"aws_kms_key":                           kmsKeyValues{},
+		"aws_lb":                                lbValues{},
+		"aws_alb":                               lbValues{},
+		"aws_mq_broker":                         mqBrokerValues{},
+		"aws_msk_cluster":                       mskClusterValues{},
+		"aws_nat_gateway":                       natGatewayValues{},
+		"aws_rds_cluster":                       rdsClusterValues{},
+		"aws_rds_cluster_instance":              rdsClusterInstanceValues{},
+		"aws_route53_zone":                      route53ZoneValues{},
+		"aws_s3_bucket":                         s3BucketValues{},
+		"aws_s3_bucket_analytics_configuration": s3BucketAnalyticsConfigurationValues{},
+		"aws_s3_bucket_inventory":               s3BucketInventoryValues{},
+		"aws_sagemaker_endpoint_configuration":  sageMakerEndpointConfigurationValues{},
+		"aws_sagemaker_notebook_instance":       sageMakerNotebookInstanceValues{},
+		"aws_secretsmanager_secret":             secretsmanagerSecretValues{},
+		"aws_sns_topic":                         snsTopicValues{},
+		"aws_sqs_queue":                         sqsQueueValues{},
+		"aws_transfer_server":                   transferServerValues{},
+		"aws_vpc_endpoint":                      vpcEndpointValues{},
+		"aws_wafv2_web_acl":                     wafv2WebACLValues{},
+	}
+}
@@ -0,0 +1,59 @@
+package terraform_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+)
+
+func TestUsageValues(t *testing.T) {
+	// All the resource types handled on the ResourceComponents have to be
+	// on the UsageValues so the usage schema is kept in sync
+	f, err := parser.ParseFile(token.NewFileSet(), "provider.go", nil, 0)
+	require.NoError(t, err)
+
+	var expected []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		cc, ok := n.(*ast.CaseClause)
+		if !ok {
+			return true
+		}
+		for _, e := range cc.List {
+			if bl, ok := e.(*ast.BasicLit); ok && bl.Kind == token.STRING {
+				s, err := strconv.Unquote(bl.Value)
+				require.NoError(t, err)
+				expected = append(expected, s)
+			}
+		}
+		return true
+	})
+
+	var actual []string
+	for rt := range awstf.UsageValues() {
+		actual = append(actual, rt)
+	}
+
+	sort.Strings(expected)
+	sort.Strings(actual)
+	assert.Equal(t, expected, actual)
+}
+
+func TestProvider_ResourceAttributes(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	attrs := p.ResourceAttributes("aws_instance")
+	assert.Contains(t, attrs, "instance_type")
+	assert.Contains(t, attrs, "root_block_device")
+	assert.NotContains(t, attrs, "tc_usage")
+
+	assert.Nil(t, p.ResourceAttributes("aws_unknown"))
+}
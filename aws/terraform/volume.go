@@ -7,6 +7,7 @@ import (
 	"github.com/cycloidio/terracost/aws/region"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 )
 
@@ -122,3 +123,11 @@ func (v *Volume) iopsComponent() query.Component {
 		},
 	}
 }
+
+// volumeExample returns the ResourceExample of the aws_ebs_volume, see Provider.ResourceExamples
+func (p *Provider) volumeExample() terraform.ResourceExample {
+	return p.example("aws_ebs_volume", map[string]interface{}{
+		"type": "gp3",
+		"size": float64(100),
+	})
+}
@@ -0,0 +1,163 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// VPCEndpoint represents a VPC endpoint definition that can be cost-estimated.
+// The Interface endpoints (PrivateLink) are charged for each AZ they are deployed on (one per subnet)
+// and the data they process, the Gateway endpoints (S3 and DynamoDB) are free.
+type VPCEndpoint struct {
+	providerKey  string
+	region       region.Code
+	endpointType string
+
+	// availabilityZones is the number of AZs of an Interface endpoint
+	availabilityZones decimal.Decimal
+
+	// Usage
+	monthlyDataProcessedGB decimal.Decimal
+}
+
+type vpcEndpointValues struct {
+	VPCEndpointType string   `mapstructure:"vpc_endpoint_type"`
+	SubnetIDs       []string `mapstructure:"subnet_ids"`
+
+	Usage struct {
+		MonthlyDataProcessedGB float64 `mapstructure:"monthly_data_processed_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeVPCEndpointValues decodes and returns vpcEndpointValues from a Terraform values map.
+func decodeVPCEndpointValues(tfVals map[string]interface{}) (vpcEndpointValues, error) {
+	var v vpcEndpointValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newVPCEndpoint creates a new VPCEndpoint from vpcEndpointValues.
+func (p *Provider) newVPCEndpoint(vals vpcEndpointValues) *VPCEndpoint {
+	v := &VPCEndpoint{
+		providerKey:  p.key,
+		region:       p.region,
+		endpointType: "Gateway",
+
+		// From Usage
+		monthlyDataProcessedGB: decimal.NewFromFloat(vals.Usage.MonthlyDataProcessedGB),
+	}
+
+	// The default type of the aws_vpc_endpoint is Gateway
+	if vals.VPCEndpointType != "" {
+		v.endpointType = vals.VPCEndpointType
+	}
+
+	if v.endpointType == "Interface" {
+		v.availabilityZones = decimal.NewFromInt(int64(len(vals.SubnetIDs)))
+		if len(vals.SubnetIDs) == 0 {
+			p.warnf("no subnet_ids, the Interface endpoint is estimated on 1 availability zone")
+			v.availabilityZones = decimal.NewFromInt(1)
+		}
+	} else if v.endpointType != "Gateway" {
+		p.warnf("vpc_endpoint_type %q is not supported, the endpoint is not estimated", v.endpointType)
+	}
+
+	return v
+}
+
+// Components returns the price component queries that make up the VPCEndpoint.
+func (v *VPCEndpoint) Components() []query.Component {
+	switch v.endpointType {
+	case "Interface":
+		return []query.Component{v.endpointComponent(), v.dataProcessedComponent()}
+	case "Gateway":
+		// The Gateway endpoints have no cost, the component has no
+		// ProductFilter so they are reported as free
+		return []query.Component{
+			{
+				Name:           "Gateway endpoint",
+				HourlyQuantity: decimal.NewFromInt(1),
+				Details:        []string{"Gateway"},
+			},
+		}
+	default:
+		return []query.Component{}
+	}
+}
+
+func (v *VPCEndpoint) endpointComponent() query.Component {
+	return query.Component{
+		Name:           "Endpoint",
+		Details:        []string{"Interface", "per availability zone"},
+		HourlyQuantity: v.availabilityZones,
+		Unit:           "AZ-hours",
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.providerKey),
+			Service:  util.StringPtr("AmazonVPC"),
+			Family:   util.StringPtr("VpcEndpoint"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?VpcEndpoint-Hours$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("Hrs"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+func (v *VPCEndpoint) dataProcessedComponent() query.Component {
+	return query.Component{
+		Name:            "Data processed",
+		Details:         []string{"Interface"},
+		MonthlyQuantity: v.monthlyDataProcessedGB,
+		Usage:           true,
+		Unit:            "GB",
+		Tiered:          true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(v.providerKey),
+			Service:  util.StringPtr("AmazonVPC"),
+			Family:   util.StringPtr("VpcEndpoint"),
+			Location: util.StringPtr(v.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?VpcEndpoint-Bytes$")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("GB"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// vpcEndpointExample returns the ResourceExample of the aws_vpc_endpoint, see Provider.ResourceExamples
+func (p *Provider) vpcEndpointExample() terraform.ResourceExample {
+	return p.example("aws_vpc_endpoint", map[string]interface{}{
+		"vpc_endpoint_type": "Interface",
+		"subnet_ids":        []interface{}{"subnet-a", "subnet-b"},
+	})
+}
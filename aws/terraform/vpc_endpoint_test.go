@@ -0,0 +1,146 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestVPCEndpoint_Components(t *testing.T) {
+	p, err := awstf.NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	t.Run("Interface", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_vpc_endpoint.ecr",
+			Type:         "aws_vpc_endpoint",
+			Name:         "ecr",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"vpc_endpoint_type": "Interface",
+				"service_name":      "com.amazonaws.eu-west-3.ecr.dkr",
+				"subnet_ids":        []interface{}{"subnet-a", "subnet-b", "subnet-c"},
+				usage.Key:           usage.Default.GetUsage("aws_vpc_endpoint"),
+			},
+		}
+
+		expected := []query.Component{
+			{
+				Name:           "Endpoint",
+				Details:        []string{"Interface", "per availability zone"},
+				HourlyQuantity: decimal.NewFromInt(3),
+				Unit:           "AZ-hours",
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonVPC"),
+					Family:   util.StringPtr("VpcEndpoint"),
+					Location: util.StringPtr("eu-west-3"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?VpcEndpoint-Hours$")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("Hrs"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+			{
+				Name:            "Data processed",
+				Details:         []string{"Interface"},
+				MonthlyQuantity: decimal.NewFromInt(10),
+				Usage:           true,
+				Unit:            "GB",
+				Tiered:          true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("aws"),
+					Service:  util.StringPtr("AmazonVPC"),
+					Family:   util.StringPtr("VpcEndpoint"),
+					Location: util.StringPtr("eu-west-3"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "UsageType", ValueRegex: util.StringPtr("^([A-Z0-9]+-)?VpcEndpoint-Bytes$")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("GB"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "TermType", Value: util.StringPtr("OnDemand")},
+					},
+				},
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("InterfaceWithoutSubnets", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_vpc_endpoint.ecr",
+			Type:         "aws_vpc_endpoint",
+			Name:         "ecr",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"vpc_endpoint_type": "Interface",
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		require.Len(t, actual, 2)
+		assert.True(t, decimal.NewFromInt(1).Equal(actual[0].HourlyQuantity))
+		assert.Equal(t, []string{"no subnet_ids, the Interface endpoint is estimated on 1 availability zone"}, warnings)
+	})
+
+	t.Run("Gateway", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_vpc_endpoint.s3",
+			Type:         "aws_vpc_endpoint",
+			Name:         "s3",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"service_name": "com.amazonaws.eu-west-3.s3",
+			},
+		}
+
+		expected := []query.Component{
+			{
+				Name:           "Gateway endpoint",
+				HourlyQuantity: decimal.NewFromInt(1),
+				Details:        []string{"Gateway"},
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		testutil.EqualQueryComponents(t, expected, actual)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("GatewayLoadBalancer", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_vpc_endpoint.gwlb",
+			Type:         "aws_vpc_endpoint",
+			Name:         "gwlb",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"vpc_endpoint_type": "GatewayLoadBalancer",
+			},
+		}
+
+		actual, warnings := p.ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		assert.Empty(t, actual)
+		assert.Equal(t, []string{`vpc_endpoint_type "GatewayLoadBalancer" is not supported, the endpoint is not estimated`}, warnings)
+	})
+}
@@ -0,0 +1,126 @@
+package terraform
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/aws/region"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+)
+
+// WAFv2WebACL represents an AWS WAF (v2) web ACL that can be cost-estimated.
+type WAFv2WebACL struct {
+	providerKey string
+	region      region.Code
+
+	// rules is the number of rule blocks of the web ACL, each
+	// of them (including the rule groups referenced) is charged
+	rules decimal.Decimal
+
+	// Usage
+	monthlyRequests decimal.Decimal
+}
+
+type wafv2WebACLValues struct {
+	Scope string `mapstructure:"scope"`
+
+	Rule []struct {
+		Name string `mapstructure:"name"`
+	} `mapstructure:"rule"`
+
+	Usage struct {
+		MonthlyRequests float64 `mapstructure:"monthly_requests"`
+	} `mapstructure:"tc_usage"`
+}
+
+func decodeWAFv2WebACLValues(tfVals map[string]interface{}) (wafv2WebACLValues, error) {
+	var v wafv2WebACLValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newWAFv2WebACL creates a new WAFv2WebACL from wafv2WebACLValues.
+func (p *Provider) newWAFv2WebACL(vals wafv2WebACLValues) *WAFv2WebACL {
+	inst := &WAFv2WebACL{
+		providerKey: p.key,
+		region:      p.region,
+		rules:       decimal.NewFromInt(int64(len(vals.Rule))),
+
+		// From Usage
+		monthlyRequests: decimal.NewFromFloat(vals.Usage.MonthlyRequests),
+	}
+
+	// The CLOUDFRONT web ACLs are created on us-east-1 whatever the region of the provider
+	if vals.Scope == "CLOUDFRONT" {
+		inst.region = region.Code("us-east-1")
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up the WAFv2WebACL.
+// The web ACL and each of its rules are charged per month and the requests it inspects per request.
+func (inst *WAFv2WebACL) Components() []query.Component {
+	components := []query.Component{
+		inst.wafComponent("Web ACL", decimal.NewFromInt(1), false, "WebACLV2$"),
+	}
+
+	if inst.rules.IsPositive() {
+		components = append(components, inst.wafComponent("Rules", inst.rules, false, "RuleV2$"))
+	}
+
+	comp := inst.wafComponent("Requests", inst.monthlyRequests, true, "RequestV2-Tier1$")
+	comp.Unit = "Requests"
+	components = append(components, comp)
+
+	return components
+}
+
+func (inst *WAFv2WebACL) wafComponent(name string, quantity decimal.Decimal, usage bool, usageType string) query.Component {
+	return query.Component{
+		Name:            name,
+		MonthlyQuantity: quantity,
+		Usage:           usage,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.providerKey),
+			Service:  util.StringPtr("awswaf"),
+			Location: util.StringPtr(inst.region.String()),
+			AttributeFilters: []*product.AttributeFilter{
+				// The UsageType has a region prefix (ex: EUW3-WebACLV2) except on us-east-1
+				{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "TermType", Value: util.StringPtr("OnDemand")},
+			},
+		},
+	}
+}
+
+// wafv2WebACLExample returns the ResourceExample of the aws_wafv2_web_acl, see Provider.ResourceExamples
+func (p *Provider) wafv2WebACLExample() terraform.ResourceExample {
+	return p.example("aws_wafv2_web_acl", map[string]interface{}{
+		"scope": "REGIONAL",
+		"rule": []interface{}{
+			map[string]interface{}{"name": "rate-limit"},
+			map[string]interface{}{"name": "common-rule-set"},
+		},
+	})
+}
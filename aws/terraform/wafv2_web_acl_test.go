@@ -0,0 +1,88 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestWAFv2WebACL_Components(t *testing.T) {
+	p, err := NewProvider("aws", "eu-west-3")
+	require.NoError(t, err)
+
+	component := func(name, location string, quantity decimal.Decimal, usage bool, usageType string) query.Component {
+		return query.Component{
+			Name:            name,
+			MonthlyQuantity: quantity,
+			Usage:           usage,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("awswaf"),
+				Location: util.StringPtr(location),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "UsageType", ValueRegex: util.StringPtr(usageType)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "TermType", Value: util.StringPtr("OnDemand")},
+				},
+			},
+		}
+	}
+
+	t.Run("Regional", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_wafv2_web_acl.test",
+			Type:         "aws_wafv2_web_acl",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"scope": "REGIONAL",
+				"rule": []interface{}{
+					map[string]interface{}{"name": "rate-limit"},
+					map[string]interface{}{"name": "common-rule-set"},
+					map[string]interface{}{"name": "ip-reputation"},
+				},
+				usage.Key: usage.Default.GetUsage("aws_wafv2_web_acl"),
+			},
+		}
+
+		requests := component("Requests", "eu-west-3", decimal.NewFromFloat(1000000), true, "RequestV2-Tier1$")
+		requests.Unit = "Requests"
+		expected := []query.Component{
+			component("Web ACL", "eu-west-3", decimal.NewFromInt(1), false, "WebACLV2$"),
+			component("Rules", "eu-west-3", decimal.NewFromInt(3), false, "RuleV2$"),
+			requests,
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("CloudFrontNoRules", func(t *testing.T) {
+		tfres := terraform.Resource{
+			Address:      "aws_wafv2_web_acl.test",
+			Type:         "aws_wafv2_web_acl",
+			Name:         "test",
+			ProviderName: "aws",
+			Values: map[string]interface{}{
+				"scope": "CLOUDFRONT",
+			},
+		}
+
+		actual := p.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		require.Len(t, actual, 2)
+		assert.Equal(t, component("Web ACL", "us-east-1", decimal.NewFromInt(1), false, "WebACLV2$"), actual[0])
+		assert.Equal(t, "Requests", actual[1].Name)
+	})
+}
@@ -29,3 +29,20 @@ var TerraformProviderInitializer = terraform.ProviderInitializer{
 		return awstf.NewProvider(ProviderName, regCode)
 	},
 }
+
+// NewTerraformProviderInitializer returns a terraform.ProviderInitializer that initializes the AWS provider
+// with the defaultRegion when none is defined on the provider configuration, the resources estimated
+// with it have a warning about the fallback. The TerraformProviderInitializer uses the DefaultRegion
+// without warning instead.
+func NewTerraformProviderInitializer(defaultRegion region.Code) terraform.ProviderInitializer {
+	return terraform.ProviderInitializer{
+		MatchNames: []string{ProviderName, RegistryName},
+		Provider: func(values map[string]interface{}) (terraform.Provider, error) {
+			r, ok := values["region"].(string)
+			if !ok || r == "" {
+				return awstf.NewProviderWithDefaultRegion(ProviderName, defaultRegion)
+			}
+			return awstf.NewProvider(ProviderName, region.Code(r))
+		},
+	}
+}
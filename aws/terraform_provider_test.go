@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/terraform"
+)
+
+func TestNewTerraformProviderInitializer(t *testing.T) {
+	tfres := terraform.Resource{
+		Address:      "aws_instance.test",
+		Mode:         "managed",
+		Type:         "aws_instance",
+		Name:         "test",
+		ProviderName: "aws",
+		Values: map[string]interface{}{
+			"instance_type": "t3.micro",
+		},
+	}
+	pi := NewTerraformProviderInitializer("eu-west-1")
+
+	t.Run("NoRegion", func(t *testing.T) {
+		prov, err := pi.Provider(map[string]interface{}{})
+		require.NoError(t, err)
+
+		components, warnings := prov.(terraform.WarningsProvider).ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		require.NotEmpty(t, components)
+		assert.Equal(t, "eu-west-1", *components[0].ProductFilter.Location)
+		assert.Equal(t, []string{`no region on the provider configuration, the default region "eu-west-1" is used`}, warnings)
+	})
+
+	t.Run("Region", func(t *testing.T) {
+		prov, err := pi.Provider(map[string]interface{}{"region": "eu-west-3"})
+		require.NoError(t, err)
+
+		components, warnings := prov.(terraform.WarningsProvider).ResourceComponentsWithWarnings(map[string]terraform.Resource{}, tfres)
+		require.NotEmpty(t, components)
+		assert.Equal(t, "eu-west-3", *components[0].ProductFilter.Location)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("RegionName", func(t *testing.T) {
+		prov, err := pi.Provider(map[string]interface{}{"region": "EU (Paris)"})
+		require.NoError(t, err)
+
+		components := prov.ResourceComponents(map[string]terraform.Resource{}, tfres)
+		require.NotEmpty(t, components)
+		assert.Equal(t, "eu-west-3", *components[0].ProductFilter.Location)
+	})
+
+	t.Run("InvalidDefaultRegion", func(t *testing.T) {
+		_, err := NewTerraformProviderInitializer("nowhere-1").Provider(map[string]interface{}{})
+		assert.Error(t, err)
+	})
+}
@@ -8,16 +8,21 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/shopspring/decimal"
+	"golang.org/x/time/rate"
 )
 
 // ProviderName is the provider that this package implements
 const ProviderName = "azurerm"
 
+// spotType is the type of the products of the Spot prices
+const spotType = "Spot"
+
 var (
 	// ErrNotSupportedService reports that the service is not supported
 	ErrNotSupportedService = errors.New("not supported service")
@@ -28,12 +33,20 @@ type Ingester struct {
 	service string
 	region  string
 
-	client *http.Client
+	client  *http.Client
+	limiter *rate.Limiter
 
 	ingestionFilter IngestionFilter
+	reservations    bool
+	spot            bool
 	endpoint        string
 	endpointURL     *url.URL
 
+	// since is the latest effective start date of the prices of the
+	// previous ingestion and mark the one of the prices ingested
+	since time.Time
+	mark  time.Time
+
 	err error
 }
 
@@ -72,6 +85,17 @@ func (ing *Ingester) Ingest(ctx context.Context, chSize int) <-chan *price.WithP
 		defer close(results)
 
 		for rp := range ing.fetchPrices(ctx) {
+			// The prices are versioned by their effective start date, the ones
+			// not effective after the previous ingestion have not changed, the
+			// ones effective on its mark are also fetched as the API filter is inclusive
+			if esd, err := time.Parse(time.RFC3339, rp.EffectiveStartDate); err == nil {
+				if !ing.since.IsZero() && !esd.After(ing.since) {
+					continue
+				}
+				if esd.After(ing.mark) {
+					ing.mark = esd
+				}
+			}
 
 			prod := &product.Product{
 				Provider: ProviderName,
@@ -85,6 +109,9 @@ func (ing *Ingester) Ingest(ctx context.Context, chSize int) <-chan *price.WithP
 					"productName":      rp.ProductName,
 					"skuName":          rp.SkuName,
 					"tierMinimumUnits": fmt.Sprintf("%f", rp.TierMinimumUnits),
+					// The type is part of the SKU so each product only has prices of one type,
+					// it's used to not match the Reservation or DevTestConsumption products
+					"type": rp.Type,
 				},
 			}
 			pwp := &price.WithProduct{
@@ -93,12 +120,24 @@ func (ing *Ingester) Ingest(ctx context.Context, chSize int) <-chan *price.WithP
 					Value:    decimal.NewFromFloat(rp.UnitPrice),
 					Currency: rp.CurrencyCode,
 					Attributes: map[string]string{
-						"type": rp.Type,
+						"type":                       rp.Type,
+						price.EffectiveDateAttribute: rp.EffectiveStartDate,
 					},
 				},
 				Product: prod,
 			}
-			if ing.ingestionFilter(pwp) {
+			// The Spot meters are pay-as-you-go prices of their own products, which have the
+			// Spot type to not be matched as the pay-as-you-go virtual machines
+			if rp.Type == "Consumption" && strings.HasSuffix(rp.MeterName, " Spot") {
+				prod.Attributes["type"] = spotType
+			}
+			// The reservationTerm is only set on the Reservation products (one for each term),
+			// their unitPrice is the total of the term (ex: 1 Year)
+			if rp.ReservationTerm != "" {
+				prod.Attributes["reservationTerm"] = rp.ReservationTerm
+				pwp.Price.Attributes["reservationTerm"] = rp.ReservationTerm
+			}
+			if ing.ingestionFilter(pwp) || (ing.reservations && rp.Type == "Reservation") || (ing.spot && isSpotPrice(pwp)) {
 				results <- pwp
 			}
 		}
@@ -123,7 +162,12 @@ func (ing *Ingester) fetchPrices(ctx context.Context) <-chan retailPrice {
 		}
 
 		// Docs: https://docs.microsoft.com/en-us/rest/api/cost-management/retail-prices/azure-retail-prices
-		f := url.PathEscape(fmt.Sprintf("serviceName eq '%s' and (armRegionName eq '%s'%s)", ing.service, ing.region, zonesFilter.String()))
+		filter := fmt.Sprintf("serviceName eq '%s' and (armRegionName eq '%s'%s)", ing.service, ing.region, zonesFilter.String())
+		// On the incremental ingestion only the prices effective since the previous one are fetched
+		if !ing.since.IsZero() {
+			filter += fmt.Sprintf(" and effectiveStartDate ge %s", ing.since.UTC().Format(time.RFC3339))
+		}
+		f := url.PathEscape(filter)
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?$filter=%s", ing.buildPricesURL(), f), nil)
 		if err != nil {
 			ing.err = fmt.Errorf("error creating HTTP request: %w", err)
@@ -131,6 +175,13 @@ func (ing *Ingester) fetchPrices(ctx context.Context) <-chan retailPrice {
 		}
 
 		for req != nil {
+			if ing.limiter != nil {
+				if err := ing.limiter.Wait(ctx); err != nil {
+					ing.err = fmt.Errorf("error waiting for the rate limit: %w", err)
+					return
+				}
+			}
+
 			res, err := ing.client.Do(req)
 			if err != nil {
 				ing.err = fmt.Errorf("error executing HTTP request: %w", err)
@@ -167,7 +218,33 @@ func (ing *Ingester) buildPricesURL() string {
 	return ing.endpointURL.ResolveReference(path).String()
 }
 
+// MarkKey returns the key of the ingested prices, see terracost.IncrementalIngester.
+func (ing *Ingester) MarkKey() string {
+	return ProviderName + "/" + ing.service + "/" + ing.region
+}
+
+// SetSince sets the latest effective start date (RFC 3339) of the prices of the previous ingestion, only
+// the prices effective after it are ingested, see terracost.IncrementalIngester. An invalid mark is
+// ignored so all the prices are ingested.
+func (ing *Ingester) SetSince(mark string) {
+	ing.since, _ = time.Parse(time.RFC3339, mark)
+	ing.mark = ing.since
+}
+
+// Mark returns the latest effective start date of the prices ingested, see terracost.IncrementalIngester.
+func (ing *Ingester) Mark() string {
+	if ing.mark.IsZero() {
+		return ""
+	}
+	return ing.mark.Format(time.RFC3339)
+}
+
 // Err returns any error that might have happened during the ingestion.
 func (ing *Ingester) Err() error {
 	return ing.err
 }
+
+// isSpotPrice returns true if the pp is the Spot price of a virtual machine
+func isSpotPrice(pp *price.WithProduct) bool {
+	return pp.Product.Service == "Virtual Machines" && pp.Product.Family == "Compute" && pp.Product.Attributes["type"] == spotType
+}
@@ -2,10 +2,16 @@ package azurerm_test
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cycloidio/terracost/azurerm"
+	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/testutil"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -44,8 +50,174 @@ func TestIngest(t *testing.T) {
 		require.NoError(t, i.Err())
 		assert.Equal(t, 1248, count) // 840 + 408
 	})
+	t.Run("SuccessMinimalWithReservations", func(t *testing.T) {
+
+		i, err := azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithReservations(), azurerm.WithEndpoint(ts.URL))
+		require.NoError(t, err)
+
+		var count, reservations int
+		for pwp := range i.Ingest(ctx, 10) {
+			count++
+			if pwp.Price.Attributes["type"] == "Reservation" {
+				reservations++
+				assert.Contains(t, []string{"1 Year", "3 Years"}, pwp.Price.Attributes["reservationTerm"])
+			}
+		}
+
+		require.NoError(t, i.Err())
+		assert.Equal(t, 810, reservations)
+		assert.Equal(t, 1248+810, count)
+	})
+	t.Run("SuccessMinimalWithSpotPrices", func(t *testing.T) {
+
+		i, err := azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithSpotPrices(), azurerm.WithEndpoint(ts.URL))
+		require.NoError(t, err)
+
+		var count, spot int
+		for pwp := range i.Ingest(ctx, 10) {
+			count++
+			if pwp.Product.Attributes["type"] == "Spot" {
+				spot++
+				assert.True(t, strings.HasSuffix(pwp.Product.Attributes["meterName"], " Spot"))
+				assert.Equal(t, "Consumption", pwp.Price.Attributes["type"])
+			}
+		}
+
+		require.NoError(t, i.Err())
+		assert.Equal(t, 794, spot)
+		assert.Equal(t, 1248+794, count)
+	})
+	t.Run("SuccessWithHTTPClient", func(t *testing.T) {
+		rt := &countingRoundTripper{}
+		i, err := azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL), azurerm.WithHTTPClient(&http.Client{Transport: rt}))
+		require.NoError(t, err)
+
+		var count int
+		for range i.Ingest(ctx, 10) {
+			count++
+		}
+
+		require.NoError(t, i.Err())
+		assert.Equal(t, 1248, count)
+		assert.Positive(t, rt.requests)
+	})
+	t.Run("SuccessWithRateLimit", func(t *testing.T) {
+		// The limit is shared by the ingesters given the same Option
+		rateLimit := azurerm.WithRateLimit(5)
+
+		start := time.Now()
+		for n := 0; n < 4; n++ {
+			i, err := azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL), rateLimit)
+			require.NoError(t, err)
+
+			for range i.Ingest(ctx, 10) {
+			}
+			require.NoError(t, i.Err())
+		}
+
+		// The first request is done right away and the 3 next each 200ms
+		assert.GreaterOrEqual(t, time.Since(start), 600*time.Millisecond)
+	})
+	t.Run("ErrRateLimitCanceled", func(t *testing.T) {
+		rateLimit := azurerm.WithRateLimit(1)
+		cctx, cancel := context.WithCancel(ctx)
+
+		i, err := azurerm.NewIngester(cctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL), rateLimit)
+		require.NoError(t, err)
+		for range i.Ingest(cctx, 10) {
+		}
+		require.NoError(t, i.Err())
+
+		// The next request would have to wait 1s but the context is canceled
+		cancel()
+		i, err = azurerm.NewIngester(cctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL), rateLimit)
+		require.NoError(t, err)
+		for range i.Ingest(cctx, 10) {
+		}
+		assert.Error(t, i.Err())
+	})
+	t.Run("SuccessWithInvalidRateLimit", func(t *testing.T) {
+		// A limit of 0 is ignored instead of blocking all the requests after the first one
+		rateLimit := azurerm.WithRateLimit(0)
+		cctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		for n := 0; n < 2; n++ {
+			i, err := azurerm.NewIngester(cctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL), rateLimit)
+			require.NoError(t, err)
+
+			for range i.Ingest(cctx, 10) {
+			}
+			require.NoError(t, i.Err())
+		}
+	})
+	t.Run("SuccessIncremental", func(t *testing.T) {
+		i, err := azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL))
+		require.NoError(t, err)
+
+		for range i.Ingest(ctx, 10) {
+		}
+		require.NoError(t, i.Err())
+		mark := i.Mark()
+		require.NotEmpty(t, mark)
+		assert.Equal(t, "azurerm/Virtual Machines/francecentral", i.MarkKey())
+
+		// No price is effective after the mark of the previous ingestion
+		rt := &countingRoundTripper{}
+		i, err = azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL), azurerm.WithHTTPClient(&http.Client{Transport: rt}))
+		require.NoError(t, err)
+		i.SetSince(mark)
+
+		var count int
+		for range i.Ingest(ctx, 10) {
+			count++
+		}
+		require.NoError(t, i.Err())
+		assert.Equal(t, 0, count)
+		assert.Equal(t, mark, i.Mark())
+
+		// Only the prices effective since the mark are requested
+		require.NotEmpty(t, rt.urls)
+		assert.Contains(t, rt.urls[0].Query().Get("$filter"), "and effectiveStartDate ge "+mark)
+	})
+	t.Run("SuccessReingestion", func(t *testing.T) {
+		// The prices are stored by product and hash like on the backends
+		rows := make(map[string]decimal.Decimal)
+		key := func(pwp *price.WithProduct) string { return pwp.Product.SKU + "/" + pwp.GenerateHash() }
+
+		i, err := azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL))
+		require.NoError(t, err)
+		for pwp := range i.Ingest(ctx, 10) {
+			rows[key(pwp)] = pwp.Value
+		}
+		require.NoError(t, i.Err())
+		count := len(rows)
+
+		// The same prices effective from a later date with a new value replace the previous ones
+		i, err = azurerm.NewIngester(ctx, azurerm.VirtualMachines.String(), region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL))
+		require.NoError(t, err)
+		for pwp := range i.Ingest(ctx, 10) {
+			pwp.Value = pwp.Value.Add(decimal.NewFromInt(1))
+			pwp.Attributes[price.EffectiveDateAttribute] = "2099-01-01T00:00:00Z"
+			rows[key(pwp)] = pwp.Value
+		}
+		require.NoError(t, i.Err())
+		assert.Equal(t, count, len(rows))
+	})
 	t.Run("ErrNotSupportedService", func(t *testing.T) {
 		_, err := azurerm.NewIngester(ctx, "invalid service", region, azurerm.WithIngestionFilter(azurerm.MinimalFilter), azurerm.WithEndpoint(ts.URL))
 		assert.EqualError(t, err, azurerm.ErrNotSupportedService.Error())
 	})
 }
+
+// countingRoundTripper counts the requests done through it and keeps their URLs
+type countingRoundTripper struct {
+	requests int
+	urls     []*url.URL
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests++
+	rt.urls = append(rt.urls, req.URL)
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -1,5 +1,11 @@
 package azurerm
 
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
 // Option is used to configure the Ingester.
 type Option func(ing *Ingester)
 
@@ -16,3 +22,41 @@ func WithEndpoint(endpoint string) Option {
 		ing.endpoint = endpoint
 	}
 }
+
+// WithHTTPClient sets a custom HTTP client to be used for the requests to the retail prices API
+// (ex: to use a proxy, custom timeouts or tracing), http.DefaultClient by default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(ing *Ingester) {
+		ing.client = client
+	}
+}
+
+// WithReservations ingests the Reservation prices (ex: reserved VM instances) even if the IngestionFilter
+// skips them, they are needed to estimate the resources with a 'reservation_term' usage.
+func WithReservations() Option {
+	return func(ing *Ingester) {
+		ing.reservations = true
+	}
+}
+
+// WithSpotPrices ingests the Spot prices of the virtual machines even if the IngestionFilter skips
+// them, they are needed to estimate the virtual machines with the 'Spot' priority.
+func WithSpotPrices() Option {
+	return func(ing *Ingester) {
+		ing.spot = true
+	}
+}
+
+// WithRateLimit throttles the requests to the retail prices API to rps requests per second. The limit
+// is shared by all the ingesters configured with the same Option, so a whole ingestion run stays
+// under the API quota when each of its ingesters is given the same Option. A rps lower or
+// equal to 0 is ignored and the requests are not throttled.
+func WithRateLimit(rps int) Option {
+	if rps <= 0 {
+		return func(ing *Ingester) {}
+	}
+	limiter := rate.NewLimiter(rate.Limit(rps), 1)
+	return func(ing *Ingester) {
+		ing.limiter = limiter
+	}
+}
@@ -1,246 +1,173 @@
 package region
 
-var (
-	locationDisplayToName = map[string]string{
-		"West US":              "westus",
-		"West US 2":            "westus2",
-		"East US":              "eastus",
-		"Central US":           "centralus",
-		"Central US EUAP":      "centraluseuap",
-		"South Central US":     "southcentralus",
-		"North Central US":     "northcentralus",
-		"West Central US":      "westcentralus",
-		"East US 2":            "eastus2",
-		"East US 2 EUAP":       "eastus2euap",
-		"Brazil South":         "brazilsouth",
-		"Brazil US":            "brazilus",
-		"North Europe":         "northeurope",
-		"West Europe":          "westeurope",
-		"East Asia":            "eastasia",
-		"Southeast Asia":       "southeastasia",
-		"Japan West":           "japanwest",
-		"Japan East":           "japaneast",
-		"Korea Central":        "koreacentral",
-		"Korea South":          "koreasouth",
-		"South India":          "southindia",
-		"West India":           "westindia",
-		"Central India":        "centralindia",
-		"Australia East":       "australiaeast",
-		"Australia Southeast":  "australiasoutheast",
-		"Canada Central":       "canadacentral",
-		"Canada East":          "canadaeast",
-		"UK South":             "uksouth",
-		"UK West":              "ukwest",
-		"France Central":       "francecentral",
-		"France South":         "francesouth",
-		"Australia Central":    "australiacentral",
-		"Australia Central 2":  "australiacentral2",
-		"UAE Central":          "uaecentral",
-		"UAE North":            "uaenorth",
-		"South Africa North":   "southafricanorth",
-		"South Africa West":    "southafricawest",
-		"Switzerland North":    "switzerlandnorth",
-		"Switzerland West":     "switzerlandwest",
-		"Germany North":        "germanynorth",
-		"Germany West Central": "germanywestcentral",
-		"Norway East":          "norwayeast",
-		"Norway West":          "norwaywest",
-		"Brazil Southeast":     "brazilsoutheast",
-		"West US 3":            "westus3",
-		"East US SLV":          "eastusslv",
-		"Sweden Central":       "swedencentral",
-		"Sweden South":         "swedensouth",
+import "strings"
+
+// Location is an Azure location with the billing zones it belongs to
+type Location struct {
+	// Name is the programmatic name of the location (ex: westeurope)
+	Name string
+	// DisplayName is the name of the location on the portal (ex: West Europe)
+	DisplayName string
+
+	// VNETZone is the zone of the data transfer of the virtual networks, see
+	// https://azure.microsoft.com/en-us/pricing/details/virtual-network/#faq
+	VNETZone string
+	// CDNZone is the billing region of the CDN, see
+	// https://learn.microsoft.com/en-us/azure/cdn/cdn-billing#what-is-a-billing-region
+	CDNZone string
+}
+
+// locations are all the Azure locations of the public and US Gov clouds,
+// with the logical ones (ex: europe) and the stage ones
+var locations = []Location{
+	{Name: "asia", DisplayName: "Asia", VNETZone: "Zone 1", CDNZone: "Zone 2"},
+	{Name: "asiapacific", DisplayName: "Asia Pacific", VNETZone: "Zone 1", CDNZone: "Zone 2"},
+	{Name: "australia", DisplayName: "Australia", VNETZone: "Zone 1", CDNZone: "Zone 4"},
+	{Name: "australiacentral", DisplayName: "Australia Central", VNETZone: "Zone 1", CDNZone: "Zone 4"},
+	{Name: "australiacentral2", DisplayName: "Australia Central 2", VNETZone: "Zone 1", CDNZone: "Zone 4"},
+	{Name: "australiaeast", DisplayName: "Australia East", VNETZone: "Zone 2", CDNZone: "Zone 4"},
+	{Name: "australiasoutheast", DisplayName: "Australia Southeast", VNETZone: "Zone 2", CDNZone: "Zone 4"},
+	{Name: "brazil", DisplayName: "Brazil", VNETZone: "Zone 3", CDNZone: "Zone 3"},
+	{Name: "brazilsouth", DisplayName: "Brazil South", VNETZone: "Zone 3", CDNZone: "Zone 3"},
+	{Name: "brazilsoutheast", DisplayName: "Brazil Southeast", VNETZone: "Zone 3", CDNZone: "Zone 3"},
+	{Name: "brazilus", DisplayName: "Brazil US", VNETZone: "Zone 1", CDNZone: "Zone 3"},
+	{Name: "canada", DisplayName: "Canada", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "canadacentral", DisplayName: "Canada Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "canadaeast", DisplayName: "Canada East", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "centralindia", DisplayName: "Central India", VNETZone: "Zone 2", CDNZone: "Zone 5"},
+	{Name: "centralus", DisplayName: "Central US", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "centraluseuap", DisplayName: "Central US EUAP", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "centralusstage", DisplayName: "Central US (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "chilecentral", DisplayName: "Chile Central", VNETZone: "Zone 3", CDNZone: "Zone 3"},
+	{Name: "eastasia", DisplayName: "East Asia", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "eastasiastage", DisplayName: "East Asia (Stage)", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "eastus", DisplayName: "East US", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "eastus2", DisplayName: "East US 2", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "eastus2euap", DisplayName: "East US 2 EUAP", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "eastus2stage", DisplayName: "East US 2 (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "eastusslv", DisplayName: "East US SLV", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "eastusstage", DisplayName: "East US (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "eastusstg", DisplayName: "East US STG", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "europe", DisplayName: "Europe", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "france", DisplayName: "France", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "francecentral", DisplayName: "France Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "francesouth", DisplayName: "France South", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "germany", DisplayName: "Germany", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "germanynorth", DisplayName: "Germany North", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "germanywestcentral", DisplayName: "Germany West Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "india", DisplayName: "India", VNETZone: "Zone 2", CDNZone: "Zone 5"},
+	{Name: "indonesiacentral", DisplayName: "Indonesia Central", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "israelcentral", DisplayName: "Israel Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "italynorth", DisplayName: "Italy North", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "japan", DisplayName: "Japan", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "japaneast", DisplayName: "Japan East", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "japanwest", DisplayName: "Japan West", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "jioindiacentral", DisplayName: "Jio India Central", VNETZone: "Zone 2", CDNZone: "Zone 5"},
+	{Name: "jioindiawest", DisplayName: "Jio India West", VNETZone: "Zone 1", CDNZone: "Zone 5"},
+	{Name: "korea", DisplayName: "Korea", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "koreacentral", DisplayName: "Korea Central", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "koreasouth", DisplayName: "Korea South", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "malaysiawest", DisplayName: "Malaysia West", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "mexicocentral", DisplayName: "Mexico Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "newzealandnorth", DisplayName: "New Zealand North", VNETZone: "Zone 2", CDNZone: "Zone 4"},
+	{Name: "northcentralus", DisplayName: "North Central US", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "northcentralusstage", DisplayName: "North Central US (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "northeurope", DisplayName: "North Europe", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "norway", DisplayName: "Norway", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "norwayeast", DisplayName: "Norway East", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "norwaywest", DisplayName: "Norway West", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "polandcentral", DisplayName: "Poland Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "qatarcentral", DisplayName: "Qatar Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "singapore", DisplayName: "Singapore", VNETZone: "Zone 1", CDNZone: "Zone 2"},
+	{Name: "southafrica", DisplayName: "South Africa", VNETZone: "Zone 3", CDNZone: "Zone 1"},
+	{Name: "southafricanorth", DisplayName: "South Africa North", VNETZone: "Zone 3", CDNZone: "Zone 1"},
+	{Name: "southafricawest", DisplayName: "South Africa West", VNETZone: "Zone 3", CDNZone: "Zone 1"},
+	{Name: "southcentralus", DisplayName: "South Central US", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "southcentralusstage", DisplayName: "South Central US (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "southeastasia", DisplayName: "Southeast Asia", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "southeastasiastage", DisplayName: "Southeast Asia (Stage)", VNETZone: "Zone 2", CDNZone: "Zone 2"},
+	{Name: "southindia", DisplayName: "South India", VNETZone: "Zone 2", CDNZone: "Zone 5"},
+	{Name: "spaincentral", DisplayName: "Spain Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "sweden", DisplayName: "Sweden", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "swedencentral", DisplayName: "Sweden Central", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "swedensouth", DisplayName: "Sweden South", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "switzerland", DisplayName: "Switzerland", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "switzerlandnorth", DisplayName: "Switzerland North", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "switzerlandwest", DisplayName: "Switzerland West", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "uae", DisplayName: "United Arab Emirates", VNETZone: "Zone 3", CDNZone: "Zone 1"},
+	{Name: "uaecentral", DisplayName: "UAE Central", VNETZone: "Zone 3", CDNZone: "Zone 1"},
+	{Name: "uaenorth", DisplayName: "UAE North", VNETZone: "Zone 3", CDNZone: "Zone 1"},
+	{Name: "uk", DisplayName: "United Kingdom", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "uksouth", DisplayName: "UK South", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "ukwest", DisplayName: "UK West", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "unitedstates", DisplayName: "United States", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "unitedstateseuap", DisplayName: "United States EUAP", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "usgovarizona", DisplayName: "US Gov Arizona", VNETZone: "US Gov Zone 1", CDNZone: "US Gov Zone 1"},
+	{Name: "usgovtexas", DisplayName: "US Gov Texas", VNETZone: "US Gov Zone 1", CDNZone: "US Gov Zone 1"},
+	{Name: "usgovvirginia", DisplayName: "US Gov Virginia", VNETZone: "US Gov Zone 1", CDNZone: "US Gov Zone 1"},
+	{Name: "westcentralus", DisplayName: "West Central US", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "westeurope", DisplayName: "West Europe", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "westindia", DisplayName: "West India", VNETZone: "Zone 2", CDNZone: "Zone 5"},
+	{Name: "westus", DisplayName: "West US", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "westus2", DisplayName: "West US 2", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "westus2stage", DisplayName: "West US 2 (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "westus3", DisplayName: "West US 3", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+	{Name: "westusstage", DisplayName: "West US (Stage)", VNETZone: "Zone 1", CDNZone: "Zone 1"},
+}
+
+// locationsByKey are the locations keyed by their normalized name and display name, see normalizeLocation
+var locationsByKey = make(map[string]Location, 2*len(locations))
+
+func init() {
+	for _, l := range locations {
+		locationsByKey[normalizeLocation(l.Name)] = l
+		locationsByKey[normalizeLocation(l.DisplayName)] = l
 	}
-)
+}
+
+// normalizeLocation returns l in lower case without whitespaces so the spelling variations
+// of the locations (ex: "France Central", "FranceCentral", " francecentral") are equal
+func normalizeLocation(l string) string {
+	return strings.Join(strings.Fields(strings.ToLower(l)), "")
+}
+
+// Locations returns all the known Azure locations sorted by Name.
+func Locations() []Location {
+	return append([]Location(nil), locations...)
+}
+
+// LookupLocation returns the Location of the l name or display name, false if it's unknown.
+// The casing and the whitespaces are ignored (ex: "France Central", "FranceCentral" and
+// "francecentral" are the same Location).
+func LookupLocation(l string) (Location, bool) {
+	loc, ok := locationsByKey[normalizeLocation(l)]
+	return loc, ok
+}
 
-// getLocationName will return the location name from the location display name (ex: UK West -> ukwest)
+// GetLocationName will return the location name from the location display name (ex: UK West -> ukwest)
 // if the l is not found it'll return the l again meaning is not found or already a name
 func GetLocationName(l string) string {
-	ln, ok := locationDisplayToName[l]
+	loc, ok := LookupLocation(l)
 	if !ok {
 		return l
 	}
-	return ln
+	return loc.Name
 }
 
-// Mapped based on the values here: https://azure.microsoft.com/en-us/pricing/details/virtual-network/#faq
+// GetRegionToVNETZone returns the VNETZone of the region, if the region is unknown it's returned as is
 func GetRegionToVNETZone(region string) string {
-	return map[string]string{
-		"eastus":              "Zone 1",
-		"eastus2":             "Zone 1",
-		"southcentralus":      "Zone 1",
-		"westus2":             "Zone 1",
-		"westus3":             "Zone 1",
-		"australiaeast":       "Zone 2",
-		"southeastasia":       "Zone 2",
-		"northeurope":         "Zone 1",
-		"swedencentral":       "Zone 1",
-		"uksouth":             "Zone 1",
-		"westeurope":          "Zone 1",
-		"centralus":           "Zone 1",
-		"southafricanorth":    "Zone 3",
-		"centralindia":        "Zone 2",
-		"eastasia":            "Zone 2",
-		"japaneast":           "Zone 2",
-		"koreacentral":        "Zone 2",
-		"canadacentral":       "Zone 1",
-		"francecentral":       "Zone 1",
-		"germanywestcentral":  "Zone 1",
-		"italynorth":          "Zone 1",
-		"norwayeast":          "Zone 1",
-		"polandcentral":       "Zone 1",
-		"switzerlandnorth":    "Zone 1",
-		"uaenorth":            "Zone 3",
-		"brazilsouth":         "Zone 3",
-		"centraluseuap":       "Zone 1",
-		"israelcentral":       "Zone 1",
-		"qatarcentral":        "Zone 1",
-		"centralusstage":      "Zone 1",
-		"eastusstage":         "Zone 1",
-		"eastus2stage":        "Zone 1",
-		"northcentralusstage": "Zone 1",
-		"southcentralusstage": "Zone 1",
-		"westusstage":         "Zone 1",
-		"westus2stage":        "Zone 1",
-		"asia":                "Zone 1",
-		"asiapacific":         "Zone 1",
-		"australia":           "Zone 1",
-		"brazil":              "Zone 3",
-		"canada":              "Zone 1",
-		"europe":              "Zone 1",
-		"france":              "Zone 1",
-		"germany":             "Zone 1",
-		"india":               "Zone 2",
-		"japan":               "Zone 2",
-		"korea":               "Zone 2",
-		"norway":              "Zone 1",
-		"singapore":           "Zone 1",
-		"southafrica":         "Zone 3",
-		"sweden":              "Zone 1",
-		"switzerland":         "Zone 1",
-		"uae":                 "Zone 3",
-		"uk":                  "Zone 1",
-		"unitedstates":        "Zone 1",
-		"unitedstateseuap":    "Zone 1",
-		"eastasiastage":       "Zone 2",
-		"southeastasiastage":  "Zone 2",
-		"brazilus":            "Zone 1",
-		"eastusstg":           "Zone 1",
-		"northcentralus":      "Zone 1",
-		"westus":              "Zone 1",
-		"japanwest":           "Zone 2",
-		"jioindiawest":        "Zone 1",
-		"eastus2euap":         "Zone 1",
-		"westcentralus":       "Zone 1",
-		"southafricawest":     "Zone 3",
-		"australiacentral":    "Zone 1",
-		"australiacentral2":   "Zone 1",
-		"australiasoutheast":  "Zone 2",
-		"jioindiacentral":     "Zone 2",
-		"koreasouth":          "Zone 2",
-		"southindia":          "Zone 2",
-		"westindia":           "Zone 2",
-		"canadaeast":          "Zone 1",
-		"francesouth":         "Zone 1",
-		"germanynorth":        "Zone 1",
-		"norwaywest":          "Zone 1",
-		"switzerlandwest":     "Zone 1",
-		"ukwest":              "Zone 1",
-		"uaecentral":          "Zone 3",
-		"brazilsoutheast":     "Zone 3",
-		"usgovvirginia":       "US Gov Zone 1",
-		"usgovarizona":        "US Gov Zone 1",
-		"usgovtexas":          "US Gov Zone 1",
-	}[region]
+	loc, ok := LookupLocation(region)
+	if !ok {
+		return region
+	}
+	return loc.VNETZone
 }
 
-// https://learn.microsoft.com/en-us/azure/cdn/cdn-billing#what-is-a-billing-region
+// GetRegionToCDNZone returns the CDNZone of the region, if the region is unknown it's returned as is
 func GetRegionToCDNZone(region string) string {
-	return map[string]string{
-		"eastus":              "Zone 1",
-		"eastus2":             "Zone 1",
-		"southcentralus":      "Zone 1",
-		"westus2":             "Zone 1",
-		"westus3":             "Zone 1",
-		"australiaeast":       "Zone 4",
-		"southeastasia":       "Zone 2",
-		"northeurope":         "Zone 1",
-		"swedencentral":       "Zone 1",
-		"uksouth":             "Zone 1",
-		"westeurope":          "Zone 1",
-		"centralus":           "Zone 1",
-		"southafricanorth":    "Zone 1",
-		"centralindia":        "Zone 5",
-		"eastasia":            "Zone 2",
-		"japaneast":           "Zone 2",
-		"koreacentral":        "Zone 2",
-		"canadacentral":       "Zone 1",
-		"francecentral":       "Zone 1",
-		"germanywestcentral":  "Zone 1",
-		"italynorth":          "Zone 1",
-		"norwayeast":          "Zone 1",
-		"polandcentral":       "Zone 1",
-		"switzerlandnorth":    "Zone 1",
-		"uaenorth":            "Zone 1",
-		"brazilsouth":         "Zone 3",
-		"centraluseuap":       "Zone 1",
-		"israelcentral":       "Zone 1",
-		"qatarcentral":        "Zone 1",
-		"centralusstage":      "Zone 1",
-		"eastusstage":         "Zone 1",
-		"eastus2stage":        "Zone 1",
-		"northcentralusstage": "Zone 1",
-		"southcentralusstage": "Zone 1",
-		"westusstage":         "Zone 1",
-		"westus2stage":        "Zone 1",
-		"asia":                "Zone 2",
-		"asiapacific":         "Zone 2",
-		"australia":           "Zone 4",
-		"brazil":              "Zone 3",
-		"canada":              "Zone 1",
-		"europe":              "Zone 1",
-		"france":              "Zone 1",
-		"germany":             "Zone 1",
-		"india":               "Zone 5",
-		"japan":               "Zone 2",
-		"korea":               "Zone 2",
-		"norway":              "Zone 1",
-		"singapore":           "Zone 2",
-		"southafrica":         "Zone 1",
-		"sweden":              "Zone 1",
-		"switzerland":         "Zone 1",
-		"uae":                 "Zone 1",
-		"uk":                  "Zone 1",
-		"unitedstates":        "Zone 1",
-		"unitedstateseuap":    "Zone 1",
-		"eastasiastage":       "Zone 2",
-		"southeastasiastage":  "Zone 2",
-		"brazilus":            "Zone 3",
-		"eastusstg":           "Zone 1",
-		"northcentralus":      "Zone 1",
-		"westus":              "Zone 1",
-		"japanwest":           "Zone 2",
-		"jioindiawest":        "Zone 5",
-		"eastus2euap":         "Zone 1",
-		"westcentralus":       "Zone 1",
-		"southafricawest":     "Zone 1",
-		"australiacentral":    "Zone 4",
-		"australiacentral2":   "Zone 4",
-		"australiasoutheast":  "Zone 4",
-		"jioindiacentral":     "Zone 5",
-		"koreasouth":          "Zone 2",
-		"southindia":          "Zone 5",
-		"westindia":           "Zone 5",
-		"canadaeast":          "Zone 1",
-		"francesouth":         "Zone 1",
-		"germanynorth":        "Zone 1",
-		"norwaywest":          "Zone 1",
-		"switzerlandwest":     "Zone 1",
-		"ukwest":              "Zone 1",
-		"uaecentral":          "Zone 1",
-		"brazilsoutheast":     "Zone 3",
-		"usgovvirginia":       "US Gov Zone 1",
-		"usgovarizona":        "US Gov Zone 1",
-		"usgovtexas":          "US Gov Zone 1",
-	}[region]
+	loc, ok := LookupLocation(region)
+	if !ok {
+		return region
+	}
+	return loc.CDNZone
 }
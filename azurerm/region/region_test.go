@@ -0,0 +1,110 @@
+package region_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/azurerm/region"
+)
+
+func TestGetLocationName(t *testing.T) {
+	testcases := []struct{ in, out string }{
+		{"", ""},
+		{"westeurope", "westeurope"},
+		{"West Europe", "westeurope"},
+		{"UK West", "ukwest"},
+		{"East US 2", "eastus2"},
+		{"East US 2 EUAP", "eastus2euap"},
+		{"Australia Central 2", "australiacentral2"},
+		{"Germany West Central", "germanywestcentral"},
+		{"Sweden Central", "swedencentral"},
+		{"UAE North", "uaenorth"},
+		{"Italy North", "italynorth"},
+		{"Poland Central", "polandcentral"},
+		{"Spain Central", "spaincentral"},
+		{"Mexico Central", "mexicocentral"},
+		{"New Zealand North", "newzealandnorth"},
+		{"Jio India West", "jioindiawest"},
+		{"US Gov Virginia", "usgovvirginia"},
+		{"Europe", "europe"},
+		{"francecentral", "francecentral"},
+		{"France Central", "francecentral"},
+		{"FranceCentral", "francecentral"},
+		{"france central", "francecentral"},
+		{"  FRANCE   CENTRAL ", "francecentral"},
+		{"West US 2 (Stage)", "westus2stage"},
+		{"Mars North", "Mars North"},
+		{"marsnorth", "marsnorth"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.out, region.GetLocationName(tc.in))
+		})
+	}
+}
+
+func TestGetRegionToVNETZone(t *testing.T) {
+	testcases := []struct{ in, out string }{
+		{"eastus", "Zone 1"},
+		{"westeurope", "Zone 1"},
+		{"swedencentral", "Zone 1"},
+		{"japaneast", "Zone 2"},
+		{"australiaeast", "Zone 2"},
+		{"newzealandnorth", "Zone 2"},
+		{"brazilsouth", "Zone 3"},
+		{"uaenorth", "Zone 3"},
+		{"southafricanorth", "Zone 3"},
+		{"usgovtexas", "US Gov Zone 1"},
+		{"marsnorth", "marsnorth"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.out, region.GetRegionToVNETZone(tc.in))
+		})
+	}
+}
+
+func TestGetRegionToCDNZone(t *testing.T) {
+	testcases := []struct{ in, out string }{
+		{"eastus", "Zone 1"},
+		{"westeurope", "Zone 1"},
+		{"eastasia", "Zone 2"},
+		{"brazilsouth", "Zone 3"},
+		{"australiaeast", "Zone 4"},
+		{"centralindia", "Zone 5"},
+		{"marsnorth", "marsnorth"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.out, region.GetRegionToCDNZone(tc.in))
+		})
+	}
+}
+
+func TestLocations(t *testing.T) {
+	names := make(map[string]struct{})
+	displayNames := make(map[string]struct{})
+	for _, l := range region.Locations() {
+		require.NotEmpty(t, l.Name)
+		assert.NotEmpty(t, l.DisplayName, l.Name)
+		assert.NotEmpty(t, l.VNETZone, l.Name)
+		assert.NotEmpty(t, l.CDNZone, l.Name)
+
+		assert.NotContains(t, names, l.Name)
+		assert.NotContains(t, displayNames, l.DisplayName)
+		names[l.Name] = struct{}{}
+		displayNames[l.DisplayName] = struct{}{}
+
+		byName, ok := region.LookupLocation(l.Name)
+		assert.True(t, ok, l.Name)
+		assert.Equal(t, l, byName)
+		byDisplayName, ok := region.LookupLocation(l.DisplayName)
+		assert.True(t, ok, l.DisplayName)
+		assert.Equal(t, l, byDisplayName)
+	}
+
+	_, ok := region.LookupLocation("Mars North")
+	assert.False(t, ok)
+}
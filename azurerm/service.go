@@ -11,26 +11,42 @@ type Service uint8
 
 // List of all the supported services
 const (
-	AzureBastion    Service = iota // Azure Bastion
-	AzureDNS        Service = iota // Azure DNS
-	NATGateway      Service = iota // NAT Gateway
-	Storage         Service = iota // Storage
-	VirtualMachines Service = iota // Virtual Machines
-	VirtualNetwork  Service = iota // Virtual Network
-	VPNGateway      Service = iota // VPN Gateway
+	ApplicationGateway         Service = iota // Application Gateway
+	AzureAppService            Service = iota // Azure App Service
+	AzureBastion               Service = iota // Azure Bastion
+	AzureContainerApps         Service = iota // Azure Container Apps
+	AzureCosmosDB              Service = iota // Azure Cosmos DB
+	AzureDNS                   Service = iota // Azure DNS
+	AzureDatabaseForMySQL      Service = iota // Azure Database for MySQL
+	AzureDatabaseForPostgreSQL Service = iota // Azure Database for PostgreSQL
+	ContainerRegistry          Service = iota // Container Registry
+	LoadBalancer               Service = iota // Load Balancer
+	NATGateway                 Service = iota // NAT Gateway
+	Storage                    Service = iota // Storage
+	VirtualMachines            Service = iota // Virtual Machines
+	VirtualNetwork             Service = iota // Virtual Network
+	VPNGateway                 Service = iota // VPN Gateway
 )
 
 var (
 	// The list of all services is https://azure.microsoft.com/en-us/services/, the left side is
 	// the Family and the main content is the Services
 	services = map[string]struct{}{
-		AzureBastion.String():    struct{}{},
-		AzureDNS.String():        struct{}{},
-		NATGateway.String():      struct{}{},
-		Storage.String():         struct{}{},
-		VirtualMachines.String(): struct{}{},
-		VPNGateway.String():      struct{}{},
-		VirtualNetwork.String():  struct{}{},
+		ApplicationGateway.String():         struct{}{},
+		AzureAppService.String():            struct{}{},
+		AzureBastion.String():               struct{}{},
+		AzureContainerApps.String():         struct{}{},
+		AzureCosmosDB.String():              struct{}{},
+		AzureDNS.String():                   struct{}{},
+		AzureDatabaseForMySQL.String():      struct{}{},
+		AzureDatabaseForPostgreSQL.String(): struct{}{},
+		ContainerRegistry.String():          struct{}{},
+		LoadBalancer.String():               struct{}{},
+		NATGateway.String():                 struct{}{},
+		Storage.String():                    struct{}{},
+		VirtualMachines.String():            struct{}{},
+		VPNGateway.String():                 struct{}{},
+		VirtualNetwork.String():             struct{}{},
 	}
 )
 
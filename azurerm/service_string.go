@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ServiceName = "Azure BastionAzure DNSNAT GatewayStorageVirtual MachinesVirtual NetworkVPN Gateway"
+const _ServiceName = "Application GatewayAzure App ServiceAzure BastionAzure Container AppsAzure Cosmos DBAzure DNSAzure Database for MySQLAzure Database for PostgreSQLContainer RegistryLoad BalancerNAT GatewayStorageVirtual MachinesVirtual NetworkVPN Gateway"
 
-var _ServiceIndex = [...]uint8{0, 13, 22, 33, 40, 56, 71, 82}
+var _ServiceIndex = [...]uint8{0, 19, 36, 49, 69, 84, 93, 117, 146, 164, 177, 188, 195, 211, 226, 237}
 
-const _ServiceLowerName = "azure bastionazure dnsnat gatewaystoragevirtual machinesvirtual networkvpn gateway"
+const _ServiceLowerName = "application gatewayazure app serviceazure bastionazure container appsazure cosmos dbazure dnsazure database for mysqlazure database for postgresqlcontainer registryload balancernat gatewaystoragevirtual machinesvirtual networkvpn gateway"
 
 func (i Service) String() string {
 	if i >= Service(len(_ServiceIndex)-1) {
@@ -24,42 +24,74 @@ func (i Service) String() string {
 // Re-run the stringer command to generate them again.
 func _ServiceNoOp() {
 	var x [1]struct{}
-	_ = x[AzureBastion-(0)]
-	_ = x[AzureDNS-(1)]
-	_ = x[NATGateway-(2)]
-	_ = x[Storage-(3)]
-	_ = x[VirtualMachines-(4)]
-	_ = x[VirtualNetwork-(5)]
-	_ = x[VPNGateway-(6)]
+	_ = x[ApplicationGateway-(0)]
+	_ = x[AzureAppService-(1)]
+	_ = x[AzureBastion-(2)]
+	_ = x[AzureContainerApps-(3)]
+	_ = x[AzureCosmosDB-(4)]
+	_ = x[AzureDNS-(5)]
+	_ = x[AzureDatabaseForMySQL-(6)]
+	_ = x[AzureDatabaseForPostgreSQL-(7)]
+	_ = x[ContainerRegistry-(8)]
+	_ = x[LoadBalancer-(9)]
+	_ = x[NATGateway-(10)]
+	_ = x[Storage-(11)]
+	_ = x[VirtualMachines-(12)]
+	_ = x[VirtualNetwork-(13)]
+	_ = x[VPNGateway-(14)]
 }
 
-var _ServiceValues = []Service{AzureBastion, AzureDNS, NATGateway, Storage, VirtualMachines, VirtualNetwork, VPNGateway}
+var _ServiceValues = []Service{ApplicationGateway, AzureAppService, AzureBastion, AzureContainerApps, AzureCosmosDB, AzureDNS, AzureDatabaseForMySQL, AzureDatabaseForPostgreSQL, ContainerRegistry, LoadBalancer, NATGateway, Storage, VirtualMachines, VirtualNetwork, VPNGateway}
 
 var _ServiceNameToValueMap = map[string]Service{
-	_ServiceName[0:13]:       AzureBastion,
-	_ServiceLowerName[0:13]:  AzureBastion,
-	_ServiceName[13:22]:      AzureDNS,
-	_ServiceLowerName[13:22]: AzureDNS,
-	_ServiceName[22:33]:      NATGateway,
-	_ServiceLowerName[22:33]: NATGateway,
-	_ServiceName[33:40]:      Storage,
-	_ServiceLowerName[33:40]: Storage,
-	_ServiceName[40:56]:      VirtualMachines,
-	_ServiceLowerName[40:56]: VirtualMachines,
-	_ServiceName[56:71]:      VirtualNetwork,
-	_ServiceLowerName[56:71]: VirtualNetwork,
-	_ServiceName[71:82]:      VPNGateway,
-	_ServiceLowerName[71:82]: VPNGateway,
+	_ServiceName[0:19]:         ApplicationGateway,
+	_ServiceLowerName[0:19]:    ApplicationGateway,
+	_ServiceName[19:36]:        AzureAppService,
+	_ServiceLowerName[19:36]:   AzureAppService,
+	_ServiceName[36:49]:        AzureBastion,
+	_ServiceLowerName[36:49]:   AzureBastion,
+	_ServiceName[49:69]:        AzureContainerApps,
+	_ServiceLowerName[49:69]:   AzureContainerApps,
+	_ServiceName[69:84]:        AzureCosmosDB,
+	_ServiceLowerName[69:84]:   AzureCosmosDB,
+	_ServiceName[84:93]:        AzureDNS,
+	_ServiceLowerName[84:93]:   AzureDNS,
+	_ServiceName[93:117]:       AzureDatabaseForMySQL,
+	_ServiceLowerName[93:117]:  AzureDatabaseForMySQL,
+	_ServiceName[117:146]:      AzureDatabaseForPostgreSQL,
+	_ServiceLowerName[117:146]: AzureDatabaseForPostgreSQL,
+	_ServiceName[146:164]:      ContainerRegistry,
+	_ServiceLowerName[146:164]: ContainerRegistry,
+	_ServiceName[164:177]:      LoadBalancer,
+	_ServiceLowerName[164:177]: LoadBalancer,
+	_ServiceName[177:188]:      NATGateway,
+	_ServiceLowerName[177:188]: NATGateway,
+	_ServiceName[188:195]:      Storage,
+	_ServiceLowerName[188:195]: Storage,
+	_ServiceName[195:211]:      VirtualMachines,
+	_ServiceLowerName[195:211]: VirtualMachines,
+	_ServiceName[211:226]:      VirtualNetwork,
+	_ServiceLowerName[211:226]: VirtualNetwork,
+	_ServiceName[226:237]:      VPNGateway,
+	_ServiceLowerName[226:237]: VPNGateway,
 }
 
 var _ServiceNames = []string{
-	_ServiceName[0:13],
-	_ServiceName[13:22],
-	_ServiceName[22:33],
-	_ServiceName[33:40],
-	_ServiceName[40:56],
-	_ServiceName[56:71],
-	_ServiceName[71:82],
+	_ServiceName[0:19],
+	_ServiceName[19:36],
+	_ServiceName[36:49],
+	_ServiceName[49:69],
+	_ServiceName[69:84],
+	_ServiceName[84:93],
+	_ServiceName[93:117],
+	_ServiceName[117:146],
+	_ServiceName[146:164],
+	_ServiceName[164:177],
+	_ServiceName[177:188],
+	_ServiceName[188:195],
+	_ServiceName[195:211],
+	_ServiceName[211:226],
+	_ServiceName[226:237],
 }
 
 // ServiceString retrieves an enum value from the enum constants string name.
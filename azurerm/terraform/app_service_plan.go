@@ -0,0 +1,71 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/cycloidio/terracost/terraform"
+)
+
+// appServicePlanValues is holds the terraform values of the deprecated
+// azurerm_app_service_plan that we need to estimate the price
+type appServicePlanValues struct {
+	Location string `mapstructure:"location"`
+	Kind     string `mapstructure:"kind"` // Windows, Linux, elastic, FunctionApp or App
+	Reserved bool   `mapstructure:"reserved"`
+
+	Sku []struct {
+		Tier     string `mapstructure:"tier"`
+		Size     string `mapstructure:"size"`
+		Capacity int64  `mapstructure:"capacity"`
+	} `mapstructure:"sku"`
+}
+
+// decodeAppServicePlanValues decodes and returns appServicePlanValues from a Terraform values map.
+func decodeAppServicePlanValues(tfVals map[string]interface{}) (appServicePlanValues, error) {
+	var v appServicePlanValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newAppServicePlan initializes a new ServicePlan from the azurerm_app_service_plan values
+func (p *Provider) newAppServicePlan(vals appServicePlanValues) *ServicePlan {
+	spv := servicePlanValues{
+		Location: vals.Location,
+		OSType:   "Windows",
+	}
+
+	if strings.EqualFold(vals.Kind, "Linux") || vals.Reserved {
+		spv.OSType = "Linux"
+	}
+
+	if len(vals.Sku) > 0 {
+		spv.SkuName = vals.Sku[0].Size
+		spv.WorkerCount = vals.Sku[0].Capacity
+	}
+
+	return p.newServicePlan(spv)
+}
+
+// appServicePlanExample returns the ResourceExample of the azurerm_app_service_plan on the location, see Provider.ResourceExamples
+func (p *Provider) appServicePlanExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_app_service_plan", map[string]interface{}{
+		"location": location,
+		"kind":     "Linux",
+		"reserved": true,
+		"sku":      []interface{}{map[string]interface{}{"tier": "Standard", "size": "S1", "capacity": 1}},
+	})
+}
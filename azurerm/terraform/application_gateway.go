@@ -0,0 +1,198 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available meterName and SkuName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=serviceName eq 'Application Gateway' and armRegionName eq 'westeurope'" | jq '.Items[] | {productName, skuName, meterName, unitOfMeasure}' | sort -u
+
+// capacityUnitsPerInstance is the number of capacity units reserved by each
+// instance of the fixed capacity or of the minimum autoscaling capacity (v2)
+var capacityUnitsPerInstance = decimal.NewFromInt(10)
+
+// applicationGatewayProductNames is the productName of the prices of each tier
+var applicationGatewayProductNames = map[string]string{
+	"Standard":    "Application Gateway Standard",
+	"WAF":         "Application Gateway WAF",
+	"Standard_v2": "Application Gateway Standard v2",
+	"WAF_v2":      "Application Gateway WAF v2",
+}
+
+// ApplicationGateway is the entity that holds the logic to calculate price
+// of the azurerm_application_gateway
+type ApplicationGateway struct {
+	provider *Provider
+	location string
+
+	// tier is Standard, WAF, Standard_v2 or WAF_v2
+	tier string
+
+	// size is the Small, Medium or Large size of the v1 tiers
+	size string
+
+	// instances is the number of instances of the v1 tiers
+	instances decimal.Decimal
+
+	// capacityUnits is the number of capacity units charged per hour (v2 tiers)
+	capacityUnits decimal.Decimal
+
+	// capacityUnitsUsage is set when the capacityUnits are the ones of the usage
+	capacityUnitsUsage bool
+}
+
+// applicationGatewayValues is holds the values that we need to be able
+// to calculate the price of the ApplicationGateway
+type applicationGatewayValues struct {
+	Location string `mapstructure:"location"`
+
+	SKU []struct {
+		Name     string  `mapstructure:"name"`
+		Tier     string  `mapstructure:"tier"`
+		Capacity float64 `mapstructure:"capacity"`
+	} `mapstructure:"sku"`
+
+	AutoscaleConfiguration []struct {
+		MinCapacity float64 `mapstructure:"min_capacity"`
+	} `mapstructure:"autoscale_configuration"`
+
+	Usage struct {
+		// CapacityUnits is the average number of capacity units consumed per hour (v2 tiers)
+		CapacityUnits float64 `mapstructure:"capacity_units"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeApplicationGatewayValues decodes and returns applicationGatewayValues from a Terraform values map.
+func decodeApplicationGatewayValues(tfVals map[string]interface{}) (applicationGatewayValues, error) {
+	var v applicationGatewayValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newApplicationGateway initializes a new ApplicationGateway from the provider
+func (p *Provider) newApplicationGateway(vals applicationGatewayValues) *ApplicationGateway {
+	inst := &ApplicationGateway{
+		provider: p,
+		location: p.locationName(vals.Location),
+		tier:     "Standard_v2",
+	}
+
+	var capacity float64
+	if len(vals.SKU) > 0 {
+		sku := vals.SKU[0]
+		capacity = sku.Capacity
+		if _, ok := applicationGatewayProductNames[sku.Tier]; ok {
+			inst.tier = sku.Tier
+		} else {
+			p.warnf("unknown sku tier %q, the gateway is estimated as Standard_v2", sku.Tier)
+		}
+		// The v1 sku names are the tier and the size (ex: Standard_Medium)
+		if !inst.isV2() {
+			inst.size = strings.TrimPrefix(sku.Name, inst.tier+"_")
+		}
+	}
+
+	if !inst.isV2() {
+		if inst.size != "Small" && inst.size != "Medium" && inst.size != "Large" {
+			p.warnf("unknown sku size %q, the gateway is estimated as Medium", inst.size)
+			inst.size = "Medium"
+		}
+		if capacity <= 0 {
+			capacity = 1
+		}
+		inst.instances = decimal.NewFromFloat(capacity)
+		return inst
+	}
+
+	// The fixed capacity or the minimum one of the autoscaling is reserved,
+	// the capacity units consumed over it are the ones of the usage
+	if len(vals.AutoscaleConfiguration) > 0 {
+		capacity = vals.AutoscaleConfiguration[0].MinCapacity
+	}
+	inst.capacityUnits = decimal.NewFromFloat(capacity).Mul(capacityUnitsPerInstance)
+	if cu := decimal.NewFromFloat(vals.Usage.CapacityUnits); cu.GreaterThan(inst.capacityUnits) {
+		inst.capacityUnits = cu
+		inst.capacityUnitsUsage = true
+	}
+
+	return inst
+}
+
+// isV2 returns true if the tier is a v2 one, charged by capacity units
+func (inst *ApplicationGateway) isV2() bool {
+	return strings.HasSuffix(inst.tier, "_v2")
+}
+
+// Components returns the price component queries that make up this Instance.
+// The v2 tiers are charged by a fixed price per hour and by the capacity units per hour, the
+// WAF_v2 ones at the WAF price, and the v1 tiers per hour for each instance of their size.
+func (inst *ApplicationGateway) Components() []query.Component {
+	if !inst.isV2() {
+		return []query.Component{
+			inst.applicationGatewayComponent("Gateway hours", []string{inst.tier, inst.size}, inst.instances, false, inst.size, fmt.Sprintf("%s Gateway", inst.size)),
+		}
+	}
+
+	return []query.Component{
+		inst.applicationGatewayComponent("Gateway hours", []string{inst.tier}, decimal.NewFromInt(1), false, "Standard", "Standard Fixed Cost"),
+		inst.applicationGatewayComponent("Capacity units", []string{inst.tier}, inst.capacityUnits, inst.capacityUnitsUsage, "Standard", "Standard Capacity Units"),
+	}
+}
+
+func (inst *ApplicationGateway) applicationGatewayComponent(name string, details []string, quantity decimal.Decimal, usage bool, skuName, meterName string) query.Component {
+	return query.Component{
+		Name:           name,
+		Details:        details,
+		HourlyQuantity: quantity,
+		Usage:          usage,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr("Application Gateway"),
+			Family:   util.StringPtr("Networking"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr(applicationGatewayProductNames[inst.tier])},
+				{Key: "skuName", Value: util.StringPtr(skuName)},
+				{Key: "meterName", Value: util.StringPtr(meterName)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+// applicationGatewayExample returns the ResourceExample of the azurerm_application_gateway on the location, see Provider.ResourceExamples
+func (p *Provider) applicationGatewayExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_application_gateway", map[string]interface{}{
+		"location": location,
+		"sku": []interface{}{map[string]interface{}{
+			"name":     "Standard_v2",
+			"tier":     "Standard_v2",
+			"capacity": 2,
+		}},
+	})
+}
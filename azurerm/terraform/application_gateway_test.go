@@ -0,0 +1,100 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestApplicationGateway_Components(t *testing.T) {
+	p, err := NewProvider("azurerm")
+	require.NoError(t, err)
+
+	component := func(name string, details []string, quantity int64, usage bool, productName, skuName, meterName string) query.Component {
+		return query.Component{
+			Name:           name,
+			Details:        details,
+			HourlyQuantity: decimal.NewFromInt(quantity),
+			Usage:          usage,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("azurerm"),
+				Service:  util.StringPtr("Application Gateway"),
+				Family:   util.StringPtr("Networking"),
+				Location: util.StringPtr("westeurope"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "productName", Value: util.StringPtr(productName)},
+					{Key: "skuName", Value: util.StringPtr(skuName)},
+					{Key: "meterName", Value: util.StringPtr(meterName)},
+				},
+			},
+			PriceFilter: &price.Filter{
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "type", Value: util.StringPtr("Consumption")},
+				},
+			},
+		}
+	}
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		values["location"] = "westeurope"
+		return terraform.Resource{
+			Address:      "azurerm_application_gateway.test",
+			Type:         "azurerm_application_gateway",
+			Name:         "test",
+			ProviderName: "azurerm",
+			Values:       values,
+		}
+	}
+
+	t.Run("StandardV2", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{
+			"sku": []interface{}{map[string]interface{}{"name": "Standard_v2", "tier": "Standard_v2", "capacity": 2}},
+		}))
+		assert.Equal(t, []query.Component{
+			component("Gateway hours", []string{"Standard_v2"}, 1, false, "Application Gateway Standard v2", "Standard", "Standard Fixed Cost"),
+			component("Capacity units", []string{"Standard_v2"}, 20, false, "Application Gateway Standard v2", "Standard", "Standard Capacity Units"),
+		}, actual)
+	})
+
+	t.Run("WAFV2Autoscaling", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{
+			"sku":                     []interface{}{map[string]interface{}{"name": "WAF_v2", "tier": "WAF_v2"}},
+			"autoscale_configuration": []interface{}{map[string]interface{}{"min_capacity": 0, "max_capacity": 10}},
+			usage.Key:                 map[string]interface{}{"capacity_units": 15},
+		}))
+		assert.Equal(t, []query.Component{
+			component("Gateway hours", []string{"WAF_v2"}, 1, false, "Application Gateway WAF v2", "Standard", "Standard Fixed Cost"),
+			component("Capacity units", []string{"WAF_v2"}, 15, true, "Application Gateway WAF v2", "Standard", "Standard Capacity Units"),
+		}, actual)
+	})
+
+	t.Run("MinCapacityOverUsage", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{
+			"sku":                     []interface{}{map[string]interface{}{"name": "Standard_v2", "tier": "Standard_v2"}},
+			"autoscale_configuration": []interface{}{map[string]interface{}{"min_capacity": 3}},
+			usage.Key:                 map[string]interface{}{"capacity_units": 15},
+		}))
+		require.Len(t, actual, 2)
+		assert.Equal(t, decimal.NewFromInt(30), actual[1].HourlyQuantity)
+		assert.False(t, actual[1].Usage)
+	})
+
+	t.Run("V1", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(nil, resource(map[string]interface{}{
+			"sku": []interface{}{map[string]interface{}{"name": "WAF_Large", "tier": "WAF", "capacity": 2}},
+		}))
+		assert.Equal(t, []query.Component{
+			component("Gateway hours", []string{"WAF", "Large"}, 2, false, "Application Gateway WAF", "Large", "Large Gateway"),
+		}, actual)
+		assert.Empty(t, warnings)
+	})
+}
@@ -3,10 +3,10 @@ package terraform
 import (
 	"fmt"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -58,7 +58,7 @@ func decodeBastionHostValues(tfVals map[string]interface{}) (bastionHostValues,
 func (p *Provider) newBastionHost(vals bastionHostValues) *BastionHost {
 	inst := &BastionHost{
 		provider: p,
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		sku:      "Basic",
 
 		// From Usage
@@ -127,3 +127,11 @@ func (inst *BastionHost) bastionHostOutboundDataTransferComponent(key string, lo
 		},
 	}
 }
+
+// bastionHostExample returns the ResourceExample of the azurerm_bastion_host on the location, see Provider.ResourceExamples
+func (p *Provider) bastionHostExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_bastion_host", map[string]interface{}{
+		"location": location,
+		"sku":      "Basic",
+	})
+}
@@ -0,0 +1,188 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available meterName and SkuName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=serviceName eq 'Azure Container Apps' and armRegionName eq 'westeurope'" | jq '.Items[] | {productName, skuName, meterName, unitOfMeasure, tierMinimumUnits}' | sort -u
+
+// secondsPerHour is used to convert the active hours to the billed seconds
+var secondsPerHour = decimal.NewFromInt(3600)
+
+// ContainerApp is the entity that holds the logic to calculate price
+// of the azurerm_container_app on the Consumption plan
+type ContainerApp struct {
+	provider *Provider
+	location string
+
+	// cpu and memoryGiB are the resources of all the containers of a replica
+	cpu       decimal.Decimal
+	memoryGiB decimal.Decimal
+
+	// Usage
+	averageReplicas    decimal.Decimal
+	monthlyActiveHours decimal.Decimal
+
+	// confidence is set when the replicas are not known and 1 is assumed
+	confidence query.Confidence
+}
+
+// containerAppValues is holds the values that we need to be able
+// to calculate the price of the ContainerApp
+type containerAppValues struct {
+	ContainerAppEnvironmentID string `mapstructure:"container_app_environment_id"`
+
+	Template []struct {
+		MinReplicas float64 `mapstructure:"min_replicas"`
+
+		Container []struct {
+			CPU    float64 `mapstructure:"cpu"`
+			Memory string  `mapstructure:"memory"`
+		} `mapstructure:"container"`
+	} `mapstructure:"template"`
+
+	Usage struct {
+		AverageReplicas    float64 `mapstructure:"average_replicas"`
+		MonthlyActiveHours float64 `mapstructure:"monthly_active_hours"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeContainerAppValues decodes and returns containerAppValues from a Terraform values map.
+func decodeContainerAppValues(tfVals map[string]interface{}) (containerAppValues, error) {
+	var v containerAppValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newContainerApp initializes a new ContainerApp from the provider, the azurerm_container_app
+// has no location so it's the one of the azurerm_container_app_environment of the rss
+func (p *Provider) newContainerApp(rss map[string]terraform.Resource, vals containerAppValues) *ContainerApp {
+	inst := &ContainerApp{
+		provider: p,
+
+		// From Usage
+		averageReplicas:    decimal.NewFromFloat(vals.Usage.AverageReplicas),
+		monthlyActiveHours: decimal.NewFromFloat(vals.Usage.MonthlyActiveHours),
+	}
+
+	for _, rs := range rss {
+		if rs.Type != "azurerm_container_app_environment" {
+			continue
+		}
+		// The environment ID is the real one or the reference to the environment
+		envID := vals.ContainerAppEnvironmentID
+		if id, _ := rs.Values["id"].(string); (id != "" && id == envID) || strings.HasSuffix("."+envID, "."+rs.Address+".id") {
+			loc, _ := rs.Values["location"].(string)
+			inst.location = p.locationName(loc)
+			break
+		}
+	}
+	if inst.location == "" {
+		p.warnf("the container_app_environment_id %q is not found, the location is unknown", vals.ContainerAppEnvironmentID)
+	}
+
+	if len(vals.Template) > 0 {
+		tpl := vals.Template[0]
+		for _, c := range tpl.Container {
+			inst.cpu = inst.cpu.Add(decimal.NewFromFloat(c.CPU))
+			mem, err := decimal.NewFromString(strings.TrimSuffix(c.Memory, "Gi"))
+			if err != nil {
+				p.warnf("invalid container memory %q, it's not estimated", c.Memory)
+				continue
+			}
+			inst.memoryGiB = inst.memoryGiB.Add(mem)
+		}
+
+		if !inst.averageReplicas.IsPositive() {
+			inst.averageReplicas = decimal.NewFromFloat(tpl.MinReplicas)
+		}
+	}
+
+	if !inst.averageReplicas.IsPositive() {
+		p.warnf("no average_replicas usage nor min_replicas, the app is estimated with 1 replica")
+		inst.averageReplicas = decimal.NewFromInt(1)
+		inst.confidence = query.DefaultedUsage
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this Instance.
+// The replicas are charged by the vCPU-seconds and GiB-seconds they are active, the
+// free monthly grant is by subscription so only the paid tier of the prices is used.
+func (inst *ContainerApp) Components() []query.Component {
+	seconds := inst.averageReplicas.Mul(inst.monthlyActiveHours).Mul(secondsPerHour)
+
+	return []query.Component{
+		inst.activeUsageComponent("vCPU", "Standard vCPU Active Usage", inst.cpu.Mul(seconds), "vCPU-seconds"),
+		inst.activeUsageComponent("Memory", "Standard Memory Active Usage", inst.memoryGiB.Mul(seconds), "GiB-seconds"),
+	}
+}
+
+func (inst *ContainerApp) activeUsageComponent(name, meterName string, quantity decimal.Decimal, unit string) query.Component {
+	return query.Component{
+		Name:            name,
+		Details:         []string{inst.averageReplicas.String() + " replicas"},
+		MonthlyQuantity: quantity,
+		Unit:            unit,
+		Usage:           true,
+		Confidence:      inst.confidence,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr("Azure Container Apps"),
+			Family:   util.StringPtr("Containers"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr("Azure Container Apps")},
+				{Key: "meterName", Value: util.StringPtr(meterName)},
+				// The first tier is the free grant
+				{Key: "tierMinimumUnits", ValueRegex: util.StringPtr("^[1-9]")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+// containerAppEnvironmentResource returns the azurerm_container_app_environment 'example' on the location, referenced by the other examples
+func (p *Provider) containerAppEnvironmentResource(location string) terraform.Resource {
+	return p.resource("azurerm_container_app_environment", map[string]interface{}{
+		"location": location,
+	})
+}
+
+// containerAppExample returns the ResourceExample of the azurerm_container_app on the location, see Provider.ResourceExamples
+func (p *Provider) containerAppExample(location string) terraform.ResourceExample {
+	cae := p.containerAppEnvironmentResource(location)
+	return p.example("azurerm_container_app", map[string]interface{}{
+		"container_app_environment_id": cae.Address + ".id",
+		"template": []interface{}{map[string]interface{}{
+			"min_replicas": float64(1),
+			"container":    []interface{}{map[string]interface{}{"cpu": 0.5, "memory": "1Gi"}},
+		}},
+	}, cae)
+}
@@ -0,0 +1,56 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+)
+
+func TestContainerApp_Components(t *testing.T) {
+	p, err := NewProvider("azurerm")
+	require.NoError(t, err)
+
+	cae := p.containerAppEnvironmentResource("westeurope")
+	rss := map[string]terraform.Resource{cae.Address: cae}
+	tfres := func(vals map[string]interface{}) terraform.Resource {
+		vals["container_app_environment_id"] = cae.Address + ".id"
+		vals["template"] = []interface{}{map[string]interface{}{
+			"container": []interface{}{map[string]interface{}{"cpu": 0.5, "memory": "1Gi"}},
+		}}
+		return terraform.Resource{
+			Address:      "azurerm_container_app.test",
+			Type:         "azurerm_container_app",
+			Name:         "test",
+			ProviderName: "azurerm",
+			Values:       vals,
+		}
+	}
+
+	t.Run("Usage", func(t *testing.T) {
+		actual := p.ResourceComponents(rss, tfres(map[string]interface{}{
+			usage.Key: map[string]interface{}{"average_replicas": 2, "monthly_active_hours": 730},
+		}))
+		require.Len(t, actual, 2)
+		for _, c := range actual {
+			assert.Equal(t, "westeurope", *c.ProductFilter.Location)
+			assert.Equal(t, []string{"2 replicas"}, c.Details)
+			// The confidence is the one of the usage, see cost.NewState
+			assert.Empty(t, c.Confidence)
+		}
+	})
+
+	t.Run("DefaultedReplicas", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(rss, tfres(map[string]interface{}{}))
+		require.Len(t, actual, 2)
+		for _, c := range actual {
+			assert.Equal(t, []string{"1 replicas"}, c.Details)
+			assert.Equal(t, query.DefaultedUsage, c.Confidence)
+		}
+		assert.Equal(t, []string{"no average_replicas usage nor min_replicas, the app is estimated with 1 replica"}, warnings)
+	})
+}
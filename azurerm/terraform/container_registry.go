@@ -0,0 +1,169 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available meterName and SkuName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=serviceName eq 'Container Registry' and armRegionName eq 'westeurope'" | jq '.Items[] | {productName, skuName, meterName, unitOfMeasure}' | sort -u
+
+// containerRegistryIncludedStorageGB is the storage included on the daily price of each SKU
+var containerRegistryIncludedStorageGB = map[string]decimal.Decimal{
+	"Basic":    decimal.NewFromInt(10),
+	"Standard": decimal.NewFromInt(100),
+	"Premium":  decimal.NewFromInt(500),
+}
+
+// ContainerRegistry is the entity that holds the logic to calculate price
+// of the azurerm_container_registry
+type ContainerRegistry struct {
+	provider *Provider
+	location string
+
+	sku string
+
+	// replicaLocations are the locations of the geo-replications (Premium only),
+	// each of them is charged as an extra Premium registry
+	replicaLocations []string
+
+	// Usage
+	storageGB decimal.Decimal
+}
+
+// containerRegistryValues is holds the values that we need to be able
+// to calculate the price of the ContainerRegistry
+type containerRegistryValues struct {
+	Location string `mapstructure:"location"`
+	SKU      string `mapstructure:"sku"`
+
+	Georeplications []struct {
+		Location string `mapstructure:"location"`
+	} `mapstructure:"georeplications"`
+
+	Usage struct {
+		StorageGB float64 `mapstructure:"storage_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeContainerRegistryValues decodes and returns containerRegistryValues from a Terraform values map.
+func decodeContainerRegistryValues(tfVals map[string]interface{}) (containerRegistryValues, error) {
+	var v containerRegistryValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newContainerRegistry initializes a new ContainerRegistry from the provider
+func (p *Provider) newContainerRegistry(vals containerRegistryValues) *ContainerRegistry {
+	inst := &ContainerRegistry{
+		provider: p,
+		location: p.locationName(vals.Location),
+		sku:      vals.SKU,
+
+		// From Usage
+		storageGB: decimal.NewFromFloat(vals.Usage.StorageGB),
+	}
+
+	if _, ok := containerRegistryIncludedStorageGB[inst.sku]; !ok {
+		p.warnf("unknown sku %q, the registry is estimated as Basic", vals.SKU)
+		inst.sku = "Basic"
+	}
+
+	for _, gr := range vals.Georeplications {
+		inst.replicaLocations = append(inst.replicaLocations, p.locationName(gr.Location))
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this Instance.
+// The registries are charged by day, the storage above the one included on the SKU is
+// charged by GB and each geo-replication is charged as a Premium registry on its location.
+func (inst *ContainerRegistry) Components() []query.Component {
+	components := []query.Component{inst.registryComponent(fmt.Sprintf("Registry (%s)", inst.sku), inst.location, inst.sku)}
+
+	if overage := inst.storageGB.Sub(containerRegistryIncludedStorageGB[inst.sku]); overage.IsPositive() {
+		components = append(components, query.Component{
+			Name:            "Storage",
+			Details:         []string{"above the included storage"},
+			MonthlyQuantity: overage,
+			Unit:            "GB",
+			Usage:           true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(inst.provider.key),
+				Service:  util.StringPtr("Container Registry"),
+				Family:   util.StringPtr("Containers"),
+				Location: util.StringPtr(inst.location),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "productName", Value: util.StringPtr("Container Registry")},
+					{Key: "meterName", Value: util.StringPtr("Data Stored")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("1 GB/Month"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "type", Value: util.StringPtr("Consumption")},
+				},
+			},
+		})
+	}
+
+	for _, loc := range inst.replicaLocations {
+		components = append(components, inst.registryComponent(fmt.Sprintf("Geo-replication (%s)", loc), loc, "Premium"))
+	}
+
+	return components
+}
+
+// registryComponent returns the daily charge of a registry of the sku on the location,
+// the price is by day so the hourly quantity is the fraction of the day
+func (inst *ContainerRegistry) registryComponent(name, location, sku string) query.Component {
+	return query.Component{
+		Name:           name,
+		HourlyQuantity: decimal.NewFromInt(1).Div(decimal.NewFromInt(24)),
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr("Container Registry"),
+			Family:   util.StringPtr("Containers"),
+			Location: util.StringPtr(location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr("Container Registry")},
+				{Key: "skuName", Value: util.StringPtr(sku)},
+				{Key: "meterName", Value: util.StringPtr(sku + " Registry Unit")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1/Day"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+// containerRegistryExample returns the ResourceExample of the azurerm_container_registry on the location, see Provider.ResourceExamples
+func (p *Provider) containerRegistryExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_container_registry", map[string]interface{}{
+		"location": location,
+		"sku":      "Standard",
+	})
+}
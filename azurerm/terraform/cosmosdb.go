@@ -0,0 +1,252 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available meterName and SkuName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=serviceName eq 'Azure Cosmos DB' and armRegionName eq 'westeurope'" | jq '.Items[] | {productName, skuName, meterName, unitOfMeasure}' | sort -u
+
+// cosmosDBRUBlock is the number of RU/s of each unit of the throughput price
+var cosmosDBRUBlock = decimal.NewFromInt(100)
+
+// CosmosDBAccount is the entity that holds the logic to calculate price
+// of the azurerm_cosmosdb_account, the throughput is the one of the
+// azurerm_cosmosdb_sql_database and azurerm_cosmosdb_sql_container
+type CosmosDBAccount struct {
+	provider *Provider
+
+	location string
+
+	// regions is the number of geo locations of the account, the storage and
+	// throughput are charged on each of them (with single or multi-region writes)
+	regions decimal.Decimal
+
+	// Usage
+	storageGB decimal.Decimal
+}
+
+// CosmosDBThroughput is the entity that holds the logic to calculate price
+// of the throughput of azurerm_cosmosdb_sql_database and azurerm_cosmosdb_sql_container
+type CosmosDBThroughput struct {
+	account *CosmosDBAccount
+
+	// throughput is the provisioned RU/s or the autoscale max RU/s
+	throughput decimal.Decimal
+	autoscale  bool
+}
+
+// cosmosDBAccountValues is holds the terraform values that we need to estimate the price
+type cosmosDBAccountValues struct {
+	Name     string `mapstructure:"name"`
+	Location string `mapstructure:"location"`
+
+	GeoLocation []struct {
+		Location string `mapstructure:"location"`
+	} `mapstructure:"geo_location"`
+
+	Usage struct {
+		StorageGB float64 `mapstructure:"storage_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+// cosmosDBThroughputValues is holds the terraform values of the azurerm_cosmosdb_sql_database
+// and azurerm_cosmosdb_sql_container that we need to estimate the price
+type cosmosDBThroughputValues struct {
+	AccountName string  `mapstructure:"account_name"`
+	Throughput  float64 `mapstructure:"throughput"`
+
+	AutoscaleSettings []struct {
+		MaxThroughput float64 `mapstructure:"max_throughput"`
+	} `mapstructure:"autoscale_settings"`
+}
+
+// decodeCosmosDBAccountValues decodes and returns cosmosDBAccountValues from a Terraform values map.
+func decodeCosmosDBAccountValues(tfVals map[string]interface{}) (cosmosDBAccountValues, error) {
+	var v cosmosDBAccountValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// decodeCosmosDBThroughputValues decodes and returns cosmosDBThroughputValues from a Terraform values map.
+func decodeCosmosDBThroughputValues(tfVals map[string]interface{}) (cosmosDBThroughputValues, error) {
+	var v cosmosDBThroughputValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newCosmosDBAccount initializes a new CosmosDBAccount from the provider
+func (p *Provider) newCosmosDBAccount(vals cosmosDBAccountValues) *CosmosDBAccount {
+	inst := &CosmosDBAccount{
+		provider: p,
+
+		location: p.locationName(vals.Location),
+		regions:  decimal.NewFromInt(1),
+		// From Usage
+		storageGB: decimal.NewFromFloat(vals.Usage.StorageGB),
+	}
+
+	if len(vals.GeoLocation) > 0 {
+		// The first geo location is the write region,
+		// the account location is the one of the metadata
+		inst.location = p.locationName(vals.GeoLocation[0].Location)
+		inst.regions = decimal.NewFromInt(int64(len(vals.GeoLocation)))
+	}
+
+	return inst
+}
+
+// newCosmosDBThroughput initializes a new CosmosDBThroughput from the provider, the account
+// is the azurerm_cosmosdb_account of the rss with the name of the AccountName
+func (p *Provider) newCosmosDBThroughput(rss map[string]terraform.Resource, vals cosmosDBThroughputValues) *CosmosDBThroughput {
+	inst := &CosmosDBThroughput{
+		throughput: decimal.NewFromFloat(vals.Throughput),
+	}
+
+	if len(vals.AutoscaleSettings) > 0 && vals.AutoscaleSettings[0].MaxThroughput > 0 {
+		inst.throughput = decimal.NewFromFloat(vals.AutoscaleSettings[0].MaxThroughput)
+		inst.autoscale = true
+	}
+
+	for _, rs := range rss {
+		if rs.Type != "azurerm_cosmosdb_account" {
+			continue
+		}
+		av, err := decodeCosmosDBAccountValues(rs.Values)
+		if err != nil {
+			continue
+		}
+		if av.Name == vals.AccountName || rs.Name == vals.AccountName || strings.HasSuffix(vals.AccountName, "."+rs.Address+".name") {
+			inst.account = p.newCosmosDBAccount(av)
+			break
+		}
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this Instance.
+func (inst *CosmosDBAccount) Components() []query.Component {
+	return []query.Component{
+		{
+			Name:            "Storage",
+			MonthlyQuantity: inst.storageGB.Mul(inst.regions),
+			Unit:            "GB",
+			Usage:           true,
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(inst.provider.key),
+				Service:  util.StringPtr("Azure Cosmos DB"),
+				Family:   util.StringPtr("Databases"),
+				Location: util.StringPtr(inst.location),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "productName", Value: util.StringPtr("Azure Cosmos DB")},
+					{Key: "skuName", Value: util.StringPtr("Standard")},
+					{Key: "meterName", Value: util.StringPtr("Data Stored")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("1 GB/Month"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "type", Value: util.StringPtr("Consumption")},
+				},
+			},
+		},
+	}
+}
+
+// Components returns the price component queries that make up this Instance.
+// The throughput is charged by blocks of 100 RU/s on each region of the account,
+// the autoscale is estimated at its max throughput.
+func (inst *CosmosDBThroughput) Components() []query.Component {
+	// Without the account the location is unknown, and without
+	// throughput it's shared or serverless so there is no cost
+	if inst.account == nil || !inst.throughput.IsPositive() {
+		return []query.Component{}
+	}
+
+	name, productName := "Provisioned throughput", "Azure Cosmos DB"
+	if inst.autoscale {
+		name, productName = "Autoscale throughput", "Azure Cosmos DB autoscale"
+	}
+
+	return []query.Component{
+		{
+			Name:           name,
+			HourlyQuantity: inst.throughput.Div(cosmosDBRUBlock).Mul(inst.account.regions),
+			Details:        []string{inst.throughput.String() + " RU/s"},
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr(inst.account.provider.key),
+				Service:  util.StringPtr("Azure Cosmos DB"),
+				Family:   util.StringPtr("Databases"),
+				Location: util.StringPtr(inst.account.location),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "productName", Value: util.StringPtr(productName)},
+					{Key: "meterName", Value: util.StringPtr("100 RU/s")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("1 Hour"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "type", Value: util.StringPtr("Consumption")},
+				},
+			},
+		},
+	}
+}
+
+// cosmosDBAccountResource returns the azurerm_cosmosdb_account 'example' on the location, referenced by the other examples
+func (p *Provider) cosmosDBAccountResource(location string) terraform.Resource {
+	return p.resource("azurerm_cosmosdb_account", map[string]interface{}{
+		"name":     "example",
+		"location": location,
+	})
+}
+
+// cosmosDBExamples returns the ResourceExamples of the azurerm_cosmosdb_account and of
+// its azurerm_cosmosdb_sql_database and azurerm_cosmosdb_sql_container on the location, see Provider.ResourceExamples
+func (p *Provider) cosmosDBExamples(location string) []terraform.ResourceExample {
+	cosmosdb := p.cosmosDBAccountResource(location)
+	return []terraform.ResourceExample{
+		{Resource: cosmosdb},
+		p.example("azurerm_cosmosdb_sql_database", map[string]interface{}{
+			"account_name": cosmosdb.Values["name"],
+			"throughput":   float64(400),
+		}, cosmosdb),
+		p.example("azurerm_cosmosdb_sql_container", map[string]interface{}{
+			"account_name":       cosmosdb.Values["name"],
+			"autoscale_settings": []interface{}{map[string]interface{}{"max_throughput": float64(4000)}},
+		}, cosmosdb),
+	}
+}
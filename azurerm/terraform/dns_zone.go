@@ -47,8 +47,16 @@ func (p *Provider) newDNSZone(rss map[string]terraform.Resource, vals dnsZoneVal
 
 	// Get the location from RG
 	if rg.Location != "" {
-		inst.location = region.GetRegionToVNETZone(region.GetLocationName(rg.Location))
+		inst.location = region.GetRegionToVNETZone(p.locationName(rg.Location))
 	}
 
 	return inst
 }
+
+// dnsZoneExample returns the ResourceExample of the azurerm_dns_zone on the location, see Provider.ResourceExamples
+func (p *Provider) dnsZoneExample(location string) terraform.ResourceExample {
+	rg := p.resourceGroupResource(location)
+	return p.example("azurerm_dns_zone", map[string]interface{}{
+		"resource_group_name": rg.Address,
+	}, rg)
+}
@@ -0,0 +1,281 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available productName, skuName and meterName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=serviceName eq 'Azure Database for PostgreSQL'" | jq '.Items[] | {productName, skuName, meterName}' | sort -u
+
+const (
+	// flexibleServerPostgreSQL is the engine of the azurerm_postgresql_flexible_server
+	flexibleServerPostgreSQL = "PostgreSQL"
+	// flexibleServerMySQL is the engine of the azurerm_mysql_flexible_server
+	flexibleServerMySQL = "MySQL"
+)
+
+var (
+	// flexibleServerSkuRe matches the sku_name (ex: B_Standard_B1ms, GP_Standard_D4s_v3)
+	// with the tier, the size and the version
+	flexibleServerSkuRe = regexp.MustCompile(`^([A-Z]+)_Standard_([A-Za-z0-9]+?)(?:_(v\d+))?$`)
+
+	// flexibleServerCoresRe matches the vCores on the size (ex: D4s -> 4)
+	flexibleServerCoresRe = regexp.MustCompile(`\d+`)
+
+	// flexibleServerTiers are the tier names used on the productName
+	// from the sku_name tier
+	flexibleServerTiers = map[string]string{
+		"B":  "Burstable",
+		"GP": "General Purpose",
+		"MO": "Memory Optimized",
+	}
+
+	// flexibleServerStorageProductNames are the productName of the storage of each engine
+	flexibleServerStorageProductNames = map[string]string{
+		flexibleServerPostgreSQL: "Az DB for PostgreSQL Flexible Server Storage",
+		flexibleServerMySQL:      "Azure Database for MySQL Flexible Server Storage",
+	}
+
+	// flexibleServerBackupProductNames are the productName of the backup storage of each engine
+	flexibleServerBackupProductNames = map[string]string{
+		flexibleServerPostgreSQL: "Az DB for PostgreSQL Flexible Server Backup Storage",
+		flexibleServerMySQL:      "Azure Database for MySQL Flexible Server Backup Storage",
+	}
+)
+
+// FlexibleServer is the entity that holds the logic to calculate price
+// of the azurerm_postgresql_flexible_server and azurerm_mysql_flexible_server
+type FlexibleServer struct {
+	provider *Provider
+
+	engine              string
+	location            string
+	skuName             string
+	storageGB           decimal.Decimal
+	geoRedundantBackups bool
+
+	// Usage
+	additionalBackupStorageGB decimal.Decimal
+}
+
+// flexibleServerValues is holds the terraform values that we need to estimate the price
+type flexibleServerValues struct {
+	Location                  string `mapstructure:"location"`
+	SkuName                   string `mapstructure:"sku_name"`
+	GeoRedundantBackupEnabled bool   `mapstructure:"geo_redundant_backup_enabled"`
+
+	// StorageMB is the storage of the azurerm_postgresql_flexible_server
+	StorageMB int64 `mapstructure:"storage_mb"`
+
+	// Storage is the storage of the azurerm_mysql_flexible_server
+	Storage []struct {
+		SizeGB int64 `mapstructure:"size_gb"`
+	} `mapstructure:"storage"`
+
+	Usage struct {
+		AdditionalBackupStorageGB float64 `mapstructure:"additional_backup_storage_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+// decodeFlexibleServerValues decodes and returns flexibleServerValues from a Terraform values map.
+func decodeFlexibleServerValues(tfVals map[string]interface{}) (flexibleServerValues, error) {
+	var v flexibleServerValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newFlexibleServer initializes a new FlexibleServer of the engine from the provider
+func (p *Provider) newFlexibleServer(engine string, vals flexibleServerValues) *FlexibleServer {
+	inst := &FlexibleServer{
+		provider: p,
+
+		engine:              engine,
+		location:            p.locationName(vals.Location),
+		skuName:             vals.SkuName,
+		geoRedundantBackups: vals.GeoRedundantBackupEnabled,
+
+		// From Usage
+		additionalBackupStorageGB: decimal.NewFromFloat(vals.Usage.AdditionalBackupStorageGB),
+	}
+
+	// Defaults from the terraform provider
+	if engine == flexibleServerPostgreSQL {
+		inst.storageGB = decimal.NewFromInt(32768).Div(decimal.NewFromInt(1024))
+		if vals.StorageMB > 0 {
+			inst.storageGB = decimal.NewFromInt(vals.StorageMB).Div(decimal.NewFromInt(1024))
+		}
+	} else {
+		inst.storageGB = decimal.NewFromInt(20)
+		if len(vals.Storage) > 0 && vals.Storage[0].SizeGB > 0 {
+			inst.storageGB = decimal.NewFromInt(vals.Storage[0].SizeGB)
+		}
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this FlexibleServer.
+func (inst *FlexibleServer) Components() []query.Component {
+	components := make([]query.Component, 0, 3)
+
+	if c, ok := inst.computeComponent(); ok {
+		components = append(components, c)
+	}
+
+	components = append(components, inst.storageComponent())
+
+	if inst.additionalBackupStorageGB.IsPositive() {
+		components = append(components, inst.backupStorageComponent())
+	}
+
+	return components
+}
+
+func (inst *FlexibleServer) service() string {
+	return fmt.Sprintf("Azure Database for %s", inst.engine)
+}
+
+// computeComponent returns the compute of the server, it's false if
+// the sku_name is not supported
+func (inst *FlexibleServer) computeComponent() (query.Component, bool) {
+	m := flexibleServerSkuRe.FindStringSubmatch(inst.skuName)
+	if m == nil {
+		return query.Component{}, false
+	}
+
+	tier, ok := flexibleServerTiers[m[1]]
+	if !ok {
+		return query.Component{}, false
+	}
+
+	// The Burstable are priced by size (ex: B1MS) and the others
+	// by number of vCores of the series (ex: 4 vCore of the Dsv3)
+	var series, skuName, meterName string
+	if m[1] == "B" {
+		series = "BS"
+		skuName = strings.ToUpper(m[2])
+		meterName = skuName
+	} else {
+		cores := flexibleServerCoresRe.FindString(m[2])
+		if cores == "" {
+			return query.Component{}, false
+		}
+		series = flexibleServerCoresRe.ReplaceAllString(m[2], "") + m[3]
+		skuName = fmt.Sprintf("%s vCore", cores)
+		meterName = "vCore"
+	}
+
+	return query.Component{
+		Name:           fmt.Sprintf("Compute (%s)", inst.skuName),
+		HourlyQuantity: decimal.NewFromInt(1),
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr(inst.service()),
+			Family:   util.StringPtr("Databases"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr(fmt.Sprintf("Azure Database for %s Flexible Server %s %s Series Compute", inst.engine, tier, series))},
+				{Key: "skuName", Value: util.StringPtr(skuName)},
+				{Key: "meterName", Value: util.StringPtr(meterName)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1 Hour"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}, true
+}
+
+func (inst *FlexibleServer) storageComponent() query.Component {
+	return query.Component{
+		Name:            "Storage",
+		MonthlyQuantity: inst.storageGB,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr(inst.service()),
+			Family:   util.StringPtr("Databases"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr(flexibleServerStorageProductNames[inst.engine])},
+				{Key: "meterName", Value: util.StringPtr("Storage Data Stored")},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1 GB/Month"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+func (inst *FlexibleServer) backupStorageComponent() query.Component {
+	redundancy := "LRS"
+	if inst.geoRedundantBackups {
+		redundancy = "GRS"
+	}
+
+	return query.Component{
+		Name:            "Additional backup storage",
+		Details:         []string{redundancy},
+		MonthlyQuantity: inst.additionalBackupStorageGB,
+		Usage:           true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr(inst.service()),
+			Family:   util.StringPtr("Databases"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr(flexibleServerBackupProductNames[inst.engine])},
+				{Key: "meterName", Value: util.StringPtr(fmt.Sprintf("Backup Storage %s Data Stored", redundancy))},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1 GB/Month"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+// flexibleServerExamples returns the ResourceExamples of the azurerm_postgresql_flexible_server
+// and azurerm_mysql_flexible_server on the location, see Provider.ResourceExamples
+func (p *Provider) flexibleServerExamples(location string) []terraform.ResourceExample {
+	return []terraform.ResourceExample{
+		p.example("azurerm_postgresql_flexible_server", map[string]interface{}{
+			"location":   location,
+			"sku_name":   "GP_Standard_D2s_v3",
+			"storage_mb": 32768,
+		}),
+		p.example("azurerm_mysql_flexible_server", map[string]interface{}{
+			"location": location,
+			"sku_name": "GP_Standard_D2ds_v4",
+			"storage":  []interface{}{map[string]interface{}{"size_gb": 32}},
+		}),
+	}
+}
@@ -0,0 +1,184 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available meterName and SkuName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=productName eq 'Load Balancer'" | jq '.Items[] | {skuName, meterName}' | sort -u
+
+// lbIncludedRules is the number of rules included on the Standard hourly price
+const lbIncludedRules = 5
+
+// LB is the entity that holds the logic to calculate price
+// of the azurerm_lb
+type LB struct {
+	provider *Provider
+
+	location string
+	sku      string
+	rules    decimal.Decimal
+
+	// Usage
+	monthlyDataProcessedGB decimal.Decimal
+}
+
+// lbValues is holds the terraform values that we need to estimate the price
+type lbValues struct {
+	Location string `mapstructure:"location"`
+	Sku      string `mapstructure:"sku"` // Basic, Standard or Gateway. Default=Basic
+
+	Usage struct {
+		// Rules is used when the azurerm_lb_rule and azurerm_lb_outbound_rule
+		// of the LB are not defined on the same module
+		Rules                  int64   `mapstructure:"rules"`
+		MonthlyDataProcessedGB float64 `mapstructure:"monthly_data_processed_gb"`
+	} `mapstructure:"tc_usage"`
+}
+
+// lbRuleValues are the values of the azurerm_lb_rule and azurerm_lb_outbound_rule
+type lbRuleValues struct {
+	LoadbalancerID string `mapstructure:"loadbalancer_id"`
+}
+
+// decodeLBValues decodes and returns lbValues from a Terraform values map.
+func decodeLBValues(tfVals map[string]interface{}) (lbValues, error) {
+	var v lbValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newLB initializes a new LB from the provider, the rules are the
+// ones of the rss referencing the address of the LB
+func (p *Provider) newLB(rss map[string]terraform.Resource, address string, vals lbValues) *LB {
+	inst := &LB{
+		provider: p,
+
+		location: p.locationName(vals.Location),
+		sku:      "Basic",
+		rules:    decimal.NewFromInt(vals.Usage.Rules),
+		// From Usage
+		monthlyDataProcessedGB: decimal.NewFromFloat(vals.Usage.MonthlyDataProcessedGB),
+	}
+
+	if vals.Sku != "" {
+		inst.sku = vals.Sku
+	}
+
+	if inst.rules.IsZero() {
+		var rules int64
+		for _, rs := range rss {
+			if rs.Type != "azurerm_lb_rule" && rs.Type != "azurerm_lb_outbound_rule" {
+				continue
+			}
+			var rv lbRuleValues
+			if err := mapstructure.WeakDecode(rs.Values, &rv); err != nil {
+				continue
+			}
+			if rv.LoadbalancerID == address || strings.HasSuffix(rv.LoadbalancerID, "."+address) {
+				rules++
+			}
+		}
+		inst.rules = decimal.NewFromInt(rules)
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this Instance.
+func (inst *LB) Components() []query.Component {
+	components := []query.Component{}
+
+	switch inst.sku {
+	case "Standard":
+		// The Standard LB is only charged when it has rules
+		if inst.rules.IsPositive() {
+			components = append(components, inst.lbComponent("Included rules", "Standard Included LB Rules and Outbound Rules", decimal.NewFromInt(1)))
+		}
+		if overage := inst.rules.Sub(decimal.NewFromInt(lbIncludedRules)); overage.IsPositive() {
+			components = append(components, inst.lbComponent("Overage rules", "Standard Overage LB Rules and Outbound Rules", overage))
+		}
+		components = append(components, inst.lbDataProcessedComponent("Standard Data Processed"))
+	case "Gateway":
+		components = append(components, inst.lbComponent("Gateway chain", "Gateway Chain Hour", decimal.NewFromInt(1)))
+		components = append(components, inst.lbDataProcessedComponent("Gateway Data Processed"))
+	}
+
+	// The Basic LB has no cost
+	return components
+}
+
+func (inst *LB) lbComponent(name, meterName string, quantity decimal.Decimal) query.Component {
+	return query.Component{
+		Name:           name,
+		HourlyQuantity: quantity,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr("Load Balancer"),
+			Family:   util.StringPtr("Networking"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "meterName", Value: util.StringPtr(meterName)},
+				{Key: "skuName", Value: util.StringPtr(inst.sku)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1 Hour"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+func (inst *LB) lbDataProcessedComponent(meterName string) query.Component {
+	return query.Component{
+		Name:            "Data processed",
+		MonthlyQuantity: inst.monthlyDataProcessedGB,
+		Usage:           true,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(inst.provider.key),
+			Service:  util.StringPtr("Load Balancer"),
+			Family:   util.StringPtr("Networking"),
+			Location: util.StringPtr(inst.location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "meterName", Value: util.StringPtr(meterName)},
+				{Key: "skuName", Value: util.StringPtr(inst.sku)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1 GB"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+// lbExample returns the ResourceExample of the azurerm_lb on the location, see Provider.ResourceExamples
+func (p *Provider) lbExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_lb", map[string]interface{}{
+		"location": location,
+		"sku":      "Standard",
+	})
+}
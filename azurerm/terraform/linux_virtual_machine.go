@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -23,9 +24,33 @@ type LinuxWindowsVirtualMachine struct {
 
 	managedDisk *ManagedDisk
 
+	// spot is set when the priority is Spot, the Spot prices vary
+	// so the current one is only an estimate
+	spot bool
+
 	// windows params
-	os          string
-	licenseType string
+	os string
+	// hybridBenefit is when the Windows license is brought with the Azure Hybrid Benefit,
+	// so only the base compute rate (the Linux one) is charged
+	hybridBenefit bool
+
+	// Usage
+	// reservationTerm is the term of the reserved instance (ex: 1 Year)
+	reservationTerm string
+
+	// monthlyHours is the number of hours the virtual machine runs per month,
+	// if not set it's considered to be always running
+	monthlyHours decimal.Decimal
+
+	// runningShare is the share of the time the virtual machine runs with its
+	// hours_per_week or schedule, it's only used if the monthlyHours are not set
+	runningShare decimal.Decimal
+}
+
+// reservationTermMonths is the number of months of each Azure reservation term
+var reservationTermMonths = map[string]int64{
+	"1 Year":  12,
+	"3 Years": 36,
 }
 
 // linuxVirtualMachineValues is holds the values that we need to be able
@@ -33,6 +58,7 @@ type LinuxWindowsVirtualMachine struct {
 type linuxVirtualMachineValues struct {
 	Size     string `mapstructure:"size"`
 	Location string `mapstructure:"location"`
+	Priority string `mapstructure:"priority"` // Regular or Spot
 
 	OSDisk []struct {
 		StorageAccountType string  `mapstructure:"storage_account_type"`
@@ -48,6 +74,16 @@ type linuxVirtualMachineValues struct {
 		OSDisk struct {
 			MonthlyDiskOperations float64 `mapstructure:"monthly_disk_operations"`
 		} `mapstructure:"os_disk"`
+
+		// ReservationTerm is the term of the reserved instance (1 Year or 3 Years),
+		// if not set the pay-as-you-go price is used
+		ReservationTerm string `mapstructure:"reservation_term"`
+
+		// MonthlyHours, or the HoursPerWeek or Schedule (ex: "Mon-Fri 08:00-20:00"),
+		// are the runtime of the virtual machine, it always runs if none is set
+		MonthlyHours float64 `mapstructure:"monthly_hours"`
+		HoursPerWeek float64 `mapstructure:"hours_per_week"`
+		Schedule     string  `mapstructure:"schedule"`
 	} `mapstructure:"tc_usage"`
 }
 
@@ -75,9 +111,15 @@ func (p *Provider) newLinuxVirtualMachine(vals linuxVirtualMachineValues) *Linux
 	inst := &LinuxWindowsVirtualMachine{
 		provider: p,
 
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		size:     vals.Size,
 		os:       "linux",
+		spot:     strings.EqualFold(vals.Priority, "Spot"),
+
+		// From Usage
+		reservationTerm: vals.Usage.ReservationTerm,
+		monthlyHours:    decimal.NewFromFloat(vals.Usage.MonthlyHours),
+		runningShare:    p.runningShare(vals.Usage.MonthlyHours, vals.Usage.HoursPerWeek, vals.Usage.Schedule),
 	}
 
 	if len(vals.AdditionalCapabilities) > 0 {
@@ -87,7 +129,7 @@ func (p *Provider) newLinuxVirtualMachine(vals linuxVirtualMachineValues) *Linux
 	if len(vals.OSDisk) > 0 {
 		inst.managedDisk = &ManagedDisk{
 			provider:           p,
-			location:           region.GetLocationName(vals.Location),
+			location:           p.locationName(vals.Location),
 			diskSizeGB:         decimal.NewFromFloat(vals.OSDisk[0].DiskSizeGB),
 			storageAccountType: vals.OSDisk[0].StorageAccountType,
 
@@ -102,12 +144,36 @@ func (p *Provider) newLinuxVirtualMachine(vals linuxVirtualMachineValues) *Linux
 func (inst *LinuxWindowsVirtualMachine) Components() []query.Component {
 	components := []query.Component{}
 
+	var compute query.Component
 	if inst.os == "linux" {
-		components = append(components, inst.linuxVirtualMachineComponent(inst.provider.key, inst.location, inst.size))
+		compute = inst.linuxVirtualMachineComponent(inst.provider.key, inst.location, inst.size)
+	} else if inst.hybridBenefit {
+		compute = inst.linuxVirtualMachineComponent(inst.provider.key, inst.location, inst.size)
+		compute.Name = "Compute Windows"
+		compute.Details = []string{"Azure Hybrid Benefit"}
 	} else {
-		components = append(components, inst.windowsVirtualMachineComponent(inst.provider.key, inst.location, inst.size, inst.licenseType))
+		compute = inst.windowsVirtualMachineComponent(inst.provider.key, inst.location, inst.size)
 	}
 
+	// The reservations are charged even when the virtual machine is stopped
+	_, reserved := reservationTermMonths[inst.reservationTerm]
+	if inst.spot {
+		compute = inst.spotComponent(compute)
+		reserved = false
+	} else if inst.os == "linux" || inst.hybridBenefit {
+		compute = inst.reservationComponent(compute)
+	} else if reserved {
+		// The reservations are on the compute meter without the Windows license
+		compute = inst.linuxVirtualMachineComponent(inst.provider.key, inst.location, inst.size)
+		compute.Name = "Compute Windows"
+		compute = inst.reservationComponent(compute)
+		inst.provider.warnf("the Windows license is not covered by the %s reservation, it's not estimated", inst.reservationTerm)
+	}
+	if !reserved {
+		compute = inst.runtimeComponent(compute)
+	}
+	components = append(components, compute)
+
 	if inst.ultraSSDEnabled {
 		components = append(components, inst.linuxVirtualMachineultraSSDReservationComponent(inst.provider.key, inst.location))
 	}
@@ -149,6 +215,84 @@ func (inst *LinuxWindowsVirtualMachine) linuxVirtualMachineComponent(key, locati
 	}
 }
 
+// runtimeComponent returns the hourly priced comp only for the monthlyHours or the runningShare of
+// the month the virtual machine runs, if they are set, instead of the full month
+func (inst *LinuxWindowsVirtualMachine) runtimeComponent(comp query.Component) query.Component {
+	switch {
+	case inst.monthlyHours.IsPositive():
+		comp.MonthlyQuantity = comp.HourlyQuantity.Mul(inst.monthlyHours)
+		comp.HourlyQuantity = decimal.Zero
+	case inst.runningShare.IsPositive():
+		comp.HourlyQuantity = comp.HourlyQuantity.Mul(inst.runningShare)
+	default:
+		return comp
+	}
+	comp.Usage = true
+	return comp
+}
+
+// runningShare returns the share of the time a virtual machine runs with the hoursPerWeek or the schedule
+// if they are set and not the monthlyHours, 0 otherwise. If the schedule is invalid it's always running.
+func (p *Provider) runningShare(monthlyHours, hoursPerWeek float64, schedule string) decimal.Decimal {
+	if monthlyHours > 0 {
+		return decimal.Zero
+	}
+	share, err := usage.RunningShare(hoursPerWeek, schedule)
+	if err != nil {
+		p.warnf("%s, the virtual machine is estimated as always running", err)
+	}
+	return decimal.NewFromFloat(share)
+}
+
+// spotComponent returns the comp with the price of the Spot meter, which is a pay-as-you-go
+// price of the product with the Spot type. The Spot prices vary so it's only an estimate.
+// The type is only filtered on the products for the Spot and Reservation ones, as the
+// products ingested before it was kept have none and the prices are filtered on it anyway.
+func (inst *LinuxWindowsVirtualMachine) spotComponent(comp query.Component) query.Component {
+	comp.Details = append(comp.Details, "Spot (estimate)")
+
+	filters := make([]*product.AttributeFilter, 0, len(comp.ProductFilter.AttributeFilters)+1)
+	filters = append(filters, comp.ProductFilter.AttributeFilters...)
+	filters = append(filters, &product.AttributeFilter{Key: "type", Value: util.StringPtr("Spot")})
+	pf := *comp.ProductFilter
+	pf.AttributeFilters = filters
+	comp.ProductFilter = &pf
+	return comp
+}
+
+// reservationComponent returns the comp with the price of the reservationTerm if it's set.
+// The price of a reservation is the total of the term, so the quantity is the share of each month.
+func (inst *LinuxWindowsVirtualMachine) reservationComponent(comp query.Component) query.Component {
+	months, ok := reservationTermMonths[inst.reservationTerm]
+	if !ok {
+		return comp
+	}
+
+	comp.MonthlyQuantity = comp.HourlyQuantity.Div(decimal.NewFromInt(months))
+	comp.HourlyQuantity = decimal.Zero
+	comp.Details = append(comp.Details, "reservation", inst.reservationTerm)
+	comp.Usage = true
+
+	filters := make([]*product.AttributeFilter, 0, len(comp.ProductFilter.AttributeFilters)+2)
+	filters = append(filters, comp.ProductFilter.AttributeFilters...)
+	filters = append(filters,
+		&product.AttributeFilter{Key: "type", Value: util.StringPtr("Reservation")},
+		&product.AttributeFilter{Key: "reservationTerm", Value: util.StringPtr(inst.reservationTerm)},
+	)
+	pf := *comp.ProductFilter
+	pf.AttributeFilters = filters
+	comp.ProductFilter = &pf
+
+	comp.PriceFilter = &price.Filter{
+		Unit: comp.PriceFilter.Unit,
+		AttributeFilters: []*price.AttributeFilter{
+			{Key: "type", Value: util.StringPtr("Reservation")},
+			{Key: "reservationTerm", Value: util.StringPtr(inst.reservationTerm)},
+		},
+	}
+	return comp
+}
+
 func (inst *LinuxWindowsVirtualMachine) linuxVirtualMachineultraSSDReservationComponent(key string, location string) query.Component {
 	return query.Component{
 		Name:           "Ultra disk reservation vCPU",
@@ -172,3 +316,12 @@ func (inst *LinuxWindowsVirtualMachine) linuxVirtualMachineultraSSDReservationCo
 		},
 	}
 }
+
+// linuxVirtualMachineExample returns the ResourceExample of the azurerm_linux_virtual_machine on the location, see Provider.ResourceExamples
+func (p *Provider) linuxVirtualMachineExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_linux_virtual_machine", map[string]interface{}{
+		"location": location,
+		"size":     "Standard_B2s",
+		"os_disk":  []interface{}{map[string]interface{}{"storage_account_type": "Standard_LRS", "disk_size_gb": float64(30)}},
+	})
+}
@@ -0,0 +1,97 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestLinuxVirtualMachine_Components(t *testing.T) {
+	p, err := NewProvider("azurerm")
+	require.NoError(t, err)
+
+	component := func(details []string, productType string) query.Component {
+		filters := []*product.AttributeFilter{
+			{Key: "productName", ValueRegex: util.StringPtr("Series( Linux)?$")},
+			{Key: "armSkuName", Value: util.StringPtr("Standard_D2s_v3")},
+		}
+		// Only the Spot and Reservation products are filtered on their type
+		if productType != "Consumption" {
+			filters = append(filters, &product.AttributeFilter{Key: "type", Value: util.StringPtr(productType)})
+		}
+		return query.Component{
+			Name:           "Compute Linux",
+			Details:        details,
+			HourlyQuantity: decimal.NewFromInt(1),
+			ProductFilter: &product.Filter{
+				Provider:         util.StringPtr("azurerm"),
+				Service:          util.StringPtr("Virtual Machines"),
+				Family:           util.StringPtr("Compute"),
+				Location:         util.StringPtr("westeurope"),
+				AttributeFilters: filters,
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("1 Hour"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "type", Value: util.StringPtr("Consumption")},
+				},
+			},
+		}
+	}
+
+	resource := func(priority string) terraform.Resource {
+		return terraform.Resource{
+			Address:      "azurerm_linux_virtual_machine.test",
+			Type:         "azurerm_linux_virtual_machine",
+			Name:         "test",
+			ProviderName: "azurerm",
+			Values: map[string]interface{}{
+				"location": "westeurope",
+				"size":     "Standard_D2s_v3",
+				"priority": priority,
+			},
+		}
+	}
+
+	t.Run("Regular", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource("Regular"))
+		assert.Equal(t, []query.Component{component(nil, "Consumption")}, actual)
+	})
+
+	t.Run("Spot", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource("Spot"))
+		assert.Equal(t, []query.Component{component([]string{"Spot (estimate)"}, "Spot")}, actual)
+	})
+
+	t.Run("Schedule", func(t *testing.T) {
+		tfres := resource("Regular")
+		tfres.Values[usage.Key] = map[string]interface{}{"schedule": "Mon-Fri 08:00-18:00"}
+
+		// The hourly quantity is kept so it's converted with the hours per month of the estimation
+		share, err := usage.RunningShare(50, "")
+		require.NoError(t, err)
+		expected := component(nil, "Consumption")
+		expected.HourlyQuantity = decimal.NewFromFloat(share)
+		expected.Usage = true
+		assert.Equal(t, []query.Component{expected}, p.ResourceComponents(nil, tfres))
+	})
+
+	t.Run("ScheduleReservation", func(t *testing.T) {
+		tfres := resource("Regular")
+		tfres.Values[usage.Key] = map[string]interface{}{"schedule": "Mon-Fri 08:00-18:00", "reservation_term": "1 Year"}
+
+		// The reservation is charged for the whole term
+		actual := p.ResourceComponents(nil, tfres)
+		require.Len(t, actual, 1)
+		assert.Equal(t, decimal.NewFromInt(1).Div(decimal.NewFromInt(12)), actual[0].MonthlyQuantity)
+	})
+}
@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -64,7 +64,7 @@ func decodeManagedDiskValues(tfVals map[string]interface{}) (managedDiskValues,
 func (p *Provider) newManagedDisk(vals managedDiskValues) *ManagedDisk {
 	inst := &ManagedDisk{
 		provider:           p,
-		location:           region.GetLocationName(vals.Location),
+		location:           p.locationName(vals.Location),
 		diskSizeGB:         decimal.NewFromFloat(vals.DiskSizeGB),
 		diskIOPSReadWrite:  decimal.NewFromFloat(vals.DiskIOPSReadWrite),
 		diskMBPSReadWrite:  decimal.NewFromFloat(vals.DiskMBPSReadWrite),
@@ -338,3 +338,12 @@ func mapDiskName(diskType string, requestedSize int) string {
 
 	return name
 }
+
+// managedDiskExample returns the ResourceExample of the azurerm_managed_disk on the location, see Provider.ResourceExamples
+func (p *Provider) managedDiskExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_managed_disk", map[string]interface{}{
+		"location":             location,
+		"storage_account_type": "Premium_LRS",
+		"disk_size_gb":         float64(128),
+	})
+}
@@ -0,0 +1,39 @@
+package terraform
+
+import (
+	"bytes"
+	_ "embed"
+
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+)
+
+// mappingsJSON are the terraform.ResourceMapping of the resources, or part of
+// them, that are simple enough to be estimated without a decoder
+//
+//go:embed mappings.json
+var mappingsJSON []byte
+
+// resourceMappings are the mappingsJSON keyed by resource type
+var resourceMappings = func() map[string]terraform.ResourceMapping {
+	rms, err := terraform.ReadResourceMappings(bytes.NewReader(mappingsJSON))
+	if err != nil {
+		panic(err)
+	}
+	m := make(map[string]terraform.ResourceMapping, len(rms))
+	for _, rm := range rms {
+		m[rm.Type] = rm
+	}
+	return m
+}()
+
+// mappedComponents returns the components of the resourceMappings of the resource,
+// the location is the one of the 'location' attribute
+func (p *Provider) mappedComponents(tfRes terraform.Resource) []query.Component {
+	rm, ok := resourceMappings[tfRes.Type]
+	if !ok {
+		return nil
+	}
+	l, _ := tfRes.Values["location"].(string)
+	return rm.ResourceComponents(p.key, p.locationName(l), tfRes.Values)
+}
@@ -3,10 +3,10 @@ package terraform
 import (
 	"fmt"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -58,7 +58,7 @@ func (p *Provider) newNatGateway(vals natGatewayValues) *NatGateway {
 	inst := &NatGateway{
 		provider: p,
 
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		skuName:  "Standard",
 		// From Usage
 		monthlyDataProcessedGB: decimal.NewFromFloat(vals.Usage.MonthlyDataProcessedGB),
@@ -126,3 +126,11 @@ func (inst *NatGateway) natGatewayDataProcessedComponent(key string, location st
 		},
 	}
 }
+
+// natGatewayExample returns the ResourceExample of the azurerm_nat_gateway on the location, see Provider.ResourceExamples
+func (p *Provider) natGatewayExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_nat_gateway", map[string]interface{}{
+		"location": location,
+		"sku_name": "Standard",
+	})
+}
@@ -63,7 +63,7 @@ func (p *Provider) newPrivateDNSZone(rss map[string]terraform.Resource, vals pri
 
 	// Get the location from RG
 	if rg.Location != "" {
-		inst.location = region.GetRegionToVNETZone(region.GetLocationName(rg.Location))
+		inst.location = region.GetRegionToVNETZone(p.locationName(rg.Location))
 	}
 
 	return inst
@@ -101,3 +101,11 @@ func (inst *DNSZone) dnsZoneComponent(key string, location string, zoneType stri
 		},
 	}
 }
+
+// privateDNSZoneExample returns the ResourceExample of the azurerm_private_dns_zone on the location, see Provider.ResourceExamples
+func (p *Provider) privateDNSZoneExample(location string) terraform.ResourceExample {
+	rg := p.resourceGroupResource(location)
+	return p.example("azurerm_private_dns_zone", map[string]interface{}{
+		"resource_group_name": rg.Address,
+	}, rg)
+}
@@ -1,10 +1,10 @@
 package terraform
 
 import (
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -60,7 +60,7 @@ func (p *Provider) newPrivateEndpoint(vals privateEndpointValues) *PrivateEndpoi
 	inst := &PrivateEndpoint{
 		provider: p,
 
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		// From Usage
 		monthlyHours: decimal.NewFromInt(vals.Usage.MonthlyHours),
 	}
@@ -98,3 +98,10 @@ func (inst *PrivateEndpoint) privateEndpointComponent(key, location string, mont
 		},
 	}
 }
+
+// privateEndpointExample returns the ResourceExample of the azurerm_private_endpoint on the location, see Provider.ResourceExamples
+func (p *Provider) privateEndpointExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_private_endpoint", map[string]interface{}{
+		"location": location,
+	})
+}
@@ -1,67 +1,25 @@
 package terraform
 
 import (
+	"fmt"
+
+	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
 )
 
-var (
-	locationDisplayToName = map[string]string{
-		"West US":              "westus",
-		"West US 2":            "westus2",
-		"East US":              "eastus",
-		"Central US":           "centralus",
-		"Central US EUAP":      "centraluseuap",
-		"South Central US":     "southcentralus",
-		"North Central US":     "northcentralus",
-		"West Central US":      "westcentralus",
-		"East US 2":            "eastus2",
-		"East US 2 EUAP":       "eastus2euap",
-		"Brazil South":         "brazilsouth",
-		"Brazil US":            "brazilus",
-		"North Europe":         "northeurope",
-		"West Europe":          "westeurope",
-		"East Asia":            "eastasia",
-		"Southeast Asia":       "southeastasia",
-		"Japan West":           "japanwest",
-		"Japan East":           "japaneast",
-		"Korea Central":        "koreacentral",
-		"Korea South":          "koreasouth",
-		"South India":          "southindia",
-		"West India":           "westindia",
-		"Central India":        "centralindia",
-		"Australia East":       "australiaeast",
-		"Australia Southeast":  "australiasoutheast",
-		"Canada Central":       "canadacentral",
-		"Canada East":          "canadaeast",
-		"UK South":             "uksouth",
-		"UK West":              "ukwest",
-		"France Central":       "francecentral",
-		"France South":         "francesouth",
-		"Australia Central":    "australiacentral",
-		"Australia Central 2":  "australiacentral2",
-		"UAE Central":          "uaecentral",
-		"UAE North":            "uaenorth",
-		"South Africa North":   "southafricanorth",
-		"South Africa West":    "southafricawest",
-		"Switzerland North":    "switzerlandnorth",
-		"Switzerland West":     "switzerlandwest",
-		"Germany North":        "germanynorth",
-		"Germany West Central": "germanywestcentral",
-		"Norway East":          "norwayeast",
-		"Norway West":          "norwaywest",
-		"Brazil Southeast":     "brazilsoutheast",
-		"West US 3":            "westus3",
-		"East US SLV":          "eastusslv",
-		"Sweden Central":       "swedencentral",
-		"Sweden South":         "swedensouth",
-	}
-)
-
 // Provider is an implementation of the terraform.Provider, used to extract component queries from
 // terraform resources.
 type Provider struct {
 	key string
+
+	// defaultLocation is the location of the resources that have none,
+	// they have a warning about it
+	defaultLocation string
+
+	// warnings are the assumptions made while estimating the resource, only
+	// set on the copy of the Provider made by ResourceComponentsWithWarnings
+	warnings []string
 }
 
 // NewProvider initializes a new Google provider with key and region
@@ -71,12 +29,145 @@ func NewProvider(key string) (*Provider, error) {
 	}, nil
 }
 
+// NewProviderWithDefaultLocation returns a new Provider as NewProvider that estimates the resources
+// without location on the defaultLocation (ex: westeurope), each of them has a warning about it.
+func NewProviderWithDefaultLocation(key, defaultLocation string) (*Provider, error) {
+	loc, ok := region.LookupLocation(defaultLocation)
+	if !ok {
+		return nil, fmt.Errorf("invalid Azure location: %q", defaultLocation)
+	}
+	return &Provider{
+		key:             key,
+		defaultLocation: loc.Name,
+	}, nil
+}
+
 // Name returns the Provider's common name.
 func (p *Provider) Name() string { return p.key }
 
+// ResourceAttributes returns the attributes of the resource type used to estimate it, see terraform.AttributesProvider.
+func (p *Provider) ResourceAttributes(resourceType string) []string {
+	v, ok := UsageValues()[resourceType]
+	if !ok {
+		return nil
+	}
+	return terraform.ValuesAttributes(v)
+}
+
+// DataSources returns the data source types estimated as the resources
+// of the same type, see terraform.DataSourcesProvider.
+func (p *Provider) DataSources() []string {
+	return []string{
+		"azurerm_container_registry",
+		"azurerm_managed_disk",
+		"azurerm_public_ip",
+	}
+}
+
+// ResourceExamples returns a canonical example of each supported resource type on the location (ex: westeurope),
+// or on the default location of p if it's empty, see terraform.ExamplesProvider.
+func (p *Provider) ResourceExamples(location string) (terraform.Provider, []terraform.ResourceExample, error) {
+	if location == "" {
+		location = p.defaultLocation
+	}
+	loc, ok := region.LookupLocation(location)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid Azure location: %q", location)
+	}
+	location = loc.Name
+
+	examples := []terraform.ResourceExample{
+		p.applicationGatewayExample(location),
+		p.bastionHostExample(location),
+		p.linuxVirtualMachineExample(location),
+		p.windowsVirtualMachineExample(location),
+		p.managedDiskExample(location),
+		p.natGatewayExample(location),
+		p.dnsZoneExample(location),
+		p.privateDNSZoneExample(location),
+		p.virtualMachineExample(location),
+		{Resource: p.virtualNetworkGatewayResource(location)},
+		p.virtualNetworkGatewayConnectionExample(location),
+		{Resource: p.storageAccountResource(location)},
+		p.storageShareExample(location),
+		p.publicIPExample(location),
+		p.lbExample(location),
+		p.privateEndpointExample(location),
+		p.servicePlanExample(location),
+		p.appServicePlanExample(location),
+	}
+	examples = append(examples, p.flexibleServerExamples(location)...)
+	examples = append(examples, p.cosmosDBExamples(location)...)
+	examples = append(examples, p.containerRegistryExample(location), p.containerAppExample(location))
+	return p, examples, nil
+}
+
+// example returns the ResourceExample of the resource type rt with the values and its references
+func (p *Provider) example(rt string, values map[string]interface{}, references ...terraform.Resource) terraform.ResourceExample {
+	return terraform.ResourceExample{
+		Resource:   p.resource(rt, values),
+		References: references,
+	}
+}
+
+// resource returns the Resource 'example' of the resource type rt with the values
+func (p *Provider) resource(rt string, values map[string]interface{}) terraform.Resource {
+	return terraform.Resource{
+		Address:      rt + ".example",
+		Mode:         "managed",
+		Type:         rt,
+		Name:         "example",
+		ProviderName: p.key,
+		Values:       values,
+	}
+}
+
 // ResourceComponents returns Component queries for a given terraform.Resource.
 func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes terraform.Resource) []query.Component {
+	components, _ := p.ResourceComponentsWithWarnings(rss, tfRes)
+	return components
+}
+
+// ResourceComponentsWithWarnings returns the Component queries of the terraform.Resource and the assumptions
+// made while estimating it, see terraform.WarningsProvider. They are collected on a copy of the Provider.
+func (p *Provider) ResourceComponentsWithWarnings(rss map[string]terraform.Resource, tfRes terraform.Resource) ([]query.Component, []string) {
+	rp := *p
+	rp.warnings = nil
+	components := rp.resourceComponents(rss, tfRes)
+	return components, rp.warnings
+}
+
+// warnf records a warning about the resource being estimated
+func (p *Provider) warnf(format string, args ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
+// locationName returns the name of the location l (ex: UK West -> ukwest), if l is
+// not a known Azure location it's returned as is and a warning is recorded. If l is
+// empty the defaultLocation is returned, with a warning if there is one
+func (p *Provider) locationName(l string) string {
+	if l == "" {
+		if p.defaultLocation != "" {
+			p.warnf("no location, the default location %q is used", p.defaultLocation)
+		}
+		return p.defaultLocation
+	}
+	loc, ok := region.LookupLocation(l)
+	if !ok {
+		p.warnf("unknown location %q, it may have no prices", l)
+		return l
+	}
+	return loc.Name
+}
+
+func (p *Provider) resourceComponents(rss map[string]terraform.Resource, tfRes terraform.Resource) []query.Component {
 	switch tfRes.Type {
+	case "azurerm_application_gateway":
+		vals, err := decodeApplicationGatewayValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newApplicationGateway(vals).Components()
 	case "azurerm_bastion_host":
 		vals, err := decodeBastionHostValues(tfRes.Values)
 		if err != nil {
@@ -130,7 +221,7 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 		if err != nil {
 			return nil
 		}
-		return p.newVirtualNetworkGateway(vals).Components()
+		return append(p.newVirtualNetworkGateway(vals).Components(), p.mappedComponents(tfRes)...)
 	case "azurerm_virtual_network_gateway_connection":
 		vals, err := decodeVirtualNetworkGatewayConnectionValues(tfRes.Values)
 		if err != nil {
@@ -155,114 +246,68 @@ func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes t
 			return nil
 		}
 		return p.newPublicIP(vals).Components()
+	case "azurerm_lb":
+		vals, err := decodeLBValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newLB(rss, tfRes.Address, vals).Components()
 	case "azurerm_private_endpoint":
 		vals, err := decodePrivateEndpointValues(tfRes.Values)
 		if err != nil {
 			return nil
 		}
 		return p.newPrivateEndpoint(vals).Components()
+	case "azurerm_service_plan":
+		vals, err := decodeServicePlanValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newServicePlan(vals).Components()
+	case "azurerm_app_service_plan":
+		vals, err := decodeAppServicePlanValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newAppServicePlan(vals).Components()
+	case "azurerm_postgresql_flexible_server":
+		vals, err := decodeFlexibleServerValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newFlexibleServer(flexibleServerPostgreSQL, vals).Components()
+	case "azurerm_mysql_flexible_server":
+		vals, err := decodeFlexibleServerValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newFlexibleServer(flexibleServerMySQL, vals).Components()
+	case "azurerm_cosmosdb_account":
+		vals, err := decodeCosmosDBAccountValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newCosmosDBAccount(vals).Components()
+	case "azurerm_cosmosdb_sql_database", "azurerm_cosmosdb_sql_container":
+		vals, err := decodeCosmosDBThroughputValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newCosmosDBThroughput(rss, vals).Components()
+	case "azurerm_container_registry":
+		vals, err := decodeContainerRegistryValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newContainerRegistry(vals).Components()
+	case "azurerm_container_app":
+		vals, err := decodeContainerAppValues(tfRes.Values)
+		if err != nil {
+			return nil
+		}
+		return p.newContainerApp(rss, vals).Components()
 	default:
-		return nil
-	}
-}
-
-// getLocationName will return the location name from the location display name (ex: UK West -> ukwest)
-// if the l is not found it'll return the l again meaning is not found or already a name
-func getLocationName(l string) string {
-	ln, ok := locationDisplayToName[l]
-	if !ok {
-		return l
+		// The resources without decoder may be described by a mapping
+		return p.mappedComponents(tfRes)
 	}
-	return ln
-}
-
-// Mapped based on the values here: https://azure.microsoft.com/en-us/pricing/details/virtual-network/#faq
-func getRegionToVNETZone(region string) string {
-	return map[string]string{
-		"eastus":              "Zone 1",
-		"eastus2":             "Zone 1",
-		"southcentralus":      "Zone 1",
-		"westus2":             "Zone 1",
-		"westus3":             "Zone 1",
-		"australiaeast":       "Zone 2",
-		"southeastasia":       "Zone 2",
-		"northeurope":         "Zone 1",
-		"swedencentral":       "Zone 1",
-		"uksouth":             "Zone 1",
-		"westeurope":          "Zone 1",
-		"centralus":           "Zone 1",
-		"southafricanorth":    "Zone 3",
-		"centralindia":        "Zone 2",
-		"eastasia":            "Zone 2",
-		"japaneast":           "Zone 2",
-		"koreacentral":        "Zone 2",
-		"canadacentral":       "Zone 1",
-		"francecentral":       "Zone 1",
-		"germanywestcentral":  "Zone 1",
-		"italynorth":          "Zone 1",
-		"norwayeast":          "Zone 1",
-		"polandcentral":       "Zone 1",
-		"switzerlandnorth":    "Zone 1",
-		"uaenorth":            "Zone 3",
-		"brazilsouth":         "Zone 3",
-		"centraluseuap":       "Zone 1",
-		"israelcentral":       "Zone 1",
-		"qatarcentral":        "Zone 1",
-		"centralusstage":      "Zone 1",
-		"eastusstage":         "Zone 1",
-		"eastus2stage":        "Zone 1",
-		"northcentralusstage": "Zone 1",
-		"southcentralusstage": "Zone 1",
-		"westusstage":         "Zone 1",
-		"westus2stage":        "Zone 1",
-		"asia":                "Zone 1",
-		"asiapacific":         "Zone 1",
-		"australia":           "Zone 1",
-		"brazil":              "Zone 3",
-		"canada":              "Zone 1",
-		"europe":              "Zone 1",
-		"france":              "Zone 1",
-		"germany":             "Zone 1",
-		"india":               "Zone 2",
-		"japan":               "Zone 2",
-		"korea":               "Zone 2",
-		"norway":              "Zone 1",
-		"singapore":           "Zone 1",
-		"southafrica":         "Zone 3",
-		"sweden":              "Zone 1",
-		"switzerland":         "Zone 1",
-		"uae":                 "Zone 3",
-		"uk":                  "Zone 1",
-		"unitedstates":        "Zone 1",
-		"unitedstateseuap":    "Zone 1",
-		"eastasiastage":       "Zone 2",
-		"southeastasiastage":  "Zone 2",
-		"brazilus":            "Zone 1",
-		"eastusstg":           "Zone 1",
-		"northcentralus":      "Zone 1",
-		"westus":              "Zone 1",
-		"japanwest":           "Zone 2",
-		"jioindiawest":        "Zone 1",
-		"eastus2euap":         "Zone 1",
-		"westcentralus":       "Zone 1",
-		"southafricawest":     "Zone 3",
-		"australiacentral":    "Zone 1",
-		"australiacentral2":   "Zone 1",
-		"australiasoutheast":  "Zone 2",
-		"jioindiacentral":     "Zone 2",
-		"koreasouth":          "Zone 2",
-		"southindia":          "Zone 2",
-		"westindia":           "Zone 2",
-		"canadaeast":          "Zone 1",
-		"francesouth":         "Zone 1",
-		"germanynorth":        "Zone 1",
-		"norwaywest":          "Zone 1",
-		"switzerlandwest":     "Zone 1",
-		"ukwest":              "Zone 1",
-		"uaecentral":          "Zone 3",
-		"brazilsoutheast":     "Zone 3",
-		"usgovvirginia":       "US Gov Zone 1",
-		"usgovarizona":        "US Gov Zone 1",
-		"usgovtexas":          "US Gov Zone 1",
-	}[region]
 }
@@ -0,0 +1,41 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_ResourceExamples(t *testing.T) {
+	t.Run("Location", func(t *testing.T) {
+		p, err := NewProvider("azurerm")
+		require.NoError(t, err)
+
+		_, examples, err := p.ResourceExamples("France Central")
+		require.NoError(t, err)
+		require.NotEmpty(t, examples)
+		for _, ex := range examples {
+			if loc, ok := ex.Resource.Values["location"]; ok {
+				assert.Equal(t, "francecentral", loc, ex.Resource.Type)
+			}
+		}
+	})
+
+	t.Run("DefaultLocation", func(t *testing.T) {
+		p, err := NewProviderWithDefaultLocation("azurerm", "westeurope")
+		require.NoError(t, err)
+
+		_, examples, err := p.ResourceExamples("")
+		require.NoError(t, err)
+		assert.Equal(t, "westeurope", examples[0].Resource.Values["location"])
+	})
+
+	t.Run("NoLocation", func(t *testing.T) {
+		p, err := NewProvider("azurerm")
+		require.NoError(t, err)
+
+		_, _, err = p.ResourceExamples("")
+		assert.Error(t, err)
+	})
+}
@@ -1,10 +1,10 @@
 package terraform
 
 import (
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -68,13 +68,18 @@ func (p *Provider) newPublicIP(vals publicIPValues) *PublicIP {
 	inst := &PublicIP{
 		provider: p,
 
-		location:         region.GetLocationName(vals.Location),
+		location:         p.locationName(vals.Location),
 		allocationMethod: vals.AllocationMethod,
-		sku:              vals.Sku,
+		sku:              "Standard",
 		skuTier:          vals.SkuTier,
 		// From Usage
 		monthlyHours: decimal.NewFromInt(vals.Usage.MonthlyHours),
 	}
+
+	if vals.Sku != "" {
+		inst.sku = vals.Sku
+	}
+
 	return inst
 }
 
@@ -125,3 +130,12 @@ func (inst *PublicIP) publicIPComponent(key, location, skuName, meterName string
 		},
 	}
 }
+
+// publicIPExample returns the ResourceExample of the azurerm_public_ip on the location, see Provider.ResourceExamples
+func (p *Provider) publicIPExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_public_ip", map[string]interface{}{
+		"location":          location,
+		"allocation_method": "Static",
+		"sku":               "Standard",
+	})
+}
@@ -2,6 +2,8 @@ package terraform
 
 import (
 	"github.com/mitchellh/mapstructure"
+
+	"github.com/cycloidio/terracost/terraform"
 )
 
 // resourceGroupValues is holds the values that we need to be able
@@ -28,3 +30,10 @@ func decodeResourceGroupValues(tfVals map[string]interface{}) (resourceGroupValu
 	}
 	return v, nil
 }
+
+// resourceGroupResource returns the azurerm_resource_group 'example' on the location, referenced by the other examples
+func (p *Provider) resourceGroupResource(location string) terraform.Resource {
+	return p.resource("azurerm_resource_group", map[string]interface{}{
+		"location": location,
+	})
+}
@@ -0,0 +1,154 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+//To check the available productName and skuName for the resource
+// - curl -s "https://prices.azure.com/api/retail/prices?\$filter=serviceName eq 'Azure App Service'" | jq '.Items[] | {productName, skuName}' | sort -u
+
+var (
+	// servicePlanSkuRe matches the App Service Plan SKUs (ex: B1, S2, P1v2, P0v3, I1v2)
+	// with the tier letter, the size and the version
+	servicePlanSkuRe = regexp.MustCompile(`^([A-Z]+)(\d+)(v\d+)?$`)
+
+	// servicePlanTiers are the tier names used on the productName
+	// from the SKU tier letter
+	servicePlanTiers = map[string]string{
+		"B": "Basic",
+		"S": "Standard",
+		"P": "Premium",
+		"I": "Isolated",
+	}
+)
+
+// ServicePlan is the entity that holds the logic to calculate price
+// of the azurerm_service_plan, the apps (ex: azurerm_linux_web_app) are
+// billed through the plan they run on
+type ServicePlan struct {
+	provider *Provider
+
+	location    string
+	skuName     string
+	osType      string
+	workerCount decimal.Decimal
+}
+
+// servicePlanValues is holds the terraform values that we need to estimate the price
+type servicePlanValues struct {
+	Location    string `mapstructure:"location"`
+	SkuName     string `mapstructure:"sku_name"` // B1, S1, P1v2, P1v3 ...
+	OSType      string `mapstructure:"os_type"`  // Linux, Windows or WindowsContainer
+	WorkerCount int64  `mapstructure:"worker_count"`
+}
+
+// decodeServicePlanValues decodes and returns servicePlanValues from a Terraform values map.
+func decodeServicePlanValues(tfVals map[string]interface{}) (servicePlanValues, error) {
+	var v servicePlanValues
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &v,
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return v, err
+	}
+
+	if err := decoder.Decode(tfVals); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// newServicePlan initializes a new ServicePlan from the provider
+func (p *Provider) newServicePlan(vals servicePlanValues) *ServicePlan {
+	inst := &ServicePlan{
+		provider: p,
+
+		location:    p.locationName(vals.Location),
+		skuName:     vals.SkuName,
+		osType:      vals.OSType,
+		workerCount: decimal.NewFromInt(1),
+	}
+
+	if vals.WorkerCount > 0 {
+		inst.workerCount = decimal.NewFromInt(vals.WorkerCount)
+	}
+
+	return inst
+}
+
+// Components returns the price component queries that make up this ServicePlan.
+func (inst *ServicePlan) Components() []query.Component {
+	m := servicePlanSkuRe.FindStringSubmatch(inst.skuName)
+	if m == nil {
+		return nil
+	}
+
+	tier, ok := servicePlanTiers[m[1]]
+	if !ok {
+		// The Free and Shared tiers are not supported
+		return nil
+	}
+
+	skuName := m[1] + m[2]
+	if m[3] != "" {
+		tier = fmt.Sprintf("%s %s", tier, m[3])
+		skuName = fmt.Sprintf("%s %s", skuName, m[3])
+	}
+
+	productName := fmt.Sprintf("Azure App Service %s Plan", tier)
+	if strings.EqualFold(inst.osType, "Linux") {
+		productName += " - Linux"
+	}
+
+	components := []query.Component{
+		inst.servicePlanComponent(inst.provider.key, inst.location, productName, skuName),
+	}
+
+	return components
+}
+
+func (inst *ServicePlan) servicePlanComponent(key, location, productName, skuName string) query.Component {
+	return query.Component{
+		Name:           fmt.Sprintf("Instance usage (%s)", inst.skuName),
+		Details:        []string{inst.osType},
+		HourlyQuantity: inst.workerCount,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(key),
+			Service:  util.StringPtr("Azure App Service"),
+			Family:   util.StringPtr("Compute"),
+			Location: util.StringPtr(location),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "productName", Value: util.StringPtr(productName)},
+				{Key: "skuName", Value: util.StringPtr(skuName)},
+			},
+		},
+		PriceFilter: &price.Filter{
+			Unit: util.StringPtr("1 Hour"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Consumption")},
+			},
+		},
+	}
+}
+
+// servicePlanExample returns the ResourceExample of the azurerm_service_plan on the location, see Provider.ResourceExamples
+func (p *Provider) servicePlanExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_service_plan", map[string]interface{}{
+		"location": location,
+		"sku_name": "P1v3",
+		"os_type":  "Linux",
+	})
+}
@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -79,3 +80,14 @@ func (p *Provider) newStorageAccount(vals storageAccountValues) *StorageAccount
 func (inst *StorageAccount) Components() []query.Component {
 	return []query.Component{}
 }
+
+// storageAccountResource returns the azurerm_storage_account 'example' on the location, referenced by the other examples
+func (p *Provider) storageAccountResource(location string) terraform.Resource {
+	return p.resource("azurerm_storage_account", map[string]interface{}{
+		"name":                     "example",
+		"location":                 location,
+		"account_tier":             "Standard",
+		"account_replication_type": "LRS",
+		"account_kind":             "StorageV2",
+	})
+}
@@ -282,3 +282,12 @@ func (inst *StorageShare) storageShareComponent(key, location, componentName str
 		},
 	}
 }
+
+// storageShareExample returns the ResourceExample of the azurerm_storage_share on the location, see Provider.ResourceExamples
+func (p *Provider) storageShareExample(location string) terraform.ResourceExample {
+	sa := p.storageAccountResource(location)
+	return p.example("azurerm_storage_share", map[string]interface{}{
+		"quota":                float64(100),
+		"storage_account_name": sa.Values["name"],
+	}, sa)
+}
@@ -0,0 +1,33 @@
+package terraform
+
+// UsageValues returns the values structs of all the supported resources keyed by
+// resource type, they are used to describe the usage fields of each resource.
+func UsageValues() map[string]interface{} {
+	return map[string]interface{}{
+		"azurerm_application_gateway":                applicationGatewayValues{},
+		"azurerm_bastion_host":                       bastionHostValues{},
+		"azurerm_linux_virtual_machine":              linuxVirtualMachineValues{},
+		"azurerm_windows_virtual_machine":            windowsVirtualMachineValues{},
+		"azurerm_managed_disk":                       managedDiskValues{},
+		"azurerm_nat_gateway":                        natGatewayValues{},
+		"azurerm_dns_zone":                           dnsZoneValues{},
+		"azurerm_private_dns_zone":                   privateDNSZoneValues{},
+		"azurerm_virtual_machine":                    virtualMachineValues{},
+		"azurerm_virtual_network_gateway":            virtualNetworkGatewayValues{},
+		"azurerm_virtual_network_gateway_connection": virtualNetworkGatewayConnectionValues{},
+		"azurerm_storage_account":                    storageAccountValues{},
+		"azurerm_storage_share":                      storageShareValues{},
+		"azurerm_lb":                                 lbValues{},
+		"azurerm_public_ip":                          publicIPValues{},
+		"azurerm_private_endpoint":                   privateEndpointValues{},
+		"azurerm_service_plan":                       servicePlanValues{},
+		"azurerm_app_service_plan":                   appServicePlanValues{},
+		"azurerm_postgresql_flexible_server":         flexibleServerValues{},
+		"azurerm_mysql_flexible_server":              flexibleServerValues{},
+		"azurerm_cosmosdb_account":                   cosmosDBAccountValues{},
+		"azurerm_cosmosdb_sql_database":              cosmosDBThroughputValues{},
+		"azurerm_cosmosdb_sql_container":             cosmosDBThroughputValues{},
+		"azurerm_container_registry":                 containerRegistryValues{},
+		"azurerm_container_app":                      containerAppValues{},
+	}
+}
@@ -3,9 +3,10 @@ package terraform
 import (
 	"strings"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/terraform"
 )
 
 // virtualMachineValues is holds the values that we need to be able
@@ -28,6 +29,16 @@ type virtualMachineValues struct {
 		OSDisk struct {
 			MonthlyDiskOperations float64 `mapstructure:"monthly_disk_operations"`
 		} `mapstructure:"os_disk"`
+
+		// ReservationTerm is the term of the reserved instance (1 Year or 3 Years),
+		// if not set the pay-as-you-go price is used
+		ReservationTerm string `mapstructure:"reservation_term"`
+
+		// MonthlyHours, or the HoursPerWeek or Schedule (ex: "Mon-Fri 08:00-20:00"),
+		// are the runtime of the virtual machine, it always runs if none is set
+		MonthlyHours float64 `mapstructure:"monthly_hours"`
+		HoursPerWeek float64 `mapstructure:"hours_per_week"`
+		Schedule     string  `mapstructure:"schedule"`
 	} `mapstructure:"tc_usage"`
 }
 
@@ -55,9 +66,14 @@ func (p *Provider) newVirtualMachine(vals virtualMachineValues) *LinuxWindowsVir
 	inst := &LinuxWindowsVirtualMachine{
 		provider: p,
 
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		size:     vals.VMSize,
 		os:       "linux",
+
+		// From Usage
+		reservationTerm: vals.Usage.ReservationTerm,
+		monthlyHours:    decimal.NewFromFloat(vals.Usage.MonthlyHours),
+		runningShare:    p.runningShare(vals.Usage.MonthlyHours, vals.Usage.HoursPerWeek, vals.Usage.Schedule),
 	}
 
 	if len(vals.AdditionalCapabilities) > 0 {
@@ -67,7 +83,7 @@ func (p *Provider) newVirtualMachine(vals virtualMachineValues) *LinuxWindowsVir
 	if len(vals.StorageOSDisk) > 0 {
 		inst.managedDisk = &ManagedDisk{
 			provider:           p,
-			location:           region.GetLocationName(vals.Location),
+			location:           p.locationName(vals.Location),
 			diskSizeGB:         decimal.NewFromFloat(vals.StorageOSDisk[0].DiskSizeGB),
 			storageAccountType: vals.StorageOSDisk[0].ManagedDiskType,
 
@@ -81,3 +97,12 @@ func (p *Provider) newVirtualMachine(vals virtualMachineValues) *LinuxWindowsVir
 
 	return inst
 }
+
+// virtualMachineExample returns the ResourceExample of the azurerm_virtual_machine on the location, see Provider.ResourceExamples
+func (p *Provider) virtualMachineExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_virtual_machine", map[string]interface{}{
+		"location":        location,
+		"vm_size":         "Standard_B2s",
+		"storage_os_disk": []interface{}{map[string]interface{}{"os_type": "Linux", "managed_disk_type": "Standard_LRS", "disk_size_gb": float64(30)}},
+	})
+}
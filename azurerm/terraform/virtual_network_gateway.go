@@ -8,6 +8,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -61,7 +62,7 @@ func (p *Provider) newVirtualNetworkGateway(vals virtualNetworkGatewayValues) *V
 	inst := &VirtualNetworkGateway{
 		provider: p,
 
-		location:  region.GetLocationName(vals.Location),
+		location:  p.locationName(vals.Location),
 		meterName: vals.SKU,
 		sku:       vals.SKU,
 		gwType:    vals.Type,
@@ -76,11 +77,11 @@ func (p *Provider) newVirtualNetworkGateway(vals virtualNetworkGatewayValues) *V
 	return inst
 }
 
-// Components returns the price component queries that make up this Instance.
+// Components returns the price component queries that make up this Instance,
+// the P2S tunnels are described on the mappings.json.
 func (inst *VirtualNetworkGateway) Components() []query.Component {
 	components := []query.Component{
 		inst.virtualNetworkGatewayComponent(inst.provider.key, inst.location, inst.sku, inst.meterName),
-		inst.virtualNetworkGatewayP2SComponent(inst.provider.key, inst.location, inst.sku),
 		inst.virtualNetworkGatewayDataTransfersComponent(inst.provider.key, inst.location),
 	}
 
@@ -109,29 +110,6 @@ func (inst *VirtualNetworkGateway) virtualNetworkGatewayComponent(key string, lo
 	}
 }
 
-func (inst *VirtualNetworkGateway) virtualNetworkGatewayP2SComponent(key string, location string, sku string) query.Component {
-	return query.Component{
-		Name:           "VPN gateway P2S tunnels (over 128)",
-		HourlyQuantity: decimal.NewFromInt(1),
-		ProductFilter: &product.Filter{
-			Provider: util.StringPtr(key),
-			Service:  util.StringPtr("VPN Gateway"),
-			Family:   util.StringPtr("Networking"),
-			Location: util.StringPtr(location),
-			AttributeFilters: []*product.AttributeFilter{
-				{Key: "skuName", Value: util.StringPtr(sku)},
-				{Key: "meterName", Value: util.StringPtr("P2S Connection")},
-			},
-		},
-		PriceFilter: &price.Filter{
-			Unit: util.StringPtr("1 Hour"),
-			AttributeFilters: []*price.AttributeFilter{
-				{Key: "type", Value: util.StringPtr("Consumption")},
-			},
-		},
-	}
-}
-
 func (inst *VirtualNetworkGateway) virtualNetworkGatewayDataTransfersComponent(key string, location string) query.Component {
 	return query.Component{
 		Name:            "VPN gateway data tranfer",
@@ -155,3 +133,12 @@ func (inst *VirtualNetworkGateway) virtualNetworkGatewayDataTransfersComponent(k
 		},
 	}
 }
+
+// virtualNetworkGatewayResource returns the azurerm_virtual_network_gateway 'example' on the location, referenced by the other examples
+func (p *Provider) virtualNetworkGatewayResource(location string) terraform.Resource {
+	return p.resource("azurerm_virtual_network_gateway", map[string]interface{}{
+		"location": location,
+		"sku":      "VpnGw1",
+		"type":     "Vpn",
+	})
+}
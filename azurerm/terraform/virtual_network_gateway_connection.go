@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
@@ -47,7 +46,7 @@ func decodeVirtualNetworkGatewayConnectionValues(tfVals map[string]interface{})
 func (p *Provider) newVirtualNetworkGatewayConnection(rss map[string]terraform.Resource, vals virtualNetworkGatewayConnectionValues) *VirtualNetworkGateway {
 	inst := &VirtualNetworkGateway{
 		provider: p,
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		sku:      "Basic",
 		gwType:   vals.Type,
 	}
@@ -100,3 +99,13 @@ func (inst *VirtualNetworkGateway) virtualNetworkGatewayConnectionS2SComponent(k
 		},
 	}
 }
+
+// virtualNetworkGatewayConnectionExample returns the ResourceExample of the azurerm_virtual_network_gateway_connection on the location, see Provider.ResourceExamples
+func (p *Provider) virtualNetworkGatewayConnectionExample(location string) terraform.ResourceExample {
+	vng := p.virtualNetworkGatewayResource(location)
+	return p.example("azurerm_virtual_network_gateway_connection", map[string]interface{}{
+		"location":                   location,
+		"type":                       "IPsec",
+		"virtual_network_gateway_id": vng.Address,
+	}, vng)
+}
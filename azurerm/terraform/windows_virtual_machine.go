@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cycloidio/terracost/azurerm/region"
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -18,6 +18,7 @@ import (
 type windowsVirtualMachineValues struct {
 	Size     string `mapstructure:"size"`
 	Location string `mapstructure:"location"`
+	Priority string `mapstructure:"priority"` // Regular or Spot
 
 	OSDisk []struct {
 		StorageAccountType string  `mapstructure:"storage_account_type"`
@@ -29,12 +30,28 @@ type windowsVirtualMachineValues struct {
 		UltraSSDLRS     bool `mapstructure:"UltraSSD_LRS"`
 	} `mapstructure:"additional_capabilities"`
 
-	LicenseYype string `mapstructure:"license_type"`
+	// LicenseType is set when the license is brought with the Azure Hybrid Benefit
+	// (Windows_Client or Windows_Server)
+	LicenseType string `mapstructure:"license_type"`
 
 	Usage struct {
 		OSDisk struct {
 			MonthlyDiskOperations float64 `mapstructure:"monthly_disk_operations"`
 		} `mapstructure:"os_disk"`
+
+		// ReservationTerm is the term of the reserved instance (1 Year or 3 Years),
+		// if not set the pay-as-you-go price is used
+		ReservationTerm string `mapstructure:"reservation_term"`
+
+		// MonthlyHours, or the HoursPerWeek or Schedule (ex: "Mon-Fri 08:00-20:00"),
+		// are the runtime of the virtual machine, it always runs if none is set
+		MonthlyHours float64 `mapstructure:"monthly_hours"`
+		HoursPerWeek float64 `mapstructure:"hours_per_week"`
+		Schedule     string  `mapstructure:"schedule"`
+
+		// AzureHybridBenefit is to estimate with the Windows license brought,
+		// even if the license_type is not set (ex: it's assigned by a policy)
+		AzureHybridBenefit bool `mapstructure:"azure_hybrid_benefit"`
 	} `mapstructure:"tc_usage"`
 }
 
@@ -62,9 +79,21 @@ func (p *Provider) newWindowsVirtualMachine(vals windowsVirtualMachineValues) *L
 	inst := &LinuxWindowsVirtualMachine{
 		provider: p,
 
-		location: region.GetLocationName(vals.Location),
+		location: p.locationName(vals.Location),
 		size:     vals.Size,
 		os:       "windows",
+		spot:     strings.EqualFold(vals.Priority, "Spot"),
+
+		// From Usage
+		reservationTerm: vals.Usage.ReservationTerm,
+		monthlyHours:    decimal.NewFromFloat(vals.Usage.MonthlyHours),
+		runningShare:    p.runningShare(vals.Usage.MonthlyHours, vals.Usage.HoursPerWeek, vals.Usage.Schedule),
+		hybridBenefit:   vals.Usage.AzureHybridBenefit,
+	}
+
+	switch strings.ToLower(vals.LicenseType) {
+	case "windows_client", "windows_server":
+		inst.hybridBenefit = true
 	}
 
 	if len(vals.AdditionalCapabilities) > 0 {
@@ -74,7 +103,7 @@ func (p *Provider) newWindowsVirtualMachine(vals windowsVirtualMachineValues) *L
 	if len(vals.OSDisk) > 0 {
 		inst.managedDisk = &ManagedDisk{
 			provider:           p,
-			location:           region.GetLocationName(vals.Location),
+			location:           p.locationName(vals.Location),
 			diskSizeGB:         decimal.NewFromFloat(vals.OSDisk[0].DiskSizeGB),
 			storageAccountType: vals.OSDisk[0].StorageAccountType,
 
@@ -85,8 +114,7 @@ func (p *Provider) newWindowsVirtualMachine(vals windowsVirtualMachineValues) *L
 	return inst
 }
 
-func (inst *LinuxWindowsVirtualMachine) windowsVirtualMachineComponent(key, location, size string, licenseType string) query.Component {
-
+func (inst *LinuxWindowsVirtualMachine) windowsVirtualMachineComponent(key, location, size string) query.Component {
 	productNameRe := "(Series )?Windows$"
 	if strings.HasPrefix(size, "Basic_") {
 		productNameRe = "Basic Windows$"
@@ -94,12 +122,6 @@ func (inst *LinuxWindowsVirtualMachine) windowsVirtualMachineComponent(key, loca
 		size = fmt.Sprintf("Standard_%s", size)
 	}
 
-	priceType := "Consumption"
-	// If defined, specifies that the image or disk that is being used was licensed on-premises
-	if strings.ToLower(licenseType) == "windows_client" || strings.ToLower(licenseType) == "windows_server" {
-		priceType = "DevTestConsumption"
-	}
-
 	return query.Component{
 		Name:           "Compute Windows",
 		HourlyQuantity: decimal.NewFromInt(1),
@@ -116,8 +138,17 @@ func (inst *LinuxWindowsVirtualMachine) windowsVirtualMachineComponent(key, loca
 		PriceFilter: &price.Filter{
 			Unit: util.StringPtr("1 Hour"),
 			AttributeFilters: []*price.AttributeFilter{
-				{Key: "type", Value: util.StringPtr(priceType)},
+				{Key: "type", Value: util.StringPtr("Consumption")},
 			},
 		},
 	}
 }
+
+// windowsVirtualMachineExample returns the ResourceExample of the azurerm_windows_virtual_machine on the location, see Provider.ResourceExamples
+func (p *Provider) windowsVirtualMachineExample(location string) terraform.ResourceExample {
+	return p.example("azurerm_windows_virtual_machine", map[string]interface{}{
+		"location": location,
+		"size":     "Standard_B2s",
+		"os_disk":  []interface{}{map[string]interface{}{"storage_account_type": "Standard_LRS", "disk_size_gb": float64(127)}},
+	})
+}
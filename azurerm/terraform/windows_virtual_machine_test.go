@@ -0,0 +1,112 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestWindowsVirtualMachine_Components(t *testing.T) {
+	p, err := NewProvider("azurerm")
+	require.NoError(t, err)
+
+	component := func(details []string, productNameRe string) query.Component {
+		return query.Component{
+			Name:           "Compute Windows",
+			Details:        details,
+			HourlyQuantity: decimal.NewFromInt(1),
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("azurerm"),
+				Service:  util.StringPtr("Virtual Machines"),
+				Family:   util.StringPtr("Compute"),
+				Location: util.StringPtr("westeurope"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "productName", ValueRegex: util.StringPtr(productNameRe)},
+					{Key: "armSkuName", Value: util.StringPtr("Standard_B2s")},
+				},
+			},
+			PriceFilter: &price.Filter{
+				Unit: util.StringPtr("1 Hour"),
+				AttributeFilters: []*price.AttributeFilter{
+					{Key: "type", Value: util.StringPtr("Consumption")},
+				},
+			},
+		}
+	}
+
+	resource := func(values map[string]interface{}) terraform.Resource {
+		values["location"] = "westeurope"
+		values["size"] = "Standard_B2s"
+		return terraform.Resource{
+			Address:      "azurerm_windows_virtual_machine.test",
+			Type:         "azurerm_windows_virtual_machine",
+			Name:         "test",
+			ProviderName: "azurerm",
+			Values:       values,
+		}
+	}
+
+	t.Run("License", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{}))
+		assert.Equal(t, []query.Component{component(nil, "(Series )?Windows$")}, actual)
+	})
+
+	t.Run("HybridBenefitUsage", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{
+			usage.Key: map[string]interface{}{"azure_hybrid_benefit": true},
+		}))
+		assert.Equal(t, []query.Component{component([]string{"Azure Hybrid Benefit"}, "Series( Linux)?$")}, actual)
+	})
+
+	t.Run("HybridBenefitLicenseType", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{
+			"license_type": "Windows_Server",
+		}))
+		assert.Equal(t, []query.Component{component([]string{"Azure Hybrid Benefit"}, "Series( Linux)?$")}, actual)
+	})
+
+	t.Run("Spot", func(t *testing.T) {
+		actual := p.ResourceComponents(nil, resource(map[string]interface{}{
+			"priority": "Spot",
+		}))
+
+		expected := component([]string{"Spot (estimate)"}, "(Series )?Windows$")
+		expected.ProductFilter.AttributeFilters = append(expected.ProductFilter.AttributeFilters, &product.AttributeFilter{Key: "type", Value: util.StringPtr("Spot")})
+		assert.Equal(t, []query.Component{expected}, actual)
+	})
+
+	t.Run("Reservation", func(t *testing.T) {
+		actual, warnings := p.ResourceComponentsWithWarnings(nil, resource(map[string]interface{}{
+			usage.Key: map[string]interface{}{"reservation_term": "3 Years"},
+		}))
+
+		expected := component([]string{"reservation", "3 Years"}, "Series( Linux)?$")
+		expected.HourlyQuantity = decimal.Zero
+		expected.MonthlyQuantity = decimal.NewFromInt(1).Div(decimal.NewFromInt(36))
+		expected.Usage = true
+		expected.ProductFilter.AttributeFilters = []*product.AttributeFilter{
+			{Key: "productName", ValueRegex: util.StringPtr("Series( Linux)?$")},
+			{Key: "armSkuName", Value: util.StringPtr("Standard_B2s")},
+			{Key: "type", Value: util.StringPtr("Reservation")},
+			{Key: "reservationTerm", Value: util.StringPtr("3 Years")},
+		}
+		expected.PriceFilter = &price.Filter{
+			Unit: util.StringPtr("1 Hour"),
+			AttributeFilters: []*price.AttributeFilter{
+				{Key: "type", Value: util.StringPtr("Reservation")},
+				{Key: "reservationTerm", Value: util.StringPtr("3 Years")},
+			},
+		}
+		assert.Equal(t, []query.Component{expected}, actual)
+		assert.Len(t, warnings, 1)
+	})
+}
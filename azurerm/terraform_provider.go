@@ -15,3 +15,14 @@ var TerraformProviderInitializer = terraform.ProviderInitializer{
 		return azurermtf.NewProvider(ProviderName)
 	},
 }
+
+// NewTerraformProviderInitializer returns a terraform.ProviderInitializer that initializes the Azure provider
+// estimating the resources without location on the defaultLocation (ex: westeurope), with a warning about it.
+func NewTerraformProviderInitializer(defaultLocation string) terraform.ProviderInitializer {
+	return terraform.ProviderInitializer{
+		MatchNames: []string{ProviderName, RegistryName},
+		Provider: func(values map[string]interface{}) (terraform.Provider, error) {
+			return azurermtf.NewProviderWithDefaultLocation(ProviderName, defaultLocation)
+		},
+	}
+}
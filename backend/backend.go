@@ -8,7 +8,9 @@ import (
 //go:generate mockgen -destination=../mock/backend.go -mock_names=Backend=Backend -package mock github.com/cycloidio/terracost/backend Backend
 
 // Backend represents a storage method used to store pricing data. It must include concrete implementations
-// of all repositories.
+// of all repositories, which can be implemented out of this module (ex: a client of a pricing service),
+// see product.Repository and price.Repository for their contracts. The optional capabilities are
+// implemented by the Backend itself (ex: MarkStore).
 type Backend interface {
 	Products() product.Repository
 	Prices() price.Repository
@@ -0,0 +1,24 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cycloidio/terracost/price"
+)
+
+// ErrChangesNotSupported is returned by ComparePrices when the price.Repository of the Backend
+// does not implement price.ChangesRepository
+var ErrChangesNotSupported = errors.New("price repository does not support the price changes")
+
+// ComparePrices returns the prices that have changed their value since the given time,
+// which can be used to know if an estimation changed only because the price did.
+// The price.Repository of the Backend must implement price.ChangesRepository.
+func ComparePrices(ctx context.Context, be Backend, since time.Time) ([]*price.Change, error) {
+	cr, ok := be.Prices().(price.ChangesRepository)
+	if !ok {
+		return nil, ErrChangesNotSupported
+	}
+	return cr.Changes(ctx, since)
+}
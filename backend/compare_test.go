@@ -0,0 +1,27 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cycloidio/terracost/backend"
+	"github.com/cycloidio/terracost/mock"
+)
+
+func TestComparePrices(t *testing.T) {
+	t.Run("ErrChangesNotSupported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		be := mock.NewBackend(ctrl)
+		be.EXPECT().Prices().Return(mock.NewPriceRepository(ctrl))
+
+		changes, err := backend.ComparePrices(context.Background(), be, time.Now())
+		assert.Equal(t, backend.ErrChangesNotSupported, err)
+		assert.Nil(t, changes)
+	})
+}
@@ -0,0 +1,14 @@
+package backend
+
+import "context"
+
+// MarkStore is implemented by the Backends that can store the high-water marks of the incremental
+// ingestions, see terracost.IngestPricingIncremental.
+type MarkStore interface {
+	// IngestionMark returns the mark stored for the key (ex: aws/AmazonEC2/eu-west-3),
+	// or an empty string if there is none.
+	IngestionMark(ctx context.Context, key string) (string, error)
+
+	// SetIngestionMark stores the mark for the key, replacing the previous one.
+	SetIngestionMark(ctx context.Context, key, mark string) error
+}
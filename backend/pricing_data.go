@@ -0,0 +1,18 @@
+package backend
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPricingDataNotSupported is returned by terracost.HasPricingData when the Backend
+// does not implement PricingDataChecker
+var ErrPricingDataNotSupported = errors.New("backend does not support checking the pricing data")
+
+// PricingDataChecker is implemented by the Backends that can check with a single query if they have
+// prices for a provider and location, see terracost.HasPricingData.
+type PricingDataChecker interface {
+	// HasPricingData returns true if at least one product of the provider (ex: aws)
+	// on the location (ex: eu-west-3) has a price.
+	HasPricingData(ctx context.Context, provider, location string) (bool, error)
+}
@@ -0,0 +1,47 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/backend"
+	"github.com/cycloidio/terracost/mock"
+)
+
+func TestOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	be := mock.NewBackend(ctrl)
+	backend.Register("test", func(_ context.Context, dsn string) (backend.Backend, error) {
+		assert.Equal(t, "test://user@localhost/db", dsn)
+		return be, nil
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		b, err := backend.Open(context.Background(), "test://user@localhost/db")
+		require.NoError(t, err)
+		assert.Equal(t, be, b)
+		assert.Contains(t, backend.Schemes(), "test")
+	})
+
+	t.Run("UnknownScheme", func(t *testing.T) {
+		_, err := backend.Open(context.Background(), "potato://localhost/db")
+		assert.ErrorIs(t, err, backend.ErrUnknownScheme)
+	})
+
+	t.Run("InvalidDSN", func(t *testing.T) {
+		_, err := backend.Open(context.Background(), "localhost/db")
+		assert.ErrorIs(t, err, backend.ErrInvalidDSN)
+	})
+
+	t.Run("RegisterTwice", func(t *testing.T) {
+		assert.Panics(t, func() {
+			backend.Register("test", func(_ context.Context, _ string) (backend.Backend, error) { return be, nil })
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package terracost_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost"
+	"github.com/cycloidio/terracost/backend"
+	"github.com/cycloidio/terracost/mock"
+)
+
+func TestHasPricingData(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		be := &pricingDataBackend{Backend: mock.NewBackend(ctrl), locations: map[string]bool{"aws/eu-west-3": true}}
+
+		ok, err := terracost.HasPricingData(ctx, be, "aws", "eu-west-3")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = terracost.HasPricingData(ctx, be, "aws", "eu-west-1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		be := &pricingDataBackend{Backend: mock.NewBackend(ctrl), err: errors.New("connection refused")}
+
+		_, err := terracost.HasPricingData(ctx, be, "aws", "eu-west-3")
+		assert.EqualError(t, err, "connection refused")
+	})
+
+	t.Run("ErrPricingDataNotSupported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// The products are not loaded to look for a price
+		_, err := terracost.HasPricingData(ctx, mock.NewBackend(ctrl), "aws", "eu-west-3")
+		assert.True(t, errors.Is(err, backend.ErrPricingDataNotSupported))
+	})
+}
+
+// pricingDataBackend implements the backend.PricingDataChecker with the provider/location it has prices for
+type pricingDataBackend struct {
+	*mock.Backend
+	locations map[string]bool
+	err       error
+}
+
+func (b *pricingDataBackend) HasPricingData(_ context.Context, provider, location string) (bool, error) {
+	if b.err != nil {
+		return false, b.err
+	}
+	return b.locations[provider+"/"+location], nil
+}
@@ -0,0 +1,40 @@
+package cost
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Adjustment is a markup or a discount applied to the rates of the components (ex: the resale markup of an MSP
+// or a negotiated discount such as an EDP or a MACC), the list rates are kept on the Component.ListRate.
+// The percentages are positive for a markup (ex: 10 for 10%) and negative for a discount (ex: -15 for 15%).
+type Adjustment struct {
+	// Percent is applied to the components of all the services
+	Percent float64
+
+	// Services are the percentages applied to the components of a service
+	// (ex: AmazonEC2 or Virtual Machines) instead of the Percent
+	Services map[string]float64
+}
+
+// RateOverride is a rate pinned for a component instead of the one of the pricing data (ex: a negotiated
+// private price not in the ingested catalog), see StateOptions.RateOverrides. The Adjustment is not applied to it.
+type RateOverride struct {
+	// Value is the price of a unit of the component, per hour if its quantity is hourly (ex: an instance)
+	Value    decimal.Decimal
+	Currency string
+}
+
+// factor returns the factor to multiply the rates of the service by, or false if they are not adjusted
+func (a *Adjustment) factor(service string) (decimal.Decimal, bool) {
+	if a == nil {
+		return decimal.Zero, false
+	}
+	pct, ok := a.Services[service]
+	if !ok {
+		pct = a.Percent
+	}
+	if pct == 0 {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromInt(100).Add(decimal.NewFromFloat(pct)).Div(decimal.NewFromInt(100)), true
+}
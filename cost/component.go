@@ -1,7 +1,12 @@
 package cost
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/query"
 )
 
 // Component describes the pricing of a single resource cost component. This includes Rate and Quantity
@@ -13,17 +18,155 @@ type Component struct {
 	Details  []string
 	Usage    bool
 
+	// Confidence is how trustworthy the Cost is, depending on the Usage
+	Confidence Confidence
+
+	// ListRate is the Rate before the Adjustment, it's only
+	// set when adjusted (see StateOptions.Adjustment)
+	ListRate Cost
+
+	// Hourly is set when the Quantity is hourly, so the
+	// original hourly rate can be returned by HourlyCost
+	Hourly bool
+
+	// HoursPerMonth is the number of hours of the month of the Rate when it's not
+	// the default HoursPerMonth (see StateOptions.HoursPerMonth), zero otherwise
+	HoursPerMonth decimal.Decimal
+
+	// Subsumed is set when the cost is already included on
+	// another Component so this one has no cost
+	Subsumed bool
+
+	// Overridden is set when the Rate is the one of a RateOverride
+	// instead of the pricing data (see StateOptions.RateOverrides)
+	Overridden bool
+
+	// Trace is how the cost has been computed, it's only
+	// set when requested (see StateOptions.Trace)
+	Trace *Trace
+
+	// Annotation identifies the product and price matched, it's
+	// only set when requested (see StateOptions.Annotate)
+	Annotation *Annotation
+
 	Error error
 }
 
+// Confidence is how trustworthy the cost of a Component is, see query.Confidence.
+type Confidence = query.Confidence
+
+const (
+	// Exact is a cost that doesn't depend on the usage
+	Exact = query.Exact
+
+	// UsageEstimate is a cost computed from the usage configured for the resource
+	UsageEstimate = query.UsageEstimate
+
+	// DefaultedUsage is a cost computed from the default usage (see usage.Default)
+	DefaultedUsage = query.DefaultedUsage
+)
+
+// newConfidence returns the Confidence of a Component depending on its usage and if the usage is the default one,
+// it's only used when the decoder did not set the query.Component.Confidence
+func newConfidence(usage, defaultUsage bool) Confidence {
+	switch {
+	case !usage:
+		return Exact
+	case defaultUsage:
+		return DefaultedUsage
+	default:
+		return UsageEstimate
+	}
+}
+
+// Annotation identifies the product and price used to price a Component, so the estimation
+// can be reconciled with the billing data (ex: the AWS Cost Explorer or CUR line items).
+type Annotation struct {
+	// SKU is the one of the product matched
+	SKU string
+
+	// Attributes are the attributes of the product used by the ProductFilter to match it
+	Attributes map[string]string
+
+	// RateCode is the rate code of the price (ex: the AWS 'RateCode'), it's empty if
+	// the provider has none or the Component is tiered as all the tiers are used
+	RateCode string
+}
+
 // Cost returns the cost of this component (Rate multiplied by Quantity).
 func (c Component) Cost() Cost {
-	if c.Rate.IsZero() || c.Quantity.IsZero() {
+	if c.Subsumed || c.Rate.IsZero() || c.Quantity.IsZero() {
 		return Zero
 	}
 	return c.Rate.MulDecimal(c.Quantity)
 }
 
+// ListCost returns the cost of this component with the ListRate, which is the Cost if it's not adjusted.
+func (c Component) ListCost() Cost {
+	if c.ListRate.IsZero() {
+		return c.Cost()
+	}
+	if c.Subsumed || c.Quantity.IsZero() {
+		return Zero
+	}
+	return c.ListRate.MulDecimal(c.Quantity)
+}
+
+// HourlyCost returns the cost per hour of this component.
+// If the component was priced hourly the original hourly rate is used so no precision is lost,
+// if not the monthly cost is divided by the hours of the month and rounded to 6 decimal places.
+func (c Component) HourlyCost() decimal.Decimal {
+	if c.Subsumed || c.Rate.IsZero() || c.Quantity.IsZero() {
+		return decimal.Zero
+	}
+	if c.Hourly {
+		return c.Rate.Div(c.hoursPerMonth()).Mul(c.Quantity)
+	}
+	return c.Cost().DivRound(c.hoursPerMonth(), 6)
+}
+
+// hoursPerMonth returns the number of hours of the month of the Rate
+func (c Component) hoursPerMonth() decimal.Decimal {
+	if c.HoursPerMonth.IsZero() {
+		return HoursPerMonth
+	}
+	return c.HoursPerMonth
+}
+
+// Breakdown returns how the monthly cost of this component is computed, ex: "500 GB × 0.023 USD = 11.50 USD".
+// The hourly components are shown with their monthly hours and the hourly rate, ex: "730 Hrs × 0.0116 USD = 8.47 USD".
+// It's empty if the component has an error. It's formatted with the DefaultFormatOptions, see FormatBreakdown.
+func (c Component) Breakdown() string {
+	return c.FormatBreakdown(DefaultFormatOptions)
+}
+
+// FormatBreakdown returns the Breakdown of this component formatted with the fo.
+func (c Component) FormatBreakdown(fo FormatOptions) string {
+	if c.Error != nil {
+		return ""
+	}
+
+	quantity, rate := c.Quantity, c.Rate.Decimal
+	if c.Hourly {
+		quantity, rate = quantity.Mul(c.hoursPerMonth()), c.Rate.Div(c.hoursPerMonth())
+	}
+
+	b := fmt.Sprintf("%s %s × %s = %s", quantity, c.Unit, fo.FormatRate(rate, c.Rate.Currency), fo.Format(c.Cost().Decimal, c.Rate.Currency))
+	if c.Subsumed {
+		b += " (subsumed)"
+	}
+	if c.Overridden {
+		b += " (overridden rate)"
+	}
+	return b
+}
+
+// CostOver returns the cost of this component over the duration d, scaled by the
+// hours of the month of its Rate (see HoursPerMonth).
+func (c Component) CostOver(d time.Duration) decimal.Decimal {
+	return over(c.Cost().Decimal, d, c.hoursPerMonth())
+}
+
 // ComponentDiff is a difference between the Prior and Planned Component.
 type ComponentDiff struct {
 	Prior, Planned *Component
@@ -3,6 +3,7 @@ package cost_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -60,3 +61,58 @@ func TestComponentDiff_Valid(t *testing.T) {
 		assert.Equal(t, tc.valid, cd.Valid(), "case %d", i)
 	}
 }
+
+func TestComponent_Breakdown(t *testing.T) {
+	testcases := []struct {
+		name      string
+		component cost.Component
+		expected  string
+	}{
+		{
+			name:      "Monthly",
+			component: cost.Component{Quantity: decimal.NewFromInt(500), Unit: "GB", Rate: cost.NewMonthly(decimal.NewFromFloat(0.023), "USD")},
+			expected:  "500 GB × 0.023 USD = 11.50 USD",
+		},
+		{
+			name:      "Hourly",
+			component: cost.Component{Quantity: decimal.NewFromInt(1), Unit: "Hrs", Rate: cost.NewHourly(decimal.NewFromFloat(0.0116), "USD"), Hourly: true},
+			expected:  "730 Hrs × 0.0116 USD = 8.47 USD",
+		},
+		{
+			name:      "Subsumed",
+			component: cost.Component{Quantity: decimal.NewFromInt(2), Unit: "Hrs", Rate: cost.NewMonthly(decimal.NewFromInt(3), "USD"), Subsumed: true},
+			expected:  "2 Hrs × 3 USD = 0.00 USD (subsumed)",
+		},
+		{
+			name:      "Error",
+			component: cost.Component{Error: fmt.Errorf("test error")},
+			expected:  "",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.component.Breakdown())
+		})
+	}
+}
+
+func TestComponent_CostOver(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		comp := cost.Component{Quantity: decimal.NewFromInt(1), Rate: cost.NewMonthly(decimal.NewFromInt(730), "USD")}
+		assertDecimalEqual(t, decimal.NewFromInt(24), comp.CostOver(24*time.Hour))
+	})
+	t.Run("HoursPerMonth", func(t *testing.T) {
+		comp := cost.Component{Quantity: decimal.NewFromInt(1), Rate: cost.NewMonthly(decimal.NewFromInt(744), "USD"), HoursPerMonth: decimal.NewFromInt(744)}
+		assertDecimalEqual(t, decimal.NewFromInt(24), comp.CostOver(24*time.Hour))
+	})
+	t.Run("Minutes", func(t *testing.T) {
+		comp := cost.Component{Quantity: decimal.NewFromInt(1), Rate: cost.NewMonthly(decimal.NewFromInt(730), "USD")}
+		assertDecimalEqual(t, decimal.RequireFromString("0.1"), comp.CostOver(6*time.Minute))
+	})
+}
+
+func TestComponent_FormatBreakdown(t *testing.T) {
+	c := cost.Component{Quantity: decimal.NewFromInt(500), Unit: "GB", Rate: cost.NewMonthly(decimal.NewFromFloat(0.02345), "USD")}
+
+	assert.Equal(t, "500 GB × $0.0235 = $11.73", c.FormatBreakdown(cost.FormatOptions{Decimals: 2, RateDecimals: 4, CurrencySymbol: "$"}))
+}
@@ -2,6 +2,7 @@ package cost
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -10,6 +11,15 @@ import (
 // It is calculated as 365 days in a year x 24 hours in a day / 12 months in year.
 var HoursPerMonth = decimal.NewFromInt(730)
 
+// HoursInMonth returns the number of hours of the calendar month of the year (ex: 672 for
+// February 2026 or 744 for a 31-day month), it can be used as the StateOptions.HoursPerMonth
+// to reconcile the estimation with the invoice of that month.
+func HoursInMonth(year int, month time.Month) decimal.Decimal {
+	// The day 0 of the next month is the last day of the month
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return decimal.NewFromInt(int64(days * 24))
+}
+
 // Cost represents a monthly or hourly cost of a cloud resource or its component.
 type Cost struct {
 	// Decimal is price per month.
@@ -28,7 +38,12 @@ func NewMonthly(monthly decimal.Decimal, currency string) Cost {
 
 // NewHourly returns a new Cost from price per hour with currency.
 func NewHourly(hourly decimal.Decimal, currency string) Cost {
-	return Cost{Decimal: hourly.Mul(HoursPerMonth), Currency: currency}
+	return newHourly(hourly, currency, HoursPerMonth)
+}
+
+// newHourly returns a new Cost from price per hour with currency over a month of hoursPerMonth.
+func newHourly(hourly decimal.Decimal, currency string, hoursPerMonth decimal.Decimal) Cost {
+	return Cost{Decimal: hourly.Mul(hoursPerMonth), Currency: currency}
 }
 
 // Monthly returns the cost per month.
@@ -41,6 +56,22 @@ func (c Cost) Hourly() decimal.Decimal {
 	return c.DivRound(HoursPerMonth, 6)
 }
 
+// Over returns the cost over the duration d. As the Cost is normalized to HoursPerMonth
+// the value is scaled by the actual number of hours in d, the Component, Resource and State
+// CostOver use the HoursPerMonth of the components instead.
+// To project it over calendar periods (quarter, year) use the difference between
+// the two dates (to.Sub(from)) so leap years and months length are accounted for.
+func (c Cost) Over(d time.Duration) decimal.Decimal {
+	return over(c.Decimal, d, HoursPerMonth)
+}
+
+// over returns the monthly value over the duration d for a month of hoursPerMonth hours,
+// the hours of d are computed from its nanoseconds so they are not rounded as a float64
+func over(monthly decimal.Decimal, d time.Duration, hoursPerMonth decimal.Decimal) decimal.Decimal {
+	hours := decimal.NewFromInt(int64(d)).Div(decimal.NewFromInt(int64(time.Hour)))
+	return monthly.Mul(hours).Div(hoursPerMonth)
+}
+
 // Add adds the values of two Cost structs.
 // If the currency of both costs doesn't match, error is returned.
 func (c Cost) Add(c2 Cost) (Cost, error) {
@@ -61,6 +92,11 @@ func (c Cost) Add(c2 Cost) (Cost, error) {
 	return Cost{Decimal: c.Decimal.Add(c2.Monthly()), Currency: c.Currency}, nil
 }
 
+// Format returns the monthly cost formatted with the fo, see FormatOptions.
+func (c Cost) Format(fo FormatOptions) string {
+	return fo.Format(c.Decimal, c.Currency)
+}
+
 // MulDecimal multiplies the Cost by the given decimal.Decimal.
 func (c Cost) MulDecimal(d decimal.Decimal) Cost {
 	return Cost{Decimal: c.Decimal.Mul(d), Currency: c.Currency}
@@ -2,6 +2,7 @@ package cost_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cycloidio/terracost/cost"
 	"github.com/shopspring/decimal"
@@ -14,6 +15,14 @@ func TestNewHourly(t *testing.T) {
 	assertDecimalEqual(t, val.Mul(cost.HoursPerMonth), c.Decimal)
 }
 
+func TestHoursInMonth(t *testing.T) {
+	assert.Equal(t, "744", cost.HoursInMonth(2026, time.January).String())
+	assert.Equal(t, "672", cost.HoursInMonth(2026, time.February).String())
+	assert.Equal(t, "696", cost.HoursInMonth(2024, time.February).String())
+	assert.Equal(t, "720", cost.HoursInMonth(2026, time.April).String())
+	assert.Equal(t, "744", cost.HoursInMonth(2026, time.December).String())
+}
+
 func TestNewMonthly(t *testing.T) {
 	val := decimal.NewFromFloat(1.23)
 	c := cost.NewMonthly(val, "USD")
@@ -54,6 +63,23 @@ func TestCost_MulDecimal(t *testing.T) {
 	assertDecimalEqual(t, decimal.NewFromFloat(3.69), c.MulDecimal(d).Decimal)
 }
 
+func TestCost_Over(t *testing.T) {
+	t.Run("Hourly", func(t *testing.T) {
+		c := cost.NewHourly(decimal.NewFromFloat(1.5), "USD")
+		assertDecimalEqual(t, decimal.NewFromFloat(36), c.Over(24*time.Hour))
+	})
+	t.Run("Monthly", func(t *testing.T) {
+		c := cost.NewMonthly(decimal.NewFromFloat(730), "USD")
+		assertDecimalEqual(t, decimal.NewFromFloat(8760), c.Over(365*24*time.Hour))
+	})
+	t.Run("LeapYear", func(t *testing.T) {
+		c := cost.NewHourly(decimal.NewFromFloat(1), "USD")
+		from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(1, 0, 0)
+		assertDecimalEqual(t, decimal.NewFromFloat(8784), c.Over(to.Sub(from)))
+	})
+}
+
 func assertDecimalEqual(t *testing.T, expected, actual decimal.Decimal) {
 	assert.Truef(t, expected.Equal(actual), "Not equal:\nexpected: %s\nactual  : %s", expected, actual)
 }
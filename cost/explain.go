@@ -0,0 +1,134 @@
+package cost
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+)
+
+// Errors returned by Plan.Explain
+var (
+	// ErrResourceNotFound is returned when the address is not on the Prior nor on the Planned State
+	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrNotTraced is returned when the resource has been estimated without the Trace of its
+	// components, the States have to be built with the StateOptions.Trace
+	ErrNotTraced = errors.New("resource estimated without trace")
+)
+
+// Trace is how the cost of a Component has been computed: the filters used to look up
+// the pricing data and what they matched.
+type Trace struct {
+	// ProductFilter and PriceFilter are the ones of the query.Component, the
+	// ProductFilter is nil for the components without cost
+	ProductFilter *product.Filter
+	PriceFilter   *price.Filter
+
+	// Product is the one matched by the ProductFilter, nil if none did
+	Product *product.Product
+
+	// Prices are all the prices of the Product matched by the PriceFilter
+	Prices []*price.Price
+
+	// Price is the one selected from the Prices to compute the Rate, it's
+	// nil for the tiered components as the Rate is computed from all of them
+	Price *price.Price
+}
+
+// Explanation is the detail of how the cost of a single resource has been estimated, see Plan.Explain.
+type Explanation struct {
+	Address string
+
+	// Prior and Planned are the explanations of the components of the resource sorted
+	// by name on each State, they are nil if the resource is not on the State
+	Prior, Planned []ComponentExplanation
+}
+
+// ComponentExplanation is how the cost of a single Component has been computed.
+type ComponentExplanation struct {
+	Name string
+
+	// Trace has the product and price filters and what they matched
+	Trace
+
+	Quantity decimal.Decimal
+	Unit     string
+	Rate     Cost
+	Hourly   bool
+	Subsumed bool
+	Cost     Cost
+
+	// Breakdown is the quantity × rate math, see Component.Breakdown
+	Breakdown string
+
+	Error error
+}
+
+// Explain returns the Explanation of the cost of the resource with the address on the Prior and the Planned State:
+// for each component the product and price filters used, the product and price matched and the quantity × rate math.
+// The States have to be built with the StateOptions.Trace (see terracost.WithExplain) or ErrNotTraced is returned.
+func (p *Plan) Explain(address string) (Explanation, error) {
+	prior, priorOK := stateResource(p.Prior, address)
+	planned, plannedOK := stateResource(p.Planned, address)
+	if !priorOK && !plannedOK {
+		return Explanation{}, ErrResourceNotFound
+	}
+
+	exp := Explanation{Address: address}
+	var err error
+	if priorOK {
+		if exp.Prior, err = explainComponents(prior); err != nil {
+			return Explanation{}, err
+		}
+	}
+	if plannedOK {
+		if exp.Planned, err = explainComponents(planned); err != nil {
+			return Explanation{}, err
+		}
+	}
+
+	return exp, nil
+}
+
+// stateResource returns the Resource with the address of the s, which can be nil
+func stateResource(s *State, address string) (Resource, bool) {
+	if s == nil {
+		return Resource{}, false
+	}
+	re, ok := s.Resources[address]
+	return re, ok
+}
+
+// explainComponents returns the explanation of all the components of the re sorted by name
+func explainComponents(re Resource) ([]ComponentExplanation, error) {
+	names := make([]string, 0, len(re.Components))
+	for name := range re.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ces := make([]ComponentExplanation, 0, len(names))
+	for _, name := range names {
+		comp := re.Components[name]
+		if comp.Trace == nil {
+			return nil, ErrNotTraced
+		}
+		ces = append(ces, ComponentExplanation{
+			Name:      name,
+			Trace:     *comp.Trace,
+			Quantity:  comp.Quantity,
+			Unit:      comp.Unit,
+			Rate:      comp.Rate,
+			Hourly:    comp.Hourly,
+			Subsumed:  comp.Subsumed,
+			Cost:      comp.Cost(),
+			Breakdown: comp.Breakdown(),
+			Error:     comp.Error,
+		})
+	}
+	return ces, nil
+}
@@ -0,0 +1,113 @@
+package cost_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/cost"
+	"github.com/cycloidio/terracost/mock"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestPlan_Explain(t *testing.T) {
+	queries := []query.Resource{
+		{
+			Address: "aws_instance.test",
+			Components: []query.Component{
+				{
+					Name:           "Compute",
+					HourlyQuantity: decimal.NewFromInt(1),
+					Unit:           "Hrs",
+					ProductFilter: &product.Filter{
+						Provider: util.StringPtr("aws"),
+						Service:  util.StringPtr("AmazonEC2"),
+						AttributeFilters: []*product.AttributeFilter{
+							{Key: "instanceType", Value: util.StringPtr("t3.micro")},
+						},
+					},
+					PriceFilter: &price.Filter{
+						Unit: util.StringPtr("Hrs"),
+					},
+				},
+				{
+					Name:           "Monitoring",
+					HourlyQuantity: decimal.NewFromInt(1),
+				},
+			},
+		},
+	}
+
+	newState := func(t *testing.T, opts cost.StateOptions) *cost.State {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(42), SKU: "SKU42"}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod}, nil)
+		prc := &price.Price{ID: price.ID(7), Value: decimal.NewFromFloat(0.0104), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, queries[0].Components[0].PriceFilter).Return([]*price.Price{prc}, nil)
+
+		state, err := cost.NewStateWithOptions(ctx, backend, queries, opts)
+		require.NoError(t, err)
+		return state
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		plan := cost.NewPlan("", nil, newState(t, cost.StateOptions{Trace: true}))
+
+		exp, err := plan.Explain("aws_instance.test")
+		require.NoError(t, err)
+		assert.Equal(t, "aws_instance.test", exp.Address)
+		assert.Nil(t, exp.Prior)
+		require.Len(t, exp.Planned, 2)
+
+		compute := exp.Planned[0]
+		assert.Equal(t, "Compute", compute.Name)
+		assert.Equal(t, queries[0].Components[0].ProductFilter, compute.ProductFilter)
+		assert.Equal(t, queries[0].Components[0].PriceFilter, compute.PriceFilter)
+		require.NotNil(t, compute.Product)
+		assert.Equal(t, product.ID(42), compute.Product.ID)
+		require.NotNil(t, compute.Price)
+		assert.Equal(t, price.ID(7), compute.Price.ID)
+		assert.Len(t, compute.Prices, 1)
+		assert.True(t, compute.Hourly)
+		assert.Equal(t, "730 Hrs × 0.0104 USD = 7.59 USD", compute.Breakdown)
+		assert.Equal(t, "7.592", compute.Cost.Decimal.String())
+		assert.NoError(t, compute.Error)
+
+		// The free components have no product nor price
+		monitoring := exp.Planned[1]
+		assert.Equal(t, "Monitoring", monitoring.Name)
+		assert.Nil(t, monitoring.ProductFilter)
+		assert.Nil(t, monitoring.Product)
+		assert.Nil(t, monitoring.Price)
+		assert.True(t, monitoring.Cost.IsZero())
+	})
+
+	t.Run("NotTraced", func(t *testing.T) {
+		plan := cost.NewPlan("", nil, newState(t, cost.StateOptions{}))
+
+		_, err := plan.Explain("aws_instance.test")
+		assert.Equal(t, cost.ErrNotTraced, err)
+	})
+
+	t.Run("ResourceNotFound", func(t *testing.T) {
+		plan := cost.NewPlan("", nil, newState(t, cost.StateOptions{Trace: true}))
+
+		_, err := plan.Explain("aws_instance.missing")
+		assert.Equal(t, cost.ErrResourceNotFound, err)
+	})
+}
@@ -0,0 +1,335 @@
+package cost
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// csvHeader is the header of the CSV export, one row is written for each component
+// and the breakdown is the planned one or the prior if the component is removed
+var csvHeader = []string{"address", "provider", "type", "component", "details", "unit", "prior_quantity", "planned_quantity", "prior_cost", "planned_cost", "currency", "error", "breakdown"}
+
+// PlanExport is the JSON representation of a Plan written by WriteJSON,
+// all the costs are monthly and the decimals are strings to keep the precision.
+type PlanExport struct {
+	Name        string           `json:"name"`
+	Currency    string           `json:"currency"`
+	PriorCost   decimal.Decimal  `json:"prior_cost"`
+	PlannedCost decimal.Decimal  `json:"planned_cost"`
+	Resources   []ResourceExport `json:"resources"`
+	Skipped     []string         `json:"skipped"`
+	Warnings    []Warning        `json:"warnings,omitempty"`
+}
+
+// ResourceExport is the JSON representation of a ResourceDiff.
+type ResourceExport struct {
+	Address     string            `json:"address"`
+	Provider    string            `json:"provider"`
+	Type        string            `json:"type"`
+	PriorCost   decimal.Decimal   `json:"prior_cost"`
+	PlannedCost decimal.Decimal   `json:"planned_cost"`
+	Components  []ComponentExport `json:"components"`
+
+	Indeterminate string `json:"indeterminate,omitempty"`
+
+	// Confidence is the least trustworthy of the Planned (or Prior
+	// if removed) components, see Resource.Confidence
+	Confidence Confidence `json:"confidence,omitempty"`
+}
+
+// ComponentExport is the JSON representation of a ComponentDiff, the Prior or
+// Planned are nil if the component doesn't exist on that side of the Plan.
+type ComponentExport struct {
+	Label   string               `json:"label"`
+	Details []string             `json:"details,omitempty"`
+	Prior   *ComponentCostExport `json:"prior,omitempty"`
+	Planned *ComponentCostExport `json:"planned,omitempty"`
+}
+
+// ComponentCostExport is the JSON representation of a Component, the Rate is the monthly one
+// and the Breakdown is the human readable computation of the Cost, see Component.Breakdown.
+type ComponentCostExport struct {
+	Quantity  decimal.Decimal `json:"quantity"`
+	Unit      string          `json:"unit"`
+	Rate      decimal.Decimal `json:"rate"`
+	Cost      decimal.Decimal `json:"cost"`
+	Breakdown string          `json:"breakdown,omitempty"`
+	Usage     bool            `json:"usage,omitempty"`
+	Subsumed  bool            `json:"subsumed,omitempty"`
+	Error     string          `json:"error,omitempty"`
+
+	// Overridden is set when the Rate is a RateOverride, see Component.Overridden
+	Overridden bool `json:"overridden,omitempty"`
+
+	// Confidence is how trustworthy the Cost is, see Component.Confidence
+	Confidence Confidence `json:"confidence,omitempty"`
+
+	// SKU, RateCode and Attributes are the ones of the Annotation, only set
+	// when the estimation is annotated (see StateOptions.Annotate)
+	SKU        string            `json:"sku,omitempty"`
+	RateCode   string            `json:"rate_code,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// ListRate and ListCost are the ones before the Adjustment, only
+	// set when the estimation is adjusted (see StateOptions.Adjustment)
+	ListRate *decimal.Decimal `json:"list_rate,omitempty"`
+	ListCost *decimal.Decimal `json:"list_cost,omitempty"`
+}
+
+// NewPlanExport returns the PlanExport of the plan with the resources sorted by address
+// and the components by label. The breakdowns are formatted with the first of the fos
+// or the DefaultFormatOptions, the costs keep their full precision.
+func NewPlanExport(plan *Plan, fos ...FormatOptions) (*PlanExport, error) {
+	fo := DefaultFormatOptions
+	if len(fos) > 0 {
+		fo = fos[0]
+	}
+
+	prior, err := plan.PriorCost()
+	if err != nil {
+		return nil, err
+	}
+	planned, err := plan.PlannedCost()
+	if err != nil {
+		return nil, err
+	}
+
+	currency := planned.Currency
+	if currency == "" {
+		currency = prior.Currency
+	}
+
+	pe := &PlanExport{
+		Name:        plan.Name,
+		Currency:    currency,
+		PriorCost:   prior.Decimal,
+		PlannedCost: planned.Decimal,
+		Resources:   make([]ResourceExport, 0),
+		Skipped:     plan.SkippedAddresses(),
+	}
+	if ws := plan.Warnings(); len(ws) > 0 {
+		pe.Warnings = ws
+	}
+
+	for _, rd := range plan.ResourceDifferences() {
+		prior, err := rd.PriorCost()
+		if err != nil {
+			return nil, err
+		}
+		planned, err := rd.PlannedCost()
+		if err != nil {
+			return nil, err
+		}
+
+		re := ResourceExport{
+			Address:     rd.Address,
+			Provider:    rd.Provider,
+			Type:        rd.Type,
+			PriorCost:   prior.Decimal,
+			PlannedCost: planned.Decimal,
+			Components:  make([]ComponentExport, 0, len(rd.ComponentDiffs)),
+
+			Indeterminate: rd.Indeterminate,
+			Confidence:    rd.Confidence(),
+		}
+		for _, label := range sortedLabels(rd.ComponentDiffs) {
+			cd := rd.ComponentDiffs[label]
+			ce := ComponentExport{
+				Label:   label,
+				Prior:   newComponentCostExport(cd.Prior, fo),
+				Planned: newComponentCostExport(cd.Planned, fo),
+			}
+			if cd.Planned != nil {
+				ce.Details = cd.Planned.Details
+			} else if cd.Prior != nil {
+				ce.Details = cd.Prior.Details
+			}
+			re.Components = append(re.Components, ce)
+		}
+		pe.Resources = append(pe.Resources, re)
+	}
+
+	return pe, nil
+}
+
+// WriteJSON writes the full breakdown of the plan as JSON to w, see PlanExport.
+func WriteJSON(w io.Writer, plan *Plan, fos ...FormatOptions) error {
+	pe, err := NewPlanExport(plan, fos...)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(pe)
+}
+
+// WriteCSV writes the breakdown of the plan as CSV to w with a row for each component
+// of each resource, the details of the component are joined with '; '.
+func WriteCSV(w io.Writer, plan *Plan, fos ...FormatOptions) error {
+	pe, err := NewPlanExport(plan, fos...)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, re := range pe.Resources {
+		for _, ce := range re.Components {
+			var unit, priorQuantity, plannedQuantity, priorCost, plannedCost, errMsg, breakdown string
+			if ce.Prior != nil {
+				unit = ce.Prior.Unit
+				priorQuantity = ce.Prior.Quantity.String()
+				priorCost = ce.Prior.Cost.String()
+				errMsg = ce.Prior.Error
+				breakdown = ce.Prior.Breakdown
+			}
+			if ce.Planned != nil {
+				unit = ce.Planned.Unit
+				plannedQuantity = ce.Planned.Quantity.String()
+				plannedCost = ce.Planned.Cost.String()
+				if ce.Planned.Error != "" {
+					errMsg = ce.Planned.Error
+				}
+				breakdown = ce.Planned.Breakdown
+			}
+			err := cw.Write([]string{
+				re.Address, re.Provider, re.Type, ce.Label, strings.Join(ce.Details, "; "), unit,
+				priorQuantity, plannedQuantity, priorCost, plannedCost, pe.Currency, errMsg, breakdown,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// PolicyExport is the flat JSON representation of a Plan written by WritePolicyJSON for the policy engines
+// (ex: the OPA 'input' of conftest). Its shape is stable and the decimals are strings to keep the precision,
+// all the costs are monthly and the Delta is the planned cost minus the prior one.
+type PolicyExport struct {
+	Name             string                 `json:"name"`
+	Currency         string                 `json:"currency"`
+	PriorMonthlyCost decimal.Decimal        `json:"prior_monthly_cost"`
+	MonthlyCost      decimal.Decimal        `json:"monthly_cost"`
+	Delta            decimal.Decimal        `json:"delta"`
+	Resources        []PolicyResourceExport `json:"resources"`
+}
+
+// PolicyResourceExport is the flat JSON representation of a ResourceDiff, the MonthlyCost is
+// the planned one so it's zero for the removed resources.
+type PolicyResourceExport struct {
+	Address     string          `json:"address"`
+	Type        string          `json:"type"`
+	MonthlyCost decimal.Decimal `json:"monthly_cost"`
+	Delta       decimal.Decimal `json:"delta"`
+	Currency    string          `json:"currency"`
+}
+
+// NewPolicyExport returns the PolicyExport of the plan with the resources sorted by address,
+// the skipped resources (without cost) are not part of it.
+func NewPolicyExport(plan *Plan) (*PolicyExport, error) {
+	prior, err := plan.PriorCost()
+	if err != nil {
+		return nil, err
+	}
+	planned, err := plan.PlannedCost()
+	if err != nil {
+		return nil, err
+	}
+
+	currency := planned.Currency
+	if currency == "" {
+		currency = prior.Currency
+	}
+
+	pe := &PolicyExport{
+		Name:             plan.Name,
+		Currency:         currency,
+		PriorMonthlyCost: prior.Decimal,
+		MonthlyCost:      planned.Decimal,
+		Delta:            planned.Decimal.Sub(prior.Decimal),
+		Resources:        make([]PolicyResourceExport, 0),
+	}
+
+	for _, rd := range plan.ResourceDifferences() {
+		s, err := rd.Summary()
+		if err != nil {
+			return nil, err
+		}
+		rcurrency := s.Delta.Currency
+		if rcurrency == "" {
+			rcurrency = currency
+		}
+		pe.Resources = append(pe.Resources, PolicyResourceExport{
+			Address:     rd.Address,
+			Type:        rd.Type,
+			MonthlyCost: s.Planned.Decimal,
+			Delta:       s.Delta.Decimal,
+			Currency:    rcurrency,
+		})
+	}
+
+	return pe, nil
+}
+
+// WritePolicyJSON writes the flat representation of the plan for the policy engines as JSON to w, see PolicyExport.
+func WritePolicyJSON(w io.Writer, plan *Plan) error {
+	pe, err := NewPolicyExport(plan)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(pe)
+}
+
+// newComponentCostExport returns the ComponentCostExport of c or nil if c is nil
+func newComponentCostExport(c *Component, fo FormatOptions) *ComponentCostExport {
+	if c == nil {
+		return nil
+	}
+	cce := &ComponentCostExport{
+		Quantity:  c.Quantity,
+		Unit:      c.Unit,
+		Rate:      c.Rate.Decimal,
+		Cost:      c.Cost().Decimal,
+		Breakdown: c.FormatBreakdown(fo),
+		Usage:     c.Usage,
+		Subsumed:  c.Subsumed,
+
+		Overridden: c.Overridden,
+		Confidence: c.Confidence,
+	}
+	if c.Error != nil {
+		cce.Error = c.Error.Error()
+	}
+	if !c.ListRate.IsZero() {
+		listCost := c.ListCost().Decimal
+		cce.ListRate, cce.ListCost = &c.ListRate.Decimal, &listCost
+	}
+	if c.Annotation != nil {
+		cce.SKU = c.Annotation.SKU
+		cce.RateCode = c.Annotation.RateCode
+		cce.Attributes = c.Annotation.Attributes
+	}
+	return cce
+}
+
+// sortedLabels returns the labels of the ComponentDiff sorted
+func sortedLabels(cds map[string]*ComponentDiff) []string {
+	labels := make([]string, 0, len(cds))
+	for l := range cds {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,125 @@
+package cost_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/cost"
+)
+
+func newExportPlan() *cost.Plan {
+	prior := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_instance.test": {
+				Provider: "aws",
+				Type:     "aws_instance",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Unit:     "Hrs",
+						Rate:     cost.NewMonthly(decimal.NewFromInt(10), "USD"),
+						Details:  []string{"Linux", "on-demand", "t3.micro"},
+					},
+				},
+			},
+		},
+	}
+	planned := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_instance.test": {
+				Provider: "aws",
+				Type:     "aws_instance",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Unit:     "Hrs",
+						Rate:     cost.NewMonthly(decimal.NewFromInt(20), "USD"),
+						Details:  []string{"Linux", "on-demand", "t3.small"},
+					},
+					"Root volume: Storage": {
+						Quantity: decimal.NewFromInt(8),
+						Unit:     "GB",
+						Rate:     cost.NewMonthly(decimal.NewFromFloat(0.1), "USD"),
+						Details:  []string{"gp2"},
+					},
+				},
+			},
+		},
+	}
+	return cost.NewPlan("test", prior, planned)
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := cost.WriteJSON(&buf, newExportPlan())
+	require.NoError(t, err)
+
+	var pe cost.PlanExport
+	err = json.Unmarshal(buf.Bytes(), &pe)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", pe.Name)
+	assert.Equal(t, "USD", pe.Currency)
+	assert.True(t, decimal.NewFromInt(10).Equal(pe.PriorCost), pe.PriorCost.String())
+	assert.True(t, decimal.NewFromFloat(20.8).Equal(pe.PlannedCost), pe.PlannedCost.String())
+
+	require.Len(t, pe.Resources, 1)
+	re := pe.Resources[0]
+	assert.Equal(t, "aws_instance.test", re.Address)
+	assert.Equal(t, "aws_instance", re.Type)
+	require.Len(t, re.Components, 2)
+
+	assert.Equal(t, "Compute", re.Components[0].Label)
+	assert.Equal(t, []string{"Linux", "on-demand", "t3.small"}, re.Components[0].Details)
+	require.NotNil(t, re.Components[0].Prior)
+	assert.True(t, decimal.NewFromInt(10).Equal(re.Components[0].Prior.Cost))
+
+	assert.Equal(t, "Root volume: Storage", re.Components[1].Label)
+	assert.Equal(t, []string{"gp2"}, re.Components[1].Details)
+	assert.Nil(t, re.Components[1].Prior)
+	require.NotNil(t, re.Components[1].Planned)
+	assert.Equal(t, "GB", re.Components[1].Planned.Unit)
+	assert.Equal(t, "8 GB × 0.1 USD = 0.80 USD", re.Components[1].Planned.Breakdown)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := cost.WriteCSV(&buf, newExportPlan())
+	require.NoError(t, err)
+
+	expected := `address,provider,type,component,details,unit,prior_quantity,planned_quantity,prior_cost,planned_cost,currency,error,breakdown
+aws_instance.test,aws,aws_instance,Compute,Linux; on-demand; t3.small,Hrs,1,1,10,20,USD,,1 Hrs × 20 USD = 20.00 USD
+aws_instance.test,aws,aws_instance,Root volume: Storage,gp2,GB,,8,,0.8,USD,,8 GB × 0.1 USD = 0.80 USD
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWritePolicyJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := cost.WritePolicyJSON(&buf, newExportPlan())
+	require.NoError(t, err)
+
+	expected := `{
+  "name": "test",
+  "currency": "USD",
+  "prior_monthly_cost": "10",
+  "monthly_cost": "20.8",
+  "delta": "10.8",
+  "resources": [
+    {
+      "address": "aws_instance.test",
+      "type": "aws_instance",
+      "monthly_cost": "20.8",
+      "delta": "10.8",
+      "currency": "USD"
+    }
+  ]
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
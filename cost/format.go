@@ -0,0 +1,56 @@
+package cost
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// FormatOptions controls how the costs are displayed, the decimals of the costs
+// are only rounded when formatted and keep their full precision otherwise.
+type FormatOptions struct {
+	// Decimals is the number of decimal places of the costs, rounded half up
+	Decimals int32
+
+	// RateDecimals is the number of decimal places of the per-unit rates,
+	// if it's negative the rates are displayed with their full precision
+	RateDecimals int32
+
+	// CurrencySymbol is written before the value (ex: "$11.50"),
+	// if it's empty the currency code is written after it (ex: "11.50 USD")
+	CurrencySymbol string
+}
+
+// DefaultFormatOptions is used when no FormatOptions are given, the
+// costs have 2 decimals and the rates their full precision.
+var DefaultFormatOptions = FormatOptions{Decimals: 2, RateDecimals: -1}
+
+// Format returns the cost d in the currency rounded to the Decimals.
+func (fo FormatOptions) Format(d decimal.Decimal, currency string) string {
+	return fo.withCurrency(roundHalfUp(d, fo.Decimals).StringFixed(fo.Decimals), currency)
+}
+
+// FormatRate returns the per-unit rate d in the currency rounded to the RateDecimals.
+func (fo FormatOptions) FormatRate(d decimal.Decimal, currency string) string {
+	if fo.RateDecimals < 0 {
+		return fo.withCurrency(d.String(), currency)
+	}
+	return fo.withCurrency(roundHalfUp(d, fo.RateDecimals).StringFixed(fo.RateDecimals), currency)
+}
+
+// withCurrency adds the CurrencySymbol or the currency to the value
+func (fo FormatOptions) withCurrency(value, currency string) string {
+	if fo.CurrencySymbol != "" {
+		return fo.CurrencySymbol + value
+	}
+	if currency == "" {
+		return value
+	}
+	return fmt.Sprintf("%s %s", value, currency)
+}
+
+// roundHalfUp rounds d to the places, the halves are rounded up (towards
+// positive infinity) which is not the case of decimal.Round for negative values
+func roundHalfUp(d decimal.Decimal, places int32) decimal.Decimal {
+	return d.Shift(places).Add(decimal.New(5, -1)).Floor().Shift(-places)
+}
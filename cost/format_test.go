@@ -0,0 +1,52 @@
+package cost_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cycloidio/terracost/cost"
+)
+
+func TestFormatOptions_Format(t *testing.T) {
+	testcases := []struct {
+		name     string
+		fo       cost.FormatOptions
+		value    string
+		currency string
+		expected string
+	}{
+		{name: "Default", fo: cost.DefaultFormatOptions, value: "11.5", currency: "USD", expected: "11.50 USD"},
+		{name: "HalfUp", fo: cost.FormatOptions{Decimals: 2}, value: "0.125", currency: "USD", expected: "0.13 USD"},
+		{name: "BelowHalf", fo: cost.FormatOptions{Decimals: 2}, value: "0.1249999", currency: "USD", expected: "0.12 USD"},
+		{name: "NegativeHalfUp", fo: cost.FormatOptions{Decimals: 2}, value: "-0.125", currency: "USD", expected: "-0.12 USD"},
+		{name: "FourDecimals", fo: cost.FormatOptions{Decimals: 4}, value: "0.00005", currency: "USD", expected: "0.0001 USD"},
+		{name: "NoDecimals", fo: cost.FormatOptions{Decimals: 0}, value: "2.5", currency: "EUR", expected: "3 EUR"},
+		{name: "CurrencySymbol", fo: cost.FormatOptions{Decimals: 2, CurrencySymbol: "$"}, value: "11.5", currency: "USD", expected: "$11.50"},
+		{name: "NoCurrency", fo: cost.FormatOptions{Decimals: 2}, value: "1", currency: "", expected: "1.00"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := decimal.RequireFromString(tc.value)
+			assert.Equal(t, tc.expected, tc.fo.Format(d, tc.currency))
+			// The value is not rounded
+			assert.Equal(t, tc.value, d.String())
+		})
+	}
+}
+
+func TestFormatOptions_FormatRate(t *testing.T) {
+	d := decimal.RequireFromString("0.02345")
+
+	assert.Equal(t, "0.02345 USD", cost.DefaultFormatOptions.FormatRate(d, "USD"))
+	assert.Equal(t, "$0.0235", cost.FormatOptions{RateDecimals: 4, CurrencySymbol: "$"}.FormatRate(d, "USD"))
+}
+
+func TestCost_Format(t *testing.T) {
+	c := cost.NewMonthly(decimal.RequireFromString("11.505"), "USD")
+
+	assert.Equal(t, "11.51 USD", c.Format(cost.DefaultFormatOptions))
+	assert.Equal(t, "$11.5050", c.Format(cost.FormatOptions{Decimals: 4, CurrencySymbol: "$"}))
+	assert.True(t, decimal.RequireFromString("11.505").Equal(c.Decimal))
+}
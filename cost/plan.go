@@ -1,11 +1,20 @@
 package cost
 
 import (
+	"errors"
+	"fmt"
 	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
 var isPlanned = true
 
+// ErrAddressCollision is returned by MergePlans when the same resource address is present on more
+// than one of the plans after being namespaced with the plan Name.
+var ErrAddressCollision = errors.New("resource address collision")
+
 // Plan is the cost difference between two State instances. It is not tied to any specific cloud provider or IaC tool.
 // Instead, it is a representation of the differences between two snapshots of cloud resources, with their associated
 // costs. The Plan instance can be used to calculate the total cost difference of a plan, as well as cost differences
@@ -20,6 +29,85 @@ func NewPlan(name string, prior, planned *State) *Plan {
 	return &Plan{Name: name, Prior: prior, Planned: planned}
 }
 
+// MergePlans combines the plans into a single one, for example the estimations of each workspace of an organization.
+// The addresses of the resources are namespaced with the Name of their plan ("name/address") and the Name of the
+// merged Plan is the names joined with ", ". An ErrAddressCollision is returned if the same address is on more than
+// one plan (ex: plans with the same Name) and an error is returned if the plans have different currencies.
+func MergePlans(plans ...*Plan) (*Plan, error) {
+	names := make([]string, 0, len(plans))
+	var prior, planned *State
+	for _, p := range plans {
+		if p == nil {
+			continue
+		}
+		if p.Name != "" {
+			names = append(names, p.Name)
+		}
+		if p.Prior != nil {
+			if prior == nil {
+				prior = &State{Resources: make(map[string]Resource)}
+			}
+			if err := mergeStateResources(prior, p.Prior, p.Name); err != nil {
+				return nil, err
+			}
+		}
+		if p.Planned != nil {
+			if planned == nil {
+				planned = &State{Resources: make(map[string]Resource)}
+			}
+			if err := mergeStateResources(planned, p.Planned, p.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	merged := NewPlan(strings.Join(names, ", "), prior, planned)
+
+	// We validate that all the currencies match
+	if _, err := merged.PriorCost(); err != nil {
+		return nil, err
+	}
+	if _, err := merged.PlannedCost(); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// MissingPricingError is returned by Plan.MissingPricing when some components have no product or price.
+type MissingPricingError struct {
+	// Components holds the labels of the components without pricing keyed by the resource address
+	Components map[string][]string
+
+	// errs are the ErrProductNotFound and ErrPriceNotFound errors hit by the components
+	errs []error
+}
+
+// Addresses returns the sorted addresses of the resources with components without pricing.
+func (e *MissingPricingError) Addresses() []string {
+	addrs := make([]string, 0, len(e.Components))
+	for addr := range e.Components {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// Error lists all the resources with the components without pricing.
+func (e *MissingPricingError) Error() string {
+	addrs := e.Addresses()
+	res := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		res = append(res, fmt.Sprintf("%s (%s)", addr, strings.Join(e.Components[addr], ", ")))
+	}
+	return fmt.Sprintf("missing pricing for %d resources: %s", len(addrs), strings.Join(res, "; "))
+}
+
+// Unwrap returns the ErrProductNotFound and ErrPriceNotFound hit by the components so errors.Is can be used on it.
+func (e *MissingPricingError) Unwrap() []error {
+	return e.errs
+}
+
 // PriorCost returns the total cost of the Prior State or decimal.Zero if it isn't included in the plan.
 func (p Plan) PriorCost() (Cost, error) {
 	if p.Prior == nil {
@@ -36,8 +124,48 @@ func (p Plan) PlannedCost() (Cost, error) {
 	return p.Planned.Cost()
 }
 
+// PriorHourlyCost returns the total hourly cost of the Prior State or decimal.Zero if it isn't included in the plan.
+func (p Plan) PriorHourlyCost() (decimal.Decimal, error) {
+	if p.Prior == nil {
+		return decimal.Zero, nil
+	}
+	return p.Prior.HourlyCost()
+}
+
+// PlannedHourlyCost returns the total hourly cost of the Planned State or decimal.Zero if it isn't included in the plan.
+func (p Plan) PlannedHourlyCost() (decimal.Decimal, error) {
+	if p.Planned == nil {
+		return decimal.Zero, nil
+	}
+	return p.Planned.HourlyCost()
+}
+
+// CostByTag returns the monthly cost of the Planned State resources aggregated by the value of their tag key,
+// see State.CostByTag. If the plan has no Planned State (ex: estimation of a Terraform state) the Prior one is used.
+func (p Plan) CostByTag(key string) (map[string]decimal.Decimal, error) {
+	return p.costBy(tagKey(key))
+}
+
+// CostByProvider returns the monthly cost of the Planned State resources aggregated by their provider,
+// see State.CostByProvider. If the plan has no Planned State (ex: estimation of a Terraform state) the Prior one is used.
+func (p Plan) CostByProvider() (map[string]decimal.Decimal, error) {
+	return p.costBy(providerKey)
+}
+
+// costBy returns the monthly cost of the Planned State resources, or the Prior ones if the plan has
+// no Planned State, aggregated by the key returned by keyFn, see State.costBy.
+func (p Plan) costBy(keyFn func(re Resource) string) (map[string]decimal.Decimal, error) {
+	if p.Planned != nil {
+		return p.Planned.costBy(keyFn)
+	}
+	if p.Prior != nil {
+		return p.Prior.costBy(keyFn)
+	}
+	return map[string]decimal.Decimal{}, nil
+}
+
 // ResourceDifferences merges the Prior and Planned State and returns a slice of differences between resources.
-// The order of the elements in the slice is undefined and unstable.
+// The elements of the slice are sorted by Address.
 func (p Plan) ResourceDifferences() []ResourceDiff {
 	rdmap := make(map[string]ResourceDiff)
 
@@ -52,11 +180,96 @@ func (p Plan) ResourceDifferences() []ResourceDiff {
 	for _, rd := range rdmap {
 		rds = append(rds, rd)
 	}
+	sort.Slice(rds, func(i, j int) bool {
+		return rds[i].Address < rds[j].Address
+	})
 	return rds
 }
 
+// MissingPricing returns a *MissingPricingError with all the components of the Prior and Planned
+// State that hit ErrProductNotFound or ErrPriceNotFound, or nil if every component is priced.
+func (p Plan) MissingPricing() error {
+	missing := make(map[string]map[string]struct{})
+	var productNotFound, priceNotFound bool
+	for _, s := range []*State{p.Prior, p.Planned} {
+		if s == nil {
+			continue
+		}
+		for addr, res := range s.Resources {
+			if res.Skipped {
+				continue
+			}
+			for label, comp := range res.Components {
+				isProduct, isPrice := errors.Is(comp.Error, ErrProductNotFound), errors.Is(comp.Error, ErrPriceNotFound)
+				if !isProduct && !isPrice {
+					continue
+				}
+				productNotFound = productNotFound || isProduct
+				priceNotFound = priceNotFound || isPrice
+				if _, ok := missing[addr]; !ok {
+					missing[addr] = make(map[string]struct{})
+				}
+				missing[addr][label] = struct{}{}
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	mpe := &MissingPricingError{Components: make(map[string][]string, len(missing))}
+	if productNotFound {
+		mpe.errs = append(mpe.errs, ErrProductNotFound)
+	}
+	if priceNotFound {
+		mpe.errs = append(mpe.errs, ErrPriceNotFound)
+	}
+	for addr, labels := range missing {
+		for label := range labels {
+			mpe.Components[addr] = append(mpe.Components[addr], label)
+		}
+		sort.Strings(mpe.Components[addr])
+	}
+	return mpe
+}
+
+// Warning is an assumption made to estimate the resource at Address, see query.Resource.
+type Warning struct {
+	Address string `json:"address"`
+	Message string `json:"message"`
+}
+
+// Warnings returns the warnings of the resources of the Prior and Planned State without duplicates,
+// sorted by address and message.
+func (p Plan) Warnings() []Warning {
+	seen := make(map[Warning]struct{})
+	warnings := make([]Warning, 0)
+	for _, s := range []*State{p.Prior, p.Planned} {
+		if s == nil {
+			continue
+		}
+		for addr, res := range s.Resources {
+			for _, msg := range res.Warnings {
+				w := Warning{Address: addr, Message: msg}
+				if _, ok := seen[w]; ok {
+					continue
+				}
+				seen[w] = struct{}{}
+				warnings = append(warnings, w)
+			}
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		if warnings[i].Address != warnings[j].Address {
+			return warnings[i].Address < warnings[j].Address
+		}
+		return warnings[i].Message < warnings[j].Message
+	})
+	return warnings
+}
+
 // SkippedAddresses returns the addresses of resources that were excluded from the estimation process.
-// The order of the elements in the slice is undefined and unstable.
+// The elements of the slice are sorted.
 func (p Plan) SkippedAddresses() []string {
 	skippedMap := make(map[string]struct{})
 	if p.Prior != nil {
@@ -97,6 +310,16 @@ func mergeResourceDiffsFromState(rdmap map[string]ResourceDiff, state *State, pl
 				ComponentDiffs: make(map[string]*ComponentDiff),
 			}
 		}
+		rd := rdmap[address]
+		if res.Indeterminate != "" {
+			rd.Indeterminate = res.Indeterminate
+		}
+		if planned {
+			rd.InPlanned = true
+		} else {
+			rd.InPrior = true
+		}
+		rdmap[address] = rd
 
 		for label, comp := range res.Components {
 			comp := comp
@@ -115,3 +338,18 @@ func mergeResourceDiffsFromState(rdmap map[string]ResourceDiff, state *State, pl
 		}
 	}
 }
+
+// mergeStateResources adds all the resources of the State src to dst with the address
+// namespaced with the name, if it's not empty
+func mergeStateResources(dst, src *State, name string) error {
+	for address, res := range src.Resources {
+		if name != "" {
+			address = name + "/" + address
+		}
+		if _, ok := dst.Resources[address]; ok {
+			return fmt.Errorf("%w: %s", ErrAddressCollision, address)
+		}
+		dst.Resources[address] = res
+	}
+	return nil
+}
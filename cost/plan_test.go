@@ -31,6 +31,7 @@ func TestPlan_ResourceDifferences(t *testing.T) {
 		require.Len(t, resourceDiffs, 1)
 		assert.Contains(t, resourceDiffs, cost.ResourceDiff{
 			Address: "aws_instance.test1",
+			InPrior: true,
 			ComponentDiffs: map[string]*cost.ComponentDiff{
 				"EC2 instance hours": {
 					Prior: &cost.Component{
@@ -62,7 +63,8 @@ func TestPlan_ResourceDifferences(t *testing.T) {
 
 		require.Len(t, resourceDiffs, 1)
 		assert.Contains(t, resourceDiffs, cost.ResourceDiff{
-			Address: "aws_instance.test1",
+			Address:   "aws_instance.test1",
+			InPlanned: true,
 			ComponentDiffs: map[string]*cost.ComponentDiff{
 				"EC2 instance hours": {
 					Planned: &cost.Component{
@@ -125,7 +127,9 @@ func TestPlan_ResourceDifferences(t *testing.T) {
 
 		require.Len(t, resourceDiffs, 3)
 		assert.Contains(t, resourceDiffs, cost.ResourceDiff{
-			Address: "aws_instance.test_update",
+			Address:   "aws_instance.test_update",
+			InPrior:   true,
+			InPlanned: true,
 			ComponentDiffs: map[string]*cost.ComponentDiff{
 				"EC2 instance hours": {
 					Prior: &cost.Component{
@@ -142,7 +146,8 @@ func TestPlan_ResourceDifferences(t *testing.T) {
 			},
 		})
 		assert.Contains(t, resourceDiffs, cost.ResourceDiff{
-			Address: "aws_instance.test_create",
+			Address:   "aws_instance.test_create",
+			InPlanned: true,
 			ComponentDiffs: map[string]*cost.ComponentDiff{
 				"EC2 instance hours": {
 					Planned: &cost.Component{
@@ -155,6 +160,7 @@ func TestPlan_ResourceDifferences(t *testing.T) {
 		})
 		assert.Contains(t, resourceDiffs, cost.ResourceDiff{
 			Address: "aws_instance.test_delete",
+			InPrior: true,
 			ComponentDiffs: map[string]*cost.ComponentDiff{
 				"EC2 instance hours": {
 					Prior: &cost.Component{
@@ -165,6 +171,12 @@ func TestPlan_ResourceDifferences(t *testing.T) {
 				},
 			},
 		})
+
+		addresses := make([]string, 0, len(resourceDiffs))
+		for _, rd := range resourceDiffs {
+			addresses = append(addresses, rd.Address)
+		}
+		assert.Equal(t, []string{"aws_instance.test_create", "aws_instance.test_delete", "aws_instance.test_update"}, addresses)
 	})
 }
 
@@ -254,3 +266,266 @@ func TestPlan_SkippedAddresses(t *testing.T) {
 		assert.Contains(t, skipped, "aws_invalid_resource.skipped_planned")
 	})
 }
+
+func TestPlan_CostByTag(t *testing.T) {
+	state := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_instance.front": {
+				Tags: map[string]string{"team": "web"},
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(730),
+						Rate:     cost.NewMonthly(decimal.NewFromFloat(1.5), "USD"),
+					},
+				},
+			},
+			"aws_instance.back": {
+				Tags: map[string]string{"team": "web", "env": "prod"},
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(10),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(2), "USD"),
+					},
+				},
+			},
+			"aws_db_instance.db": {
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(100), "USD"),
+					},
+				},
+			},
+			"aws_invalid_resource.test_skipped": {
+				Skipped: true,
+			},
+		},
+	}
+
+	t.Run("Planned", func(t *testing.T) {
+		costs, err := cost.NewPlan("name", nil, state).CostByTag("team")
+		require.NoError(t, err)
+		require.Len(t, costs, 2)
+		assert.True(t, decimal.NewFromInt(1115).Equal(costs["web"]), costs["web"].String())
+		assert.True(t, decimal.NewFromInt(100).Equal(costs[cost.UntaggedKey]), costs[cost.UntaggedKey].String())
+	})
+
+	t.Run("OnlyPrior", func(t *testing.T) {
+		costs, err := cost.NewPlan("name", state, nil).CostByTag("env")
+		require.NoError(t, err)
+		require.Len(t, costs, 2)
+		assert.True(t, decimal.NewFromInt(20).Equal(costs["prod"]), costs["prod"].String())
+		assert.True(t, decimal.NewFromInt(1195).Equal(costs[cost.UntaggedKey]), costs[cost.UntaggedKey].String())
+	})
+}
+
+func TestPlan_CostByProvider(t *testing.T) {
+	state := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_instance.front": {
+				Provider: "aws",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(730),
+						Rate:     cost.NewMonthly(decimal.NewFromFloat(1.5), "USD"),
+					},
+				},
+			},
+			"azurerm_linux_virtual_machine.back": {
+				Provider: "azurerm",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(10),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(2), "USD"),
+					},
+				},
+			},
+			"custom_resource.test": {
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(100), "USD"),
+					},
+				},
+			},
+			"aws_invalid_resource.test_skipped": {
+				Provider: "aws",
+				Skipped:  true,
+			},
+		},
+	}
+
+	t.Run("Planned", func(t *testing.T) {
+		costs, err := cost.NewPlan("name", nil, state).CostByProvider()
+		require.NoError(t, err)
+		require.Len(t, costs, 3)
+		assert.True(t, decimal.NewFromInt(1095).Equal(costs["aws"]), costs["aws"].String())
+		assert.True(t, decimal.NewFromInt(20).Equal(costs["azurerm"]), costs["azurerm"].String())
+		assert.True(t, decimal.NewFromInt(100).Equal(costs[cost.UnknownProviderKey]), costs[cost.UnknownProviderKey].String())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		costs, err := cost.NewPlan("name", nil, nil).CostByProvider()
+		require.NoError(t, err)
+		assert.Empty(t, costs)
+	})
+}
+
+func TestMergePlans(t *testing.T) {
+	newState := func(address string, monthly int64, currency string) *cost.State {
+		return &cost.State{
+			Resources: map[string]cost.Resource{
+				address: {
+					Components: map[string]cost.Component{
+						"Compute": {
+							Quantity: decimal.NewFromInt(1),
+							Rate:     cost.NewMonthly(decimal.NewFromInt(monthly), currency),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		staging := cost.NewPlan("staging", nil, newState("aws_instance.web", 10, "USD"))
+		prod := cost.NewPlan("prod", newState("aws_instance.web", 20, "USD"), newState("aws_instance.web", 30, "USD"))
+
+		plan, err := cost.MergePlans(staging, prod)
+		require.NoError(t, err)
+		assert.Equal(t, "staging, prod", plan.Name)
+
+		require.NotNil(t, plan.Prior)
+		assert.Len(t, plan.Prior.Resources, 1)
+		assert.Contains(t, plan.Prior.Resources, "prod/aws_instance.web")
+
+		require.NotNil(t, plan.Planned)
+		assert.Len(t, plan.Planned.Resources, 2)
+		assert.Contains(t, plan.Planned.Resources, "staging/aws_instance.web")
+		assert.Contains(t, plan.Planned.Resources, "prod/aws_instance.web")
+
+		prior, err := plan.PriorCost()
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(20).Equal(prior.Decimal), prior.Decimal.String())
+
+		planned, err := plan.PlannedCost()
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(40).Equal(planned.Decimal), planned.Decimal.String())
+	})
+
+	t.Run("AddressCollision", func(t *testing.T) {
+		a := cost.NewPlan("prod", nil, newState("aws_instance.web", 10, "USD"))
+		b := cost.NewPlan("prod", nil, newState("aws_instance.web", 20, "USD"))
+
+		_, err := cost.MergePlans(a, b)
+		assert.ErrorIs(t, err, cost.ErrAddressCollision)
+	})
+
+	t.Run("CurrencyMismatch", func(t *testing.T) {
+		a := cost.NewPlan("staging", nil, newState("aws_instance.web", 10, "USD"))
+		b := cost.NewPlan("prod", nil, newState("aws_instance.web", 20, "EUR"))
+
+		_, err := cost.MergePlans(a, b)
+		assert.Error(t, err)
+	})
+}
+
+func TestPlan_MissingPricing(t *testing.T) {
+	t.Run("Priced", func(t *testing.T) {
+		state := &cost.State{
+			Resources: map[string]cost.Resource{
+				"aws_instance.test": {
+					Components: map[string]cost.Component{
+						"Compute": {Quantity: decimal.NewFromInt(1), Rate: cost.NewMonthly(decimal.NewFromInt(1), "USD")},
+					},
+				},
+			},
+		}
+		assert.NoError(t, cost.NewPlan("name", state, state).MissingPricing())
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		prior := &cost.State{
+			Resources: map[string]cost.Resource{
+				"aws_instance.test": {
+					Components: map[string]cost.Component{
+						"Compute": {Error: cost.ErrPriceNotFound},
+					},
+				},
+				"aws_invalid_resource.test_skipped": {
+					Skipped: true,
+				},
+			},
+		}
+		planned := &cost.State{
+			Resources: map[string]cost.Resource{
+				"aws_instance.test": {
+					Components: map[string]cost.Component{
+						"Compute":              {Error: cost.ErrPriceNotFound},
+						"Root volume: Storage": {Error: cost.ErrProductNotFound},
+					},
+				},
+				"aws_db_instance.test": {
+					Components: map[string]cost.Component{
+						"Storage": {Error: cost.ErrProductNotFound},
+						"Compute": {Quantity: decimal.NewFromInt(1), Rate: cost.NewMonthly(decimal.NewFromInt(1), "USD")},
+					},
+				},
+			},
+		}
+
+		err := cost.NewPlan("name", prior, planned).MissingPricing()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, cost.ErrPriceNotFound)
+
+		var mpe *cost.MissingPricingError
+		require.ErrorAs(t, err, &mpe)
+		assert.Equal(t, []string{"aws_db_instance.test", "aws_instance.test"}, mpe.Addresses())
+		assert.Equal(t, []string{"Compute", "Root volume: Storage"}, mpe.Components["aws_instance.test"])
+		assert.Equal(t, "missing pricing for 2 resources: aws_db_instance.test (Storage); aws_instance.test (Compute, Root volume: Storage)", err.Error())
+	})
+
+	t.Run("MissingPriceOnly", func(t *testing.T) {
+		planned := &cost.State{
+			Resources: map[string]cost.Resource{
+				"aws_instance.test": {
+					Components: map[string]cost.Component{
+						"Compute": {Error: cost.ErrPriceNotFound},
+					},
+				},
+			},
+		}
+
+		err := cost.NewPlan("name", nil, planned).MissingPricing()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, cost.ErrPriceNotFound)
+		assert.NotErrorIs(t, err, cost.ErrProductNotFound)
+	})
+}
+
+func TestPlan_Warnings(t *testing.T) {
+	prior := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_lb.test": {
+				Warnings: []string{`unknown load_balancer_type "other", an application load balancer is assumed`},
+			},
+		},
+	}
+	planned := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_lb.test": {
+				Warnings: []string{`unknown load_balancer_type "other", an application load balancer is assumed`},
+			},
+			"aws_eks_node_group.test": {
+				Warnings: []string{"no instance_types, the default t3.medium is assumed"},
+			},
+			"aws_instance.test": {},
+		},
+	}
+
+	assert.Empty(t, cost.NewPlan("name", nil, nil).Warnings())
+	assert.Equal(t, []cost.Warning{
+		{Address: "aws_eks_node_group.test", Message: "no instance_types, the default t3.medium is assumed"},
+		{Address: "aws_lb.test", Message: `unknown load_balancer_type "other", an application load balancer is assumed`},
+	}, cost.NewPlan("name", prior, planned).Warnings())
+}
@@ -1,14 +1,30 @@
 package cost
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // Resource represents costs of a single cloud resource. Each Resource includes a Component map, keyed
 // by the label.
 type Resource struct {
 	Provider   string
 	Type       string
+	Tags       map[string]string
 	Components map[string]Component
 	Skipped    bool
+
+	// Indeterminate is the reason why the cost may not be accurate, see query.Resource
+	Indeterminate string
+
+	// Warnings are the assumptions made to estimate the Resource, see query.Resource
+	Warnings []string
+
+	// Count is the number of identical resources it represents, see query.Resource,
+	// the quantities (and so the costs) of the Components are the ones of all of them
+	Count int
 }
 
 // Cost returns the sum of costs of every Component of this Resource.
@@ -25,6 +41,63 @@ func (re Resource) Cost() (Cost, error) {
 	return total, nil
 }
 
+// ListCost returns the sum of the list costs (see Component.ListCost) of every Component of this Resource.
+// Error is returned if there is a mismatch in Component currency.
+func (re Resource) ListCost() (Cost, error) {
+	var total Cost
+	var err error
+	for name, comp := range re.Components {
+		total, err = total.Add(comp.ListCost())
+		if err != nil {
+			return Zero, fmt.Errorf("failed to add list cost of component %s: %w", name, err)
+		}
+	}
+	return total, nil
+}
+
+// Confidence returns the least trustworthy Confidence of the Components of this Resource
+// with a cost, so the low confidence resources can be flagged. It's Exact if there are none.
+func (re Resource) Confidence() Confidence {
+	c := Exact
+	for _, comp := range re.Components {
+		if comp.Subsumed {
+			continue
+		}
+		c = c.Lower(comp.Confidence)
+	}
+	return c
+}
+
+// HourlyCost returns the sum of the hourly costs of every Component of this Resource.
+// Error is returned if there is a mismatch in Component currency.
+func (re Resource) HourlyCost() (decimal.Decimal, error) {
+	// We validate that all the currencies match
+	if _, err := re.Cost(); err != nil {
+		return decimal.Zero, err
+	}
+
+	total := decimal.Zero
+	for _, comp := range re.Components {
+		total = total.Add(comp.HourlyCost())
+	}
+	return total, nil
+}
+
+// CostOver returns the sum of costs of every Component of this Resource over the duration d.
+// The Decimal of the returned Cost is the total for the duration and not the monthly one.
+func (re Resource) CostOver(d time.Duration) (Cost, error) {
+	c, err := re.Cost()
+	if err != nil {
+		return Zero, err
+	}
+
+	total := decimal.Zero
+	for _, comp := range re.Components {
+		total = total.Add(comp.CostOver(d))
+	}
+	return Cost{Decimal: total, Currency: c.Currency}, nil
+}
+
 // ResourceDiff is the difference in costs between prior and planned Resource. It contains a ComponentDiff
 // map, keyed by the label.
 type ResourceDiff struct {
@@ -32,6 +105,36 @@ type ResourceDiff struct {
 	Provider       string
 	Type           string
 	ComponentDiffs map[string]*ComponentDiff
+
+	// Indeterminate is the reason why the Planned (or Prior)
+	// cost may not be accurate, see Resource
+	Indeterminate string
+
+	// InPrior and InPlanned are set when the Resource is on the Prior
+	// and Planned State, even if it has no Components
+	InPrior, InPlanned bool
+}
+
+// Confidence returns the least trustworthy Confidence of the Planned components with a cost, or of the
+// Prior ones if the resource is removed, see Resource.Confidence.
+func (rd ResourceDiff) Confidence() Confidence {
+	var planned, prior Confidence = Exact, Exact
+	var hasPlanned bool
+	for _, cd := range rd.ComponentDiffs {
+		if cd.Planned != nil {
+			hasPlanned = true
+			if !cd.Planned.Subsumed {
+				planned = planned.Lower(cd.Planned.Confidence)
+			}
+		}
+		if cd.Prior != nil && !cd.Prior.Subsumed {
+			prior = prior.Lower(cd.Prior.Confidence)
+		}
+	}
+	if hasPlanned {
+		return planned
+	}
+	return prior
 }
 
 // PriorCost returns the sum of costs of every Component's PriorCost.
@@ -84,3 +187,101 @@ func (rd ResourceDiff) Valid() bool {
 	}
 	return true
 }
+
+// Classification is the kind of change of the cost of a ResourceDiff
+type Classification string
+
+// List of all the Classification of a ResourceDiff
+const (
+	// Added is a resource only on the Planned State
+	Added Classification = "added"
+
+	// Removed is a resource only on the Prior State
+	Removed Classification = "removed"
+
+	// Increased is a resource whose Planned cost is higher than the Prior one
+	Increased Classification = "increased"
+
+	// Decreased is a resource whose Planned cost is lower than the Prior one
+	Decreased Classification = "decreased"
+
+	// Unchanged is a resource with the same Prior and Planned cost
+	Unchanged Classification = "unchanged"
+)
+
+// DiffSummary is the machine-readable summary of the change of a ResourceDiff.
+type DiffSummary struct {
+	Classification Classification
+
+	Prior, Planned Cost
+
+	// Delta is the Planned cost minus the Prior one
+	Delta Cost
+
+	// RelativeDelta is the Delta as a fraction of the Prior cost (ex: 0.5 for +50%),
+	// it's not Valid when the Prior cost is zero as it's undefined
+	RelativeDelta decimal.NullDecimal
+}
+
+// Summary returns the DiffSummary with the classification of the change and the delta
+// between the Prior and Planned costs. A resource that exists on both the Prior and Planned
+// State is Increased or Decreased even if one of the costs is zero (ex: from zero to nonzero).
+// The existence is the one of InPrior and InPlanned, or the one of the Components when none is set.
+// Error is returned if there is a mismatch between currencies of the Components.
+func (rd ResourceDiff) Summary() (DiffSummary, error) {
+	var s DiffSummary
+	prior, err := rd.PriorCost()
+	if err != nil {
+		return s, err
+	}
+	planned, err := rd.PlannedCost()
+	if err != nil {
+		return s, err
+	}
+
+	currency := planned.Currency
+	if currency == "" {
+		currency = prior.Currency
+	} else if prior.Currency != "" && prior.Currency != currency {
+		return s, fmt.Errorf("currency mismatch: expected %s, got %s", prior.Currency, currency)
+	}
+
+	s.Prior, s.Planned = prior, planned
+	s.Delta = Cost{Decimal: planned.Decimal.Sub(prior.Decimal), Currency: currency}
+	if !prior.Decimal.IsZero() {
+		s.RelativeDelta = decimal.NewNullDecimal(s.Delta.Decimal.Div(prior.Decimal))
+	}
+
+	hasPrior, hasPlanned := rd.InPrior, rd.InPlanned
+	if !hasPrior && !hasPlanned {
+		for _, cd := range rd.ComponentDiffs {
+			hasPrior = hasPrior || cd.Prior != nil
+			hasPlanned = hasPlanned || cd.Planned != nil
+		}
+	}
+
+	switch {
+	case hasPlanned && !hasPrior:
+		s.Classification = Added
+	case hasPrior && !hasPlanned:
+		s.Classification = Removed
+	case s.Delta.Decimal.IsPositive():
+		s.Classification = Increased
+	case s.Delta.Decimal.IsNegative():
+		s.Classification = Decreased
+	default:
+		s.Classification = Unchanged
+	}
+
+	return s, nil
+}
+
+// Classification returns the Classification of the change of the ResourceDiff, see Summary.
+// Error is returned if there is a mismatch between currencies of the Components.
+func (rd ResourceDiff) Classification() (Classification, error) {
+	s, err := rd.Summary()
+	if err != nil {
+		return "", err
+	}
+	return s.Classification, nil
+}
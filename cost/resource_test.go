@@ -245,3 +245,117 @@ func TestResourceDiff_Cost(t *testing.T) {
 		assert.Contains(t, errs, err.Error())
 	})
 }
+
+func TestResourceDiff_Summary(t *testing.T) {
+	component := func(monthly float64) *cost.Component {
+		return &cost.Component{
+			Rate:     cost.NewMonthly(decimal.NewFromFloat(monthly), "USD"),
+			Quantity: decimal.NewFromInt(1),
+		}
+	}
+
+	tcs := []struct {
+		name           string
+		cd             *cost.ComponentDiff
+		classification cost.Classification
+		delta          decimal.Decimal
+		relativeDelta  decimal.NullDecimal
+	}{
+		{
+			name:           "Added",
+			cd:             &cost.ComponentDiff{Planned: component(10)},
+			classification: cost.Added,
+			delta:          decimal.NewFromInt(10),
+		},
+		{
+			name:           "Removed",
+			cd:             &cost.ComponentDiff{Prior: component(10)},
+			classification: cost.Removed,
+			delta:          decimal.NewFromInt(-10),
+			relativeDelta:  decimal.NewNullDecimal(decimal.NewFromInt(-1)),
+		},
+		{
+			name:           "Increased",
+			cd:             &cost.ComponentDiff{Prior: component(10), Planned: component(15)},
+			classification: cost.Increased,
+			delta:          decimal.NewFromInt(5),
+			relativeDelta:  decimal.NewNullDecimal(decimal.NewFromFloat(0.5)),
+		},
+		{
+			name:           "Decreased",
+			cd:             &cost.ComponentDiff{Prior: component(10), Planned: component(5)},
+			classification: cost.Decreased,
+			delta:          decimal.NewFromInt(-5),
+			relativeDelta:  decimal.NewNullDecimal(decimal.NewFromFloat(-0.5)),
+		},
+		{
+			name:           "Unchanged",
+			cd:             &cost.ComponentDiff{Prior: component(10), Planned: component(10)},
+			classification: cost.Unchanged,
+			delta:          decimal.Zero,
+			relativeDelta:  decimal.NewNullDecimal(decimal.Zero),
+		},
+		{
+			name:           "ZeroToNonzero",
+			cd:             &cost.ComponentDiff{Prior: component(0), Planned: component(10)},
+			classification: cost.Increased,
+			delta:          decimal.NewFromInt(10),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			rd := cost.ResourceDiff{
+				ComponentDiffs: map[string]*cost.ComponentDiff{"comp": tc.cd},
+			}
+
+			s, err := rd.Summary()
+			require.NoError(t, err)
+			assert.Equal(t, tc.classification, s.Classification)
+			assert.Equal(t, "USD", s.Delta.Currency)
+			assertDecimalEqual(t, tc.delta, s.Delta.Decimal)
+			assert.Equal(t, tc.relativeDelta.Valid, s.RelativeDelta.Valid)
+			if tc.relativeDelta.Valid {
+				assertDecimalEqual(t, tc.relativeDelta.Decimal, s.RelativeDelta.Decimal)
+			}
+
+			c, err := rd.Classification()
+			require.NoError(t, err)
+			assert.Equal(t, tc.classification, c)
+		})
+	}
+
+	t.Run("ZeroComponents", func(t *testing.T) {
+		// The resources without Components (ex: free resources) are
+		// classified from their presence on the Prior and Planned State
+		free := cost.Resource{Components: map[string]cost.Component{}}
+		prior := &cost.State{Resources: map[string]cost.Resource{"removed": free, "unchanged": free}}
+		planned := &cost.State{Resources: map[string]cost.Resource{"added": free, "unchanged": free}}
+
+		classifications := make(map[string]cost.Classification)
+		for _, rd := range cost.NewPlan("test", prior, planned).ResourceDifferences() {
+			c, err := rd.Classification()
+			require.NoError(t, err)
+			classifications[rd.Address] = c
+		}
+		assert.Equal(t, map[string]cost.Classification{
+			"added":     cost.Added,
+			"removed":   cost.Removed,
+			"unchanged": cost.Unchanged,
+		}, classifications)
+	})
+
+	t.Run("CurrencyMismatch", func(t *testing.T) {
+		rd := cost.ResourceDiff{
+			ComponentDiffs: map[string]*cost.ComponentDiff{
+				"comp": {
+					Prior:   component(10),
+					Planned: &cost.Component{Rate: cost.NewMonthly(decimal.NewFromInt(10), "EUR"), Quantity: decimal.NewFromInt(1)},
+				},
+			},
+		}
+
+		_, err := rd.Summary()
+		assert.Error(t, err)
+	})
+}
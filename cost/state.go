@@ -3,10 +3,14 @@ package cost
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	"github.com/cycloidio/terracost/backend"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
-	"github.com/cycloidio/terracost/terraform"
 )
 
 // State represents a collection of all the Resource costs (either prior or planned.) It is not tied to any specific
@@ -24,56 +28,231 @@ var (
 
 // NewState returns a new State from a query.Resource slice by using the Backend to fetch the pricing data.
 func NewState(ctx context.Context, backend backend.Backend, queries []query.Resource) (*State, error) {
+	return NewStateWithOptions(ctx, backend, queries, StateOptions{})
+}
+
+// StateOptions are the optional settings of NewStateWithOptions.
+type StateOptions struct {
+	// Timings, if set, gets the time spent on each step added to it
+	Timings *Timings
+
+	// Trace keeps on each Component the Trace of how its cost has been
+	// computed, so it can be explained with Plan.Explain
+	Trace bool
+
+	// HoursPerMonth is the number of hours of a month used to convert the hourly prices
+	// to monthly ones (ex: HoursInMonth of the month of an invoice), by default (zero)
+	// it's the average HoursPerMonth
+	HoursPerMonth decimal.Decimal
+
+	// Annotate keeps on each Component the Annotation with the SKU and
+	// attributes of the product and the rate code of the price matched
+	Annotate bool
+
+	// Adjustment, if set, is applied to the rates of the components
+	// and their rates before it are kept as their ListRate
+	Adjustment *Adjustment
+
+	// RateOverrides are the rates used for the components instead of querying the Backend, keyed
+	// by the resource address and the component name, those components are flagged as Overridden
+	RateOverrides map[string]map[string]RateOverride
+}
+
+// NewStateWithOptions is like NewState but with the StateOptions opts.
+func NewStateWithOptions(ctx context.Context, backend backend.Backend, queries []query.Resource, opts StateOptions) (*State, error) {
 	state := &State{Resources: make(map[string]Resource)}
+	err := StreamState(ctx, backend, queries, opts, func(address string, res Resource) error {
+		state.addResource(address, res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
 
+// StreamState prices the queries like NewStateWithOptions but, instead of keeping them all on a State, it calls fn
+// with each Resource once it's priced, so the memory is bounded for the very large plans when only the totals or
+// a streamed output are needed. The resources are passed in the order of the queries, if fn returns an error the
+// pricing stops and it's returned.
+func StreamState(ctx context.Context, backend backend.Backend, queries []query.Resource, opts StateOptions, fn func(address string, res Resource) error) error {
 	if len(queries) == 0 {
-		return nil, terraform.ErrNoQueries
+		return query.ErrNoQueries
 	}
-	for _, res := range queries {
-		// Mark the Resource as skipped if there are no valid Components.
-		state.ensureResource(res.Address, res.Provider, res.Type, len(res.Components) == 0)
 
-		for _, comp := range res.Components {
-			prods, err := backend.Products().Filter(ctx, comp.ProductFilter)
-			if err != nil {
-				state.addComponent(res.Address, comp.Name, Component{Error: err})
-				continue
+	sp := newStatePricer(backend, opts)
+	for _, q := range queries {
+		if err := fn(q.Address, sp.priceResource(ctx, q)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statePricer prices the query.Resource with the StateOptions
+type statePricer struct {
+	backend backend.Backend
+	opts    StateOptions
+
+	// hoursPerMonth converts the hourly prices and customHours is the custom
+	// one kept on the components so their hourly cost can be computed back
+	hoursPerMonth decimal.Decimal
+	customHours   decimal.Decimal
+}
+
+func newStatePricer(backend backend.Backend, opts StateOptions) *statePricer {
+	sp := &statePricer{backend: backend, opts: opts, hoursPerMonth: HoursPerMonth}
+	if opts.HoursPerMonth.IsPositive() {
+		sp.hoursPerMonth, sp.customHours = opts.HoursPerMonth, opts.HoursPerMonth
+	}
+	return sp
+}
+
+// priceResource returns the Resource of the query res with its components priced
+func (sp *statePricer) priceResource(ctx context.Context, res query.Resource) Resource {
+	var (
+		t    = sp.opts.Timings
+		opts = sp.opts
+		rs   = newResource(res)
+	)
+
+	// The rates (and tiers) are the ones of a single resource, only the quantities are multiplied by the Count
+	count := decimal.NewFromInt(int64(res.Count))
+
+	for _, comp := range res.Components {
+		var (
+			tr  *Trace
+			ann *Annotation
+		)
+		if opts.Trace {
+			tr = &Trace{ProductFilter: comp.ProductFilter, PriceFilter: comp.PriceFilter}
+		}
+		addComponent := func(c Component) {
+			c.Trace = tr
+			c.Annotation = ann
+			c.HoursPerMonth = sp.customHours
+			c.Confidence = comp.Confidence
+			if c.Confidence == "" {
+				c.Confidence = newConfidence(comp.Usage, res.DefaultUsage)
 			}
-			if len(prods) < 1 {
-				state.addComponent(res.Address, comp.Name, Component{Error: ErrProductNotFound})
-				continue
+			if res.Count > 1 {
+				c.Quantity = c.Quantity.Mul(count)
+			}
+			rs.Components[comp.Name] = c
+		}
+
+		if ro, ok := opts.RateOverrides[res.Address][comp.Name]; ok {
+			start := t.now()
+			addComponent(sp.overriddenComponent(comp, ro))
+			t.record(stepAssembly, start)
+			continue
+		}
+
+		if comp.ProductFilter == nil {
+			start := t.now()
+			addComponent(freeComponent(comp))
+			t.record(stepAssembly, start)
+			continue
+		}
+
+		start := t.now()
+		prods, err := sp.backend.Products().Filter(ctx, firstProductFilter(comp.ProductFilter))
+		t.record(stepProductLookup, start)
+		if err != nil {
+			addComponent(Component{Error: err})
+			continue
+		}
+		if len(prods) < 1 {
+			addComponent(Component{Error: ErrProductNotFound})
+			continue
+		}
+		if tr != nil {
+			tr.Product = prods[0]
+		}
+		if opts.Annotate {
+			ann = newAnnotation(prods[0], comp.ProductFilter)
+		}
+		start = t.now()
+		prices, err := sp.backend.Prices().Filter(ctx, prods[0].ID, comp.PriceFilter)
+		t.record(stepPriceLookup, start)
+		if err != nil {
+			addComponent(Component{Error: err})
+			continue
+		}
+		if comp.PriceFilter != nil && !comp.PriceFilter.EffectiveAt.IsZero() {
+			prices = price.ActiveAt(prices, comp.PriceFilter.EffectiveAt)
+		}
+		if len(prices) < 1 {
+			addComponent(Component{Error: ErrPriceNotFound})
+			continue
+		}
+		if tr != nil {
+			tr.Prices = prices
+		}
+
+		start = t.now()
+		var sel price.Selector
+		if comp.PriceFilter != nil {
+			sel = comp.PriceFilter.Selector
+			// The price active at a date is the latest one effective before it
+			if sel == price.SelectFirst && !comp.PriceFilter.EffectiveAt.IsZero() {
+				sel = price.SelectLatest
 			}
-			prices, err := backend.Prices().Filter(ctx, prods[0].ID, comp.PriceFilter)
+		}
+		prc := sel.Select(prices)
+
+		quantity := comp.MonthlyQuantity
+		rate := NewMonthly(prc.Value, prc.Currency)
+		hourly := false
+
+		if comp.Tiered {
+			rate, err = tieredRate(prices, quantity)
 			if err != nil {
-				state.addComponent(res.Address, comp.Name, Component{Error: err})
+				addComponent(Component{Error: err})
+				t.record(stepAssembly, start)
 				continue
 			}
-			if len(prices) < 1 {
-				state.addComponent(res.Address, comp.Name, Component{Error: ErrPriceNotFound})
-				continue
+		} else {
+			if tr != nil {
+				tr.Price = prc
+			}
+			if ann != nil {
+				ann.RateCode = prc.Attributes[price.RateCodeAttribute]
 			}
-
-			quantity := comp.MonthlyQuantity
-			rate := NewMonthly(prices[0].Value, prices[0].Currency)
-
 			if quantity.IsZero() {
 				quantity = comp.HourlyQuantity
-				rate = NewHourly(prices[0].Value, prices[0].Currency)
-			}
-
-			component := Component{
-				Quantity: quantity,
-				Unit:     comp.Unit,
-				Rate:     rate,
-				Details:  comp.Details,
-				Usage:    comp.Usage,
+				rate = newHourly(prc.Value, prc.Currency, sp.hoursPerMonth)
+				hourly = true
 			}
+		}
 
-			state.addComponent(res.Address, comp.Name, component)
+		var listRate Cost
+		var service string
+		if comp.ProductFilter.Service != nil {
+			service = *comp.ProductFilter.Service
 		}
+		if f, ok := opts.Adjustment.factor(service); ok {
+			listRate = rate
+			rate = Cost{Decimal: rate.Mul(f), Currency: rate.Currency}
+		}
+
+		addComponent(Component{
+			Quantity: quantity,
+			Unit:     comp.Unit,
+			Rate:     rate,
+			ListRate: listRate,
+			Details:  comp.Details,
+			Usage:    comp.Usage,
+			Hourly:   hourly,
+		})
+		t.record(stepAssembly, start)
 	}
 
-	return state, nil
+	start := t.now()
+	subsumeComponents(rs, res)
+	t.record(stepAssembly, start)
+
+	return rs
 }
 
 // Cost returns the sum of the costs of every Resource included in this State.
@@ -94,24 +273,267 @@ func (s *State) Cost() (Cost, error) {
 	return total, nil
 }
 
-// ensureResource creates Resource at the given address if it doesn't already exist.
-func (s *State) ensureResource(address, provider, typ string, skipped bool) {
-	if _, ok := s.Resources[address]; !ok {
-		res := Resource{
-			Provider: provider,
-			Type:     typ,
-			Skipped:  skipped,
+// ListCost returns the sum of the list costs (see Component.ListCost) of every Resource included in this State,
+// which is the Cost if it's not adjusted. Error is returned if there is a mismatch in resource currencies.
+func (s *State) ListCost() (Cost, error) {
+	var total Cost
+	for name, re := range s.Resources {
+		rCost, err := re.ListCost()
+		if err != nil {
+			return Zero, fmt.Errorf("failed to get list cost of resource %s: %w", name, err)
+		}
+		total, err = total.Add(rCost)
+		if err != nil {
+			return Zero, fmt.Errorf("failed to add list cost of resource %s: %w", name, err)
 		}
+	}
 
-		if !skipped {
-			res.Components = make(map[string]Component)
+	return total, nil
+}
+
+// HourlyCost returns the sum of the hourly costs of every Resource included in this State.
+// Error is returned if there is a mismatch in resource currencies.
+func (s *State) HourlyCost() (decimal.Decimal, error) {
+	// We validate that all the currencies match
+	if _, err := s.Cost(); err != nil {
+		return decimal.Zero, err
+	}
+
+	total := decimal.Zero
+	for name, re := range s.Resources {
+		rCost, err := re.HourlyCost()
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("failed to get hourly cost of resource %s: %w", name, err)
 		}
+		total = total.Add(rCost)
+	}
+	return total, nil
+}
+
+// CostOver returns the sum of the costs of every Resource included in this State over the duration d.
+// The Decimal of the returned Cost is the total for the duration and not the monthly one.
+func (s *State) CostOver(d time.Duration) (Cost, error) {
+	c, err := s.Cost()
+	if err != nil {
+		return Zero, err
+	}
+
+	total := decimal.Zero
+	for name, re := range s.Resources {
+		rc, err := re.CostOver(d)
+		if err != nil {
+			return Zero, fmt.Errorf("failed to get cost of resource %s: %w", name, err)
+		}
+		total = total.Add(rc.Decimal)
+	}
+	return Cost{Decimal: total, Currency: c.Currency}, nil
+}
+
+// UntaggedKey is the key under which the cost of the resources without the tag is aggregated by CostByTag
+const UntaggedKey = "untagged"
+
+// CostByTag returns the monthly cost of the resources aggregated by the value of their tag key,
+// the resources without it are aggregated under the UntaggedKey.
+// Error is returned if there is a mismatch in resource currencies.
+func (s *State) CostByTag(key string) (map[string]decimal.Decimal, error) {
+	return s.costBy(tagKey(key))
+}
+
+// tagKey returns the function giving the value of the tag key of a resource, or the UntaggedKey
+func tagKey(key string) func(re Resource) string {
+	return func(re Resource) string {
+		if tv := re.Tags[key]; tv != "" {
+			return tv
+		}
+		return UntaggedKey
+	}
+}
+
+// UnknownProviderKey is the key under which the cost of the resources without a provider is aggregated by CostByProvider
+const UnknownProviderKey = "unknown"
+
+// CostByProvider returns the monthly cost of the resources aggregated by their Provider (ex: aws, azurerm),
+// the resources without it are aggregated under the UnknownProviderKey.
+// Error is returned if there is a mismatch in resource currencies.
+func (s *State) CostByProvider() (map[string]decimal.Decimal, error) {
+	return s.costBy(providerKey)
+}
+
+// providerKey returns the Provider of the resource, or the UnknownProviderKey
+func providerKey(re Resource) string {
+	if re.Provider != "" {
+		return re.Provider
+	}
+	return UnknownProviderKey
+}
+
+// costBy returns the monthly cost of the resources, not skipped, aggregated by the key returned by keyFn.
+// Error is returned if there is a mismatch in resource currencies.
+func (s *State) costBy(keyFn func(re Resource) string) (map[string]decimal.Decimal, error) {
+	// We validate that all the currencies match
+	if _, err := s.Cost(); err != nil {
+		return nil, err
+	}
+
+	costs := make(map[string]decimal.Decimal)
+	for name, re := range s.Resources {
+		if re.Skipped {
+			continue
+		}
+		rCost, err := re.Cost()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cost of resource %s: %w", name, err)
+		}
+		k := keyFn(re)
+		costs[k] = costs[k].Add(rCost.Decimal)
+	}
+	return costs, nil
+}
 
+// tieredRate returns the monthly rate to apply to the quantity when the prices are divided
+// in tiers, which is the weighted average of the rates of the tiers the quantity falls in.
+// If the quantity is zero the rate of the first tier is returned.
+func tieredRate(prices []*price.Price, quantity decimal.Decimal) (Cost, error) {
+	var (
+		total    = decimal.Zero
+		first    *price.Price
+		firstSt  decimal.Decimal
+		currency string
+	)
+	for _, p := range prices {
+		start, end, ok := p.TierRange()
+		if !ok {
+			continue
+		}
+		if currency == "" {
+			currency = p.Currency
+		} else if currency != p.Currency {
+			return Zero, fmt.Errorf("currency mismatch: expected %s, got %s", currency, p.Currency)
+		}
+		if first == nil || start.LessThan(firstSt) {
+			first, firstSt = p, start
+		}
+
+		upper := quantity
+		if !end.IsZero() && end.LessThan(quantity) {
+			upper = end
+		}
+		if units := upper.Sub(start); units.IsPositive() {
+			total = total.Add(units.Mul(p.Value))
+		}
+	}
+
+	if first == nil {
+		return Zero, ErrPriceNotFound
+	}
+	if quantity.IsZero() {
+		return NewMonthly(first.Value, currency), nil
+	}
+	return NewMonthly(total.Div(quantity), currency), nil
+}
+
+// newResource returns the Resource of the query q without its components priced.
+// The Resource is marked as skipped if there are no valid Components.
+func newResource(q query.Resource) Resource {
+	skipped := len(q.Components) == 0
+	res := Resource{
+		Provider:      q.Provider,
+		Type:          q.Type,
+		Tags:          q.Tags,
+		Skipped:       skipped,
+		Indeterminate: q.Indeterminate,
+		Warnings:      q.Warnings,
+		Count:         q.Count,
+	}
+
+	if !skipped {
+		res.Components = make(map[string]Component)
+	}
+	return res
+}
+
+// addResource adds the Resource res at the address, if there is already one its components are added to it.
+func (s *State) addResource(address string, res Resource) {
+	rs, ok := s.Resources[address]
+	if !ok {
 		s.Resources[address] = res
+		return
+	}
+	if rs.Skipped {
+		return
+	}
+	for name, c := range res.Components {
+		rs.Components[name] = c
 	}
 }
 
-// addComponent adds the Component with given label to the Resource at given address.
-func (s *State) addComponent(resAddress, compLabel string, component Component) {
-	s.Resources[resAddress].Components[compLabel] = component
+// subsumeComponents marks as Subsumed the components of the resource rs
+// which are included on another priced component of the resource
+func subsumeComponents(rs Resource, res query.Resource) {
+	if rs.Skipped {
+		return
+	}
+	for _, comp := range res.Components {
+		if comp.SubsumedBy == "" {
+			continue
+		}
+		by, ok := rs.Components[comp.SubsumedBy]
+		if !ok || by.Error != nil || by.Subsumed {
+			continue
+		}
+		c := rs.Components[comp.Name]
+		c.Subsumed = true
+		// An error pricing it does not matter as it has no cost
+		c.Error = nil
+		rs.Components[comp.Name] = c
+	}
+}
+
+// freeComponent returns the Component without cost of the free query.Component comp
+func freeComponent(comp query.Component) Component {
+	c := Component{
+		Quantity: comp.MonthlyQuantity,
+		Unit:     comp.Unit,
+		Details:  comp.Details,
+		Usage:    comp.Usage,
+	}
+	if c.Quantity.IsZero() {
+		c.Quantity = comp.HourlyQuantity
+		c.Hourly = true
+	}
+	return c
+}
+
+// overriddenComponent returns the Component of the query comp priced with the rate of the RateOverride ro
+func (sp *statePricer) overriddenComponent(comp query.Component, ro RateOverride) Component {
+	c := freeComponent(comp)
+	c.Overridden = true
+	if c.Hourly {
+		c.Rate = newHourly(ro.Value, ro.Currency, sp.hoursPerMonth)
+	} else {
+		c.Rate = NewMonthly(ro.Value, ro.Currency)
+	}
+	return c
+}
+
+// newAnnotation returns the Annotation of the prod with the attributes used by the filter f
+func newAnnotation(prod *product.Product, f *product.Filter) *Annotation {
+	ann := &Annotation{SKU: prod.SKU, Attributes: make(map[string]string)}
+	for _, af := range f.AttributeFilters {
+		if v, ok := prod.Attributes[af.Key]; ok {
+			ann.Attributes[af.Key] = v
+		}
+	}
+	return ann
+}
+
+// firstProductFilter returns a copy of the filter limited to 1 product
+// as only the first product matching is used to get the prices
+func firstProductFilter(f *product.Filter) *product.Filter {
+	var pf product.Filter
+	if f != nil {
+		pf = *f
+	}
+	pf.Limit = 1
+	return &pf
 }
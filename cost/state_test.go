@@ -3,7 +3,9 @@ package cost_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
@@ -56,7 +58,7 @@ func TestNewState(t *testing.T) {
 		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
 
 		prod1 := &product.Product{ID: product.ID(1)}
-		productRepo.EXPECT().Filter(ctx, queries[0].Components[0].ProductFilter).Return([]*product.Product{prod1}, nil)
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
 		prc1 := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD"}
 		priceRepo.EXPECT().Filter(ctx, prod1.ID, queries[0].Components[0].PriceFilter).Return([]*price.Price{prc1}, nil)
 
@@ -65,8 +67,10 @@ func TestNewState(t *testing.T) {
 				"aws_instance.test1": {
 					Components: map[string]cost.Component{
 						"Compute": {
-							Rate:     cost.NewMonthly(decimal.New(89790, -2), "USD"),
-							Quantity: decimal.NewFromInt(1),
+							Rate:       cost.NewMonthly(decimal.New(89790, -2), "USD"),
+							Quantity:   decimal.NewFromInt(1),
+							Hourly:     true,
+							Confidence: cost.Exact,
 						},
 					},
 				},
@@ -92,7 +96,7 @@ func TestNewState(t *testing.T) {
 		backend.EXPECT().Products().AnyTimes().Return(productRepo)
 		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
 
-		productRepo.EXPECT().Filter(ctx, queries[0].Components[0].ProductFilter).Return(nil, errors.New("repo fail"))
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return(nil, errors.New("repo fail"))
 
 		state, err := cost.NewState(ctx, backend, queries)
 		require.NoError(t, err)
@@ -111,13 +115,939 @@ func TestNewState(t *testing.T) {
 		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
 
 		prod1 := &product.Product{ID: product.ID(1)}
-		productRepo.EXPECT().Filter(ctx, queries[0].Components[0].ProductFilter).Return([]*product.Product{prod1}, nil)
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
 		priceRepo.EXPECT().Filter(ctx, prod1.ID, queries[0].Components[0].PriceFilter).Return(nil, errors.New("repo fail"))
 
 		state, err := cost.NewState(ctx, backend, queries)
 		require.NoError(t, err)
 		assert.Error(t, state.Resources["aws_instance.test1"].Components["Compute"].Error)
 	})
+
+	t.Run("Tiered", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		tqueries := []query.Resource{
+			{
+				Address: "aws_s3_bucket.test",
+				Components: []query.Component{
+					{
+						Name:            "Storage",
+						MonthlyQuantity: decimal.NewFromInt(150),
+						Tiered:          true,
+						ProductFilter:   &product.Filter{Service: util.StringPtr("AmazonS3")},
+					},
+				},
+			},
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(tqueries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, tqueries[0].Components[0].PriceFilter).Return([]*price.Price{
+			{Value: decimal.NewFromFloat(0.5), Currency: "USD", Attributes: map[string]string{"StartingRange": "100", "EndingRange": "Inf"}},
+			{Value: decimal.NewFromFloat(1), Currency: "USD", Attributes: map[string]string{"StartingRange": "0", "EndingRange": "100"}},
+		}, nil)
+
+		state, err := cost.NewState(ctx, backend, tqueries)
+		require.NoError(t, err)
+
+		comp := state.Resources["aws_s3_bucket.test"].Components["Storage"]
+		require.NoError(t, comp.Error)
+		// 100 * 1 + 50 * 0.5
+		assert.True(t, decimal.NewFromInt(125).Equal(comp.Cost().Round(6)), "got %s", comp.Cost())
+	})
+
+	t.Run("SubsumedBy", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		// The price of the instance already includes its root volume
+		squeries := []query.Resource{
+			{
+				Address: "aws_lightsail_instance.test",
+				Components: []query.Component{
+					{
+						Name:           "Bundle",
+						HourlyQuantity: decimal.NewFromInt(1),
+						ProductFilter:  &product.Filter{Family: util.StringPtr("Bundle")},
+					},
+					{
+						Name:            "Root volume",
+						MonthlyQuantity: decimal.NewFromInt(40),
+						ProductFilter:   &product.Filter{Family: util.StringPtr("Storage")},
+						SubsumedBy:      "Bundle",
+					},
+				},
+			},
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		prod2 := &product.Product{ID: product.ID(2)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(squeries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
+		productRepo.EXPECT().Filter(ctx, firstProduct(squeries[0].Components[1].ProductFilter)).Return([]*product.Product{prod2}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, nil).Return([]*price.Price{{Value: decimal.NewFromFloat(0.01), Currency: "USD"}}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod2.ID, nil).Return([]*price.Price{{Value: decimal.NewFromFloat(0.1), Currency: "USD"}}, nil)
+
+		state, err := cost.NewState(ctx, backend, squeries)
+		require.NoError(t, err)
+
+		rs := state.Resources["aws_lightsail_instance.test"]
+		assert.True(t, rs.Components["Root volume"].Subsumed)
+		assert.False(t, rs.Components["Bundle"].Subsumed)
+
+		c, err := state.Cost()
+		require.NoError(t, err)
+		// 0.01 * 730, without the 40 * 0.1 of the root volume
+		assert.True(t, decimal.NewFromFloat(7.3).Equal(c.Monthly()), "got %s", c.Monthly())
+	})
+
+	t.Run("PriceSelector", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		squeries := []query.Resource{
+			{
+				Address: "azurerm_linux_virtual_machine.test",
+				Components: []query.Component{
+					{
+						Name:           "Compute",
+						HourlyQuantity: decimal.NewFromInt(1),
+						ProductFilter:  &product.Filter{Service: util.StringPtr("Virtual Machines")},
+						PriceFilter:    &price.Filter{Selector: price.SelectLatest},
+					},
+				},
+			},
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(squeries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, squeries[0].Components[0].PriceFilter).Return([]*price.Price{
+			{Value: decimal.NewFromFloat(0.5), Currency: "USD", Attributes: map[string]string{price.EffectiveDateAttribute: "2021-01-01T00:00:00Z"}},
+			{Value: decimal.NewFromFloat(0.4), Currency: "USD", Attributes: map[string]string{price.EffectiveDateAttribute: "2023-01-01T00:00:00Z"}},
+			{Value: decimal.NewFromFloat(0.3), Currency: "USD", Attributes: map[string]string{price.EffectiveDateAttribute: "2022-01-01T00:00:00Z"}},
+		}, nil)
+
+		state, err := cost.NewState(ctx, backend, squeries)
+		require.NoError(t, err)
+
+		comp := state.Resources["azurerm_linux_virtual_machine.test"].Components["Compute"]
+		require.NoError(t, comp.Error)
+		// 0.4 * 730
+		assert.True(t, decimal.NewFromInt(292).Equal(comp.Rate.Monthly()), "got %s", comp.Rate.Monthly())
+	})
+
+	t.Run("EffectiveAt", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		squeries := []query.Resource{
+			{
+				Address: "azurerm_linux_virtual_machine.test",
+				Components: []query.Component{
+					{
+						Name:           "Compute",
+						HourlyQuantity: decimal.NewFromInt(1),
+						ProductFilter:  &product.Filter{Service: util.StringPtr("Virtual Machines")},
+						PriceFilter:    &price.Filter{EffectiveAt: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+			},
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(squeries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, squeries[0].Components[0].PriceFilter).Return([]*price.Price{
+			{Value: decimal.NewFromFloat(0.5), Currency: "USD", Attributes: map[string]string{price.EffectiveDateAttribute: "2021-01-01T00:00:00Z"}},
+			{Value: decimal.NewFromFloat(0.4), Currency: "USD", Attributes: map[string]string{price.EffectiveDateAttribute: "2023-01-01T00:00:00Z"}},
+			{Value: decimal.NewFromFloat(0.3), Currency: "USD", Attributes: map[string]string{price.EffectiveDateAttribute: "2022-01-01T00:00:00Z"}},
+		}, nil)
+
+		state, err := cost.NewState(ctx, backend, squeries)
+		require.NoError(t, err)
+
+		comp := state.Resources["azurerm_linux_virtual_machine.test"].Components["Compute"]
+		require.NoError(t, comp.Error)
+		// The 0.4 is not effective yet so the latest one is 0.3 * 730
+		assert.True(t, decimal.NewFromInt(219).Equal(comp.Rate.Monthly()), "got %s", comp.Rate.Monthly())
+	})
+
+	t.Run("Free", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// The free components are not priced so the backend is not used
+		backend := mock.NewBackend(ctrl)
+
+		squeries := []query.Resource{
+			{
+				Address: "aws_vpc_endpoint.s3",
+				Components: []query.Component{
+					{
+						Name:           "Gateway endpoint",
+						HourlyQuantity: decimal.NewFromInt(1),
+					},
+				},
+			},
+		}
+
+		state, err := cost.NewState(ctx, backend, squeries)
+		require.NoError(t, err)
+
+		rs := state.Resources["aws_vpc_endpoint.s3"]
+		assert.False(t, rs.Skipped)
+		comp := rs.Components["Gateway endpoint"]
+		require.NoError(t, comp.Error)
+		assert.True(t, comp.Hourly)
+
+		c, err := state.Cost()
+		require.NoError(t, err)
+		assert.True(t, c.Decimal.IsZero())
+	})
+
+	t.Run("HoursPerMonth", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod1}, nil)
+		prc1 := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, queries[0].Components[0].PriceFilter).Return([]*price.Price{prc1}, nil)
+
+		// A 31-day month
+		state, err := cost.NewStateWithOptions(ctx, backend, queries, cost.StateOptions{HoursPerMonth: cost.HoursInMonth(2026, time.October)})
+		require.NoError(t, err)
+
+		comp := state.Resources["aws_instance.test1"].Components["Compute"]
+		require.NoError(t, comp.Error)
+		// 1.23 * 744
+		assert.Equal(t, "915.12", comp.Cost().String())
+		assert.Equal(t, "1.23", comp.HourlyCost().String())
+		assert.Contains(t, comp.Breakdown(), "744")
+		assert.Contains(t, comp.Breakdown(), "× 1.23 USD = 915.12 USD")
+
+		c, err := state.Cost()
+		require.NoError(t, err)
+		assert.Equal(t, "915.12", c.String())
+
+		// 1.23 * 24
+		c, err = state.CostOver(24 * time.Hour)
+		require.NoError(t, err)
+		assertDecimalEqual(t, decimal.RequireFromString("29.52"), c.Decimal)
+	})
+
+	t.Run("Annotate", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod1 := &product.Product{
+			ID:  product.ID(1),
+			SKU: "SKU1",
+			Attributes: map[string]string{
+				"instanceType": "t3.micro",
+				"tenancy":      "Shared",
+			},
+		}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Times(2).Return([]*product.Product{prod1}, nil)
+		prc1 := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD", Attributes: map[string]string{price.RateCodeAttribute: "SKU1.JRTCKXETXF.6YS6EN2CT7"}}
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, queries[0].Components[0].PriceFilter).Times(2).Return([]*price.Price{prc1}, nil)
+
+		state, err := cost.NewStateWithOptions(ctx, backend, queries, cost.StateOptions{Annotate: true})
+		require.NoError(t, err)
+
+		comp := state.Resources["aws_instance.test1"].Components["Compute"]
+		require.NoError(t, comp.Error)
+		assert.Equal(t, &cost.Annotation{
+			SKU:        "SKU1",
+			RateCode:   "SKU1.JRTCKXETXF.6YS6EN2CT7",
+			Attributes: map[string]string{"instanceType": "t3.micro"},
+		}, comp.Annotation)
+
+		state, err = cost.NewStateWithOptions(ctx, backend, queries, cost.StateOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, state.Resources["aws_instance.test1"].Components["Compute"].Annotation)
+	})
+
+	t.Run("Timings", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Times(2).Return([]*product.Product{prod1}, nil)
+		prc1 := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, queries[0].Components[0].PriceFilter).Times(2).Return([]*price.Price{prc1}, nil)
+
+		var tm cost.Timings
+		state, err := cost.NewStateWithOptions(ctx, backend, queries, cost.StateOptions{Timings: &tm})
+		require.NoError(t, err)
+
+		// The timings do not change the State
+		expected, err := cost.NewState(ctx, backend, queries)
+		require.NoError(t, err)
+		assert.Equal(t, expected, state)
+
+		assert.Zero(t, tm.Parse)
+		assert.Positive(t, tm.ProductLookup)
+		assert.Positive(t, tm.PriceLookup)
+		assert.Positive(t, tm.Assembly)
+		assert.Equal(t, tm.ProductLookup+tm.PriceLookup+tm.Assembly, tm.Total())
+
+		// The Parse step is measured by the callers reading the IaC, nothing is measured without Timings
+		stop := tm.StartParse()
+		time.Sleep(time.Millisecond)
+		stop()
+		assert.GreaterOrEqual(t, tm.Parse, time.Millisecond)
+
+		var nt *cost.Timings
+		assert.NotPanics(t, nt.StartParse())
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		instance := query.Resource{
+			Address: "aws_instance.test",
+			Components: []query.Component{
+				{
+					Name:           "Compute",
+					HourlyQuantity: decimal.NewFromInt(1),
+					ProductFilter:  &product.Filter{Family: util.StringPtr("Compute Instance")},
+				},
+				{
+					Name:            "Storage",
+					MonthlyQuantity: decimal.NewFromInt(150),
+					Tiered:          true,
+					ProductFilter:   &product.Filter{Family: util.StringPtr("Storage")},
+				},
+			},
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		prod2 := &product.Product{ID: product.ID(2)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(instance.Components[0].ProductFilter)).AnyTimes().Return([]*product.Product{prod1}, nil)
+		productRepo.EXPECT().Filter(ctx, firstProduct(instance.Components[1].ProductFilter)).AnyTimes().Return([]*product.Product{prod2}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.01), Currency: "USD"}}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod2.ID, nil).AnyTimes().Return([]*price.Price{
+			{Value: decimal.NewFromFloat(1), Currency: "USD", Attributes: map[string]string{price.TierStartAttribute: "0", price.TierEndAttribute: "100"}},
+			{Value: decimal.NewFromFloat(0.5), Currency: "USD", Attributes: map[string]string{price.TierStartAttribute: "100", price.TierEndAttribute: "Inf"}},
+		}, nil)
+
+		counted := instance
+		counted.Count = 3
+		state, err := cost.NewState(ctx, backend, []query.Resource{counted})
+		require.NoError(t, err)
+
+		require.Len(t, state.Resources, 1)
+		re := state.Resources["aws_instance.test"]
+		assert.Equal(t, 3, re.Count)
+		assert.Equal(t, "3", re.Components["Compute"].Quantity.String())
+		assert.Equal(t, "450", re.Components["Storage"].Quantity.String())
+
+		// The tiers are applied to the quantity of each resource: 3 × (0.01 × 730 + 100 × 1 + 50 × 0.5)
+		c, err := state.Cost()
+		require.NoError(t, err)
+		assertDecimalEqual(t, decimal.RequireFromString("396.9"), c.Decimal)
+
+		separate := make([]query.Resource, 0, 3)
+		for i := 0; i < 3; i++ {
+			q := instance
+			q.Address = fmt.Sprintf("aws_instance.test%d", i)
+			separate = append(separate, q)
+		}
+		sstate, err := cost.NewState(ctx, backend, separate)
+		require.NoError(t, err)
+		sc, err := sstate.Cost()
+		require.NoError(t, err)
+		assertDecimalEqual(t, c.Decimal, sc.Decimal)
+	})
+
+	t.Run("Adjustment", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		aqueries := []query.Resource{
+			{
+				Address: "aws_instance.test",
+				Components: []query.Component{
+					{
+						Name:           "Compute",
+						HourlyQuantity: decimal.NewFromInt(1),
+						ProductFilter:  &product.Filter{Service: util.StringPtr("AmazonEC2")},
+					},
+					{
+						Name:            "Storage",
+						MonthlyQuantity: decimal.NewFromInt(100),
+						ProductFilter:   &product.Filter{Service: util.StringPtr("AmazonEBS")},
+					},
+				},
+			},
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		prod2 := &product.Product{ID: product.ID(2)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(aqueries[0].Components[0].ProductFilter)).AnyTimes().Return([]*product.Product{prod1}, nil)
+		productRepo.EXPECT().Filter(ctx, firstProduct(aqueries[0].Components[1].ProductFilter)).AnyTimes().Return([]*product.Product{prod2}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.01), Currency: "USD"}}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod2.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.1), Currency: "USD"}}, nil)
+
+		// The Compute is 0.01 × 730 = 7.3 and the Storage 100 × 0.1 = 10
+		for _, tc := range []struct {
+			name       string
+			adjustment *cost.Adjustment
+			adjusted   bool
+			cost       string
+		}{
+			{name: "None", cost: "17.3"},
+			{name: "Markup", adjustment: &cost.Adjustment{Percent: 10}, adjusted: true, cost: "19.03"},
+			{name: "Discount", adjustment: &cost.Adjustment{Percent: -15}, adjusted: true, cost: "14.705"},
+			{name: "Zero", adjustment: &cost.Adjustment{}, cost: "17.3"},
+			{
+				name:       "Services",
+				adjustment: &cost.Adjustment{Percent: 10, Services: map[string]float64{"AmazonEC2": -20}},
+				adjusted:   true,
+				// 7.3 × 0.8 + 10 × 1.1
+				cost: "16.84",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				state, err := cost.NewStateWithOptions(ctx, backend, aqueries, cost.StateOptions{Adjustment: tc.adjustment})
+				require.NoError(t, err)
+
+				c, err := state.Cost()
+				require.NoError(t, err)
+				assert.Equal(t, tc.cost, c.Decimal.String())
+
+				// The list cost is kept
+				lc, err := state.ListCost()
+				require.NoError(t, err)
+				assert.Equal(t, "17.3", lc.Decimal.String())
+				assert.Equal(t, "USD", lc.Currency)
+
+				comp := state.Resources["aws_instance.test"].Components["Compute"]
+				if tc.adjusted {
+					assert.Equal(t, "7.3", comp.ListRate.Decimal.String())
+				} else {
+					assert.True(t, comp.ListRate.IsZero())
+				}
+			})
+		}
+	})
+
+	t.Run("Confidence", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		// The Storage is known from the configuration and the Requests from the usage
+		bucket := func() query.Resource {
+			return query.Resource{
+				Address: "aws_s3_bucket.test",
+				Components: []query.Component{
+					{
+						Name:            "Storage",
+						MonthlyQuantity: decimal.NewFromInt(100),
+						ProductFilter:   &product.Filter{Family: util.StringPtr("Storage")},
+					},
+					{
+						Name:            "Requests",
+						MonthlyQuantity: decimal.NewFromInt(1000),
+						Usage:           true,
+						ProductFilter:   &product.Filter{Family: util.StringPtr("API Request")},
+					},
+				},
+			}
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		prod2 := &product.Product{ID: product.ID(2)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(bucket().Components[0].ProductFilter)).AnyTimes().Return([]*product.Product{prod1}, nil)
+		productRepo.EXPECT().Filter(ctx, firstProduct(bucket().Components[1].ProductFilter)).AnyTimes().Return([]*product.Product{prod2}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.023), Currency: "USD"}}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod2.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.0004), Currency: "USD"}}, nil)
+
+		t.Run("UsageEstimate", func(t *testing.T) {
+			state, err := cost.NewState(ctx, backend, []query.Resource{bucket()})
+			require.NoError(t, err)
+
+			res := state.Resources["aws_s3_bucket.test"]
+			assert.Equal(t, cost.Exact, res.Components["Storage"].Confidence)
+			assert.Equal(t, cost.UsageEstimate, res.Components["Requests"].Confidence)
+			assert.Equal(t, cost.UsageEstimate, res.Confidence())
+		})
+
+		t.Run("DefaultedUsage", func(t *testing.T) {
+			q := bucket()
+			q.DefaultUsage = true
+			state, err := cost.NewState(ctx, backend, []query.Resource{q})
+			require.NoError(t, err)
+
+			res := state.Resources["aws_s3_bucket.test"]
+			assert.Equal(t, cost.Exact, res.Components["Storage"].Confidence)
+			assert.Equal(t, cost.DefaultedUsage, res.Components["Requests"].Confidence)
+			assert.Equal(t, cost.DefaultedUsage, res.Confidence())
+
+			rd := cost.NewPlan("test", nil, state).ResourceDifferences()
+			require.Len(t, rd, 1)
+			assert.Equal(t, cost.DefaultedUsage, rd[0].Confidence())
+		})
+
+		t.Run("FromDecoder", func(t *testing.T) {
+			q := bucket()
+			q.Components[0].Confidence = query.DefaultedUsage
+			state, err := cost.NewState(ctx, backend, []query.Resource{q})
+			require.NoError(t, err)
+
+			res := state.Resources["aws_s3_bucket.test"]
+			assert.Equal(t, cost.DefaultedUsage, res.Components["Storage"].Confidence)
+			assert.Equal(t, cost.UsageEstimate, res.Components["Requests"].Confidence)
+		})
+	})
+
+	t.Run("RateOverrides", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		oqueries := []query.Resource{
+			{
+				Address: "aws_instance.test",
+				Components: []query.Component{
+					{
+						Name:           "Compute",
+						HourlyQuantity: decimal.NewFromInt(1),
+						ProductFilter:  &product.Filter{Family: util.StringPtr("Compute Instance")},
+					},
+					{
+						Name:            "Storage",
+						MonthlyQuantity: decimal.NewFromInt(100),
+						ProductFilter:   &product.Filter{Family: util.StringPtr("Storage")},
+					},
+				},
+			},
+		}
+
+		// The overridden Compute is not looked up
+		prod2 := &product.Product{ID: product.ID(2)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(oqueries[0].Components[1].ProductFilter)).AnyTimes().Return([]*product.Product{prod2}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod2.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.1), Currency: "USD"}}, nil)
+
+		overrides := map[string]map[string]cost.RateOverride{
+			"aws_instance.test":  {"Compute": {Value: decimal.NewFromFloat(0.02), Currency: "USD"}},
+			"aws_instance.other": {"Compute": {Value: decimal.NewFromFloat(1), Currency: "USD"}},
+		}
+
+		t.Run("Success", func(t *testing.T) {
+			state, err := cost.NewStateWithOptions(ctx, backend, oqueries, cost.StateOptions{RateOverrides: overrides})
+			require.NoError(t, err)
+
+			// The Compute is 0.02 × 730 = 14.6 and the Storage is priced as usual (100 × 0.1)
+			c, err := state.Cost()
+			require.NoError(t, err)
+			assert.Equal(t, "24.6", c.Decimal.String())
+
+			res := state.Resources["aws_instance.test"]
+			assert.True(t, res.Components["Compute"].Overridden)
+			assert.True(t, res.Components["Compute"].Hourly)
+			assert.Contains(t, res.Components["Compute"].Breakdown(), "(overridden rate)")
+			assert.False(t, res.Components["Storage"].Overridden)
+		})
+
+		t.Run("Adjustment", func(t *testing.T) {
+			state, err := cost.NewStateWithOptions(ctx, backend, oqueries, cost.StateOptions{
+				RateOverrides: overrides,
+				Adjustment:    &cost.Adjustment{Percent: 10},
+			})
+			require.NoError(t, err)
+
+			// The overridden Compute is not adjusted: 14.6 + 10 × 1.1
+			c, err := state.Cost()
+			require.NoError(t, err)
+			assert.Equal(t, "25.6", c.Decimal.String())
+		})
+	})
+
+	t.Run("StreamState", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		squeries := make([]query.Resource, 0, 3)
+		for i := 0; i < 3; i++ {
+			squeries = append(squeries, query.Resource{
+				Address: fmt.Sprintf("aws_instance.test%d", i),
+				Components: []query.Component{
+					{
+						Name:           "Compute",
+						HourlyQuantity: decimal.NewFromInt(int64(i + 1)),
+						ProductFilter:  &product.Filter{Family: util.StringPtr("Compute Instance")},
+					},
+				},
+			})
+		}
+
+		prod1 := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(squeries[0].Components[0].ProductFilter)).AnyTimes().Return([]*product.Product{prod1}, nil)
+		priceRepo.EXPECT().Filter(ctx, prod1.ID, nil).AnyTimes().Return([]*price.Price{{Value: decimal.NewFromFloat(0.01), Currency: "USD"}}, nil)
+
+		state, err := cost.NewState(ctx, backend, squeries)
+		require.NoError(t, err)
+
+		t.Run("Success", func(t *testing.T) {
+			var (
+				addresses []string
+				total     cost.Cost
+			)
+			err := cost.StreamState(ctx, backend, squeries, cost.StateOptions{}, func(address string, res cost.Resource) error {
+				addresses = append(addresses, address)
+				assert.Equal(t, state.Resources[address], res)
+
+				c, err := res.Cost()
+				require.NoError(t, err)
+				total, err = total.Add(c)
+				return err
+			})
+			require.NoError(t, err)
+
+			// The resources are streamed in the order of the queries
+			assert.Equal(t, []string{"aws_instance.test0", "aws_instance.test1", "aws_instance.test2"}, addresses)
+
+			// (1 + 2 + 3) × 0.01 × 730
+			assert.Equal(t, "43.8", total.Decimal.String())
+		})
+
+		t.Run("ErrCallback", func(t *testing.T) {
+			errStop := errors.New("stop")
+			var calls int
+			err := cost.StreamState(ctx, backend, squeries, cost.StateOptions{}, func(string, cost.Resource) error {
+				calls++
+				return errStop
+			})
+			assert.Equal(t, errStop, err)
+			assert.Equal(t, 1, calls)
+		})
+
+		t.Run("ErrNoQueries", func(t *testing.T) {
+			err := cost.StreamState(ctx, backend, nil, cost.StateOptions{}, func(string, cost.Resource) error { return nil })
+			assert.Equal(t, query.ErrNoQueries, err)
+		})
+	})
+}
+
+func BenchmarkNewState(b *testing.B) {
+	ctx := context.Background()
+	for _, bc := range []struct {
+		name      string
+		resources int
+	}{
+		{name: "Small", resources: 10},
+		{name: "Medium", resources: 100},
+		{name: "Large", resources: 1000},
+	} {
+		be := newMemoryBackend(bc.resources)
+		queries := benchmarkQueries(bc.resources)
+
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := cost.NewState(ctx, be, queries); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(bc.name+"WithTimings", func(b *testing.B) {
+			b.ReportAllocs()
+			var tm cost.Timings
+			for i := 0; i < b.N; i++ {
+				if _, err := cost.NewStateWithOptions(ctx, be, queries, cost.StateOptions{Timings: &tm}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(tm.ProductLookup.Nanoseconds())/float64(b.N), "product-ns/op")
+			b.ReportMetric(float64(tm.PriceLookup.Nanoseconds())/float64(b.N), "price-ns/op")
+			b.ReportMetric(float64(tm.Assembly.Nanoseconds())/float64(b.N), "assembly-ns/op")
+		})
+	}
+}
+
+func BenchmarkNewState_Count(b *testing.B) {
+	ctx := context.Background()
+	be := newMemoryBackend(1)
+
+	fleet := fleetQueries(200)
+	single := fleet[0]
+	single.Count = len(fleet)
+
+	b.Run("Separate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cost.NewState(ctx, be, fleet); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Count", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cost.NewState(ctx, be, []query.Resource{single}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkStreamState compares the allocations of the totals of a large plan computed
+// from the State of NewState and streamed with StreamState
+func BenchmarkStreamState(b *testing.B) {
+	ctx := context.Background()
+	be := newMemoryBackend(10000)
+	queries := benchmarkQueries(10000)
+
+	b.Run("NewState", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			state, err := cost.NewState(ctx, be, queries)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := state.Cost(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("StreamState", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var total cost.Cost
+			err := cost.StreamState(ctx, be, queries, cost.StateOptions{}, func(_ string, res cost.Resource) error {
+				c, err := res.Cost()
+				if err != nil {
+					return err
+				}
+				total, err = total.Add(c)
+				return err
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// fleetQueries returns n identical instances of the first of the benchmarkQueries
+func fleetQueries(n int) []query.Resource {
+	q := benchmarkQueries(1)[0]
+	queries := make([]query.Resource, 0, n)
+	for i := 0; i < n; i++ {
+		q.Address = fmt.Sprintf("aws_instance.test%d", i)
+		queries = append(queries, q)
+	}
+	return queries
+}
+
+// benchmarkQueries returns n instances with a priced compute, a tiered storage and a free component
+func benchmarkQueries(n int) []query.Resource {
+	queries := make([]query.Resource, 0, n)
+	for i := 0; i < n; i++ {
+		queries = append(queries, query.Resource{
+			Address:  fmt.Sprintf("aws_instance.test%d", i),
+			Provider: "aws",
+			Type:     "aws_instance",
+			Components: []query.Component{
+				{
+					Name:           "Compute",
+					HourlyQuantity: decimal.NewFromInt(1),
+					ProductFilter: &product.Filter{
+						Provider: util.StringPtr("aws"),
+						Service:  util.StringPtr("AmazonEC2"),
+						Family:   util.StringPtr("Compute Instance"),
+						Location: util.StringPtr("eu-west-3"),
+						AttributeFilters: []*product.AttributeFilter{
+							{Key: "instanceType", Value: util.StringPtr(fmt.Sprintf("t3.type%d", i))},
+						},
+					},
+				},
+				{
+					Name:            "Storage",
+					MonthlyQuantity: decimal.NewFromInt(100),
+					Tiered:          true,
+					ProductFilter: &product.Filter{
+						Provider: util.StringPtr("aws"),
+						Service:  util.StringPtr("AmazonEC2"),
+						Family:   util.StringPtr("Storage"),
+						Location: util.StringPtr("eu-west-3"),
+						AttributeFilters: []*product.AttributeFilter{
+							{Key: "volumeApiName", Value: util.StringPtr("gp3")},
+						},
+					},
+				},
+				{
+					Name:           "Free",
+					HourlyQuantity: decimal.NewFromInt(1),
+				},
+			},
+		})
+	}
+	return queries
+}
+
+// memoryBackend is a backend.Backend holding the pricing data on memory, the products
+// are matched by their family and the first attribute filter of the query
+type memoryBackend struct {
+	products memoryProductRepository
+	prices   memoryPriceRepository
+}
+
+// newMemoryBackend returns a memoryBackend with the products and prices of
+// the n instance types of the benchmarkQueries and the gp3 storage
+func newMemoryBackend(n int) *memoryBackend {
+	be := &memoryBackend{
+		products: make(memoryProductRepository),
+		prices:   make(memoryPriceRepository),
+	}
+
+	storage := &product.Product{ID: product.ID(n + 1), Family: "Storage", Attributes: map[string]string{"volumeApiName": "gp3"}}
+	be.products[memoryProductKey(storage.Family, "volumeApiName", "gp3")] = storage
+	be.prices[storage.ID] = []*price.Price{
+		{Value: decimal.NewFromFloat(0.1), Unit: "GB-Mo", Currency: "USD", Attributes: map[string]string{price.TierStartAttribute: "0", price.TierEndAttribute: "50"}},
+		{Value: decimal.NewFromFloat(0.08), Unit: "GB-Mo", Currency: "USD", Attributes: map[string]string{price.TierStartAttribute: "50", price.TierEndAttribute: "Inf"}},
+	}
+
+	for i := 0; i < n; i++ {
+		it := fmt.Sprintf("t3.type%d", i)
+		prod := &product.Product{ID: product.ID(i + 1), Family: "Compute Instance", Attributes: map[string]string{"instanceType": it}}
+		be.products[memoryProductKey(prod.Family, "instanceType", it)] = prod
+		be.prices[prod.ID] = []*price.Price{
+			{Value: decimal.NewFromFloat(0.0104), Unit: "Hrs", Currency: "USD"},
+		}
+	}
+	return be
+}
+
+func (b *memoryBackend) Products() product.Repository { return b.products }
+func (b *memoryBackend) Prices() price.Repository     { return b.prices }
+
+func memoryProductKey(family, key, value string) string {
+	return family + "/" + key + "=" + value
+}
+
+type memoryProductRepository map[string]*product.Product
+
+func (r memoryProductRepository) Filter(_ context.Context, f *product.Filter) ([]*product.Product, error) {
+	if f.Family == nil || len(f.AttributeFilters) == 0 || f.AttributeFilters[0].Value == nil {
+		return nil, nil
+	}
+	af := f.AttributeFilters[0]
+	if p, ok := r[memoryProductKey(*f.Family, af.Key, *af.Value)]; ok {
+		return []*product.Product{p}, nil
+	}
+	return nil, nil
+}
+
+func (r memoryProductRepository) FindByVendorAndSKU(_ context.Context, _ string, _ string) (*product.Product, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r memoryProductRepository) Upsert(_ context.Context, _ *product.Product) (product.ID, error) {
+	return 0, errors.New("not implemented")
+}
+
+type memoryPriceRepository map[product.ID][]*price.Price
+
+func (r memoryPriceRepository) Filter(_ context.Context, id product.ID, _ *price.Filter) ([]*price.Price, error) {
+	return r[id], nil
+}
+
+func (r memoryPriceRepository) Upsert(_ context.Context, _ *price.WithProduct) (price.ID, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (r memoryPriceRepository) DeleteByProductWithKeep(_ context.Context, _ product.ID, _ []price.ID) error {
+	return errors.New("not implemented")
 }
 
 func TestState_Cost(t *testing.T) {
@@ -163,3 +1093,59 @@ func TestState_Cost(t *testing.T) {
 		assert.Equal(t, cost.Zero, actual)
 	})
 }
+
+func TestState_HourlyCost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		state := &cost.State{
+			Resources: map[string]cost.Resource{
+				"aws_instance.test1": {
+					Components: map[string]cost.Component{
+						"Compute": {
+							Rate:     cost.NewHourly(decimal.NewFromFloat(0.0116), "USD"),
+							Quantity: decimal.NewFromInt(2),
+							Hourly:   true,
+						},
+						"Storage": {
+							Rate:     cost.NewMonthly(decimal.NewFromFloat(0.1), "USD"),
+							Quantity: decimal.NewFromInt(73),
+						},
+					},
+				},
+			},
+		}
+
+		actual, err := state.HourlyCost()
+		assert.NoError(t, err)
+		assert.Truef(t, decimal.NewFromFloat(0.0332).Equal(actual), "got %s", actual)
+	})
+	t.Run("ComponentCostMismatch", func(t *testing.T) {
+		state := &cost.State{
+			Resources: map[string]cost.Resource{
+				"aws_instance.test1": {
+					Components: map[string]cost.Component{
+						"Compute": {
+							Rate:     cost.NewMonthly(decimal.NewFromFloat(1.23), "USD"),
+							Quantity: decimal.NewFromInt(730),
+						},
+						"Storage": {
+							Rate:     cost.NewMonthly(decimal.NewFromFloat(1.23), "EUR"),
+							Quantity: decimal.NewFromInt(730),
+						},
+					},
+				},
+			},
+		}
+
+		actual, err := state.HourlyCost()
+		assert.Error(t, err)
+		assert.True(t, actual.IsZero())
+	})
+}
+
+// firstProduct returns the filter as it's sent to the product.Repository,
+// limited to the first product
+func firstProduct(f *product.Filter) *product.Filter {
+	pf := *f
+	pf.Limit = 1
+	return &pf
+}
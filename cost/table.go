@@ -0,0 +1,103 @@
+package cost
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/shopspring/decimal"
+)
+
+// ANSI colors of the deltas of WriteTable, the increases
+// are in red and the decreases in green
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorReset = "\033[0m"
+)
+
+// WriteTable writes to w a human readable table of the plan with the prior, planned and delta monthly cost
+// of each resource and a row with the totals. The costs are formatted with the first of the fos or the
+// DefaultFormatOptions. When w is a terminal the increases are colored in red and the decreases in green,
+// unless the NO_COLOR environment variable is set. It's meant for the CLIs, see WriteJSON or WriteCSV for
+// the machine-readable exports.
+func WriteTable(w io.Writer, plan *Plan, fos ...FormatOptions) error {
+	fo := DefaultFormatOptions
+	if len(fos) > 0 {
+		fo = fos[0]
+	}
+	color := isTerminal(w)
+
+	prior, err := plan.PriorCost()
+	if err != nil {
+		return err
+	}
+	planned, err := plan.PlannedCost()
+	if err != nil {
+		return err
+	}
+	currency := planned.Currency
+	if currency == "" {
+		currency = prior.Currency
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ADDRESS\tPRIOR\tPLANNED\tDELTA")
+	for _, rd := range plan.ResourceDifferences() {
+		s, err := rd.Summary()
+		if err != nil {
+			return err
+		}
+		rcurrency := s.Delta.Currency
+		if rcurrency == "" {
+			rcurrency = currency
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", rd.Address,
+			fo.Format(s.Prior.Decimal, rcurrency), fo.Format(s.Planned.Decimal, rcurrency),
+			formatDelta(fo, s.Delta.Decimal, rcurrency, color),
+		)
+	}
+	fmt.Fprintf(tw, "TOTAL\t%s\t%s\t%s\n",
+		fo.Format(prior.Decimal, currency), fo.Format(planned.Decimal, currency),
+		formatDelta(fo, planned.Decimal.Sub(prior.Decimal), currency, color),
+	)
+	return tw.Flush()
+}
+
+// formatDelta returns the delta d formatted with fo with a '+' sign when it's
+// positive, it's colored if color is set and the delta is not zero
+func formatDelta(fo FormatOptions, d decimal.Decimal, currency string, color bool) string {
+	s := fo.Format(d, currency)
+	if roundHalfUp(d, fo.Decimals).IsPositive() {
+		s = "+" + s
+	}
+	if !color {
+		return s
+	}
+	switch {
+	case d.IsPositive():
+		return colorRed + s + colorReset
+	case d.IsNegative():
+		return colorGreen + s + colorReset
+	default:
+		return s
+	}
+}
+
+// isTerminal returns true if w is a terminal and the colors are
+// not disabled with the NO_COLOR environment variable
+func isTerminal(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,38 @@
+package cost_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/cost"
+)
+
+func TestWriteTable(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cost.WriteTable(&buf, newExportPlan())
+		require.NoError(t, err)
+
+		expected := "ADDRESS            PRIOR      PLANNED    DELTA\n" +
+			"aws_instance.test  10.00 USD  20.80 USD  +10.80 USD\n" +
+			"TOTAL              10.00 USD  20.80 USD  +10.80 USD\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("FormatOptions", func(t *testing.T) {
+		plan := newExportPlan()
+		plan.Prior, plan.Planned = plan.Planned, plan.Prior
+
+		var buf bytes.Buffer
+		err := cost.WriteTable(&buf, plan, cost.FormatOptions{Decimals: 1, CurrencySymbol: "$"})
+		require.NoError(t, err)
+
+		expected := "ADDRESS            PRIOR  PLANNED  DELTA\n" +
+			"aws_instance.test  $20.8  $10.0    $-10.8\n" +
+			"TOTAL              $20.8  $10.0    $-10.8\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
@@ -0,0 +1,73 @@
+package cost
+
+import (
+	"time"
+)
+
+// Timings is the breakdown of the time spent on each step of an estimation. The durations
+// are added to the ones already on it, so the same Timings can be used for more than one State.
+// It's only measured when requested (see StateOptions.Timings) so there is no overhead otherwise.
+type Timings struct {
+	// Parse is the time spent reading the IaC (plan, state or HCL) and
+	// extracting the queries, it's set by the estimation helpers
+	Parse time.Duration
+
+	// ProductLookup is the time spent filtering the products on the Backend
+	ProductLookup time.Duration
+
+	// PriceLookup is the time spent filtering the prices on the Backend
+	PriceLookup time.Duration
+
+	// Assembly is the time spent computing the cost of the components
+	// from the prices and building the State
+	Assembly time.Duration
+}
+
+// Total returns the sum of the time spent on all the steps
+func (t Timings) Total() time.Duration {
+	return t.Parse + t.ProductLookup + t.PriceLookup + t.Assembly
+}
+
+// timingStep is one of the steps measured by Timings
+type timingStep int
+
+const (
+	stepParse timingStep = iota
+	stepProductLookup
+	stepPriceLookup
+	stepAssembly
+)
+
+// now returns the current time to start measuring a step, or the zero
+// one if t is nil so nothing is measured when the timings are not requested
+func (t *Timings) now() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// record adds the time elapsed since start to the step, it's a no-op if t is nil
+func (t *Timings) record(step timingStep, start time.Time) {
+	if t == nil {
+		return
+	}
+	d := time.Since(start)
+	switch step {
+	case stepParse:
+		t.Parse += d
+	case stepProductLookup:
+		t.ProductLookup += d
+	case stepPriceLookup:
+		t.PriceLookup += d
+	case stepAssembly:
+		t.Assembly += d
+	}
+}
+
+// StartParse starts measuring the Parse step and returns the function to call once it's done, nothing
+// is measured if t is nil. It's used by the callers that read the IaC before calling NewStateWithOptions.
+func (t *Timings) StartParse() (stop func()) {
+	start := t.now()
+	return func() { t.record(stepParse, start) }
+}
@@ -0,0 +1,133 @@
+package cost
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Variance is the difference between the estimated monthly cost and the actual one (ex: from a billing
+// export) of a resource, or of all the resources of a type when it's aggregated by Compare.
+type Variance struct {
+	// Address is the address of the resource, it's empty on the variances by type
+	Address string
+	Type    string
+
+	Estimated decimal.Decimal
+	Actual    decimal.Decimal
+
+	// HasActual is false if there are no actuals for the resource (or
+	// for none of the resources of the type), so Actual is zero
+	HasActual bool
+}
+
+// Delta returns the actual cost minus the estimated one, it's positive if the estimation is under the actual cost.
+func (v Variance) Delta() decimal.Decimal {
+	return v.Actual.Sub(v.Estimated)
+}
+
+// Ratio returns the Delta relative to the estimated cost (ex: 0.1 if the actual cost is 10% over
+// the estimation) or false if the estimated cost is zero.
+func (v Variance) Ratio() (decimal.Decimal, bool) {
+	if v.Estimated.IsZero() {
+		return decimal.Zero, false
+	}
+	return v.Delta().Div(v.Estimated), true
+}
+
+// VarianceReport is the result of Compare.
+type VarianceReport struct {
+	// Resources are the variances of each resource estimated, sorted by Address
+	Resources []Variance
+
+	// Types are the variances aggregated by resource type, sorted by Type
+	Types []Variance
+
+	// Unmatched are the actuals keyed by an address that is not on the estimated plan
+	// (ex: resources not managed by Terraform), they are not in the Total
+	Unmatched map[string]decimal.Decimal
+
+	// Total is the variance of all the resources estimated
+	Total Variance
+}
+
+// Compare compares the monthly cost of the resources of the estimated Plan with the actual monthly
+// cost keyed by the resource address, the actuals import (ex: a CUR summary mapped to the addresses
+// with the tags of the resources) is the responsibility of the caller and have to be in the currency
+// of the estimation. The Planned State is used, or the Prior one if there is no Planned State (ex:
+// estimation of a Terraform state), and the skipped resources are ignored.
+// Error is returned if there is a mismatch in resource currencies.
+func Compare(estimated *Plan, actuals map[string]decimal.Decimal) (VarianceReport, error) {
+	report := VarianceReport{
+		Resources: make([]Variance, 0),
+		Types:     make([]Variance, 0),
+		Unmatched: make(map[string]decimal.Decimal),
+	}
+
+	var state *State
+	if estimated != nil {
+		state = estimated.Planned
+		if state == nil {
+			state = estimated.Prior
+		}
+	}
+	if state == nil {
+		state = &State{}
+	}
+
+	// We validate that all the currencies match
+	if _, err := state.Cost(); err != nil {
+		return report, err
+	}
+
+	types := make(map[string]*Variance)
+	for address, re := range state.Resources {
+		if re.Skipped {
+			continue
+		}
+		rCost, err := re.Cost()
+		if err != nil {
+			return report, fmt.Errorf("failed to get cost of resource %s: %w", address, err)
+		}
+
+		actual, ok := actuals[address]
+		v := Variance{
+			Address:   address,
+			Type:      re.Type,
+			Estimated: rCost.Decimal,
+			Actual:    actual,
+			HasActual: ok,
+		}
+		report.Resources = append(report.Resources, v)
+
+		tv, ok := types[re.Type]
+		if !ok {
+			tv = &Variance{Type: re.Type}
+			types[re.Type] = tv
+		}
+		tv.add(v)
+		report.Total.add(v)
+	}
+
+	for address, actual := range actuals {
+		if re, ok := state.Resources[address]; !ok || re.Skipped {
+			report.Unmatched[address] = actual
+		}
+	}
+
+	for _, tv := range types {
+		report.Types = append(report.Types, *tv)
+	}
+	sort.Slice(report.Resources, func(i, j int) bool { return report.Resources[i].Address < report.Resources[j].Address })
+	sort.Slice(report.Types, func(i, j int) bool { return report.Types[i].Type < report.Types[j].Type })
+
+	return report, nil
+}
+
+// add adds the estimated and actual costs of o to v
+func (v *Variance) add(o Variance) {
+	v.Estimated = v.Estimated.Add(o.Estimated)
+	v.Actual = v.Actual.Add(o.Actual)
+	v.HasActual = v.HasActual || o.HasActual
+}
@@ -0,0 +1,99 @@
+package cost_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/cost"
+)
+
+func TestCompare(t *testing.T) {
+	state := &cost.State{
+		Resources: map[string]cost.Resource{
+			"aws_instance.front": {
+				Type: "aws_instance",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(100), "USD"),
+					},
+				},
+			},
+			"aws_instance.back": {
+				Type: "aws_instance",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(50), "USD"),
+					},
+				},
+			},
+			"aws_db_instance.db": {
+				Type: "aws_db_instance",
+				Components: map[string]cost.Component{
+					"Compute": {
+						Quantity: decimal.NewFromInt(1),
+						Rate:     cost.NewMonthly(decimal.NewFromInt(200), "USD"),
+					},
+				},
+			},
+			"aws_invalid_resource.test_skipped": {
+				Skipped: true,
+			},
+		},
+	}
+	actuals := map[string]decimal.Decimal{
+		"aws_instance.front":                decimal.NewFromInt(110),
+		"aws_db_instance.db":                decimal.NewFromInt(180),
+		"aws_s3_bucket.logs":                decimal.NewFromInt(5),
+		"aws_invalid_resource.test_skipped": decimal.NewFromInt(1),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		report, err := cost.Compare(cost.NewPlan("name", nil, state), actuals)
+		require.NoError(t, err)
+
+		require.Len(t, report.Resources, 3)
+		assert.Equal(t, "aws_db_instance.db", report.Resources[0].Address)
+		assert.Equal(t, "-20", report.Resources[0].Delta().String())
+		assert.Equal(t, "aws_instance.back", report.Resources[1].Address)
+		assert.False(t, report.Resources[1].HasActual)
+		assert.Equal(t, "-50", report.Resources[1].Delta().String())
+		assert.True(t, report.Resources[2].HasActual)
+		ratio, ok := report.Resources[2].Ratio()
+		require.True(t, ok)
+		assert.Equal(t, "0.1", ratio.String())
+
+		require.Len(t, report.Types, 2)
+		assert.Equal(t, "aws_db_instance", report.Types[0].Type)
+		assert.Equal(t, "aws_instance", report.Types[1].Type)
+		assert.Equal(t, "150", report.Types[1].Estimated.String())
+		assert.Equal(t, "110", report.Types[1].Actual.String())
+		assert.True(t, report.Types[1].HasActual)
+
+		assert.Equal(t, "350", report.Total.Estimated.String())
+		assert.Equal(t, "290", report.Total.Actual.String())
+		assert.Equal(t, map[string]decimal.Decimal{
+			"aws_s3_bucket.logs":                decimal.NewFromInt(5),
+			"aws_invalid_resource.test_skipped": decimal.NewFromInt(1),
+		}, report.Unmatched)
+	})
+
+	t.Run("OnlyPrior", func(t *testing.T) {
+		report, err := cost.Compare(cost.NewPlan("name", state, nil), actuals)
+		require.NoError(t, err)
+		assert.Len(t, report.Resources, 3)
+	})
+
+	t.Run("NoPlan", func(t *testing.T) {
+		report, err := cost.Compare(nil, actuals)
+		require.NoError(t, err)
+		assert.Empty(t, report.Resources)
+		assert.Len(t, report.Unmatched, 4)
+		_, ok := report.Total.Ratio()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,234 @@
+package terracost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cycloidio/terracost/backend"
+	"github.com/cycloidio/terracost/cost"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+)
+
+// Coverage is the report of which resources of a plan can be estimated,
+// both maps have the number of resources keyed by the resource type.
+type Coverage struct {
+	Supported   map[string]int
+	Unsupported map[string]int
+}
+
+// SupportedCount returns the number of resources that can be estimated
+func (c Coverage) SupportedCount() int {
+	var n int
+	for _, v := range c.Supported {
+		n += v
+	}
+	return n
+}
+
+// UnsupportedCount returns the number of resources that can not be estimated
+func (c Coverage) UnsupportedCount() int {
+	var n int
+	for _, v := range c.Unsupported {
+		n += v
+	}
+	return n
+}
+
+// Ratio returns the ratio (from 0 to 1) of resources that can be estimated,
+// if there are no resources it returns 0
+func (c Coverage) Ratio() float64 {
+	total := c.SupportedCount() + c.UnsupportedCount()
+	if total == 0 {
+		return 0
+	}
+	return float64(c.SupportedCount()) / float64(total)
+}
+
+// CoverageReport reads a Terraform plan using the provided io.Reader and returns the
+// Coverage of the planned resources without retrieving any pricing data.
+// A resource is supported if its provider is known and it has price components.
+// The ignored addresses (WithIgnoreAddresses) are not part of the Coverage.
+func CoverageReport(ctx context.Context, plan io.Reader, opts ...Option) (Coverage, error) {
+	o := newOptions(opts)
+
+	cov := Coverage{
+		Supported:   make(map[string]int),
+		Unsupported: make(map[string]int),
+	}
+
+	tfplan := terraform.NewPlan(o.providerInitializers...)
+	if err := tfplan.Read(plan); err != nil {
+		return cov, err
+	}
+	tfplan.SetUsage(usage.Default)
+
+	// If no providers are known all the resources are unsupported
+	queries, err := tfplan.ExtractPlannedQueries()
+	if err != nil && !errors.Is(err, terraform.ErrNoProviders) {
+		return cov, err
+	}
+
+	supported := make(map[string]struct{})
+	for _, q := range queries {
+		if len(q.Components) != 0 {
+			supported[q.Address] = struct{}{}
+		}
+	}
+
+	addCoverageFromModule(cov, &tfplan.PlannedValues.RootModule, supported, o)
+
+	return cov, nil
+}
+
+// addCoverageFromModule adds all the managed resources of the module and its children to the Coverage
+func addCoverageFromModule(cov Coverage, module *terraform.Module, supported map[string]struct{}, o *estimationOptions) {
+	for _, res := range module.Resources {
+		if res.Mode != "managed" || o.isIgnored(res.Address) {
+			continue
+		}
+		if _, ok := supported[res.Address]; ok {
+			cov.Supported[res.Type]++
+		} else {
+			cov.Unsupported[res.Type]++
+		}
+	}
+	for _, child := range module.ChildModules {
+		addCoverageFromModule(cov, child, supported, o)
+	}
+}
+
+// CoverageCheck is the result of the estimation of the example of a resource type, see VerifyCoverage.
+type CoverageCheck struct {
+	ResourceType string
+
+	// Components is the number of components of the example
+	Components int
+
+	// Errors are the errors of the components without pricing (ex: cost.ErrProductNotFound)
+	// keyed by the component label, it's empty if all the components have a price
+	Errors map[string]error
+}
+
+// Passed returns true if the example has components and all of them have a price
+func (c CoverageCheck) Passed() bool { return c.Components > 0 && len(c.Errors) == 0 }
+
+// CoverageVerification is the result of VerifyCoverage, it has
+// the CoverageCheck of each resource type sorted by type.
+type CoverageVerification struct {
+	Provider string
+	Checks   []CoverageCheck
+}
+
+// Passed returns true if all the checks passed
+func (v CoverageVerification) Passed() bool { return len(v.Failed()) == 0 }
+
+// Failed returns the checks that did not pass
+func (v CoverageVerification) Failed() []CoverageCheck {
+	failed := make([]CoverageCheck, 0)
+	for _, c := range v.Checks {
+		if !c.Passed() {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// WriteTable writes to w a table with the PASS or FAIL result of each resource type
+// and the errors of the components without pricing
+func (v CoverageVerification) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE TYPE\tRESULT\tDETAILS")
+	for _, c := range v.Checks {
+		result, details := "PASS", fmt.Sprintf("%d components", c.Components)
+		if !c.Passed() {
+			result = "FAIL"
+		}
+		if c.Components == 0 {
+			details = "no components"
+		} else if len(c.Errors) != 0 {
+			labels := make([]string, 0, len(c.Errors))
+			for l := range c.Errors {
+				labels = append(labels, l)
+			}
+			sort.Strings(labels)
+			errs := make([]string, 0, len(labels))
+			for _, l := range labels {
+				errs = append(errs, fmt.Sprintf("%s: %s", l, c.Errors[l]))
+			}
+			details = strings.Join(errs, ", ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.ResourceType, result, details)
+	}
+	return tw.Flush()
+}
+
+// VerifyCoverage estimates the example of each resource type supported by the provider (see
+// terraform.ExamplesProvider) on the location (ex: the ingested region) with the default usage and
+// reports which ones have a price, if the location is empty the one of the provider is used. It's
+// meant to be used as a smoke test after ingesting the pricing data of the provider on the Backend.
+func VerifyCoverage(ctx context.Context, be backend.Backend, provider terraform.Provider, location string) (CoverageVerification, error) {
+	v := CoverageVerification{Provider: provider.Name()}
+
+	ep, ok := provider.(terraform.ExamplesProvider)
+	if !ok {
+		return v, fmt.Errorf("provider %q has no resource examples", provider.Name())
+	}
+
+	provider, examples, err := ep.ResourceExamples(location)
+	if err != nil {
+		return v, err
+	}
+	if len(examples) == 0 {
+		return v, nil
+	}
+
+	queries := make([]query.Resource, 0, len(examples))
+	for _, ex := range examples {
+		res := ex.Resource
+		if _, ok := res.Values[usage.Key]; !ok {
+			res.Values[usage.Key] = usage.Default.GetUsage(res.Type)
+		}
+
+		rss := make(map[string]terraform.Resource, len(ex.References)+1)
+		for _, ref := range ex.References {
+			rss[ref.Address] = ref
+		}
+		rss[res.Address] = res
+
+		queries = append(queries, query.Resource{
+			Address:    res.Address,
+			Provider:   provider.Name(),
+			Type:       res.Type,
+			Components: provider.ResourceComponents(rss, res),
+		})
+	}
+
+	state, err := cost.NewState(ctx, be, queries)
+	if err != nil {
+		return v, err
+	}
+
+	for _, q := range queries {
+		c := CoverageCheck{
+			ResourceType: q.Type,
+			Components:   len(q.Components),
+			Errors:       make(map[string]error),
+		}
+		for label, comp := range state.Resources[q.Address].Components {
+			if comp.Error != nil {
+				c.Errors[label] = comp.Error
+			}
+		}
+		v.Checks = append(v.Checks, c)
+	}
+	sort.Slice(v.Checks, func(i, j int) bool { return v.Checks[i].ResourceType < v.Checks[j].ResourceType })
+
+	return v, nil
+}
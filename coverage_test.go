@@ -0,0 +1,129 @@
+package terracost_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost"
+	"github.com/cycloidio/terracost/aws"
+	"github.com/cycloidio/terracost/cost"
+	googletf "github.com/cycloidio/terracost/google/terraform"
+	"github.com/cycloidio/terracost/mock"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/terraform"
+)
+
+func TestCoverageReport(t *testing.T) {
+	t.Run("Supported", func(t *testing.T) {
+		f, err := os.Open("testdata/aws/terraform-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		// The providers of the plan are named aws-test
+		awsTest := terraform.ProviderInitializer{
+			MatchNames: []string{"aws-test"},
+			Provider:   aws.TerraformProviderInitializer.Provider,
+		}
+		cov, err := terracost.CoverageReport(context.Background(), f, terracost.WithProviderInitializers(awsTest))
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]int{"aws_lb": 1, "aws_instance": 1}, cov.Supported)
+		assert.Empty(t, cov.Unsupported)
+		assert.Equal(t, float64(1), cov.Ratio())
+	})
+
+	t.Run("NoKnownProvider", func(t *testing.T) {
+		f, err := os.Open("testdata/aws/terraform-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		cov, err := terracost.CoverageReport(context.Background(), f, terracost.WithProviderInitializers(terraform.ProviderInitializer{MatchNames: []string{"unknown"}}))
+		require.NoError(t, err)
+
+		assert.Empty(t, cov.Supported)
+		assert.Equal(t, map[string]int{"aws_lb": 1, "aws_instance": 1}, cov.Unsupported)
+		assert.Equal(t, float64(0), cov.Ratio())
+	})
+}
+
+func TestVerifyCoverage(t *testing.T) {
+	provider, err := googletf.NewProvider("google", "europe-west1")
+	require.NoError(t, err)
+
+	t.Run("Passed", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, f *product.Filter) ([]*product.Product, error) {
+			// The examples are estimated on the location given
+			assert.Equal(t, "europe-west4", *f.Location)
+			return []*product.Product{prod}, nil
+		})
+		prc := &price.Price{Value: decimal.NewFromFloat(0.03), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, gomock.Any()).AnyTimes().Return([]*price.Price{prc}, nil)
+
+		v, err := terracost.VerifyCoverage(ctx, backend, provider, "europe-west4")
+		require.NoError(t, err)
+		assert.Equal(t, "google", v.Provider)
+		require.Len(t, v.Checks, 1)
+		assert.Equal(t, "google_compute_instance", v.Checks[0].ResourceType)
+		assert.True(t, v.Passed())
+		assert.Empty(t, v.Failed())
+
+		var buf bytes.Buffer
+		require.NoError(t, v.WriteTable(&buf))
+		assert.Contains(t, buf.String(), "google_compute_instance  PASS")
+	})
+
+	t.Run("ProductNotFound", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+
+		productRepo.EXPECT().Filter(ctx, gomock.Any()).AnyTimes().Return([]*product.Product{}, nil)
+
+		v, err := terracost.VerifyCoverage(ctx, backend, provider, "")
+		require.NoError(t, err)
+		assert.False(t, v.Passed())
+		require.Len(t, v.Failed(), 1)
+		for _, err := range v.Failed()[0].Errors {
+			assert.ErrorIs(t, err, cost.ErrProductNotFound)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, v.WriteTable(&buf))
+		assert.Contains(t, buf.String(), "google_compute_instance  FAIL")
+		assert.Contains(t, buf.String(), "product not found")
+	})
+
+	t.Run("NoExamples", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tp := mock.NewTerraformProvider(ctrl)
+		tp.EXPECT().Name().AnyTimes().Return("unknown")
+
+		_, err := terracost.VerifyCoverage(context.Background(), mock.NewBackend(ctrl), tp, "")
+		assert.Error(t, err)
+	})
+}
@@ -19,6 +19,8 @@
 //	file, err := os.Open("path/to/tfplan.json")
 //	plan, err := terracost.EstimateTerraformPlan(ctx, backend, file)
 //
+// Any io.Reader can be estimated, for example os.Stdin to pipe the output of 'terraform show -json'.
+//
 //	for _, res := range plan.ResourceDifferences() {
 //	    fmt.Printf("%s: %s -> %s\n", res.Address, res.PriorCost().String(), res.PlannedCost().String())
 //	}
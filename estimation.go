@@ -19,6 +19,7 @@ import (
 	"github.com/cycloidio/terracost/backend"
 	"github.com/cycloidio/terracost/cost"
 	"github.com/cycloidio/terracost/log"
+	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/usage"
 	"github.com/cycloidio/terracost/util"
@@ -31,46 +32,202 @@ import (
 
 // EstimateTerraformPlan is a helper function that reads a Terraform plan using the provided io.Reader,
 // generates the prior and planned cost.State, and then creates a cost.Plan from them that is returned.
-// It uses the Backend to retrieve the pricing data.
+// It uses the Backend to retrieve the pricing data. Any io.Reader works, the plan is read until EOF so it
+// can be a pipe (ex: os.Stdin with the output of 'terraform show -json' piped in a CI pipeline).
+// To configure the estimation use EstimateTerraformPlanWithOptions.
 func EstimateTerraformPlan(ctx context.Context, be backend.Backend, plan io.Reader, u usage.Usage, providerInitializers ...terraform.ProviderInitializer) (*cost.Plan, error) {
-	if len(providerInitializers) == 0 {
-		providerInitializers = getDefaultProviders()
-	}
+	return EstimateTerraformPlanWithOptions(ctx, be, plan, u, WithProviderInitializers(providerInitializers...))
+}
+
+// EstimateTerraformPlanWithOptions is the same as EstimateTerraformPlan with the estimation configured by the opts.
+func EstimateTerraformPlanWithOptions(ctx context.Context, be backend.Backend, plan io.Reader, u usage.Usage, opts ...Option) (*cost.Plan, error) {
+	o := newOptions(opts)
 
-	tfplan := terraform.NewPlan(providerInitializers...)
+	stopParse := o.timings.StartParse()
+	tfplan := terraform.NewPlan(o.providerInitializers...)
 	if err := tfplan.Read(plan); err != nil {
 		return nil, err
 	}
 	tfplan.SetUsage(u)
+	tfplan.SetDataSources(o.dataSources)
+
+	var changed map[string]struct{}
+	if o.changedOnly {
+		changed = tfplan.ChangedAddresses()
+	}
 
 	priorQueries, err := tfplan.ExtractPriorQueries()
 	if err != nil {
 		return nil, err
 	}
+	priorQueries = o.filterQueries(priorQueries)
+	var unchanged []query.Resource
+	if changed != nil {
+		priorQueries, unchanged = splitChanged(priorQueries, changed)
+	}
+
+	plannedQueries, err := tfplan.ExtractPlannedQueries()
+	if err != nil {
+		return nil, err
+	}
+	plannedQueries = o.filterQueries(plannedQueries)
+	if changed != nil {
+		plannedQueries, _ = splitChanged(plannedQueries, changed)
+	}
+	stopParse()
 
 	// If it's the first time we run the plan, then we might not have
 	// prior queries so we ignore it and move forward
-	prior, err := cost.NewState(ctx, be, priorQueries)
-	if err != nil && err != terraform.ErrNoQueries {
+	prior, err := cost.NewStateWithOptions(ctx, be, priorQueries, o.stateOptions())
+	if err != nil && !errors.Is(err, query.ErrNoQueries) {
 		return nil, err
 	}
+	if prior == nil && len(unchanged) != 0 {
+		prior = &cost.State{Resources: make(map[string]cost.Resource)}
+	}
 
-	plannedQueries, err := tfplan.ExtractPlannedQueries()
-	if err != nil {
-		return nil, err
+	// A plan that destroys all the resources (ex: 'terraform plan -destroy') has no planned
+	// queries, the planned State is then empty so the difference is the saving of the prior
+	planned, err := cost.NewStateWithOptions(ctx, be, plannedQueries, o.stateOptions())
+	if errors.Is(err, query.ErrNoQueries) && (prior != nil || changed != nil) {
+		planned, err = &cost.State{Resources: make(map[string]cost.Resource)}, nil
 	}
-	planned, err := cost.NewState(ctx, be, plannedQueries)
 	if err != nil {
 		return nil, err
 	}
 
+	// The unchanged resources are on both states without being priced so they have a zero difference
+	for _, q := range unchanged {
+		res := cost.Resource{Provider: q.Provider, Type: q.Type, Tags: q.Tags, Count: q.Count, Components: make(map[string]cost.Component)}
+		prior.Resources[q.Address] = res
+		planned.Resources[q.Address] = res
+	}
+
 	modules := make([]string, 0, 0)
 	for k := range tfplan.Configuration.RootModule.ModuleCalls {
 		modules = append(modules, k)
 	}
 	sort.Strings(modules)
 
-	return cost.NewPlan(strings.Join(modules, ", "), prior, planned), nil
+	return o.checkPricing(cost.NewPlan(strings.Join(modules, ", "), prior, planned))
+}
+
+// EstimateTerraformState is a helper function that reads a Terraform state (the raw 'terraform.tfstate'
+// or the output of 'terraform show -json') using the provided io.Reader and generates the prior cost.State
+// with the cost of the resources already deployed, which is returned wrapped in a cost.Plan.
+// It uses the Backend to retrieve the pricing data. As for EstimateTerraformPlan the state can be a pipe (ex: os.Stdin).
+func EstimateTerraformState(ctx context.Context, be backend.Backend, state io.Reader, u usage.Usage, opts ...Option) (*cost.Plan, error) {
+	o := newOptions(opts)
+
+	stopParse := o.timings.StartParse()
+	tfstate := terraform.NewStateFile(o.providerInitializers...)
+	if err := tfstate.Read(state); err != nil {
+		return nil, err
+	}
+	tfstate.SetUsage(u)
+	tfstate.SetDataSources(o.dataSources)
+
+	queries, err := tfstate.ExtractQueries()
+	if err != nil {
+		return nil, err
+	}
+	stopParse()
+
+	prior, err := cost.NewStateWithOptions(ctx, be, o.filterQueries(queries), o.stateOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return o.checkPricing(cost.NewPlan("", prior, nil))
+}
+
+// EstimateQueries estimates the query.Resource built from any source (not only Terraform) and
+// returns them as the planned cost.State of a cost.Plan with the given name.
+// It uses the Backend to retrieve the pricing data. As the query.Component already hold the
+// quantities, any usage has to be applied when building them.
+func EstimateQueries(ctx context.Context, be backend.Backend, name string, queries []query.Resource, opts ...Option) (*cost.Plan, error) {
+	o := newOptions(opts)
+
+	planned, err := cost.NewStateWithOptions(ctx, be, o.filterQueries(queries), o.stateOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return o.checkPricing(cost.NewPlan(name, nil, planned))
+}
+
+// ErrUnsupportedResource is returned by EstimateResource when the resource type is not supported by the provider.
+var ErrUnsupportedResource = errors.New("resource type not supported")
+
+// EstimateResource estimates a single resource of the resourceType (ex: aws_instance) with the Terraform attributes
+// attrs (ex: {"instance_type": "m5.xlarge"}) on the provider (ex: awstf.NewProvider("aws", "us-east-1")) and the usage
+// of its type, without building a plan, for quick what-if estimations (ex: calculators). The resources it may reference
+// (ex: a launch template) are not known so their defaults are used. An ErrUnsupportedResource is returned if the provider
+// can't estimate the resourceType. It uses the Backend to retrieve the pricing data.
+func EstimateResource(ctx context.Context, be backend.Backend, provider terraform.Provider, resourceType string, attrs map[string]interface{}, u usage.Usage, opts ...Option) (*cost.Resource, error) {
+	o := newOptions(opts)
+
+	values := make(map[string]interface{}, len(attrs)+1)
+	for k, v := range attrs {
+		values[k] = v
+	}
+	res := terraform.Resource{
+		Address:      fmt.Sprintf("%s.this", resourceType),
+		Mode:         "managed",
+		Type:         resourceType,
+		Name:         "this",
+		ProviderName: provider.Name(),
+		Values:       values,
+	}
+
+	var warnings []string
+	us, err := u.GetResourceUsage(resourceType, res.Tags())
+	if err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	res.Values[usage.Key] = us
+
+	rss := map[string]terraform.Resource{res.Address: res}
+	var comps []query.Component
+	if wp, ok := provider.(terraform.WarningsProvider); ok {
+		var pws []string
+		comps, pws = wp.ResourceComponentsWithWarnings(rss, res)
+		warnings = append(warnings, pws...)
+	} else {
+		comps = provider.ResourceComponents(rss, res)
+	}
+	if comps == nil {
+		return nil, fmt.Errorf("%w: %s on provider %s", ErrUnsupportedResource, resourceType, provider.Name())
+	}
+
+	state, err := cost.NewStateWithOptions(ctx, be, []query.Resource{
+		{
+			Address:      res.Address,
+			Provider:     provider.Name(),
+			Type:         resourceType,
+			Tags:         res.Tags(),
+			Components:   comps,
+			Warnings:     warnings,
+			DefaultUsage: u.IsDefault(resourceType, res.Tags()),
+		},
+	}, o.stateOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	re := state.Resources[res.Address]
+	return &re, nil
+}
+
+// ModuleQueries is the result of parsing a Terraform module from HCL, it holds
+// the name of the module and the query.Resource extracted from it.
+type ModuleQueries struct {
+	Name    string
+	Queries []query.Resource
+
+	// Skipped is set when the module was not parsed (Terragrunt 'skip'
+	// or no known provider) so it'll not have any cost
+	Skipped bool
 }
 
 // EstimateHCL is a helper function that recursively reads Terraform modules from a directory at the
@@ -80,10 +237,225 @@ func EstimateTerraformPlan(ctx context.Context, be backend.Backend, plan io.Read
 // If Force Terragrunt(ftg) is set then we'll just run Terragrunt
 // If Parallelisim Terragrunt is set(!=0) it'll set it when running TG
 // If debug is set to true it'll add more complex logging
+// To configure the estimation use EstimateHCLWithOptions.
 func EstimateHCL(ctx context.Context, be backend.Backend, afs afero.Fs, stackPath, modulePath string, ftg bool, ptg int, u usage.Usage, debug bool, providerInitializers ...terraform.ProviderInitializer) ([]*cost.Plan, error) {
-	if len(providerInitializers) == 0 {
-		providerInitializers = getDefaultProviders()
+	return EstimateHCLWithOptions(ctx, be, afs, stackPath, modulePath, ftg, ptg, u, debug, WithProviderInitializers(providerInitializers...))
+}
+
+// EstimateHCLWithOptions is the same as EstimateHCL with the estimation configured by the opts.
+func EstimateHCLWithOptions(ctx context.Context, be backend.Backend, afs afero.Fs, stackPath, modulePath string, ftg bool, ptg int, u usage.Usage, debug bool, opts ...Option) ([]*cost.Plan, error) {
+	o := newOptions(opts)
+
+	stopParse := o.timings.StartParse()
+	mqs, err := ParseHCLWithOptions(ctx, afs, stackPath, modulePath, ftg, ptg, u, debug, opts...)
+	if err != nil {
+		return nil, err
 	}
+	stopParse()
+
+	costs := make([]*cost.Plan, 0, len(mqs))
+	for _, mq := range mqs {
+		if mq.Skipped {
+			costs = append(costs, cost.NewPlan(mq.Name, nil, nil))
+			continue
+		}
+		planned, err := cost.NewStateWithOptions(ctx, be, mq.Queries, o.stateOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize a state: %w", err)
+		}
+
+		plan, err := o.checkPricing(cost.NewPlan(mq.Name, nil, planned))
+		if err != nil {
+			return nil, err
+		}
+		costs = append(costs, plan)
+	}
+	return costs, nil
+}
+
+// EstimateHCLDir is a helper function that walks the directory tree at rootPath, as the ones of the
+// Terragrunt repositories, and estimates each Terraform root module and Terragrunt leaf found on it.
+// The resources of all of them are aggregated on the planned cost.State of the returned cost.Plan,
+// see ParseHCLDir. It uses the Backend to retrieve the pricing data.
+func EstimateHCLDir(ctx context.Context, be backend.Backend, afs afero.Fs, rootPath string, ptg int, u usage.Usage, opts ...Option) (*cost.Plan, error) {
+	o := newOptions(opts)
+
+	stopParse := o.timings.StartParse()
+	mqs, err := ParseHCLDir(ctx, afs, rootPath, ptg, u, opts...)
+	if err != nil {
+		return nil, err
+	}
+	stopParse()
+
+	names := make([]string, 0, len(mqs))
+	queries := make([]query.Resource, 0)
+	for _, mq := range mqs {
+		names = append(names, mq.Name)
+		queries = append(queries, mq.Queries...)
+	}
+
+	// A tree without any priceable resource is an empty planned State, as on EstimateTerraformPlan
+	planned, err := cost.NewStateWithOptions(ctx, be, queries, o.stateOptions())
+	if errors.Is(err, query.ErrNoQueries) {
+		planned, err = &cost.State{Resources: make(map[string]cost.Resource)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize a state: %w", err)
+	}
+
+	return o.checkPricing(cost.NewPlan(strings.Join(names, ", "), nil, planned))
+}
+
+// ParseHCLDir walks the directory tree at rootPath and returns the query.Resource of each Terraform
+// root module found on it, sorted by path, without retrieving any pricing data. The Name of each
+// ModuleQueries is the path of the module relative to the rootPath, and the addresses of its
+// resources are prefixed with one 'module.<dir>.' by directory (ex: 'prod/vpc' prefixes
+// 'module.prod.module.vpc.') so they are unique on the aggregated plan.
+// The directories with a Terragrunt configuration and none below them (the leaves, not the root
+// 'terragrunt.hcl' they include) are parsed with Terragrunt as EstimateHCL does, so their inputs
+// are set, using ptg as the Terragrunt parallelism (0 is the default one).
+// The directories without Terraform files and the hidden ones (ex: '.terraform',
+// '.terragrunt-cache') are skipped, as the modules with no known provider, which is the
+// case of the child modules only called from a root module.
+func ParseHCLDir(ctx context.Context, afs afero.Fs, rootPath string, ptg int, u usage.Usage, opts ...Option) ([]ModuleQueries, error) {
+	o := newOptions(opts)
+
+	if afs == nil {
+		afs = afero.NewOsFs()
+	}
+
+	tfDirs := make(map[string]struct{})
+	tgDirs := make(map[string]struct{})
+	err := afero.Walk(afs, rootPath, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if p != rootPath && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(p, ".tf") || strings.HasSuffix(p, ".tf.json") {
+			tfDirs[filepath.Dir(p)] = struct{}{}
+		}
+		if info.Name() == config.DefaultTerragruntConfigPath || info.Name() == config.DefaultTerragruntJsonConfigPath {
+			tgDirs[filepath.Dir(p)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk path %q: %w", rootPath, err)
+	}
+
+	tgLeaves := terragruntLeaves(tgDirs)
+	dirs := make([]string, 0, len(tfDirs)+len(tgLeaves))
+	for dir := range tfDirs {
+		// The Terraform files of a Terragrunt leaf (or below it) are part of its module
+		if !isOnDirs(dir, tgLeaves) {
+			dirs = append(dirs, dir)
+		}
+	}
+	for dir := range tgLeaves {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	mqs := make([]ModuleQueries, 0, len(dirs))
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(rootPath, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relative path of %q from %q: %w", dir, rootPath, err)
+		}
+
+		var queries []query.Resource
+		if _, ok := tgLeaves[dir]; ok {
+			log.Logger.DebugContext(ctx, "Running TerraGrunt on leaf", "path", dir)
+			tgmqs, err := parseTerragrunt(ctx, afs, rootPath, dir, rel, ptg, u, false, o)
+			if err != nil {
+				return nil, err
+			}
+			skipped := true
+			for _, mq := range tgmqs {
+				skipped = skipped && mq.Skipped
+				queries = append(queries, mq.Queries...)
+			}
+			if skipped {
+				log.Logger.DebugContext(ctx, "Skipping Terragrunt leaf", "path", dir)
+				continue
+			}
+		} else {
+			log.Logger.DebugContext(ctx, "ExtractQueriesFromHCL", "path", dir)
+			queries, _, err = terraform.ExtractQueriesFromHCL(afs, o.providerInitializers, dir, u, nil)
+			if err != nil {
+				if err == terraform.ErrNoKnownProvider {
+					log.Logger.DebugContext(ctx, "Skipping module with no known provider", "path", dir)
+					continue
+				}
+				return nil, fmt.Errorf("failed to ExtractQueriesFromHCL on %q: %w", dir, err)
+			}
+		}
+
+		var prefix string
+		if rel != "." {
+			for _, d := range strings.Split(filepath.ToSlash(rel), "/") {
+				prefix += "module." + d + "."
+			}
+		}
+		for i := range queries {
+			queries[i].Address = prefix + queries[i].Address
+		}
+
+		mqs = append(mqs, ModuleQueries{Name: filepath.ToSlash(rel), Queries: o.filterQueries(queries)})
+	}
+
+	return mqs, nil
+}
+
+// terragruntLeaves returns the directories of tgDirs without any other one below them
+func terragruntLeaves(tgDirs map[string]struct{}) map[string]struct{} {
+	leaves := make(map[string]struct{}, len(tgDirs))
+	for dir := range tgDirs {
+		leaves[dir] = struct{}{}
+	}
+	for dir := range tgDirs {
+		for _, parent := range parentDirs(dir) {
+			delete(leaves, parent)
+		}
+	}
+	return leaves
+}
+
+// isOnDirs returns true if the dir, or any of its parents, is one of the dirs
+func isOnDirs(dir string, dirs map[string]struct{}) bool {
+	for _, d := range append([]string{dir}, parentDirs(dir)...) {
+		if _, ok := dirs[d]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parentDirs returns all the parent directories of dir, from the closest one
+func parentDirs(dir string) []string {
+	parents := make([]string, 0)
+	for d := filepath.Dir(dir); d != dir; dir, d = d, filepath.Dir(d) {
+		parents = append(parents, d)
+	}
+	return parents
+}
+
+// ParseHCL recursively reads Terraform modules from a directory at the given stackPath
+// and returns the query.Resource found on each one of them without retrieving any pricing
+// data, so it can be used to validate what would be estimated.
+// The parameters have the same meaning as the ones on EstimateHCL.
+func ParseHCL(ctx context.Context, afs afero.Fs, stackPath, modulePath string, ftg bool, ptg int, u usage.Usage, debug bool, providerInitializers ...terraform.ProviderInitializer) ([]ModuleQueries, error) {
+	return ParseHCLWithOptions(ctx, afs, stackPath, modulePath, ftg, ptg, u, debug, WithProviderInitializers(providerInitializers...))
+}
+
+// ParseHCLWithOptions is the same as ParseHCL with the parsing configured by the opts.
+func ParseHCLWithOptions(ctx context.Context, afs afero.Fs, stackPath, modulePath string, ftg bool, ptg int, u usage.Usage, debug bool, opts ...Option) ([]ModuleQueries, error) {
+	o := newOptions(opts)
 	var (
 		relModulePath string
 		err           error
@@ -128,19 +500,29 @@ func EstimateHCL(ctx context.Context, be backend.Backend, afs afero.Fs, stackPat
 		// If no Terragrunt file is found then we execute the normal code
 		if !hasTG {
 			log.Logger.DebugContext(ctx, "No TerraGrunt found executing ExtractQueriesFromHCL", "modulePath", modulePath)
-			plannedQueries, modAddr, err := terraform.ExtractQueriesFromHCL(afs, providerInitializers, modulePath, u, nil)
+			plannedQueries, modAddr, err := terraform.ExtractQueriesFromHCL(afs, o.providerInitializers, modulePath, u, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to ExtractQueriesFromHCL on module %q executed on 'stackPath' %q and 'modulePath' %q with error: %w", modAddr, stackPath, modulePath, err)
 			}
-			planned, err := cost.NewState(ctx, be, plannedQueries)
-			if err != nil {
-				return nil, fmt.Errorf("failed to initialize a state: %w", err)
-			}
+			return []ModuleQueries{{Name: modAddr, Queries: o.filterQueries(plannedQueries)}}, nil
+		}
+	}
 
-			return []*cost.Plan{cost.NewPlan(modAddr, nil, planned)}, nil
+	mqs, err := parseTerragrunt(ctx, afs, stackPath, modulePath, relModulePath, ptg, u, debug, o)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mqs {
+		if !mqs[i].Skipped {
+			mqs[i].Queries = o.filterQueries(mqs[i].Queries)
 		}
 	}
+	return mqs, nil
+}
 
+// parseTerragrunt runs Terragrunt on the relModulePath of the stackPath (modulePath) and returns the
+// query.Resource of each module found, with the Terragrunt inputs set, without filtering them
+func parseTerragrunt(ctx context.Context, afs afero.Fs, stackPath, modulePath, relModulePath string, ptg int, u usage.Usage, debug bool, o *estimationOptions) ([]ModuleQueries, error) {
 	// We create a tmp dir to move the files from fs to it so we can
 	// run Terragrunt on it. Terragrunt only runs on OS
 	tmpdir, err := os.MkdirTemp("", "terracost-terragrunt")
@@ -218,7 +600,7 @@ func EstimateHCL(ctx context.Context, be backend.Backend, afs afero.Fs, stackPat
 
 	log.Logger.DebugContext(ctx, "Modules found", "count", len(stack.Modules))
 
-	costs := make([]*cost.Plan, 0)
+	mqs := make([]ModuleQueries, 0)
 	for _, m := range stack.Modules {
 		log.Logger.DebugContext(ctx, "Working on module", "path", m.TerragruntOptions.WorkingDir)
 		// We ReadTerragruntConfig so we can have the 'tgc.Inputs' which has the values+variables
@@ -229,7 +611,7 @@ func EstimateHCL(ctx context.Context, be backend.Backend, afs afero.Fs, stackPat
 		if tgc.Skip {
 			modAddr := filepath.Base(m.TerragruntOptions.WorkingDir)
 
-			costs = append(costs, cost.NewPlan(modAddr, nil, nil))
+			mqs = append(mqs, ModuleQueries{Name: modAddr, Skipped: true})
 			continue
 		}
 
@@ -254,7 +636,7 @@ func EstimateHCL(ctx context.Context, be backend.Backend, afs afero.Fs, stackPat
 		}
 
 		log.Logger.DebugContext(ctx, "ExtractQueriesFromHCL", "Inputs", tgc.Inputs)
-		plannedQueries, modAddr, err := terraform.ExtractQueriesFromHCL(nfs, providerInitializers, "", u, tgc.Inputs)
+		plannedQueries, modAddr, err := terraform.ExtractQueriesFromHCL(nfs, o.providerInitializers, "", u, tgc.Inputs)
 		if err != nil {
 			if err == terraform.ErrNoKnownProvider {
 				// If we do not know the provider it means we have to skip it,
@@ -263,23 +645,18 @@ func EstimateHCL(ctx context.Context, be backend.Backend, afs afero.Fs, stackPat
 				if modAddr == "" {
 					modAddr = filepath.Base(m.TerragruntOptions.WorkingDir)
 				}
-				costs = append(costs, cost.NewPlan(modAddr, nil, nil))
+				mqs = append(mqs, ModuleQueries{Name: modAddr, Skipped: true})
 				continue
 			}
 			return nil, fmt.Errorf("failed to ExtractQueriesFromHCL on module %q executed on 'stackPath' %q and 'modulePath' %q with error: %w", modAddr, stackPath, modulePath, err)
 		}
-		planned, err := cost.NewState(ctx, be, plannedQueries)
-		if err != nil {
-			return nil, err
-		}
-
 		// If no module is defined we can always use the name of the WorkingDir in which
 		// TG found the modules
 		if modAddr == "" {
 			modAddr = filepath.Base(m.TerragruntOptions.WorkingDir)
 		}
 
-		costs = append(costs, cost.NewPlan(modAddr, nil, planned))
+		mqs = append(mqs, ModuleQueries{Name: modAddr, Queries: plannedQueries})
 	}
-	return costs, nil
+	return mqs, nil
 }
@@ -0,0 +1,532 @@
+package terracost_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost"
+	"github.com/cycloidio/terracost/aws"
+	"github.com/cycloidio/terracost/azurerm"
+	"github.com/cycloidio/terracost/cost"
+	"github.com/cycloidio/terracost/mock"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestParseHCL(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mqs, err := terracost.ParseHCL(context.Background(), nil, "testdata/aws/stack-aws", "", false, 0, usage.Default, false)
+		require.NoError(t, err)
+		require.Len(t, mqs, 1)
+
+		mq := mqs[0]
+		assert.Equal(t, "ec2, rds", mq.Name)
+		assert.False(t, mq.Skipped)
+		require.Len(t, mq.Queries, 5)
+		for _, q := range mq.Queries {
+			assert.Equal(t, "aws", q.Provider)
+			assert.NotEmpty(t, q.Address)
+			assert.NotEmpty(t, q.Components)
+		}
+	})
+
+	t.Run("IgnoreAddresses", func(t *testing.T) {
+		mqs, err := terracost.ParseHCLWithOptions(context.Background(), nil, "testdata/aws/stack-aws", "", false, 0, usage.Default, false, terracost.WithIgnoreAddresses([]string{"aws_instance.*", "aws_elb.front"}))
+		require.NoError(t, err)
+		require.Len(t, mqs, 1)
+
+		// The addresses of the resources of the modules are not prefixed by the module
+		addresses := make([]string, 0, len(mqs[0].Queries))
+		for _, q := range mqs[0].Queries {
+			addresses = append(addresses, q.Address)
+		}
+		assert.ElementsMatch(t, []string{"aws_ebs_volume.volume", "aws_db_instance.db"}, addresses)
+	})
+}
+
+func TestParseHCL_DefaultRegion(t *testing.T) {
+	afs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(afs, "stack/main.tf", []byte(`
+provider "aws" {}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t3.micro"
+}
+`), 0644))
+
+	mqs, err := terracost.ParseHCL(context.Background(), afs, "stack", "", false, 0, usage.Default, false, aws.NewTerraformProviderInitializer("eu-west-1"))
+	require.NoError(t, err)
+	require.Len(t, mqs, 1)
+	require.Len(t, mqs[0].Queries, 1)
+
+	q := mqs[0].Queries[0]
+	require.NotEmpty(t, q.Components)
+	assert.Equal(t, "eu-west-1", *q.Components[0].ProductFilter.Location)
+	assert.Contains(t, q.Warnings, `no region on the provider configuration, the default region "eu-west-1" is used`)
+}
+
+func TestParseHCL_MultipleProviders(t *testing.T) {
+	mqs, err := terracost.ParseHCL(context.Background(), nil, "testdata/mixed/stack-mixed", "", false, 0, usage.Default, false)
+	require.NoError(t, err)
+	require.Len(t, mqs, 1)
+
+	providers := make(map[string]string)
+	for _, q := range mqs[0].Queries {
+		assert.NotEmpty(t, q.Components, q.Address)
+		providers[q.Address] = q.Provider
+	}
+	assert.Equal(t, map[string]string{
+		"aws_instance.web":          "aws",
+		"azurerm_managed_disk.data": "azurerm",
+	}, providers)
+}
+
+func TestParseHCLDir(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mqs, err := terracost.ParseHCLDir(context.Background(), nil, "testdata/aws/stack-dir", 0, usage.Default)
+		require.NoError(t, err)
+		require.Len(t, mqs, 2)
+
+		// The modules/disk has no provider and docs no Terraform files so they are skipped
+		assert.Equal(t, "prod/web", mqs[0].Name)
+		assert.Equal(t, "staging/web", mqs[1].Name)
+
+		require.Len(t, mqs[0].Queries, 1)
+		assert.Equal(t, "module.prod.module.web.aws_instance.web", mqs[0].Queries[0].Address)
+		require.Len(t, mqs[1].Queries, 1)
+		assert.Equal(t, "module.staging.module.web.aws_instance.web", mqs[1].Queries[0].Address)
+	})
+
+	t.Run("IgnoreAddresses", func(t *testing.T) {
+		mqs, err := terracost.ParseHCLDir(context.Background(), nil, "testdata/aws/stack-dir", 0, usage.Default, terracost.WithIgnoreAddresses([]string{"module.staging.*"}))
+		require.NoError(t, err)
+		require.Len(t, mqs, 2)
+		assert.Len(t, mqs[0].Queries, 1)
+		assert.Empty(t, mqs[1].Queries)
+	})
+
+	t.Run("TerragruntLeaves", func(t *testing.T) {
+		mqs, err := terracost.ParseHCLDir(context.Background(), nil, "testdata/aws/stack-terragrunt-dir", 0, usage.Default)
+		require.NoError(t, err)
+
+		// The root terragrunt.hcl is only included by the leaves and the modules/web has no provider
+		require.Len(t, mqs, 3)
+		assert.Equal(t, "plain", mqs[0].Name)
+		assert.Equal(t, "prod/web", mqs[1].Name)
+		assert.Equal(t, "staging/web", mqs[2].Name)
+
+		// The leaves are estimated with their Terragrunt inputs
+		instanceType := func(q query.Resource) string {
+			for _, c := range q.Components {
+				for _, af := range c.ProductFilter.AttributeFilters {
+					if af.Key == "InstanceType" && af.Value != nil {
+						return *af.Value
+					}
+				}
+			}
+			return ""
+		}
+		require.Len(t, mqs[1].Queries, 1)
+		assert.Equal(t, "module.prod.module.web.aws_instance.web", mqs[1].Queries[0].Address)
+		assert.Equal(t, "m5.large", instanceType(mqs[1].Queries[0]))
+		require.Len(t, mqs[2].Queries, 1)
+		assert.Equal(t, "module.staging.module.web.aws_instance.web", mqs[2].Queries[0].Address)
+		assert.Equal(t, "t3.micro", instanceType(mqs[2].Queries[0]))
+	})
+}
+
+func TestEstimateHCLDir(t *testing.T) {
+	t.Run("NoResources", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// No pricing data is requested when there are no resources
+		backend := mock.NewBackend(ctrl)
+
+		afs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(afs, "stack/prod/main.tf", []byte(`
+provider "aws" {
+  region = "eu-west-3"
+}
+
+variable "name" {
+  default = "web"
+}
+`), 0644))
+
+		plan, err := terracost.EstimateHCLDir(context.Background(), backend, afs, "stack", 0, usage.Default)
+		require.NoError(t, err)
+		assert.Equal(t, "prod", plan.Name)
+		assert.Nil(t, plan.Prior)
+		require.NotNil(t, plan.Planned)
+		assert.Empty(t, plan.Planned.Resources)
+		assert.Empty(t, plan.ResourceDifferences())
+	})
+}
+
+func TestEstimateQueries(t *testing.T) {
+	queries := []query.Resource{
+		{
+			Address:  "catalog.vm",
+			Provider: "aws",
+			Type:     "vm",
+			Components: []query.Component{
+				{
+					Name:           "Compute",
+					HourlyQuantity: decimal.NewFromInt(2),
+					ProductFilter: &product.Filter{
+						Provider: util.StringPtr("aws"),
+						Service:  util.StringPtr("AmazonEC2"),
+						Family:   util.StringPtr("Compute Instance"),
+						Location: util.StringPtr("eu-west-3"),
+						AttributeFilters: []*product.AttributeFilter{
+							{Key: "instanceType", Value: util.StringPtr("t3.micro")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, queries[0].Components[0].PriceFilter).Return([]*price.Price{prc}, nil)
+
+		plan, err := terracost.EstimateQueries(ctx, backend, "catalog", queries)
+		require.NoError(t, err)
+		assert.Equal(t, "catalog", plan.Name)
+		assert.Nil(t, plan.Prior)
+
+		planned, err := plan.PlannedCost()
+		require.NoError(t, err)
+		assert.Equal(t, "USD", planned.Currency)
+		assert.True(t, decimal.New(179580, -2).Equal(planned.Monthly()), planned.Monthly().String())
+	})
+
+	t.Run("IgnoreAddresses", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, queries[0].Components[0].PriceFilter).Return([]*price.Price{prc}, nil)
+
+		qs := append([]query.Resource{
+			{
+				Address:    "sandbox.vm[0]",
+				Provider:   "aws",
+				Type:       "vm",
+				Components: queries[0].Components,
+			},
+		}, queries...)
+
+		plan, err := terracost.EstimateQueries(ctx, backend, "catalog", qs, terracost.WithIgnoreAddresses([]string{"sandbox.vm[?]"}))
+		require.NoError(t, err)
+		assert.Len(t, plan.Planned.Resources, 1)
+		assert.Contains(t, plan.Planned.Resources, "catalog.vm")
+	})
+
+	t.Run("StrictPricing", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Times(2).Return([]*product.Product{}, nil)
+
+		plan, err := terracost.EstimateQueries(ctx, backend, "catalog", queries)
+		require.NoError(t, err)
+		assert.ErrorIs(t, plan.Planned.Resources["catalog.vm"].Components["Compute"].Error, cost.ErrProductNotFound)
+
+		_, err = terracost.EstimateQueries(ctx, backend, "catalog", queries, terracost.WithStrictPricing(true))
+		var mpe *cost.MissingPricingError
+		require.ErrorAs(t, err, &mpe)
+		assert.Equal(t, []string{"catalog.vm"}, mpe.Addresses())
+	})
+
+	t.Run("Timings", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, firstProduct(queries[0].Components[0].ProductFilter)).Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(1.23), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, queries[0].Components[0].PriceFilter).Return([]*price.Price{prc}, nil)
+
+		var tm cost.Timings
+		_, err := terracost.EstimateQueries(ctx, backend, "catalog", queries, terracost.WithTimings(&tm))
+		require.NoError(t, err)
+
+		// The queries are given so there is nothing to parse
+		assert.Zero(t, tm.Parse)
+		assert.Positive(t, tm.ProductLookup)
+		assert.Positive(t, tm.PriceLookup)
+	})
+
+	t.Run("NoQueries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, err := terracost.EstimateQueries(context.Background(), mock.NewBackend(ctrl), "catalog", nil)
+		assert.Equal(t, terraform.ErrNoQueries, err)
+	})
+}
+
+func TestEstimateResource(t *testing.T) {
+	comps := []query.Component{
+		{
+			Name:           "Compute",
+			HourlyQuantity: decimal.NewFromInt(1),
+			ProductFilter: &product.Filter{
+				Provider: util.StringPtr("aws"),
+				Service:  util.StringPtr("AmazonEC2"),
+				Family:   util.StringPtr("Compute Instance"),
+				Location: util.StringPtr("us-east-1"),
+				AttributeFilters: []*product.AttributeFilter{
+					{Key: "instanceType", Value: util.StringPtr("m5.xlarge")},
+				},
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, gomock.Any()).Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(0.192), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, gomock.Any()).Return([]*price.Price{prc}, nil)
+
+		tp := mock.NewTerraformProvider(ctrl)
+		tp.EXPECT().Name().AnyTimes().Return("aws")
+		tp.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			assert.Equal(t, "aws_instance", res.Type)
+			assert.Equal(t, "m5.xlarge", res.Values["instance_type"])
+			assert.Equal(t, usage.Default.GetUsage("aws_instance"), res.Values[usage.Key])
+			assert.Contains(t, rss, res.Address)
+			return comps
+		})
+
+		re, err := terracost.EstimateResource(ctx, backend, tp, "aws_instance", map[string]interface{}{"instance_type": "m5.xlarge"}, usage.Default)
+		require.NoError(t, err)
+		assert.Equal(t, "aws_instance", re.Type)
+		assert.Equal(t, "aws", re.Provider)
+
+		c, err := re.Cost()
+		require.NoError(t, err)
+		assert.Equal(t, "USD", c.Currency)
+		assert.True(t, decimal.New(14016, -2).Equal(c.Monthly()), c.Monthly().String())
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tp := mock.NewTerraformProvider(ctrl)
+		tp.EXPECT().Name().AnyTimes().Return("aws")
+		tp.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := terracost.EstimateResource(context.Background(), mock.NewBackend(ctrl), tp, "aws_unknown", nil, usage.Default)
+		assert.ErrorIs(t, err, terracost.ErrUnsupportedResource)
+	})
+}
+
+func TestEstimateTerraformPlan(t *testing.T) {
+	t.Run("Destroy", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, gomock.Any()).AnyTimes().Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(0.01), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, gomock.Any()).AnyTimes().Return([]*price.Price{prc}, nil)
+
+		f, err := os.Open("testdata/aws/destroy-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		plan, err := terracost.EstimateTerraformPlan(ctx, backend, f, usage.Default)
+		require.NoError(t, err)
+		require.NotNil(t, plan.Prior)
+		require.NotNil(t, plan.Planned)
+		assert.Empty(t, plan.Planned.Resources)
+
+		prior, err := plan.PriorCost()
+		require.NoError(t, err)
+		require.True(t, prior.Decimal.IsPositive(), prior.Decimal.String())
+
+		planned, err := plan.PlannedCost()
+		require.NoError(t, err)
+		assert.True(t, planned.Decimal.IsZero(), planned.Decimal.String())
+
+		delta := planned.Decimal.Sub(prior.Decimal)
+		assert.True(t, delta.IsNegative(), delta.String())
+		assert.True(t, delta.Equal(prior.Decimal.Neg()), delta.String())
+
+		rds := plan.ResourceDifferences()
+		require.Len(t, rds, 1)
+		assert.Equal(t, "aws_instance.web", rds[0].Address)
+		for _, cd := range rds[0].ComponentDiffs {
+			assert.NotNil(t, cd.Prior)
+			assert.Nil(t, cd.Planned)
+		}
+	})
+
+	t.Run("ChangedOnly", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, gomock.Any()).AnyTimes().Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(0.01), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, gomock.Any()).AnyTimes().Return([]*price.Price{prc}, nil)
+
+		f, err := os.Open("testdata/aws/mixed-actions-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		plan, err := terracost.EstimateTerraformPlanWithOptions(ctx, backend, f, usage.Default, terracost.WithChangedOnly(true))
+		require.NoError(t, err)
+		assert.Empty(t, plan.SkippedAddresses())
+
+		rds := plan.ResourceDifferences()
+		require.Len(t, rds, 4)
+		byAddress := make(map[string]cost.ResourceDiff, len(rds))
+		for _, rd := range rds {
+			byAddress[rd.Address] = rd
+		}
+
+		require.Contains(t, byAddress, "aws_instance.noop")
+		assert.Empty(t, byAddress["aws_instance.noop"].ComponentDiffs)
+		assert.Empty(t, plan.Prior.Resources["aws_instance.noop"].Components)
+		assert.Empty(t, plan.Planned.Resources["aws_instance.noop"].Components)
+		c, err := byAddress["aws_instance.noop"].Classification()
+		require.NoError(t, err)
+		assert.Equal(t, cost.Unchanged, c)
+
+		require.Contains(t, byAddress, "aws_instance.create")
+		require.NotEmpty(t, byAddress["aws_instance.create"].ComponentDiffs)
+		for _, cd := range byAddress["aws_instance.create"].ComponentDiffs {
+			assert.Nil(t, cd.Prior)
+			assert.NotNil(t, cd.Planned)
+		}
+
+		require.Contains(t, byAddress, "aws_instance.update")
+		require.NotEmpty(t, byAddress["aws_instance.update"].ComponentDiffs)
+		for _, cd := range byAddress["aws_instance.update"].ComponentDiffs {
+			assert.NotNil(t, cd.Prior)
+			assert.NotNil(t, cd.Planned)
+		}
+
+		require.Contains(t, byAddress, "aws_instance.delete")
+		require.NotEmpty(t, byAddress["aws_instance.delete"].ComponentDiffs)
+		for _, cd := range byAddress["aws_instance.delete"].ComponentDiffs {
+			assert.NotNil(t, cd.Prior)
+			assert.Nil(t, cd.Planned)
+		}
+	})
+
+	t.Run("MultipleProviders", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productRepo := mock.NewProductRepository(ctrl)
+		priceRepo := mock.NewPriceRepository(ctrl)
+		backend := mock.NewBackend(ctrl)
+		backend.EXPECT().Products().AnyTimes().Return(productRepo)
+		backend.EXPECT().Prices().AnyTimes().Return(priceRepo)
+
+		prod := &product.Product{ID: product.ID(1)}
+		productRepo.EXPECT().Filter(ctx, gomock.Any()).AnyTimes().Return([]*product.Product{prod}, nil)
+		prc := &price.Price{Value: decimal.NewFromFloat(0.01), Unit: "Hrs", Currency: "USD"}
+		priceRepo.EXPECT().Filter(ctx, prod.ID, gomock.Any()).AnyTimes().Return([]*price.Price{prc}, nil)
+
+		f, err := os.Open("testdata/mixed/plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		plan, err := terracost.EstimateTerraformPlan(ctx, backend, f, usage.Default, aws.TerraformProviderInitializer, azurerm.TerraformProviderInitializer)
+		require.NoError(t, err)
+		require.NotNil(t, plan.Planned)
+
+		// Each resource is estimated by the provider matching its name in the same pass
+		require.Len(t, plan.Planned.Resources, 2)
+		assert.Equal(t, "aws", plan.Planned.Resources["aws_instance.web"].Provider)
+		assert.NotEmpty(t, plan.Planned.Resources["aws_instance.web"].Components)
+		assert.Equal(t, "azurerm", plan.Planned.Resources["azurerm_managed_disk.data"].Provider)
+		assert.NotEmpty(t, plan.Planned.Resources["azurerm_managed_disk.data"].Components)
+	})
+}
+
+// firstProduct returns the filter as it's sent to the product.Repository,
+// limited to the first product
+func firstProduct(f *product.Filter) *product.Filter {
+	pf := *f
+	pf.Limit = 1
+	return &pf
+}
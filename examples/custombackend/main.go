@@ -0,0 +1,207 @@
+// Package main is an example of a custom backend.Backend implemented out of the terracost module, which
+// keeps the pricing data on memory. A Backend getting the prices from another pricing service (ex: over gRPC)
+// would implement the same repositories, using the product.Filter and price.Filter Matches as reference.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost"
+	awstf "github.com/cycloidio/terracost/aws/terraform"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/usage"
+)
+
+// Backend is a backend.Backend holding the products and their prices on memory
+type Backend struct {
+	mu       sync.RWMutex
+	products []*product.Product
+	prices   map[product.ID][]*price.Price
+}
+
+// NewBackend returns an empty Backend
+func NewBackend() *Backend {
+	return &Backend{prices: make(map[product.ID][]*price.Price)}
+}
+
+// Products returns the product.Repository of the Backend
+func (b *Backend) Products() product.Repository { return productRepository{b} }
+
+// Prices returns the price.Repository of the Backend
+func (b *Backend) Prices() price.Repository { return priceRepository{b} }
+
+type productRepository struct{ *Backend }
+
+func (r productRepository) Filter(_ context.Context, f *product.Filter) ([]*product.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prods := make([]*product.Product, 0)
+	for _, p := range r.products {
+		if f.Matches(p) {
+			if f.Truncated(len(prods) + 1) {
+				return nil, product.ErrFilterTruncated
+			}
+			if len(prods) == f.GetLimit() {
+				break
+			}
+			prods = append(prods, p)
+		}
+	}
+	return prods, nil
+}
+
+func (r productRepository) FindByVendorAndSKU(_ context.Context, vendor, sku string) (*product.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.products {
+		if p.Provider == vendor && p.SKU == sku {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("product %s/%s not found", vendor, sku)
+}
+
+func (r productRepository) Upsert(_ context.Context, prod *product.Product) (product.ID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, p := range r.products {
+		if p.Provider == prod.Provider && p.SKU == prod.SKU {
+			prod.ID = p.ID
+			r.products[i] = prod
+			return prod.ID, nil
+		}
+	}
+	prod.ID = product.ID(len(r.products) + 1)
+	r.products = append(r.products, prod)
+	return prod.ID, nil
+}
+
+type priceRepository struct{ *Backend }
+
+func (r priceRepository) Filter(_ context.Context, id product.ID, f *price.Filter) ([]*price.Price, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// There is no history so the current prices are returned for any EffectiveAt
+	prices := make([]*price.Price, 0)
+	for _, p := range r.prices[id] {
+		if f.Matches(p) {
+			prices = append(prices, p)
+		}
+	}
+	return prices, nil
+}
+
+func (r priceRepository) Upsert(_ context.Context, pwp *price.WithProduct) (price.ID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prc := pwp.Price
+	hash := prc.GenerateHash()
+	prices := r.prices[pwp.Product.ID]
+	for i, p := range prices {
+		if p.GenerateHash() == hash {
+			prc.ID = p.ID
+			prices[i] = &prc
+			return prc.ID, nil
+		}
+	}
+	prc.ID = price.ID(len(prices) + 1)
+	r.prices[pwp.Product.ID] = append(prices, &prc)
+	return prc.ID, nil
+}
+
+func (r priceRepository) DeleteByProductWithKeep(_ context.Context, id product.ID, keep []price.ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]*price.Price, 0, len(keep))
+	for _, p := range r.prices[id] {
+		for _, k := range keep {
+			if p.ID == k {
+				kept = append(kept, p)
+				break
+			}
+		}
+	}
+	r.prices[id] = kept
+	return nil
+}
+
+func main() {
+	ctx := context.Background()
+	be := NewBackend()
+
+	// The pricing data would be loaded by terracost.IngestPricing from an Ingester,
+	// here the only price needed is set directly
+	err := terracost.IngestPricing(ctx, be, staticIngester{
+		{
+			Product: &product.Product{
+				Provider:   "aws",
+				SKU:        "EXAMPLE-GP3",
+				Service:    "AmazonEC2",
+				Family:     "Storage",
+				Location:   "eu-west-3",
+				Attributes: map[string]string{"VolumeAPIName": "gp3"},
+			},
+			Price: price.Price{
+				Unit:       "GB-Mo",
+				Currency:   "USD",
+				Value:      decimal.RequireFromString("0.0928"),
+				Attributes: map[string]string{"TermType": "OnDemand"},
+			},
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	provider, err := awstf.NewProvider("aws", "eu-west-3")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	res, err := terracost.EstimateResource(ctx, be, provider, "aws_ebs_volume", map[string]interface{}{
+		"type": "gp3",
+		"size": 100,
+	}, usage.Default)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	c, err := res.Cost()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// It prints "aws_ebs_volume: 9.28 USD/month", 100 GB at 0.0928 USD per GB-month
+	fmt.Printf("aws_ebs_volume: %s %s/month\n", c.Decimal.StringFixed(2), c.Currency)
+}
+
+// staticIngester is a terracost.Ingester sending the prices it holds
+type staticIngester []*price.WithProduct
+
+func (ing staticIngester) Ingest(_ context.Context, chSize int) <-chan *price.WithProduct {
+	results := make(chan *price.WithProduct, chSize)
+	go func() {
+		defer close(results)
+		for _, pp := range ing {
+			results <- pp
+		}
+	}()
+	return results
+}
+
+func (ing staticIngester) Err() error { return nil }
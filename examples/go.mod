@@ -7,6 +7,7 @@ toolchain go1.22.2
 require (
 	github.com/cycloidio/terracost v0.5.0
 	github.com/go-sql-driver/mysql v1.6.0
+	github.com/shopspring/decimal v1.3.1
 )
 
 require (
@@ -124,7 +125,6 @@ require (
 	github.com/russross/blackfriday/v2 v2.0.1 // indirect
 	github.com/ryanuber/go-glob v1.0.0 // indirect
 	github.com/sergi/go-diff v1.2.0 // indirect
-	github.com/shopspring/decimal v1.3.1 // indirect
 	github.com/shurcooL/sanitized_anchor_name v1.0.0 // indirect
 	github.com/sirupsen/logrus v1.8.1 // indirect
 	github.com/sourcegraph/go-lsp v0.0.0-20200429204803-219e11d77f5d // indirect
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -50,7 +51,7 @@ func main() {
 	flag.BoolVar(&flagIngest, "ingest", flagIngest, "Run price ingester")
 	flag.BoolVar(&flagIngestMinimal, "ingest-minimal", flagIngestMinimal, "Minimal ingest")
 	flag.StringVar(&flagIngestRegion, "ingest-region", flagIngestRegion, "Region used to ingest")
-	flag.StringVar(&flagestimatePlan, "estimate-plan", flagestimatePlan, "terraform-plan.json file path to estimate (example: ./terraform-plan.json)")
+	flag.StringVar(&flagestimatePlan, "estimate-plan", flagestimatePlan, "terraform-plan.json file path to estimate, '-' reads it from stdin (example: ./terraform-plan.json)")
 	flag.StringVar(&flagestimateHCL, "estimate-hcl", flagestimateHCL, "terraform HCL code path to estimate (example: ../testdata/aws/stack-aws)")
 	flag.StringVar(&flagProvider, "provider", flagProvider, "Terraform provider used [aws|azure|gcp]")
 	flag.StringVar(&googleCredentialFilePath, "google-cred-file", googleCredentialFilePath, "GCP JSON credential file path (/tmp/credentials.json)")
@@ -193,10 +194,17 @@ func ingest(flagProvider string, region string, backend *mysql.Backend, db *sql.
 
 func estimatePlan(path string, backend *mysql.Backend) {
 	fmt.Printf("EstimateTerraformPlan\n")
-	file, err := os.Open(path)
-	if err != nil {
-		fmt.Printf("%s\n", err)
-		os.Exit(1)
+
+	// The plan is read from stdin with '-' (ex: terraform show -json update.tfplan | go run terracost.go -estimate-plan -)
+	var file io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		file = f
 	}
 
 	plan, err := terracost.EstimateTerraformPlan(context.Background(), backend, file, usage.Default)
@@ -260,19 +268,8 @@ func estimateHCL(path string, provider string, backend *mysql.Backend) {
 	}
 }
 
-func estimateDisplay(resourceDiff *cost.Plan) {
-	for _, res := range resourceDiff.ResourceDifferences() {
-		priorCost, err := res.PriorCost()
-		if err != nil {
-			fmt.Printf("PriorCost %s: %s\n", res.Address, err)
-			continue
-		}
-
-		plannedCost, err := res.PlannedCost()
-		if err != nil {
-			fmt.Printf("PlannedCost %s: %s\n", res.Address, err)
-			continue
-		}
-		fmt.Printf("%s: %s -> %s\n", res.Address, priorCost, plannedCost)
+func estimateDisplay(plan *cost.Plan) {
+	if err := cost.WriteTable(os.Stdout, plan); err != nil {
+		fmt.Printf("%s\n", err)
 	}
 }
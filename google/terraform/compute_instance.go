@@ -4,6 +4,7 @@ import (
 	"github.com/cycloidio/terracost/price"
 	"github.com/cycloidio/terracost/product"
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
 	"github.com/cycloidio/terracost/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/shopspring/decimal"
@@ -81,3 +82,19 @@ func (inst *ComputeInstance) computeComponent() query.Component {
 		},
 	}
 }
+
+// computeInstanceExample returns the ResourceExample of the google_compute_instance, see Provider.ResourceExamples
+func (p *Provider) computeInstanceExample() terraform.ResourceExample {
+	return terraform.ResourceExample{
+		Resource: terraform.Resource{
+			Address:      "google_compute_instance.example",
+			Mode:         "managed",
+			Type:         "google_compute_instance",
+			Name:         "example",
+			ProviderName: p.key,
+			Values: map[string]interface{}{
+				"machine_type": "e2-medium",
+			},
+		},
+	}
+}
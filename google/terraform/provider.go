@@ -23,6 +23,25 @@ func NewProvider(key, region string) (*Provider, error) {
 // Name returns the Provider's common name.
 func (p *Provider) Name() string { return p.key }
 
+// ResourceAttributes returns the attributes of the resource type used to estimate it, see terraform.AttributesProvider.
+func (p *Provider) ResourceAttributes(resourceType string) []string {
+	v, ok := UsageValues()[resourceType]
+	if !ok {
+		return nil
+	}
+	return terraform.ValuesAttributes(v)
+}
+
+// ResourceExamples returns a canonical example of each supported resource type on the region, or on the region
+// of p if it's empty, see terraform.ExamplesProvider. The examples are estimated by the returned Provider.
+func (p *Provider) ResourceExamples(region string) (terraform.Provider, []terraform.ResourceExample, error) {
+	rp := p
+	if region != "" {
+		rp = &Provider{key: p.key, region: region}
+	}
+	return rp, []terraform.ResourceExample{rp.computeInstanceExample()}, nil
+}
+
 // ResourceComponents returns Component queries for a given terraform.Resource.
 func (p *Provider) ResourceComponents(rss map[string]terraform.Resource, tfRes terraform.Resource) []query.Component {
 	switch tfRes.Type {
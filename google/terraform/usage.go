@@ -0,0 +1,9 @@
+package terraform
+
+// UsageValues returns the values structs of all the supported resources keyed by
+// resource type, they are used to describe the usage fields of each resource.
+func UsageValues() map[string]interface{} {
+	return map[string]interface{}{
+		"google_compute_instance": computeInstanceValues{},
+	}
+}
@@ -2,6 +2,7 @@ package terracost
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cycloidio/terracost/backend"
@@ -21,6 +22,57 @@ type Ingester interface {
 	Err() error
 }
 
+// IncrementalIngester is implemented by the Ingesters that can ingest only the prices changed since the
+// mark of a previous ingestion, see IngestPricingIncremental.
+type IncrementalIngester interface {
+	Ingester
+
+	// MarkKey returns the key identifying the ingested pricing data (ex: aws/AmazonEC2/eu-west-3)
+	// under which its mark is stored.
+	MarkKey() string
+
+	// SetSince sets the mark of the previous ingestion before calling Ingest, all the
+	// prices are ingested if it's empty.
+	SetSince(mark string)
+
+	// Mark returns the mark of the pricing data ingested, once the ingestion is complete.
+	Mark() string
+}
+
+// ErrMarksNotSupported is returned by IngestPricingIncremental when the Backend does not implement backend.MarkStore
+var ErrMarksNotSupported = errors.New("backend does not support ingestion marks")
+
+// IngestPricingIncremental uses the IncrementalIngester to load only the pricing data changed since the
+// previous ingestion and stores it into the Backend, which is cheaper than IngestPricing to refresh the
+// prices regularly. The mark of the previous ingestion is read from the Backend, which must implement
+// backend.MarkStore, and all the prices are ingested if there is none. The new mark is only stored once
+// the ingestion succeeded. The marks are kept by provider, service and region so the same IngestionFilter
+// has to be used on each ingestion.
+func IngestPricingIncremental(ctx context.Context, be backend.Backend, ingester IncrementalIngester) error {
+	ms, ok := be.(backend.MarkStore)
+	if !ok {
+		return ErrMarksNotSupported
+	}
+
+	key := ingester.MarkKey()
+	since, err := ms.IngestionMark(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get ingestion mark %q: %w", key, err)
+	}
+	ingester.SetSince(since)
+
+	if err := IngestPricing(ctx, be, ingester); err != nil {
+		return err
+	}
+
+	if mark := ingester.Mark(); mark != "" && mark != since {
+		if err := ms.SetIngestionMark(ctx, key, mark); err != nil {
+			return fmt.Errorf("failed to set ingestion mark %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
 // IngestPricing uses the Ingester to load the pricing data and stores it into the Backend.
 func IngestPricing(ctx context.Context, be backend.Backend, ingester Ingester) error {
 	ctx, cancel := context.WithCancel(ctx)
@@ -73,3 +73,76 @@ func TestIngestPricing(t *testing.T) {
 	err := IngestPricing(context.Background(), backend, ingester)
 	require.NoError(t, err)
 }
+
+// markStoreBackend is a Backend that stores the ingestion marks in memory
+type markStoreBackend struct {
+	*mock.Backend
+	marks map[string]string
+}
+
+func (b *markStoreBackend) IngestionMark(_ context.Context, key string) (string, error) {
+	return b.marks[key], nil
+}
+
+func (b *markStoreBackend) SetIngestionMark(_ context.Context, key, mark string) error {
+	b.marks[key] = mark
+	return nil
+}
+
+// incrementalIngester is an IncrementalIngester that sends no prices and the mark
+type incrementalIngester struct {
+	*mock.Ingester
+	since, mark string
+}
+
+func (ing *incrementalIngester) MarkKey() string      { return "provider/service/region" }
+func (ing *incrementalIngester) SetSince(mark string) { ing.since = mark }
+func (ing *incrementalIngester) Mark() string         { return ing.mark }
+
+func TestIngestPricingIncremental(t *testing.T) {
+	newIngester := func(ctrl *gomock.Controller, mark string) *incrementalIngester {
+		ing := &incrementalIngester{Ingester: mock.NewIngester(ctrl), mark: mark}
+		ing.Ingester.EXPECT().Ingest(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, chSize int) <-chan *price.WithProduct {
+			results := make(chan *price.WithProduct, chSize)
+			close(results)
+			return results
+		})
+		ing.Ingester.EXPECT().Err().Return(nil)
+		return ing
+	}
+
+	t.Run("NoPreviousMark", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		be := &markStoreBackend{Backend: mock.NewBackend(ctrl), marks: map[string]string{}}
+		ing := newIngester(ctrl, "v1")
+
+		err := IngestPricingIncremental(context.Background(), be, ing)
+		require.NoError(t, err)
+		require.Equal(t, "", ing.since)
+		require.Equal(t, map[string]string{"provider/service/region": "v1"}, be.marks)
+	})
+
+	t.Run("PreviousMark", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		be := &markStoreBackend{Backend: mock.NewBackend(ctrl), marks: map[string]string{"provider/service/region": "v1"}}
+		ing := newIngester(ctrl, "v2")
+
+		err := IngestPricingIncremental(context.Background(), be, ing)
+		require.NoError(t, err)
+		require.Equal(t, "v1", ing.since)
+		require.Equal(t, map[string]string{"provider/service/region": "v2"}, be.marks)
+	})
+
+	t.Run("ErrMarksNotSupported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ing := &incrementalIngester{Ingester: mock.NewIngester(ctrl)}
+		err := IngestPricingIncremental(context.Background(), mock.NewBackend(ctrl), ing)
+		require.ErrorIs(t, err, ErrMarksNotSupported)
+	})
+}
@@ -13,15 +13,36 @@ type Backend struct {
 	querier     sqlr.Querier
 	productRepo *ProductRepository
 	priceRepo   *PriceRepository
+
+	// migrationsTable is the table used by Migrate to track the migrations
+	migrationsTable string
+}
+
+// Option is used to configure the Backend
+type Option func(b *Backend)
+
+// WithMigrationsTable sets the table given to Migrate to track the migrations,
+// by default it's the DefaultMigrationsTable
+func WithMigrationsTable(table string) Option {
+	return func(b *Backend) {
+		b.migrationsTable = table
+	}
 }
 
 // NewBackend returns a new Backend with a product.Repository and a price.Repository included.
-func NewBackend(querier sqlr.Querier) *Backend {
-	return &Backend{
-		querier:     querier,
-		productRepo: NewProductRepository(querier),
-		priceRepo:   NewPriceRepository(querier),
+func NewBackend(querier sqlr.Querier, opts ...Option) *Backend {
+	b := &Backend{
+		querier:         querier,
+		productRepo:     NewProductRepository(querier),
+		priceRepo:       NewPriceRepository(querier),
+		migrationsTable: DefaultMigrationsTable,
+	}
+
+	for _, opt := range opts {
+		opt(b)
 	}
+
+	return b
 }
 
 // Products returns the product.Repository that uses the Backend's querier.
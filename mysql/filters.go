@@ -59,12 +59,34 @@ func parseProductFilter(filter *product.Filter) *Where {
 			w.add(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(attributes, '$.%s')) = ?", f.Key), *f.Value)
 		} else if f.ValueRegex != nil {
 			w.add(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(attributes, '$.%s')) RLIKE ?", f.Key), *f.ValueRegex)
+		} else if f.ValueIn != nil {
+			// An empty (but set) ValueIn matches none of the values
+			if len(f.ValueIn) == 0 {
+				w.add("FALSE")
+				continue
+			}
+			params := make([]interface{}, 0, len(f.ValueIn))
+			for _, v := range f.ValueIn {
+				params = append(params, v)
+			}
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(params)), ", ")
+			w.add(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(attributes, '$.%s')) IN (%s)", f.Key, placeholders), params...)
+		} else if f.ValuePrefix != nil {
+			w.add(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(attributes, '$.%s')) LIKE ?", f.Key), likePrefix(*f.ValuePrefix))
 		}
 	}
 
 	return w
 }
 
+// likeEscaper escapes the LIKE wildcards so they are matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// likePrefix returns the LIKE pattern matching the values starting with prefix
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
 func parsePriceFilter(filter *price.Filter, productID product.ID) *Where {
 	w := &Where{}
 
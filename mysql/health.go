@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	driver "github.com/go-sql-driver/mysql"
+
+	"github.com/cycloidio/terracost/mysql/migrations"
+)
+
+// LatestSchemaVersion is the schema version expected by this version of terracost,
+// which is the number of migrations to apply with Migrate
+const LatestSchemaVersion = len(migrations.Migrations)
+
+// DefaultMigrationsTable is the table used by the Backend to read the schema version,
+// it's the one to give to Migrate unless the Backend is created WithMigrationsTable
+const DefaultMigrationsTable = "pricing_migrations"
+
+// errNoSuchTable is the MySQL error number returned when a table does not exist
+const errNoSuchTable = 1146
+
+var (
+	// ErrOutdatedSchema is returned by CheckSchema when the migrations have not all been applied
+	ErrOutdatedSchema = errors.New("outdated schema")
+
+	// ErrInvalidMigrationsTable is returned by SchemaVersion when the migrations table
+	// is not a valid unquoted identifier (letters, digits, '_' and '$')
+	ErrInvalidMigrationsTable = errors.New("invalid migrations table")
+)
+
+// identifierRe matches the unquoted MySQL identifiers
+var identifierRe = regexp.MustCompile(`^[0-9a-zA-Z_$]{1,64}$`)
+
+// pinger is implemented by the *sql.DB and *sql.Conn
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Ping verifies that the database is reachable
+func (b *Backend) Ping(ctx context.Context) error {
+	if p, ok := b.querier.(pinger); ok {
+		return p.PingContext(ctx)
+	}
+
+	var one int
+	return b.querier.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
+
+// SchemaVersion returns the number of migrations applied on the database, read from
+// the migrations table of the Backend (see WithMigrationsTable). If the table does not
+// exist yet it returns 0
+func (b *Backend) SchemaVersion(ctx context.Context) (int, error) {
+	if !identifierRe.MatchString(b.migrationsTable) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMigrationsTable, b.migrationsTable)
+	}
+
+	var version int
+	err := b.querier.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM `%s`", b.migrationsTable)).Scan(&version)
+	if err != nil {
+		var merr *driver.MySQLError
+		if errors.As(err, &merr) && merr.Number == errNoSuchTable {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// CheckSchema checks that the database is reachable and that all the migrations
+// have been applied, if not ErrOutdatedSchema is returned
+func (b *Backend) CheckSchema(ctx context.Context) error {
+	if err := b.Ping(ctx); err != nil {
+		return err
+	}
+
+	version, err := b.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version < LatestSchemaVersion {
+		return fmt.Errorf("%w: version %d, expected %d", ErrOutdatedSchema, version, LatestSchemaVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package mysql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/mysql"
+)
+
+func TestBackend_Ping(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectPing()
+
+	be := mysql.NewBackend(db)
+	require.NoError(t, be.Ping(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBackend_SchemaVersion(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `pricing_migrations`").
+			WillReturnRows(mock.NewRows([]string{"count"}).AddRow(2))
+
+		be := mysql.NewBackend(db)
+		version, err := be.SchemaVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+	})
+
+	t.Run("NoTable", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `pricing_migrations`").
+			WillReturnError(&driver.MySQLError{Number: 1146, Message: "Table doesn't exist"})
+
+		be := mysql.NewBackend(db)
+		version, err := be.SchemaVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, version)
+	})
+
+	t.Run("WithMigrationsTable", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `migrations`").
+			WillReturnRows(mock.NewRows([]string{"count"}).AddRow(2))
+
+		be := mysql.NewBackend(db, mysql.WithMigrationsTable("migrations"))
+		version, err := be.SchemaVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+	})
+
+	t.Run("ErrInvalidMigrationsTable", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		be := mysql.NewBackend(db, mysql.WithMigrationsTable("migrations; DROP TABLE pricing_products"))
+		_, err = be.SchemaVersion(context.Background())
+		assert.True(t, errors.Is(err, mysql.ErrInvalidMigrationsTable))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBackend_CheckSchema(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `pricing_migrations`").
+			WillReturnRows(mock.NewRows([]string{"count"}).AddRow(mysql.LatestSchemaVersion))
+
+		be := mysql.NewBackend(db)
+		require.NoError(t, be.CheckSchema(context.Background()))
+	})
+
+	t.Run("Outdated", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `pricing_migrations`").
+			WillReturnRows(mock.NewRows([]string{"count"}).AddRow(mysql.LatestSchemaVersion - 1))
+
+		be := mysql.NewBackend(db)
+		err = be.CheckSchema(context.Background())
+		assert.True(t, errors.Is(err, mysql.ErrOutdatedSchema))
+	})
+}
@@ -0,0 +1,30 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// IngestionMark returns the mark of the last incremental ingestion of the key, see backend.MarkStore.
+// It returns an empty string if there is none.
+func (b *Backend) IngestionMark(ctx context.Context, key string) (string, error) {
+	var mark string
+	err := b.querier.QueryRowContext(ctx, "SELECT mark FROM pricing_ingestion_marks WHERE mark_key = ?", key).Scan(&mark)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return mark, nil
+}
+
+// SetIngestionMark stores the mark of the incremental ingestion of the key, see backend.MarkStore.
+func (b *Backend) SetIngestionMark(ctx context.Context, key, mark string) error {
+	_, err := b.querier.ExecContext(ctx, `
+		INSERT INTO pricing_ingestion_marks (mark_key, mark) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE mark = VALUES(mark), updated_at = CURRENT_TIMESTAMP
+	`, key, mark)
+	return err
+}
@@ -0,0 +1,58 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/mysql"
+)
+
+func TestBackend_IngestionMark(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT mark FROM pricing_ingestion_marks WHERE mark_key = \?`).
+			WithArgs("aws/AmazonEC2/eu-west-3").
+			WillReturnRows(mock.NewRows([]string{"mark"}).AddRow("v1"))
+
+		be := mysql.NewBackend(db)
+		mark, err := be.IngestionMark(context.Background(), "aws/AmazonEC2/eu-west-3")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", mark)
+	})
+
+	t.Run("NoMark", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT mark FROM pricing_ingestion_marks WHERE mark_key = \?`).
+			WithArgs("aws/AmazonEC2/eu-west-3").
+			WillReturnRows(mock.NewRows([]string{"mark"}))
+
+		be := mysql.NewBackend(db)
+		mark, err := be.IngestionMark(context.Background(), "aws/AmazonEC2/eu-west-3")
+		require.NoError(t, err)
+		assert.Equal(t, "", mark)
+	})
+}
+
+func TestBackend_SetIngestionMark(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO pricing_ingestion_marks .+ ON DUPLICATE KEY UPDATE`).
+		WithArgs("aws/AmazonEC2/eu-west-3", "v2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	be := mysql.NewBackend(db)
+	require.NoError(t, be.SetIngestionMark(context.Background(), "aws/AmazonEC2/eu-west-3", "v2"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -8,8 +8,10 @@ type Migration struct {
 
 // Migrations is an ordered list of migrations to track and execute. It is represented by a fixed-size array
 // to break the build if conflicting migrations were added concurrently.
-var Migrations = [3]Migration{
+var Migrations = [5]Migration{
 	v0Initial,
 	v1NameIndexes,
 	v2ExtendPriceUnit,
+	v3PriceHistory,
+	v4IngestionMarks,
 }
@@ -0,0 +1,31 @@
+package migrations
+
+// v3PriceHistory keeps track of when a price was last changed and
+// adds a table in which the previous values of the changed prices
+// are archived so the changes between ingestions can be compared.
+// The prices ingested before are not known to have changed since
+// when they were ingested, they are effective since the epoch
+var v3PriceHistory = Migration{
+	Name: "Price History",
+	SQL: `
+		ALTER TABLE pricing_product_prices
+			ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+		UPDATE pricing_product_prices SET updated_at = '1970-01-01 00:00:00';
+
+		CREATE TABLE pricing_product_price_history (
+			id INT(8) UNSIGNED AUTO_INCREMENT,
+			product_id INT(8) UNSIGNED NOT NULL,
+			hash VARCHAR(32) NOT NULL,
+			currency VARCHAR(16) NOT NULL,
+			unit VARCHAR(255) NOT NULL,
+			price DECIMAL(24,10) NOT NULL,
+			attributes JSON NOT NULL,
+			valid_from DATETIME NOT NULL,
+			valid_to DATETIME NOT NULL,
+			PRIMARY KEY (id),
+			INDEX idx__product_id__hash (product_id, hash),
+			INDEX idx__valid_to (valid_to)
+		);
+	`,
+}
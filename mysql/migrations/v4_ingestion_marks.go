@@ -0,0 +1,16 @@
+package migrations
+
+// v4IngestionMarks adds a table in which the high-water mark of the
+// last ingestion of each provider, service and region is stored for
+// the incremental ingestions
+var v4IngestionMarks = Migration{
+	Name: "Ingestion Marks",
+	SQL: `
+		CREATE TABLE pricing_ingestion_marks (
+			mark_key VARCHAR(255) NOT NULL,
+			mark VARCHAR(255) NOT NULL,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (mark_key)
+		);
+	`,
+}
@@ -2,9 +2,11 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"golang.org/x/text/currency"
 
@@ -15,7 +17,7 @@ import (
 	"github.com/cycloidio/terracost/product"
 )
 
-// PriceRepository implements the price.Repository.
+// PriceRepository implements the price.Repository and the price.ChangesRepository.
 type PriceRepository struct {
 	querier sqlr.Querier
 }
@@ -77,9 +79,33 @@ func (r *PriceRepository) Filter(ctx context.Context, productID product.ID, filt
 		FROM pricing_product_prices
 		WHERE %s
 	`, where.String())
+	params := where.Parameters()
+
+	// The prices active at a past date are the current ones not changed since then
+	// and the ones of the history that were valid at that date, which keep the ID
+	// of the current price if it still exists
+	if filter != nil && !filter.EffectiveAt.IsZero() {
+		at := filter.EffectiveAt.Unix()
+		q = fmt.Sprintf(`
+			SELECT id, hash, product_id, currency, price, unit, attributes
+			FROM pricing_product_prices
+			WHERE %[1]s AND updated_at <= FROM_UNIXTIME(?)
+			UNION ALL
+			SELECT COALESCE((
+				SELECT pp.id FROM pricing_product_prices AS pp WHERE pp.product_id = h.product_id AND pp.hash = h.hash
+			), 0), hash, product_id, currency, price, unit, attributes
+			FROM pricing_product_price_history AS h
+			WHERE %[1]s AND h.valid_from <= FROM_UNIXTIME(?) AND h.valid_to > FROM_UNIXTIME(?)
+		`, where.String())
+		params = make([]interface{}, 0, 2*len(where.Parameters())+3)
+		params = append(params, where.Parameters()...)
+		params = append(params, at)
+		params = append(params, where.Parameters()...)
+		params = append(params, at, at)
+	}
 
 	ps := make([]*price.Price, 0)
-	rows, err := r.querier.QueryContext(ctx, q, where.Parameters()...)
+	rows, err := r.querier.QueryContext(ctx, q, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -99,29 +125,46 @@ func (r *PriceRepository) Filter(ctx context.Context, productID product.ID, filt
 }
 
 // Upsert updates a price.WithProduct if it exists or inserts it otherwise.
+// If the value of an existing price changes the previous one is archived
+// on the history so it can be retrieved with Changes, both on the same transaction.
 func (r *PriceRepository) Upsert(ctx context.Context, pwp *price.WithProduct) (price.ID, error) {
 	p, err := newPrice(pwp)
 	if err != nil {
 		return 0, err
 	}
 
-	q := `
-		INSERT INTO pricing_product_prices (product_id, hash, currency, price, unit, attributes)
-		VALUES (?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			id = LAST_INSERT_ID(id),
-			currency = VALUES(currency),
-			price = VALUES(price),
-			unit = VALUES(unit),
-			attributes = VALUES(attributes)
-	`
+	var id int64
+	err = withTx(ctx, r.querier, func(q sqlr.Querier) error {
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO pricing_product_price_history (product_id, hash, currency, price, unit, attributes, valid_from, valid_to)
+			SELECT product_id, hash, currency, price, unit, attributes, updated_at, NOW()
+			FROM pricing_product_prices
+			WHERE product_id = ? AND hash = ? AND price <> ?
+		`, p.ProductID, p.Hash, p.Value)
+		if err != nil {
+			return err
+		}
 
-	res, err := r.querier.ExecContext(ctx, q, p.ProductID, p.Hash, p.Currency, p.Value, p.Unit, p.Attributes)
-	if err != nil {
-		return 0, err
-	}
+		// The updated_at has to be set before the price
+		// as the assignments are evaluated in order
+		res, err := q.ExecContext(ctx, `
+			INSERT INTO pricing_product_prices (product_id, hash, currency, price, unit, attributes)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				id = LAST_INSERT_ID(id),
+				updated_at = IF(price <> VALUES(price), NOW(), updated_at),
+				currency = VALUES(currency),
+				price = VALUES(price),
+				unit = VALUES(unit),
+				attributes = VALUES(attributes)
+		`, p.ProductID, p.Hash, p.Currency, p.Value, p.Unit, p.Attributes)
+		if err != nil {
+			return err
+		}
 
-	id, err := res.LastInsertId()
+		id, err = res.LastInsertId()
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -129,6 +172,7 @@ func (r *PriceRepository) Upsert(ctx context.Context, pwp *price.WithProduct) (p
 }
 
 // DeleteByProductWithKeep deletes all the prices of the product with given product.ID except the ones in the keep slice.
+// The deleted prices are archived on the history, on the same transaction, so they are still found at the dates they were valid.
 func (r *PriceRepository) DeleteByProductWithKeep(ctx context.Context, productID product.ID, keep []price.ID) error {
 	marks := make([]string, 0, len(keep))
 	values := make([]interface{}, 0, len(keep)+1)
@@ -139,13 +183,102 @@ func (r *PriceRepository) DeleteByProductWithKeep(ctx context.Context, productID
 		values = append(values, v)
 	}
 
-	q := fmt.Sprintf(`DELETE FROM pricing_product_prices WHERE product_id = ? AND id NOT IN (%s)`, strings.Join(marks, ","))
+	return withTx(ctx, r.querier, func(q sqlr.Querier) error {
+		_, err := q.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO pricing_product_price_history (product_id, hash, currency, price, unit, attributes, valid_from, valid_to)
+			SELECT product_id, hash, currency, price, unit, attributes, updated_at, NOW()
+			FROM pricing_product_prices
+			WHERE product_id = ? AND id NOT IN (%s)
+		`, strings.Join(marks, ",")), values...)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.ExecContext(ctx, fmt.Sprintf(`DELETE FROM pricing_product_prices WHERE product_id = ? AND id NOT IN (%s)`, strings.Join(marks, ",")), values...)
+		return err
+	})
+}
+
+// Changes returns all the prices that changed their value since the given time, with
+// the previous value they had. A price that changed more than once will be returned
+// once per change, each with the value it changed to: the one of the next archived
+// value or the current one for the last change. The deleted prices are not changes.
+// See price.ChangesRepository.
+func (r *PriceRepository) Changes(ctx context.Context, since time.Time) ([]*price.Change, error) {
+	q := `
+		SELECT pp.id, pp.hash, pp.product_id, pp.currency, COALESCE((
+				SELECT n.price FROM pricing_product_price_history AS n
+				WHERE n.product_id = h.product_id AND n.hash = h.hash AND n.valid_to > h.valid_to
+				ORDER BY n.valid_to LIMIT 1
+			), pp.price), pp.unit, pp.attributes,
+			p.id, p.provider, p.sku, p.service, p.family, p.location, p.attributes,
+			h.price, UNIX_TIMESTAMP(h.valid_to)
+		FROM pricing_product_price_history AS h
+		JOIN pricing_product_prices AS pp ON pp.product_id = h.product_id AND pp.hash = h.hash
+		JOIN pricing_products AS p ON p.id = h.product_id
+		WHERE h.valid_to >= FROM_UNIXTIME(?)
+		ORDER BY h.valid_to, pp.id
+	`
+
+	cs := make([]*price.Change, 0)
+	rows, err := r.querier.QueryContext(ctx, q, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			pp        dbPrice
+			prod      dbProduct
+			prevValue decimal.Decimal
+			changedAt int64
+		)
+		err := rows.Scan(
+			&pp.ID, &pp.Hash, &pp.ProductID, &pp.Currency, &pp.Value, &pp.Unit, &pp.Attributes,
+			&prod.ID, &prod.Provider, &prod.SKU, &prod.Service, &prod.Family, &prod.Location, &prod.Attributes,
+			&prevValue, &changedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, &price.Change{
+			Price:         *pp.toDomainEntity(),
+			Product:       prod.toDomainEntity(),
+			PreviousValue: prevValue,
+			ChangedAt:     time.Unix(changedAt, 0),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// txBeginner is implemented by the queriers that can start a transaction, like the *sql.DB
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
 
-	_, err := r.querier.ExecContext(ctx, q, values...)
+// withTx runs fn on a transaction started from the querier, which is committed if fn
+// succeeds and rolled back otherwise. If the querier can not start one (ex: it's already
+// a *sql.Tx) fn is run with it directly.
+func withTx(ctx context.Context, querier sqlr.Querier, fn func(q sqlr.Querier) error) error {
+	b, ok := querier.(txBeginner)
+	if !ok {
+		return fn(querier)
+	}
+
+	tx, err := b.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
 }
 
 func scanPrice(row sqlr.Scanner) (*price.Price, error) {
@@ -2,7 +2,9 @@ package mysql_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/shopspring/decimal"
@@ -112,4 +114,186 @@ func TestPriceRepository_FilterByProduct(t *testing.T) {
 
 		require.Equal(t, expected, prices)
 	})
+
+	t.Run("EffectiveAt", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewPriceRepository(db)
+
+		at := time.Unix(1700000000, 0)
+		rows := mock.NewRows(priceColumns).AddRow(1, "HASH", 1, "USD", "1.0000000000", "Hrs", `{"key":"value"}`)
+		mock.ExpectQuery(`SELECT .+ FROM pricing_product_prices WHERE product_id = \? AND unit = \? AND updated_at <= FROM_UNIXTIME\(\?\) UNION ALL SELECT .+ FROM pricing_product_price_history AS h WHERE product_id = \? AND unit = \? AND h.valid_from <= FROM_UNIXTIME\(\?\) AND h.valid_to > FROM_UNIXTIME\(\?\)`).
+			WithArgs(1, "Hrs", at.Unix(), 1, "Hrs", at.Unix(), at.Unix()).
+			WillReturnRows(rows)
+
+		filter := &price.Filter{
+			Unit:        strPtr("Hrs"),
+			EffectiveAt: at,
+		}
+		prices, err := repo.Filter(context.Background(), product.ID(1), filter)
+		require.NoError(t, err)
+
+		expected := []*price.Price{
+			{
+				ID:         1,
+				Unit:       "Hrs",
+				Currency:   "USD",
+				Value:      decimal.RequireFromString("1.0000000000"),
+				Attributes: map[string]string{"key": "value"},
+			},
+		}
+
+		require.Equal(t, expected, prices)
+	})
+}
+
+func TestPriceRepository_Upsert(t *testing.T) {
+	pwp := &price.WithProduct{
+		Price: price.Price{
+			Unit:       "Hrs",
+			Currency:   "USD",
+			Value:      decimal.RequireFromString("1.23"),
+			Attributes: map[string]string{"key": "value"},
+		},
+		Product: &product.Product{ID: 1},
+	}
+	hash := pwp.GenerateHash()
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewPriceRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO pricing_product_price_history .+ FROM pricing_product_prices WHERE product_id = \? AND hash = \? AND price <> \?`).
+			WithArgs(1, hash, decimal.RequireFromString("1.23")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO pricing_product_prices .+ ON DUPLICATE KEY UPDATE`).
+			WithArgs(1, hash, "USD", decimal.RequireFromString("1.23"), "Hrs", `{"key":"value"}`).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		id, err := repo.Upsert(context.Background(), pwp)
+		require.NoError(t, err)
+		require.Equal(t, price.ID(3), id)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrUpsertRollsBackHistory", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewPriceRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO pricing_product_price_history`).
+			WithArgs(1, hash, decimal.RequireFromString("1.23")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO pricing_product_prices`).
+			WillReturnError(errors.New("failed"))
+		mock.ExpectRollback()
+
+		_, err = repo.Upsert(context.Background(), pwp)
+		require.EqualError(t, err, "failed")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPriceRepository_DeleteByProductWithKeep(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewPriceRepository(db)
+
+		// The deleted prices are archived before being deleted
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO pricing_product_price_history .+ FROM pricing_product_prices WHERE product_id = \? AND id NOT IN \(\?,\?\)`).
+			WithArgs(1, 2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM pricing_product_prices WHERE product_id = \? AND id NOT IN \(\?,\?\)`).
+			WithArgs(1, 2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = repo.DeleteByProductWithKeep(context.Background(), product.ID(1), []price.ID{2, 3})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrDeleteRollsBackHistory", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewPriceRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO pricing_product_price_history`).
+			WithArgs(1, 2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM pricing_product_prices`).
+			WithArgs(1, 2, 3).
+			WillReturnError(errors.New("failed"))
+		mock.ExpectRollback()
+
+		err = repo.DeleteByProductWithKeep(context.Background(), product.ID(1), []price.ID{2, 3})
+		require.EqualError(t, err, "failed")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPriceRepository_Changes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := mysql.NewPriceRepository(db)
+
+	since := time.Unix(1700000000, 0)
+	columns := append(append([]string{}, priceColumns...), "id", "provider", "sku", "service", "family", "location", "attributes", "previous", "valid_to")
+	rows := mock.NewRows(columns).AddRow(
+		1, "HASH", 1, "USD", decimal.RequireFromString("1.23"), "Hrs", `{"key":"value"}`,
+		1, "aws", "SKU", "AmazonEC2", "Compute Instance", "eu-west-1", `{"instanceType":"t2.micro"}`,
+		"1.0000000000", 1700000100,
+	)
+	// The new value of each change is the one of the next archived value or the current one
+	mock.ExpectQuery(`SELECT .+ COALESCE\(\( ?SELECT n.price FROM pricing_product_price_history AS n .+ ORDER BY n.valid_to LIMIT 1 ?\), pp.price\) .+ FROM pricing_product_price_history AS h .+ WHERE h.valid_to >= FROM_UNIXTIME\(\?\)`).
+		WithArgs(since.Unix()).
+		WillReturnRows(rows)
+
+	changes, err := repo.Changes(context.Background(), since)
+	require.NoError(t, err)
+
+	expected := []*price.Change{
+		{
+			Price: price.Price{
+				ID:         1,
+				Unit:       "Hrs",
+				Currency:   "USD",
+				Value:      decimal.RequireFromString("1.23"),
+				Attributes: map[string]string{"key": "value"},
+			},
+			Product: &product.Product{
+				ID:         1,
+				Provider:   "aws",
+				SKU:        "SKU",
+				Service:    "AmazonEC2",
+				Family:     "Compute Instance",
+				Location:   "eu-west-1",
+				Attributes: map[string]string{"instanceType": "t2.micro"},
+			},
+			// The DECIMAL(24,10) are returned with all their digits
+			PreviousValue: decimal.RequireFromString("1.0000000000"),
+			ChangedAt:     time.Unix(1700000100, 0),
+		},
+	}
+
+	require.Equal(t, expected, changes)
 }
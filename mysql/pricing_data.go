@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// HasPricingData returns true if a product of the provider on the location has a price,
+// see backend.PricingDataChecker.
+func (b *Backend) HasPricingData(ctx context.Context, provider, location string) (bool, error) {
+	var one int
+	err := b.querier.QueryRowContext(ctx, `
+		SELECT 1
+		FROM pricing_products AS p
+		JOIN pricing_product_prices AS pp ON pp.product_id = p.id
+		WHERE p.provider = ? AND p.location = ?
+		LIMIT 1
+	`, provider, location).Scan(&one)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/mysql"
+)
+
+func TestBackend_HasPricingData(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT 1 FROM pricing_products AS p JOIN pricing_product_prices AS pp .+ LIMIT 1`).
+			WithArgs("aws", "eu-west-3").
+			WillReturnRows(mock.NewRows([]string{"1"}).AddRow(1))
+
+		be := mysql.NewBackend(db)
+		ok, err := be.HasPricingData(context.Background(), "aws", "eu-west-3")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoPrices", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT 1 FROM pricing_products AS p JOIN pricing_product_prices AS pp .+ LIMIT 1`).
+			WithArgs("aws", "eu-west-3").
+			WillReturnRows(mock.NewRows([]string{"1"}))
+
+		be := mysql.NewBackend(db)
+		ok, err := be.HasPricingData(context.Background(), "aws", "eu-west-3")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -61,14 +61,23 @@ func newProduct(p *product.Product) (*dbProduct, error) {
 	}, nil
 }
 
-// Filter returns all the product.Product that match the given product.Filter.
+// Filter returns all the product.Product that match the given product.Filter ordered by ID, up to the
+// product.Filter.GetLimit if set. It returns product.ErrFilterTruncated if the limit was lowered and more products match.
 func (r *ProductRepository) Filter(ctx context.Context, filter *product.Filter) ([]*product.Product, error) {
 	where := parseProductFilter(filter)
 	q := fmt.Sprintf(`
 		SELECT id, provider, sku, service, family, location, attributes
 		FROM pricing_products
 		WHERE %s
+		ORDER BY id
 	`, where.String())
+	if limit := filter.GetLimit(); limit > 0 {
+		// One more product is requested to know if the lowered limit truncated the result
+		if filter.Limit > product.MaxFilterLimit {
+			limit++
+		}
+		q += fmt.Sprintf("LIMIT %d", limit)
+	}
 
 	ps := make([]*product.Product, 0)
 	rows, err := r.querier.QueryContext(ctx, q, where.Parameters()...)
@@ -87,6 +96,9 @@ func (r *ProductRepository) Filter(ctx context.Context, filter *product.Filter)
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	if filter.Truncated(len(ps)) {
+		return nil, product.ErrFilterTruncated
+	}
 	return ps, nil
 }
 
@@ -2,9 +2,11 @@ package mysql_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cycloidio/terracost/mysql"
@@ -144,6 +146,133 @@ func TestProductRepository_Filter(t *testing.T) {
 
 		require.Equal(t, expected, prods)
 	})
+
+	t.Run("AttributeFilterIn", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewProductRepository(db)
+
+		rows := mock.NewRows(productColumns).AddRow(1, "aws", "PRODUCT", "service", "family", "location", `{"key":"value2"}`)
+		mock.ExpectQuery(`SELECT .+ FROM .+ WHERE JSON_UNQUOTE\(JSON_EXTRACT\(attributes, '\$\.key'\)\) IN \(\?, \?\)`).
+			WithArgs("value1", "value2").
+			WillReturnRows(rows)
+
+		filter := &product.Filter{
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "key", ValueIn: []string{"value1", "value2"}},
+			},
+		}
+		prods, err := repo.Filter(context.Background(), filter)
+		require.NoError(t, err)
+		require.Len(t, prods, 1)
+		assert.Equal(t, "value2", prods[0].Attributes["key"])
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AttributeFilterEmptyIn", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewProductRepository(db)
+
+		rows := mock.NewRows(productColumns)
+		mock.ExpectQuery(`SELECT .+ FROM .+ WHERE provider = \? AND FALSE\s+ORDER BY id`).
+			WithArgs("aws").
+			WillReturnRows(rows)
+
+		filter := &product.Filter{
+			Provider: strPtr("aws"),
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "key", ValueIn: []string{}},
+			},
+		}
+		prods, err := repo.Filter(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Empty(t, prods)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AttributeFilterPrefix", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewProductRepository(db)
+
+		rows := mock.NewRows(productColumns).AddRow(1, "aws", "PRODUCT", "service", "family", "location", `{"instanceType":"m5_large"}`)
+		mock.ExpectQuery(`SELECT .+ FROM .+ WHERE JSON_UNQUOTE\(JSON_EXTRACT\(attributes, '\$\.instanceType'\)\) LIKE \?`).
+			WithArgs(`m5\_%`).
+			WillReturnRows(rows)
+
+		filter := &product.Filter{
+			AttributeFilters: []*product.AttributeFilter{
+				{Key: "instanceType", ValuePrefix: strPtr("m5_")},
+			},
+		}
+		prods, err := repo.Filter(context.Background(), filter)
+		require.NoError(t, err)
+		require.Len(t, prods, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("BroadFilter", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewProductRepository(db)
+
+		rows := mock.NewRows(productColumns).AddRow(1, "aws", "PRODUCT", "service", "family", "location", `{"key":"value"}`)
+		mock.ExpectQuery(`SELECT .+ FROM .+ WHERE provider = \? AND service = \?\s+ORDER BY id\s*$`).
+			WithArgs("aws", "service").
+			WillReturnRows(rows)
+
+		filter := &product.Filter{
+			Provider: strPtr("aws"),
+			Service:  strPtr("service"),
+		}
+		prods, err := repo.Filter(context.Background(), filter)
+		require.NoError(t, err)
+		require.Len(t, prods, 1)
+
+		rows = mock.NewRows(productColumns).AddRow(1, "aws", "PRODUCT", "service", "family", "location", `{"key":"value"}`)
+		mock.ExpectQuery(`SELECT .+ FROM .+ WHERE provider = \? AND service = \?\s+ORDER BY id\s+LIMIT 1$`).
+			WithArgs("aws", "service").
+			WillReturnRows(rows)
+
+		filter.Limit = 1
+		prods, err = repo.Filter(context.Background(), filter)
+		require.NoError(t, err)
+		require.Len(t, prods, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := mysql.NewProductRepository(db)
+
+		rows := mock.NewRows(productColumns)
+		for i := 1; i <= product.MaxFilterLimit+1; i++ {
+			rows.AddRow(i, "aws", fmt.Sprintf("PRODUCT%d", i), "service", "family", "location", `{"key":"value"}`)
+		}
+		mock.ExpectQuery(fmt.Sprintf(`SELECT .+ FROM .+ WHERE provider = \?\s+ORDER BY id\s+LIMIT %d$`, product.MaxFilterLimit+1)).
+			WithArgs("aws").
+			WillReturnRows(rows)
+
+		filter := &product.Filter{
+			Provider: strPtr("aws"),
+			Limit:    product.MaxFilterLimit + 10,
+		}
+		_, err = repo.Filter(context.Background(), filter)
+		assert.Equal(t, product.ErrFilterTruncated, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestProductRepository_Upsert(t *testing.T) {
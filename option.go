@@ -0,0 +1,272 @@
+package terracost
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/cost"
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+)
+
+// Option is used to configure the estimation
+type Option func(o *estimationOptions)
+
+// estimationOptions holds all the configuration of the estimation
+type estimationOptions struct {
+	providerInitializers []terraform.ProviderInitializer
+	ignoreAddresses      []*regexp.Regexp
+	changedOnly          bool
+	strictPricing        bool
+	timings              *cost.Timings
+	effectiveAt          time.Time
+	dataSources          bool
+	explain              bool
+	hoursPerMonth        decimal.Decimal
+	annotate             bool
+	adjustment           *cost.Adjustment
+	rateOverrides        map[string]map[string]cost.RateOverride
+}
+
+// newOptions returns the estimationOptions with the opts applied
+// and the defaults for the ones not set
+func newOptions(opts []Option) *estimationOptions {
+	o := &estimationOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if len(o.providerInitializers) == 0 {
+		o.providerInitializers = getDefaultProviders()
+	}
+
+	return o
+}
+
+// WithProviderInitializers sets the terraform.ProviderInitializer used to
+// estimate the resources, by default all the supported providers are used
+func WithProviderInitializers(providerInitializers ...terraform.ProviderInitializer) Option {
+	return func(o *estimationOptions) {
+		o.providerInitializers = append(o.providerInitializers, providerInitializers...)
+	}
+}
+
+// WithIgnoreAddresses skips the resources with any of the addresses so they are not
+// estimated nor present on the result. The addresses can be glob patterns in which
+// '*' matches any sequence of characters and '?' any single character (ex: module.test.*)
+func WithIgnoreAddresses(addresses []string) Option {
+	return func(o *estimationOptions) {
+		for _, a := range addresses {
+			o.ignoreAddresses = append(o.ignoreAddresses, globToRegexp(a))
+		}
+	}
+}
+
+// WithChangedOnly only estimates the resources of a Terraform plan that have
+// a change planned (create, update, delete or replace). The unchanged resources are
+// kept on both states without components so they have a zero difference, which is
+// faster on large plans but the totals are not the ones of the whole infrastructure.
+// It's only used by EstimateTerraformPlanWithOptions
+func WithChangedOnly(changedOnly bool) Option {
+	return func(o *estimationOptions) {
+		o.changedOnly = changedOnly
+	}
+}
+
+// WithStrictPricing makes the estimation fail with a *cost.MissingPricingError listing all
+// the resources with components without product or price (cost.ErrProductNotFound or
+// cost.ErrPriceNotFound) instead of returning a plan with the errors on the components.
+// By default the estimation is lenient.
+func WithStrictPricing(strict bool) Option {
+	return func(o *estimationOptions) {
+		o.strictPricing = strict
+	}
+}
+
+// WithTimings adds the time spent on each step of the estimation (parse, product lookup,
+// price lookup and assembly) to t, which can be read once the estimation is done.
+// The durations are added to the ones already on t. By default nothing is measured.
+func WithTimings(t *cost.Timings) Option {
+	return func(o *estimationOptions) {
+		o.timings = t
+	}
+}
+
+// WithEffectiveAt estimates with the prices that were active at the date at (see price.Filter.EffectiveAt)
+// instead of the current ones, which requires the Backend to keep the history of the prices.
+// By default (zero) the current prices are used.
+func WithEffectiveAt(at time.Time) Option {
+	return func(o *estimationOptions) {
+		o.effectiveAt = at
+	}
+}
+
+// WithDataSources also estimates the Terraform data sources supported by the providers (see
+// terraform.DataSourcesProvider), ex: an instance referenced but managed outside of the configuration,
+// so their cost is known for informational purposes. By default only the managed resources are estimated.
+// It's used by EstimateTerraformPlanWithOptions and EstimateTerraformState
+func WithDataSources(dataSources bool) Option {
+	return func(o *estimationOptions) {
+		o.dataSources = dataSources
+	}
+}
+
+// WithExplain keeps on the components of the estimation how their cost has been computed
+// (the filters, the product and price matched) so the cost of a resource can be explained
+// with cost.Plan.Explain. It's opt-in as it keeps all the pricing data matched.
+func WithExplain(explain bool) Option {
+	return func(o *estimationOptions) {
+		o.explain = explain
+	}
+}
+
+// WithHoursPerMonth sets the number of hours of a month used to convert the hourly prices to monthly
+// ones, ex: cost.HoursInMonth(2026, time.February) to reconcile the estimation with the invoice of that
+// month. By default it's the average cost.HoursPerMonth (730).
+func WithHoursPerMonth(hours decimal.Decimal) Option {
+	return func(o *estimationOptions) {
+		o.hoursPerMonth = hours
+	}
+}
+
+// WithAnnotations keeps on the components of the estimation the SKU and attributes of the product and the
+// rate code of the price matched (see cost.Annotation), so they can be reconciled with the billing data
+// (ex: AWS Cost Explorer or CUR). They are part of the cost.WriteJSON export. By default they are not kept.
+func WithAnnotations(annotate bool) Option {
+	return func(o *estimationOptions) {
+		o.annotate = annotate
+	}
+}
+
+// WithAdjustment applies the markup or discount adj to the rates of the estimation (ex: the resale markup of an
+// MSP or a negotiated discount), the list rates and costs are kept (see cost.Component.ListCost) so both can be shown.
+func WithAdjustment(adj cost.Adjustment) Option {
+	return func(o *estimationOptions) {
+		o.adjustment = &adj
+	}
+}
+
+// WithRateOverrides pins the rates of some components instead of the ones of the pricing data (ex: negotiated
+// private prices not in the ingested catalog), keyed by the resource address and the component name. The
+// overridden components are flagged on the result, see cost.Component.Overridden.
+func WithRateOverrides(overrides map[string]map[string]cost.RateOverride) Option {
+	return func(o *estimationOptions) {
+		o.rateOverrides = overrides
+	}
+}
+
+// stateOptions returns the cost.StateOptions used to build the cost.State
+func (o *estimationOptions) stateOptions() cost.StateOptions {
+	return cost.StateOptions{
+		Timings:       o.timings,
+		Trace:         o.explain,
+		HoursPerMonth: o.hoursPerMonth,
+		Annotate:      o.annotate,
+		Adjustment:    o.adjustment,
+		RateOverrides: o.rateOverrides,
+	}
+}
+
+// checkPricing returns the plan or, on strict pricing, the error of
+// the components without pricing if there is any
+func (o *estimationOptions) checkPricing(plan *cost.Plan) (*cost.Plan, error) {
+	if !o.strictPricing {
+		return plan, nil
+	}
+	if err := plan.MissingPricing(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// isIgnored checks if the address matches any of the ignored addresses
+func (o *estimationOptions) isIgnored(address string) bool {
+	for _, re := range o.ignoreAddresses {
+		if re.MatchString(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterQueries returns the queries without the ignored addresses and
+// with the effective date on the price filters if it's set
+func (o *estimationOptions) filterQueries(queries []query.Resource) []query.Resource {
+	if !o.effectiveAt.IsZero() {
+		queries = withEffectiveAt(queries, o.effectiveAt)
+	}
+	if len(o.ignoreAddresses) == 0 {
+		return queries
+	}
+
+	res := make([]query.Resource, 0, len(queries))
+	for _, q := range queries {
+		if o.isIgnored(q.Address) {
+			continue
+		}
+		res = append(res, q)
+	}
+	return res
+}
+
+// withEffectiveAt returns a copy of the queries with the price filter of the priced
+// components set to be effective at the date at, the queries are not modified
+func withEffectiveAt(queries []query.Resource, at time.Time) []query.Resource {
+	res := make([]query.Resource, 0, len(queries))
+	for _, q := range queries {
+		if len(q.Components) != 0 {
+			comps := make([]query.Component, 0, len(q.Components))
+			for _, c := range q.Components {
+				if c.ProductFilter != nil {
+					var pf price.Filter
+					if c.PriceFilter != nil {
+						pf = *c.PriceFilter
+					}
+					pf.EffectiveAt = at
+					c.PriceFilter = &pf
+				}
+				comps = append(comps, c)
+			}
+			q.Components = comps
+		}
+		res = append(res, q)
+	}
+	return res
+}
+
+// splitChanged splits the queries between the ones with an address present on changed and the others
+func splitChanged(queries []query.Resource, changed map[string]struct{}) ([]query.Resource, []query.Resource) {
+	res := make([]query.Resource, 0, len(queries))
+	unchanged := make([]query.Resource, 0)
+	for _, q := range queries {
+		if _, ok := changed[q.Address]; ok {
+			res = append(res, q)
+		} else {
+			unchanged = append(unchanged, q)
+		}
+	}
+	return res, unchanged
+}
+
+// globToRegexp converts the glob pattern g to a regexp matching the whole string,
+// all the characters other than '*' and '?' are literals
+func globToRegexp(g string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range g {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
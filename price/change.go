@@ -0,0 +1,24 @@
+package price
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/product"
+)
+
+// Change represents a Price whose value was changed by an ingestion.
+type Change struct {
+	// Price is the current Price
+	Price
+
+	// Product is the product.Product the Price belongs to
+	Product *product.Product
+
+	// PreviousValue is the value the Price had before the change
+	PreviousValue decimal.Decimal
+
+	// ChangedAt is when the change was ingested
+	ChangedAt time.Time
+}
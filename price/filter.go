@@ -1,10 +1,25 @@
 package price
 
+import (
+	"regexp"
+	"time"
+)
+
 // Filter is used to filter prices.
 type Filter struct {
 	Unit             *string
 	Currency         *string
 	AttributeFilters []*AttributeFilter
+
+	// Selector is used to choose the Price when more than one matches
+	// the Filter, by default the first one is used
+	Selector Selector
+
+	// EffectiveAt is the date at which the prices have to be active, the Repository
+	// returns the prices of its history that were valid at that date and the ones
+	// with an EffectiveDateAttribute after it are not used. By default (zero)
+	// the current prices are used
+	EffectiveAt time.Time
 }
 
 // AttributeFilter is used for filtering of prices by attribute.
@@ -13,3 +28,35 @@ type AttributeFilter struct {
 	Value      *string
 	ValueRegex *string
 }
+
+// Matches returns true if the Price p matches the Unit, Currency and AttributeFilters of the Filter, which
+// is how the Repository implementations are expected to filter the prices of a product. The ValueRegex is a
+// Go regular expression (RE2) and the attributes missing on p don't match. The Selector and EffectiveAt are
+// not used, the prices are selected by the caller. A nil Filter matches all the prices.
+func (f *Filter) Matches(p *Price) bool {
+	if f == nil {
+		return true
+	}
+	if f.Unit != nil && *f.Unit != p.Unit {
+		return false
+	}
+	if f.Currency != nil && *f.Currency != p.Currency {
+		return false
+	}
+
+	for _, af := range f.AttributeFilters {
+		v, ok := p.Attributes[af.Key]
+		switch {
+		case af.Value != nil:
+			if !ok || v != *af.Value {
+				return false
+			}
+		case af.ValueRegex != nil:
+			re, err := regexp.Compile(*af.ValueRegex)
+			if !ok || err != nil || !re.MatchString(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+package price_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	prc := &price.Price{
+		Unit:       "Hrs",
+		Currency:   "USD",
+		Value:      decimal.NewFromFloat(0.1),
+		Attributes: map[string]string{"TermType": "OnDemand"},
+	}
+
+	assert.True(t, (*price.Filter)(nil).Matches(prc))
+	assert.True(t, (&price.Filter{Unit: util.StringPtr("Hrs"), Currency: util.StringPtr("USD")}).Matches(prc))
+	assert.True(t, (&price.Filter{AttributeFilters: []*price.AttributeFilter{{Key: "TermType", ValueRegex: util.StringPtr("^On")}}}).Matches(prc))
+	assert.False(t, (&price.Filter{Unit: util.StringPtr("GB-Mo")}).Matches(prc))
+	assert.False(t, (&price.Filter{AttributeFilters: []*price.AttributeFilter{{Key: "TermType", Value: util.StringPtr("Reserved")}}}).Matches(prc))
+	assert.False(t, (&price.Filter{AttributeFilters: []*price.AttributeFilter{{Key: "LeaseContractLength", Value: util.StringPtr("1yr")}}}).Matches(prc))
+}
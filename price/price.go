@@ -24,6 +24,17 @@ type Price struct {
 	Attributes map[string]string
 }
 
+// Attributes used to define the tier of a Price, the EndingRange is
+// not defined or has the "Inf" value when the tier has no upper bound.
+const (
+	TierStartAttribute = "StartingRange"
+	TierEndAttribute   = "EndingRange"
+)
+
+// RateCodeAttribute is the attribute with the provider identifier of the Price
+// (ex: the AWS 'RateCode' of the billing data), not all the providers have it
+const RateCodeAttribute = "RateCode"
+
 var (
 	// ErrMismatchingUnit when the unit of the 2 prices do not match when using Add
 	ErrMismatchingUnit = errors.New("the unit is not the same")
@@ -33,12 +44,16 @@ var (
 )
 
 // GenerateHash generates the Hash field of the Price, equal to the MD5 sum of its unique values.
+// The EffectiveDateAttribute is not part of it as a new effective date is a change of the same Price.
 func (p *Price) GenerateHash() string {
 	values := make([]string, 0, len(p.Attributes)+2)
 	values = append(values, p.Unit, p.Currency)
 
 	keys := make([]string, 0, len(p.Attributes))
 	for k := range p.Attributes {
+		if k == EffectiveDateAttribute {
+			continue
+		}
 		keys = append(keys, k)
 	}
 
@@ -69,6 +84,28 @@ func (p *Price) Add(pr Price) error {
 	return nil
 }
 
+// TierRange returns the start and end units of the tier of the Price, if the Price
+// has no tier information ok is false. If the tier has no upper bound end is zero.
+func (p *Price) TierRange() (start, end decimal.Decimal, ok bool) {
+	s, ok := p.Attributes[TierStartAttribute]
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+	start, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	if e, ok := p.Attributes[TierEndAttribute]; ok && e != "Inf" {
+		end, err = decimal.NewFromString(e)
+		if err != nil {
+			return decimal.Zero, decimal.Zero, false
+		}
+	}
+
+	return start, end, true
+}
+
 // WithProduct is an aggregation of a Price with a product.Product.
 type WithProduct struct {
 	Price
@@ -69,3 +69,52 @@ func TestAdd(t *testing.T) {
 		assert.EqualError(t, err, price.ErrMismatchingCurrency.Error())
 	})
 }
+
+func TestGenerateHash(t *testing.T) {
+	p := price.Price{
+		Unit:       "1 Hour",
+		Currency:   "USD",
+		Value:      decimal.NewFromInt(10),
+		Attributes: map[string]string{"type": "Consumption", price.EffectiveDateAttribute: "2021-01-01T00:00:00Z"},
+	}
+	t.Run("EffectiveDate", func(t *testing.T) {
+		np := price.Price{
+			Unit:       p.Unit,
+			Currency:   p.Currency,
+			Value:      decimal.NewFromInt(12),
+			Attributes: map[string]string{"type": "Consumption", price.EffectiveDateAttribute: "2023-01-01T00:00:00Z"},
+		}
+		assert.Equal(t, p.GenerateHash(), np.GenerateHash())
+	})
+	t.Run("Attributes", func(t *testing.T) {
+		np := price.Price{
+			Unit:       p.Unit,
+			Currency:   p.Currency,
+			Value:      p.Value,
+			Attributes: map[string]string{"type": "Reservation", price.EffectiveDateAttribute: "2021-01-01T00:00:00Z"},
+		}
+		assert.NotEqual(t, p.GenerateHash(), np.GenerateHash())
+	})
+}
+
+func TestTierRange(t *testing.T) {
+	t.Run("Bounded", func(t *testing.T) {
+		p := price.Price{Attributes: map[string]string{"StartingRange": "0", "EndingRange": "51200"}}
+		start, end, ok := p.TierRange()
+		require.True(t, ok)
+		assert.True(t, start.IsZero())
+		assert.True(t, end.Equal(decimal.NewFromInt(51200)))
+	})
+	t.Run("Unbounded", func(t *testing.T) {
+		p := price.Price{Attributes: map[string]string{"StartingRange": "512000", "EndingRange": "Inf"}}
+		start, end, ok := p.TierRange()
+		require.True(t, ok)
+		assert.True(t, start.Equal(decimal.NewFromInt(512000)))
+		assert.True(t, end.IsZero())
+	})
+	t.Run("NoTier", func(t *testing.T) {
+		p := price.Price{Attributes: map[string]string{}}
+		_, _, ok := p.TierRange()
+		assert.False(t, ok)
+	})
+}
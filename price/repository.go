@@ -2,6 +2,7 @@ package price
 
 import (
 	"context"
+	"time"
 
 	"github.com/cycloidio/terracost/product"
 )
@@ -9,13 +10,26 @@ import (
 //go:generate mockgen -destination=../mock/price_repository.go -mock_names=Repository=PriceRepository -package mock github.com/cycloidio/terracost/price Repository
 
 // Repository describes interactions with a storage system to deal with Price entries.
+// It can be implemented out of this module (ex: by a pricing service) to be used by a backend.Backend,
+// the estimations only use Filter, the other methods are used by the ingestion.
 type Repository interface {
-	// Filter returns Prices with attributes matching the product.ID and Filter.
+	// Filter returns all the Prices of the product.ID matching the Filter, see Filter.Matches, and no
+	// Price matching is not an error (an empty slice is returned). The Price is selected from them by
+	// the caller (ex: with the Filter.Selector or the tiers). If the Filter.EffectiveAt is set the Prices
+	// valid at that date are expected, a Repository without history can return the current ones.
 	Filter(ctx context.Context, productID product.ID, filter *Filter) ([]*Price, error)
 
-	// Upsert updates a Price or creates a new one if it doesn't already exist.
+	// Upsert updates a Price or creates a new one if it doesn't already exist, the Prices of
+	// the Product (which has its ID set) are identified by their hash, see Price.GenerateHash.
 	Upsert(ctx context.Context, p *WithProduct) (ID, error)
 
 	// DeleteByProductWithKeep deletes all Prices of the specified product.ID except the ones with ID in the keep slice.
 	DeleteByProductWithKeep(ctx context.Context, productID product.ID, keep []ID) error
 }
+
+// ChangesRepository is implemented by the Repositories that keep the history of the
+// Prices, so the changes between ingestions can be compared, see backend.ComparePrices.
+type ChangesRepository interface {
+	// Changes returns the Prices that had their value changed since the given time.
+	Changes(ctx context.Context, since time.Time) ([]*Change, error)
+}
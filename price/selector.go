@@ -0,0 +1,82 @@
+package price
+
+import (
+	"time"
+)
+
+// EffectiveDateAttribute is the attribute of the Price with the date (RFC3339) from which it's effective,
+// it's not part of the Price.GenerateHash so the same Price effective from a new date replaces the previous one
+const EffectiveDateAttribute = "EffectiveDate"
+
+// Selector is the strategy used to choose a single Price when a Filter matches more than one
+type Selector string
+
+// List of all the Selector supported
+const (
+	// SelectFirst uses the first Price returned by the Repository, it's the default
+	SelectFirst Selector = ""
+
+	// SelectLowest uses the Price with the lowest Value
+	SelectLowest Selector = "lowest"
+
+	// SelectHighest uses the Price with the highest Value
+	SelectHighest Selector = "highest"
+
+	// SelectLatest uses the Price with the most recent EffectiveDateAttribute,
+	// the prices without it are considered the oldest ones. Only the Azure
+	// ingester sets the EffectiveDateAttribute, on the other prices it's SelectFirst
+	SelectLatest Selector = "latest"
+)
+
+// Select returns the Price chosen from the prices, when more than one is
+// equally valid the first of them is returned. It returns nil if no prices are given.
+func (s Selector) Select(prices []*Price) *Price {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	sel := prices[0]
+	for _, p := range prices[1:] {
+		switch s {
+		case SelectLowest:
+			if p.Value.LessThan(sel.Value) {
+				sel = p
+			}
+		case SelectHighest:
+			if p.Value.GreaterThan(sel.Value) {
+				sel = p
+			}
+		case SelectLatest:
+			if p.effectiveDate().After(sel.effectiveDate()) {
+				sel = p
+			}
+		}
+	}
+
+	return sel
+}
+
+// ActiveAt returns the prices that are effective at the time at, the ones with an
+// EffectiveDateAttribute after it are excluded and the ones without it are kept.
+// Only the Azure ingester sets the EffectiveDateAttribute, the AWS and Google prices
+// are all kept and only the history of the Repository can filter them by date.
+func ActiveAt(prices []*Price, at time.Time) []*Price {
+	res := make([]*Price, 0, len(prices))
+	for _, p := range prices {
+		if p.effectiveDate().After(at) {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+// effectiveDate returns the EffectiveDateAttribute of the Price or the zero time
+// if it's not defined or invalid
+func (p *Price) effectiveDate() time.Time {
+	t, err := time.Parse(time.RFC3339, p.Attributes[EffectiveDateAttribute])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
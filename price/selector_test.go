@@ -0,0 +1,56 @@
+package price_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cycloidio/terracost/price"
+)
+
+func TestSelector_Select(t *testing.T) {
+	prices := []*price.Price{
+		{Value: decimal.NewFromInt(2), Attributes: map[string]string{price.EffectiveDateAttribute: "2022-01-01T00:00:00Z"}},
+		{Value: decimal.NewFromInt(1), Attributes: map[string]string{}},
+		{Value: decimal.NewFromInt(3), Attributes: map[string]string{price.EffectiveDateAttribute: "2023-06-01T00:00:00Z"}},
+		{Value: decimal.NewFromInt(1), Attributes: map[string]string{price.EffectiveDateAttribute: "2021-01-01T00:00:00Z"}},
+	}
+
+	tcs := []struct {
+		Name     string
+		Selector price.Selector
+		Expected *price.Price
+	}{
+		{Name: "First", Selector: price.SelectFirst, Expected: prices[0]},
+		{Name: "Lowest", Selector: price.SelectLowest, Expected: prices[1]},
+		{Name: "Highest", Selector: price.SelectHighest, Expected: prices[2]},
+		{Name: "Latest", Selector: price.SelectLatest, Expected: prices[2]},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Same(t, tc.Expected, tc.Selector.Select(prices))
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, price.SelectLowest.Select(nil))
+	})
+}
+
+func TestActiveAt(t *testing.T) {
+	prices := []*price.Price{
+		{Value: decimal.NewFromInt(2), Attributes: map[string]string{price.EffectiveDateAttribute: "2022-01-01T00:00:00Z"}},
+		{Value: decimal.NewFromInt(1), Attributes: map[string]string{}},
+		{Value: decimal.NewFromInt(3), Attributes: map[string]string{price.EffectiveDateAttribute: "2023-06-01T00:00:00Z"}},
+	}
+
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	active := price.ActiveAt(prices, at)
+	assert.Equal(t, []*price.Price{prices[0], prices[1]}, active)
+	assert.Same(t, prices[0], price.SelectLatest.Select(active))
+
+	assert.Equal(t, prices, price.ActiveAt(prices, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
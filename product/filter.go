@@ -1,5 +1,18 @@
 package product
 
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// MaxFilterLimit is the maximum Limit of a Filter, a greater Limit is lowered to it
+const MaxFilterLimit = 1000
+
+// ErrFilterTruncated is returned by Repository.Filter when more than MaxFilterLimit products
+// match a Filter with a greater Limit, instead of silently returning only part of them
+var ErrFilterTruncated = errors.New("more products than the maximum filter limit match the filter")
+
 // Filter is used to filter products.
 type Filter struct {
 	Provider         *string
@@ -8,11 +21,96 @@ type Filter struct {
 	Family           *string
 	Location         *string
 	AttributeFilters []*AttributeFilter
+
+	// Limit is the maximum number of products to return, if it's 0 all the matching
+	// products are returned and if it's greater than MaxFilterLimit then MaxFilterLimit is used
+	Limit int
 }
 
-// AttributeFilter is used for filtering of products by attribute.
+// AttributeFilter is used for filtering of products by attribute. Only one of the
+// values is used, by order of preference: Value (exact match), ValueRegex, ValueIn
+// (any of the values, an empty non-nil ValueIn matches nothing) and ValuePrefix (ex: "m5." for all the m5 instance types).
 type AttributeFilter struct {
-	Key        string
-	Value      *string
-	ValueRegex *string
+	Key         string
+	Value       *string
+	ValueRegex  *string
+	ValueIn     []string
+	ValuePrefix *string
+}
+
+// GetLimit returns the Limit to apply to the Filter, 0 means no limit
+func (f *Filter) GetLimit() int {
+	if f == nil || f.Limit <= 0 {
+		return 0
+	}
+	if f.Limit > MaxFilterLimit {
+		return MaxFilterLimit
+	}
+	return f.Limit
+}
+
+// Truncated returns true if n products matching the Filter exceed the lowered Limit, in which
+// case the Repository.Filter returns ErrFilterTruncated
+func (f *Filter) Truncated(n int) bool {
+	return f != nil && f.Limit > MaxFilterLimit && n > MaxFilterLimit
+}
+
+// Matches returns true if the Product p matches all the fields and AttributeFilters of the Filter, which
+// is how the Repository implementations are expected to filter the products (the Limit is not applied).
+// The ValueRegex is a Go regular expression (RE2) and the attributes missing on p don't match.
+// A nil Filter matches all the products.
+func (f *Filter) Matches(p *Product) bool {
+	if f == nil {
+		return true
+	}
+
+	fields := []struct {
+		filter *string
+		value  string
+	}{
+		{f.Provider, p.Provider},
+		{f.SKU, p.SKU},
+		{f.Service, p.Service},
+		{f.Family, p.Family},
+		{f.Location, p.Location},
+	}
+	for _, fl := range fields {
+		if fl.filter != nil && *fl.filter != fl.value {
+			return false
+		}
+	}
+
+	for _, af := range f.AttributeFilters {
+		if !af.Matches(p.Attributes) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns true if the attribute Key of attrs matches the AttributeFilter, an AttributeFilter
+// without any value matches all the attributes.
+func (af *AttributeFilter) Matches(attrs map[string]string) bool {
+	v, ok := attrs[af.Key]
+	switch {
+	case af.Value != nil:
+		return ok && v == *af.Value
+	case af.ValueRegex != nil:
+		re, err := regexp.Compile(*af.ValueRegex)
+		return ok && err == nil && re.MatchString(v)
+	case af.ValueIn != nil:
+		if !ok {
+			return false
+		}
+		for _, in := range af.ValueIn {
+			if v == in {
+				return true
+			}
+		}
+		return false
+	case af.ValuePrefix != nil:
+		return ok && strings.HasPrefix(v, *af.ValuePrefix)
+	default:
+		return true
+	}
 }
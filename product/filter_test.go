@@ -0,0 +1,50 @@
+package product_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	prod := &product.Product{
+		Provider:   "aws",
+		Service:    "AmazonEC2",
+		Family:     "Compute Instance",
+		Location:   "eu-west-3",
+		Attributes: map[string]string{"InstanceType": "m5.large", "Tenancy": "Shared"},
+	}
+
+	t.Run("Matching", func(t *testing.T) {
+		filters := []*product.Filter{
+			nil,
+			{},
+			{Provider: util.StringPtr("aws"), Family: util.StringPtr("Compute Instance"), Location: util.StringPtr("eu-west-3")},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "InstanceType", Value: util.StringPtr("m5.large")}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "InstanceType", ValueRegex: util.StringPtr("^m5\\.")}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "Tenancy", ValueIn: []string{"Shared", "Dedicated"}}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "InstanceType", ValuePrefix: util.StringPtr("m5.")}}},
+		}
+		for i, f := range filters {
+			assert.True(t, f.Matches(prod), "case %d", i)
+		}
+	})
+
+	t.Run("NotMatching", func(t *testing.T) {
+		filters := []*product.Filter{
+			{Provider: util.StringPtr("azurerm")},
+			{Location: util.StringPtr("eu-west-1")},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "InstanceType", Value: util.StringPtr("m5.xlarge")}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "InstanceType", ValueRegex: util.StringPtr("^c5")}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "Tenancy", ValueIn: []string{"Host"}}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "Tenancy", ValueIn: []string{}}}},
+			{AttributeFilters: []*product.AttributeFilter{{Key: "OperatingSystem", ValuePrefix: util.StringPtr("")}}},
+		}
+		for i, f := range filters {
+			assert.False(t, f.Matches(prod), "case %d", i)
+		}
+	})
+}
@@ -7,13 +7,21 @@ import (
 //go:generate mockgen -destination=../mock/product_repository.go -mock_names=Repository=ProductRepository -package mock github.com/cycloidio/terracost/product Repository
 
 // Repository describes interactions with a storage system to deal with Product entries.
+// It can be implemented out of this module (ex: by a pricing service) to be used by a backend.Backend,
+// the estimations only use Filter, the other methods are used by the ingestion.
 type Repository interface {
-	// Filter returns Products with attributes matching the Filter.
+	// Filter returns Products with attributes matching the Filter, see Filter.Matches.
+	// It must not return more Products than the Filter.GetLimit (0 means no limit) and must return
+	// ErrFilterTruncated when the Filter.Truncated. The estimations price the first Product returned,
+	// so the order must be stable, and no Product matching is not an error (an empty slice is returned).
 	Filter(ctx context.Context, filter *Filter) ([]*Product, error)
 
 	// FindByVendorAndSKU finds a single Product by its vendor and SKU.
+	// An error is returned if there is none.
 	FindByVendorAndSKU(ctx context.Context, vendor string, sku string) (*Product, error)
 
-	// Upsert updates a Product or creates a new one if it doesn't already exist.
+	// Upsert updates a Product or creates a new one if it doesn't already exist, the Products
+	// are identified by their Provider and SKU. It returns the ID of the Product, which is then
+	// used to upsert and filter its prices.
 	Upsert(ctx context.Context, p *Product) (ID, error)
 }
@@ -2,9 +2,13 @@ package terracost
 
 import (
 	"github.com/cycloidio/terracost/aws"
+	awstf "github.com/cycloidio/terracost/aws/terraform"
 	"github.com/cycloidio/terracost/azurerm"
+	azurermtf "github.com/cycloidio/terracost/azurerm/terraform"
 	"github.com/cycloidio/terracost/google"
+	googletf "github.com/cycloidio/terracost/google/terraform"
 	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
 )
 
 // defaultProviders are the currently known and supported terraform providers
@@ -18,3 +22,19 @@ var defaultProviders = []terraform.ProviderInitializer{
 func getDefaultProviders() []terraform.ProviderInitializer {
 	return defaultProviders
 }
+
+// UsageJSONSchema returns the JSON Schema of the usage file with all
+// the usage fields supported by the default providers
+func UsageJSONSchema() ([]byte, error) {
+	resources := make(map[string]interface{})
+	for _, uv := range []map[string]interface{}{
+		awstf.UsageValues(),
+		googletf.UsageValues(),
+		azurermtf.UsageValues(),
+	} {
+		for rt, v := range uv {
+			resources[rt] = v
+		}
+	}
+	return usage.JSONSchema(resources)
+}
@@ -0,0 +1,6 @@
+package query
+
+import "errors"
+
+// ErrNoQueries is returned when there are no resources to estimate
+var ErrNoQueries = errors.New("no terraform entities found, looks empty")
@@ -19,14 +19,35 @@ type Resource struct {
 	// Type describes the type of the Resource.
 	Type string
 
+	// Tags are the tags (or labels) of the Resource, used to aggregate the costs.
+	Tags map[string]string
+
 	// Components is a list of price components that make up this Resource. If it is empty, the resource
 	// is considered to be skipped.
 	Components []Component
+
+	// Indeterminate is the reason why the estimation of the Resource may not be accurate
+	// (ex: values only known after apply), it's empty if the estimation is accurate.
+	Indeterminate string
+
+	// DefaultUsage is set when the usage of the Resource is the default one (see usage.Default)
+	// or it has none, so the cost of its usage Components is a guess.
+	DefaultUsage bool
+
+	// Warnings are the assumptions made to estimate the Resource (ex: a default value
+	// used for an unknown one) that the caller may want to check.
+	Warnings []string
+
+	// Count is the number of identical resources this Resource represents (ex: a fleet of
+	// instances), they are priced once and the quantities of the Components are multiplied
+	// by it. The Address is the one of a representative resource. Zero means 1.
+	Count int
 }
 
 // Component represents a price component of a cloud Resource. It is used to fetch the price for a single
 // component of a resource. For example, a compute instance might be have different pricing for the number
 // of CPU's, amount of RAM, etc. - each of these would be a Component.
+// A Component without ProductFilter is free (ex: a Gateway VPC endpoint), it's not priced and has no cost.
 type Component struct {
 	Name            string
 	HourlyQuantity  decimal.Decimal
@@ -36,4 +57,49 @@ type Component struct {
 	Usage           bool
 	ProductFilter   *product.Filter
 	PriceFilter     *price.Filter
+
+	// Tiered is set when the price is divided in tiers (see price.Price.TierRange)
+	// that are applied to the MonthlyQuantity. The PriceFilter should
+	// not filter by tier so all of them are returned.
+	Tiered bool
+
+	// SubsumedBy is the Name of another Component of the same Resource that already
+	// includes the cost of this one (ex: a root volume bundled with the instance price).
+	// When that Component is priced this one is not charged, if both are subsumed by
+	// each other (mutually exclusive) only the last one is charged.
+	SubsumedBy string
+
+	// Confidence is how trustworthy the cost of this Component is, it's set when the decoder
+	// knows it better than the Usage and the Resource.DefaultUsage (ex: a usage it defaulted itself).
+	Confidence Confidence
+}
+
+// Confidence is how trustworthy the cost of a Component is, from the most to the least trustworthy:
+// Exact, UsageEstimate and DefaultedUsage.
+type Confidence string
+
+const (
+	// Exact is a cost that doesn't depend on the usage (ex: an instance running all the month)
+	Exact Confidence = "exact"
+
+	// UsageEstimate is a cost computed from the usage configured for the resource (ex: the requests per month)
+	UsageEstimate Confidence = "usage_estimate"
+
+	// DefaultedUsage is a cost computed from the default usage (see usage.Default), the resource has no usage configured
+	DefaultedUsage Confidence = "defaulted_usage"
+)
+
+// confidenceRanks orders the Confidence from the most to the least trustworthy
+var confidenceRanks = map[Confidence]int{
+	Exact:          0,
+	UsageEstimate:  1,
+	DefaultedUsage: 2,
+}
+
+// Lower returns the least trustworthy of c and o.
+func (c Confidence) Lower(o Confidence) Confidence {
+	if confidenceRanks[o] > confidenceRanks[c] {
+		return o
+	}
+	return c
 }
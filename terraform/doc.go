@@ -2,4 +2,7 @@
 // here according to the JSON output format described at https://www.terraform.io/docs/internals/json-format.html
 //
 // The found resources are then transformed into query.Resource that can be utilized further.
+//
+// The reading of HCL (ExtractQueriesFromHCL) is not available on WebAssembly as it needs to download
+// the modules, the plan and state files can be read on any platform.
 package terraform
@@ -1,10 +1,14 @@
 package terraform
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/cycloidio/terracost/query"
+)
 
 // Errors that might be returned from procesing the HCL
 var (
-	ErrNoQueries       = errors.New("no terraform entities found, looks empty")
+	ErrNoQueries       = query.ErrNoQueries
 	ErrNoKnownProvider = errors.New("terraform providers are not yet supported")
 	ErrNoProviders     = errors.New("no valid providers found")
 )
@@ -1,3 +1,5 @@
+//go:build !js && !wasip1
+
 package terraform
 
 import (
@@ -91,23 +93,13 @@ func extractHCLModule(fs afero.Fs, providers map[string]Provider, parser *config
 		}
 		provider := providers[providerKey]
 
-		each := make(map[string]interface{})
-		if rv.ForEach != nil {
-			if fe, ok := rv.ForEach.(*hclsyntax.ForExpr); ok {
-				fev, err := fe.Value(evalCtx)
-				if err != nil {
-					return nil, fmt.Errorf("could not get value from ForEach: %w", err)
-				}
-				v, ok := convertCtyValue("", nil, fev)
-				if !ok {
-					// TODO: Return an error?
-				}
-				each = v.(map[string]interface{})
-			}
+		each, err := getForEach(rv.ForEach, evalCtx)
+		if err != nil {
+			return nil, err
 		}
 		// When we only have to do it once
 		if len(each) == 0 {
-			each[""] = nil
+			each[""] = cty.NilVal
 		}
 		// Parse the HCL body of the resource block and evaluate it. The JSON (in the form of map[string]interface{} type)
 		// is then placed into the cfg.
@@ -116,32 +108,13 @@ func extractHCLModule(fs afero.Fs, providers map[string]Provider, parser *config
 			return nil, fmt.Errorf("invalid resource configuration body")
 		}
 		for k, v := range each {
-			if v != nil {
-				delete(evalCtx.Variables, "each")
-				vals := make(map[string]cty.Value)
-				for kv, vv := range v.(map[string]interface{}) {
-					it, err := gocty.ImpliedType(vv)
-					if err != nil {
-						continue
-					}
-					ctyv, err := gocty.ToCtyValue(vv, it)
-					if err != nil {
-						continue
-					}
-					vals[kv] = ctyv
-				}
-
-				vt, err := gocty.ImpliedType(vals)
-				if err != nil {
-					continue
-				}
-				ctyv, err := gocty.ToCtyValue(vals, vt)
-				if err != nil {
-					continue
-				}
-
+			delete(evalCtx.Variables, "each")
+			if k != "" {
 				// TODO: potentially overwrite the rk with the [k]
-				evalCtx.Variables["each"] = cty.ObjectVal(map[string]cty.Value{"value": ctyv})
+				evalCtx.Variables["each"] = cty.ObjectVal(map[string]cty.Value{
+					"key":   cty.StringVal(k),
+					"value": v,
+				})
 			}
 			cfg := getBodyJSON(modName, body, evalCtx)
 			// We delete the `for_each` key as we do not need it
@@ -253,13 +226,18 @@ func extractHCLModule(fs afero.Fs, providers map[string]Provider, parser *config
 				rss[kr].Values[k] = vals
 			}
 		}
-		r.Values[usage.Key] = u.GetUsage(r.Type)
+		usageWarnings := r.setUsage(u)
 		provider := providers[r.ProviderName]
+		comps, warnings := resourceComponents(provider, rss, r)
 		queries = append(queries, query.Resource{
 			Address:    r.Address,
 			Type:       r.Type,
 			Provider:   r.ProviderName,
-			Components: provider.ResourceComponents(rss, r),
+			Tags:       r.Tags(),
+			Components: comps,
+			Warnings:   append(usageWarnings, warnings...),
+
+			DefaultUsage: u.IsDefault(r.Type, r.Tags()),
 		})
 	}
 
@@ -488,20 +466,43 @@ func getEvalCtx(mod *configs.Module, vars map[string]cty.Value, inputs map[strin
 		Functions: scope.Functions(),
 	}
 
-	// Set values of locals.
+	// Set values of locals. As they can reference other locals they are
+	// evaluated in passes, on each one the locals whose references are already
+	// resolved are set, until all of them are or no more can be resolved.
+	// The diagnostics of the last pass are kept to log the locals that could not be resolved.
 	lm := make(map[string]cty.Value)
+	pending := make(map[string]*configs.Local, len(mod.Locals))
 	for lk, lv := range mod.Locals {
-		val, diags := lv.Expr.Value(evalCtx)
-		if diags != nil && diags.HasErrors() {
-			log.Logger.Error("hcl: Error on abstracting value for 'local'", "key", lk, "reason", diags.Error())
-			continue
+		pending[lk] = lv
+	}
+	pendingDiags := make(map[string]hcl.Diagnostics)
+	for len(pending) > 0 {
+		evalCtx.Variables["local"] = cty.ObjectVal(lm)
+
+		resolved := false
+		for lk, lv := range pending {
+			val, diags := lv.Expr.Value(evalCtx)
+			if diags.HasErrors() {
+				pendingDiags[lk] = diags
+				continue
+			}
+			lm[lk] = val
+			delete(pending, lk)
+			delete(pendingDiags, lk)
+			resolved = true
+
+			cv, ok := convertCtyValue("", nil, val)
+			if ok {
+				llocal[lk] = cv
+			}
 		}
-		lm[lk] = val
-		lv, ok := convertCtyValue("", nil, val)
-		if ok {
-			llocal[lk] = lv
+		if !resolved {
+			break
 		}
 	}
+	for lk, diags := range pendingDiags {
+		log.Logger.Error("hcl: Error on abstracting value for 'local'", "key", lk, "reason", diags.Error())
+	}
 	evalCtx.Variables["local"] = cty.ObjectVal(lm)
 
 	log.Logger.Debug("hcl: New variables/locals found", "var", lvars, "local", llocal)
@@ -683,6 +684,46 @@ func getBodyJSON(modulePrefix string, b *hclsyntax.Body, evalCtx *hcl.EvalContex
 	return cfg
 }
 
+// getForEach evaluates the for_each expression fe and returns the instances keyed by
+// their each.key, it returns an empty map if there is no for_each or if its value can not
+// be known without applying (ex: it depends on another resource attributes)
+func getForEach(fe hcl.Expression, evalCtx *hcl.EvalContext) (map[string]cty.Value, error) {
+	each := make(map[string]cty.Value)
+	if fe == nil {
+		return each, nil
+	}
+
+	fev, diags := fe.Value(evalCtx)
+	if diags.HasErrors() {
+		if _, ok := fe.(*hclsyntax.ForExpr); ok {
+			return nil, fmt.Errorf("could not get value from ForEach: %w", diags)
+		}
+		log.Logger.Debug("hcl: Could not evaluate for_each", "error", diags.Error())
+		return each, nil
+	}
+
+	if fev.IsNull() || !fev.IsWhollyKnown() || !fev.CanIterateElements() {
+		return each, nil
+	}
+
+	ty := fev.Type()
+	if !ty.IsMapType() && !ty.IsObjectType() && !ty.IsSetType() {
+		return each, nil
+	}
+
+	// On sets the key and the value are the same
+	iter := fev.ElementIterator()
+	for iter.Next() {
+		k, v := iter.Element()
+		if k.Type() != cty.String {
+			continue
+		}
+		each[k.AsString()] = v
+	}
+
+	return each, nil
+}
+
 // convertCtyValue converts the value v to a normal type, the second
 // return indicates if there is something to convert or no
 func convertCtyValue(modulePrefix string, attrvars []hcl.Traversal, val cty.Value) (interface{}, bool) {
@@ -803,12 +844,7 @@ func convertCtyValue(modulePrefix string, attrvars []hcl.Traversal, val cty.Valu
 // getHCLProviders extracts provider configurations from the module and initializes the providers using the
 // providerInitializers slice. The resulting map of aliases to instantiated providers is then returned.
 func getHCLProviders(mod *configs.Module, evalCtx *hcl.EvalContext, providerInitializers []ProviderInitializer) (map[string]Provider, error) {
-	piMap := make(map[string]ProviderInitializer)
-	for _, pi := range providerInitializers {
-		for _, name := range pi.MatchNames {
-			piMap[name] = pi
-		}
-	}
+	piMap := providerInitializersByName(providerInitializers)
 
 	providers := make(map[string]Provider)
 	for pk, pv := range mod.ProviderConfigs {
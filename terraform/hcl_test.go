@@ -1,3 +1,5 @@
+//go:build !js && !wasip1
+
 package terraform_test
 
 import (
@@ -177,5 +179,90 @@ func TestExtractQueriesFromHCL(t *testing.T) {
 				assert.Len(t, res.Components, 0)
 			}
 		})
+
+		t.Run("MetaArguments", func(t *testing.T) {
+			fs := afero.NewOsFs()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			provider := mock.NewTerraformProvider(ctrl)
+			providerInitializers := []terraform.ProviderInitializer{{
+				MatchNames: []string{"aws", "aws-test"},
+				Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+					return provider, nil
+				},
+			}}
+
+			values := make(map[string]map[string]interface{})
+			provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+				values[res.Address] = res.Values
+				return nil
+			})
+
+			queries, _, err := terraform.ExtractQueriesFromHCL(fs, providerInitializers, "../testdata/aws/stack-meta-arguments", usage.Default, noInputs)
+			require.NoError(t, err)
+
+			addrs := make([]string, 0, len(queries))
+			for _, q := range queries {
+				addrs = append(addrs, q.Address)
+			}
+
+			t.Run("Count", func(t *testing.T) {
+				assert.Subset(t, addrs, []string{
+					"aws_instance.count[0]",
+					"aws_instance.count[1]",
+					"aws_instance.count[2]",
+				})
+			})
+
+			t.Run("ForEachMap", func(t *testing.T) {
+				assert.Subset(t, addrs, []string{
+					"aws_instance.for_each[front]",
+					"aws_instance.for_each[back]",
+				})
+				assert.Equal(t, "t3.small", values["aws_instance.for_each[front]"]["instance_type"])
+				assert.Equal(t, "some-ami-front", values["aws_instance.for_each[front]"]["ami"])
+				assert.Equal(t, "t3.medium", values["aws_instance.for_each[back]"]["instance_type"])
+				assert.Equal(t, "some-ami-back", values["aws_instance.for_each[back]"]["ami"])
+			})
+
+			assert.Len(t, queries, 5)
+		})
+
+		t.Run("Locals", func(t *testing.T) {
+			fs := afero.NewOsFs()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			provider := mock.NewTerraformProvider(ctrl)
+			providerInitializers := []terraform.ProviderInitializer{{
+				MatchNames: []string{"aws", "aws-test"},
+				Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+					return provider, nil
+				},
+			}}
+
+			values := make(map[string]map[string]interface{})
+			provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+				values[res.Address] = res.Values
+				return nil
+			})
+
+			t.Run("Default", func(t *testing.T) {
+				_, _, err := terraform.ExtractQueriesFromHCL(fs, providerInitializers, "../testdata/aws/stack-locals", usage.Default, noInputs)
+				require.NoError(t, err)
+
+				assert.Equal(t, "m5.large", values["aws_instance.web"]["instance_type"])
+				assert.Equal(t, "t3.large", values["aws_instance.interpolated"]["instance_type"])
+			})
+
+			t.Run("Inputs", func(t *testing.T) {
+				_, _, err := terraform.ExtractQueriesFromHCL(fs, providerInitializers, "../testdata/aws/stack-locals", usage.Default, map[string]interface{}{"size": "xlarge"})
+				require.NoError(t, err)
+
+				assert.Equal(t, "m5.xlarge", values["aws_instance.web"]["instance_type"])
+				assert.Equal(t, "t3.xlarge", values["aws_instance.interpolated"]["instance_type"])
+			})
+		})
 	})
 }
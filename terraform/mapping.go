@@ -0,0 +1,158 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+// ResourceMapping describes declaratively how to estimate the simple resources of a Type, each
+// ComponentMapping is turned into a query.Component from the values of the resource. It complements
+// the decoders written for the resources that need more logic, so a Provider can estimate
+// a resource with both.
+type ResourceMapping struct {
+	Type       string             `json:"type"`
+	Components []ComponentMapping `json:"components"`
+}
+
+// ComponentMapping describes a query.Component, the location of the ProductFilter is
+// the one of the resource given by the Provider.
+type ComponentMapping struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+	Family  string `json:"family"`
+	Unit    string `json:"unit,omitempty"`
+
+	// The quantity is the HourlyQuantity or the MonthlyQuantity, if the Usage is set
+	// it's the value of this usage key multiplied by the MonthlyQuantity (1 by default).
+	HourlyQuantity  float64 `json:"hourly_quantity,omitempty"`
+	MonthlyQuantity float64 `json:"monthly_quantity,omitempty"`
+	Usage           string  `json:"usage,omitempty"`
+
+	ProductAttributes []AttributeMapping `json:"product_attributes,omitempty"`
+	PriceUnit         string             `json:"price_unit,omitempty"`
+	PriceAttributes   []AttributeMapping `json:"price_attributes,omitempty"`
+}
+
+// AttributeMapping is an attribute filter with the Key equal to the Value or, if the
+// Attribute is set, to the value of this attribute of the resource. The component is
+// not estimated if the resource has no such attribute and there is no Value as default.
+type AttributeMapping struct {
+	Key       string `json:"key"`
+	Value     string `json:"value,omitempty"`
+	Attribute string `json:"attribute,omitempty"`
+}
+
+// ReadResourceMappings reads a JSON list of ResourceMapping from r.
+func ReadResourceMappings(r io.Reader) ([]ResourceMapping, error) {
+	var rms []ResourceMapping
+	if err := json.NewDecoder(r).Decode(&rms); err != nil {
+		return nil, fmt.Errorf("failed to read the resource mappings: %w", err)
+	}
+	return rms, nil
+}
+
+// ResourceComponents returns the components of the resource values with the providerKey and the location
+// of the resource, the components missing one of their attributes are skipped.
+func (rm ResourceMapping) ResourceComponents(providerKey, location string, values map[string]interface{}) []query.Component {
+	components := make([]query.Component, 0, len(rm.Components))
+	for _, cm := range rm.Components {
+		if c, ok := cm.Component(providerKey, location, values); ok {
+			components = append(components, c)
+		}
+	}
+	return components
+}
+
+// Component returns the query.Component of the resource values, false is returned if
+// one of the attributes of the mapping is missing on the values.
+func (cm ComponentMapping) Component(providerKey, location string, values map[string]interface{}) (query.Component, bool) {
+	c := query.Component{
+		Name: cm.Name,
+		Unit: cm.Unit,
+		ProductFilter: &product.Filter{
+			Provider: util.StringPtr(providerKey),
+			Service:  util.StringPtr(cm.Service),
+			Family:   util.StringPtr(cm.Family),
+			Location: util.StringPtr(location),
+		},
+	}
+
+	if cm.Usage != "" {
+		quantity := decimal.NewFromInt(1)
+		if cm.MonthlyQuantity != 0 {
+			quantity = decimal.NewFromFloat(cm.MonthlyQuantity)
+		}
+		us, _ := values[usage.Key].(map[string]interface{})
+		c.MonthlyQuantity = quantity.Mul(mappingDecimal(us[cm.Usage]))
+		c.Usage = true
+	} else if cm.MonthlyQuantity != 0 {
+		c.MonthlyQuantity = decimal.NewFromFloat(cm.MonthlyQuantity)
+	} else {
+		c.HourlyQuantity = decimal.NewFromFloat(cm.HourlyQuantity)
+	}
+
+	for _, am := range cm.ProductAttributes {
+		v, ok := am.value(values)
+		if !ok {
+			return query.Component{}, false
+		}
+		c.ProductFilter.AttributeFilters = append(c.ProductFilter.AttributeFilters, &product.AttributeFilter{Key: am.Key, Value: util.StringPtr(v)})
+	}
+
+	if cm.PriceUnit != "" || len(cm.PriceAttributes) > 0 {
+		c.PriceFilter = &price.Filter{}
+		if cm.PriceUnit != "" {
+			c.PriceFilter.Unit = util.StringPtr(cm.PriceUnit)
+		}
+		for _, am := range cm.PriceAttributes {
+			v, ok := am.value(values)
+			if !ok {
+				return query.Component{}, false
+			}
+			c.PriceFilter.AttributeFilters = append(c.PriceFilter.AttributeFilters, &price.AttributeFilter{Key: am.Key, Value: util.StringPtr(v)})
+		}
+	}
+
+	return c, true
+}
+
+// value returns the value of the attribute filter from the resource values
+func (am AttributeMapping) value(values map[string]interface{}) (string, bool) {
+	if am.Attribute == "" {
+		return am.Value, true
+	}
+	v, ok := values[am.Attribute]
+	if !ok || v == nil || v == "" {
+		return am.Value, am.Value != ""
+	}
+	return fmt.Sprint(v), true
+}
+
+// mappingDecimal returns the decimal of a numeric value or zero
+func mappingDecimal(v interface{}) decimal.Decimal {
+	switch n := v.(type) {
+	case float64:
+		return decimal.NewFromFloat(n)
+	case int:
+		return decimal.NewFromInt(int64(n))
+	case int64:
+		return decimal.NewFromInt(n)
+	case string:
+		d, err := decimal.NewFromString(n)
+		if err != nil {
+			return decimal.Zero
+		}
+		return d
+	default:
+		return decimal.Zero
+	}
+}
@@ -0,0 +1,113 @@
+package terraform_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/price"
+	"github.com/cycloidio/terracost/product"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/testutil"
+	"github.com/cycloidio/terracost/usage"
+	"github.com/cycloidio/terracost/util"
+)
+
+func TestResourceMapping_ResourceComponents(t *testing.T) {
+	rms, err := terraform.ReadResourceMappings(strings.NewReader(`[
+		{
+			"type": "azurerm_virtual_network_gateway",
+			"components": [
+				{
+					"name": "VPN gateway P2S tunnels (over 128)",
+					"service": "VPN Gateway",
+					"family": "Networking",
+					"hourly_quantity": 1,
+					"product_attributes": [
+						{"key": "skuName", "attribute": "sku"},
+						{"key": "meterName", "value": "P2S Connection"}
+					],
+					"price_unit": "1 Hour",
+					"price_attributes": [{"key": "type", "value": "Consumption"}]
+				},
+				{
+					"name": "Data transfer",
+					"service": "VPN Gateway",
+					"family": "Networking",
+					"unit": "GB",
+					"usage": "monthly_data_transfer_gb",
+					"product_attributes": [{"key": "meterName", "attribute": "transfer_meter", "value": "Data Transfer Out"}]
+				}
+			]
+		}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, rms, 1)
+	assert.Equal(t, "azurerm_virtual_network_gateway", rms[0].Type)
+
+	t.Run("Success", func(t *testing.T) {
+		values := map[string]interface{}{
+			"sku": "VpnGw1",
+			usage.Key: map[string]interface{}{
+				"monthly_data_transfer_gb": 100,
+			},
+		}
+
+		expected := []query.Component{
+			{
+				Name:           "VPN gateway P2S tunnels (over 128)",
+				HourlyQuantity: decimal.NewFromInt(1),
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("azurerm"),
+					Service:  util.StringPtr("VPN Gateway"),
+					Family:   util.StringPtr("Networking"),
+					Location: util.StringPtr("westeurope"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "skuName", Value: util.StringPtr("VpnGw1")},
+						{Key: "meterName", Value: util.StringPtr("P2S Connection")},
+					},
+				},
+				PriceFilter: &price.Filter{
+					Unit: util.StringPtr("1 Hour"),
+					AttributeFilters: []*price.AttributeFilter{
+						{Key: "type", Value: util.StringPtr("Consumption")},
+					},
+				},
+			},
+			{
+				Name:            "Data transfer",
+				MonthlyQuantity: decimal.NewFromInt(100),
+				Unit:            "GB",
+				Usage:           true,
+				ProductFilter: &product.Filter{
+					Provider: util.StringPtr("azurerm"),
+					Service:  util.StringPtr("VPN Gateway"),
+					Family:   util.StringPtr("Networking"),
+					Location: util.StringPtr("westeurope"),
+					AttributeFilters: []*product.AttributeFilter{
+						{Key: "meterName", Value: util.StringPtr("Data Transfer Out")},
+					},
+				},
+			},
+		}
+
+		actual := rms[0].ResourceComponents("azurerm", "westeurope", values)
+		testutil.EqualQueryComponents(t, expected, actual)
+	})
+
+	t.Run("MissingAttribute", func(t *testing.T) {
+		actual := rms[0].ResourceComponents("azurerm", "westeurope", map[string]interface{}{})
+		require.Len(t, actual, 1)
+		assert.Equal(t, "Data transfer", actual[0].Name)
+		assert.True(t, actual[0].MonthlyQuantity.IsZero())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := terraform.ReadResourceMappings(strings.NewReader(`{"type": "invalid"}`))
+		assert.Error(t, err)
+	})
+}
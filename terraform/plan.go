@@ -14,25 +14,25 @@ import (
 type Plan struct {
 	providerInitializers map[string]ProviderInitializer
 	usage                usage.Usage
+	dataSources          bool
 
-	Configuration Configuration       `json:"configuration"`
-	PriorState    *State              `json:"prior_state"`
-	PlannedValues Values              `json:"planned_values"`
-	Variables     map[string]Variable `json:"variables"`
+	Configuration   Configuration       `json:"configuration"`
+	PriorState      *State              `json:"prior_state"`
+	PlannedValues   Values              `json:"planned_values"`
+	ResourceChanges []ResourceChange    `json:"resource_changes"`
+	Variables       map[string]Variable `json:"variables"`
 }
 
 // SetUsage will set the usage of the plan
 func (p *Plan) SetUsage(u usage.Usage) { p.usage = u }
 
+// SetDataSources sets if the data sources supported by the Providers (see DataSourcesProvider)
+// are estimated along the managed resources, by default they are ignored
+func (p *Plan) SetDataSources(ds bool) { p.dataSources = ds }
+
 // NewPlan returns an empty Plan.
 func NewPlan(providerInitializers ...ProviderInitializer) *Plan {
-	piMap := make(map[string]ProviderInitializer)
-	for _, pi := range providerInitializers {
-		for _, name := range pi.MatchNames {
-			piMap[name] = pi
-		}
-	}
-	plan := &Plan{providerInitializers: piMap}
+	plan := &Plan{providerInitializers: providerInitializersByName(providerInitializers)}
 	return plan
 }
 
@@ -44,13 +44,32 @@ func (p *Plan) Read(r io.Reader) error {
 	return nil
 }
 
+// ChangedAddresses returns the addresses of the managed resources that have
+// any action planned on the `resource_changes` part of the Plan.
+func (p *Plan) ChangedAddresses() map[string]struct{} {
+	addrs := make(map[string]struct{})
+	for _, rc := range p.ResourceChanges {
+		if rc.Mode != "managed" || rc.Change.IsNoOp() {
+			continue
+		}
+		addrs[rc.Address] = struct{}{}
+	}
+	return addrs
+}
+
 // ExtractPlannedQueries extracts a query.Resource slice from the `planned_values` part of the Plan.
 func (p *Plan) ExtractPlannedQueries() ([]query.Resource, error) {
 	providers, err := p.extractProviders()
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract planned queries: %w", err)
 	}
-	q, err := p.extractQueries(p.PlannedValues, providers)
+	values := p.PlannedValues
+	if p.dataSources && p.PriorState != nil {
+		// The data sources read while planning are only on the prior state,
+		// they are the same on the planned values so they have no difference
+		values = Values{RootModule: withDataSources(p.PlannedValues.RootModule, p.PriorState.Values.RootModule)}
+	}
+	q, err := p.extractQueries(values, providers, p.unknownAttributes())
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract queries: %w", err)
 	}
@@ -67,7 +86,7 @@ func (p *Plan) ExtractPriorQueries() ([]query.Resource, error) {
 		return nil, fmt.Errorf("unable to extract prior queries: %w", err)
 	}
 
-	q, err := p.extractQueries(p.PriorState.Values, providers)
+	q, err := p.extractQueries(p.PriorState.Values, providers, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract queries: %w", err)
 	}
@@ -79,7 +98,11 @@ func (p *Plan) ExtractPriorQueries() ([]query.Resource, error) {
 func (p *Plan) extractProviders() (map[string]Provider, error) {
 	providers := make(map[string]Provider)
 	for name, provConfig := range p.Configuration.ProviderConfig {
-		if pi, ok := p.providerInitializers[provConfig.Name]; ok {
+		pi, ok := p.providerInitializers[provConfig.Name]
+		if !ok && provConfig.FullName != "" {
+			pi, ok = p.providerInitializers[provConfig.FullName]
+		}
+		if ok {
 			values, err := p.evaluateProviderConfigExpressions(provConfig)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read config of provider %q: %w", name, err)
@@ -101,7 +124,8 @@ func (p *Plan) extractProviders() (map[string]Provider, error) {
 
 // extractQueries iterates over every resource and passes each to the corresponding Provider to get the components.
 // These are used to form a slice of resource queries that are then returned back to the caller.
-func (p *Plan) extractQueries(values Values, providers map[string]Provider) ([]query.Resource, error) {
+// The unknowns are the attributes of each resource address that will only be known after apply.
+func (p *Plan) extractQueries(values Values, providers map[string]Provider, unknowns map[string][]string) ([]query.Resource, error) {
 	// Create a map to associate each resource with a Provider that
 	// should be used to estimate it.
 	resourceProviders := make(map[string]providerWithResourceValues)
@@ -109,7 +133,64 @@ func (p *Plan) extractQueries(values Values, providers map[string]Provider) ([]q
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract module (%s) configuration: %w", "root_module", err)
 	}
-	return p.extractModuleQueries(&values.RootModule, resourceProviders), nil
+	err = p.extractRemovedResources(&values.RootModule, providers, resourceProviders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract the removed resources: %w", err)
+	}
+	return p.extractModuleQueries(&values.RootModule, resourceProviders, unknowns), nil
+}
+
+// extractRemovedResources assigns a Provider to the resources of the module (and of its descendants) that are not
+// on the configuration, which is how the resources removed from it are reported on the prior state. The Provider
+// is the one of their provider_name (ex: registry.terraform.io/hashicorp/aws), see providerByName.
+func (p *Plan) extractRemovedResources(module *Module, providers map[string]Provider, resourceProviders map[string]providerWithResourceValues) error {
+	for _, res := range module.Resources {
+		if _, ok := resourceProviders[res.Address]; ok || res.ProviderName == "" {
+			continue
+		}
+		prov, err := p.providerByName(res.ProviderName, providers)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the provider of %q: %w", res.Address, err)
+		}
+		if prov != nil {
+			resourceProviders[res.Address] = providerWithResourceValues{Provider: prov}
+		}
+	}
+
+	for _, child := range module.ChildModules {
+		if err := p.extractRemovedResources(child, providers, resourceProviders); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// providerByName returns the Provider of the provider name (or full name) of a resource. It's the one of
+// the configuration with that name, preferring the one without alias, so it has the same configuration
+// (ex: the region). If the configuration has none, a Provider is initialized without configuration.
+func (p *Plan) providerByName(name string, providers map[string]Provider) (Provider, error) {
+	var (
+		prov       Provider
+		configured bool
+	)
+	for key, provConfig := range p.Configuration.ProviderConfig {
+		if provConfig.Name != name && provConfig.FullName != name {
+			continue
+		}
+		if !configured || provConfig.Alias == "" {
+			prov = providers[key]
+		}
+		configured = true
+	}
+	if configured {
+		return prov, nil
+	}
+
+	pi, ok := p.providerInitializers[name]
+	if !ok {
+		return nil, nil
+	}
+	return pi.Provider(map[string]interface{}{})
 }
 
 type providerWithResourceValues struct {
@@ -168,13 +249,15 @@ func (p *Plan) extractModuleConfiguration(prefix string, module *ConfigurationMo
 
 // extractModuleQueries iterates recursively over all the module's (and its descendants) resources. It uses the
 // resourceProviders map to retrieve the correct Provider based on the resource address.
-func (p *Plan) extractModuleQueries(module *Module, resourceProviders map[string]providerWithResourceValues) []query.Resource {
+func (p *Plan) extractModuleQueries(module *Module, resourceProviders map[string]providerWithResourceValues, unknowns map[string][]string) []query.Resource {
 	result := make([]query.Resource, 0, len(resourceProviders))
 
 	rss := make(map[string]Resource)
+	indeterminate := make(map[string]string)
+	usageWarnings := make(map[string][]string)
 	for _, tfres := range module.Resources {
 		pwrv, ok := resourceProviders[tfres.Address]
-		if !ok || tfres.Mode != "managed" {
+		if !ok || !isEstimated(tfres, pwrv.Provider, p.dataSources) {
 			continue
 		}
 		for k, v := range pwrv.Values {
@@ -229,29 +312,126 @@ func (p *Plan) extractModuleQueries(module *Module, resourceProviders map[string
 			}
 		}
 		rss[tfres.Address] = tfres
-		tfres.Values[usage.Key] = p.usage.GetUsage(tfres.Type)
+		usageWarnings[tfres.Address] = tfres.setUsage(p.usage)
+		indeterminate[tfres.Address] = resolveUnknownValues(tfres, pwrv.Provider, unknowns[tfres.Address])
 	}
 
 	for _, rs := range rss {
 		// We know it's present as it has passed the previous loop
 		pwrv := resourceProviders[rs.Address]
-		comps := pwrv.Provider.ResourceComponents(rss, rs)
+		comps, warnings := resourceComponents(pwrv.Provider, rss, rs)
 		q := query.Resource{
 			Address:    rs.Address,
 			Provider:   pwrv.Provider.Name(),
 			Type:       rs.Type,
+			Tags:       rs.Tags(),
 			Components: comps,
+			Warnings:   append(usageWarnings[rs.Address], warnings...),
+
+			Indeterminate: indeterminate[rs.Address],
+			DefaultUsage:  p.usage.IsDefault(rs.Type, rs.Tags()),
 		}
 		result = append(result, q)
 	}
 
 	for _, child := range module.ChildModules {
-		result = append(result, p.extractModuleQueries(child, resourceProviders)...)
+		result = append(result, p.extractModuleQueries(child, resourceProviders, unknowns)...)
 	}
 
 	return result
 }
 
+// withDataSources returns a copy of the module with the data sources of the prior module (and of their
+// descendants) that are not already on it, the modules are matched by address
+func withDataSources(module, prior Module) Module {
+	res := Module{Address: module.Address}
+	addrs := make(map[string]struct{}, len(module.Resources))
+	for _, rs := range module.Resources {
+		addrs[rs.Address] = struct{}{}
+		res.Resources = append(res.Resources, rs)
+	}
+	for _, rs := range prior.Resources {
+		if _, ok := addrs[rs.Address]; ok || rs.Mode != "data" {
+			continue
+		}
+		res.Resources = append(res.Resources, rs)
+	}
+
+	priorChildren := make(map[string]*Module, len(prior.ChildModules))
+	for _, child := range prior.ChildModules {
+		priorChildren[child.Address] = child
+	}
+	for _, child := range module.ChildModules {
+		nc := *child
+		if pc, ok := priorChildren[child.Address]; ok {
+			nc = withDataSources(*child, *pc)
+			delete(priorChildren, child.Address)
+		}
+		res.ChildModules = append(res.ChildModules, &nc)
+	}
+	// The modules only on the prior are kept in their order
+	for _, child := range prior.ChildModules {
+		if _, ok := priorChildren[child.Address]; !ok {
+			continue
+		}
+		nc := withDataSources(Module{Address: child.Address}, *child)
+		res.ChildModules = append(res.ChildModules, &nc)
+	}
+	return res
+}
+
+// unknownAttributes returns the attributes of each managed resource address
+// that will only be known after apply from the `resource_changes` of the Plan.
+func (p *Plan) unknownAttributes() map[string][]string {
+	unknowns := make(map[string][]string)
+	for _, rc := range p.ResourceChanges {
+		if rc.Mode != "managed" {
+			continue
+		}
+		if attrs := rc.Change.UnknownAttributes(); len(attrs) > 0 {
+			unknowns[rc.Address] = attrs
+		}
+	}
+	return unknowns
+}
+
+// resolveUnknownValues sets the values of the unknown attributes of the resource used by the Provider to
+// estimate it from the usage of the resource (ex: 'instance_type' on the usage of the aws_instance), the
+// ones without usage are returned as the reason why the resource estimation is indeterminate.
+// Only the Providers implementing the AttributesProvider report the unknown attributes.
+func resolveUnknownValues(res Resource, prov Provider, unknowns []string) string {
+	ap, ok := prov.(AttributesProvider)
+	if !ok || len(unknowns) == 0 {
+		return ""
+	}
+
+	used := make(map[string]struct{})
+	for _, attr := range ap.ResourceAttributes(res.Type) {
+		used[attr] = struct{}{}
+	}
+
+	u, _ := res.Values[usage.Key].(map[string]interface{})
+	missing := make([]string, 0)
+	for _, attr := range unknowns {
+		if _, ok := used[attr]; !ok {
+			continue
+		}
+		if v, ok := u[attr]; ok && v != nil {
+			res.Values[attr] = v
+			continue
+		}
+		if v, ok := res.Values[attr]; ok && v != nil {
+			continue
+		}
+		missing = append(missing, attr)
+	}
+
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("values known after apply: %s, they can be set on the usage", strings.Join(missing, ", "))
+}
+
 // evaluateProviderConfigExpressions returns evaluated values of provider's configuration block, whether a constant
 // value or reference to a variable.
 func (p *Plan) evaluateProviderConfigExpressions(config ProviderConfig) (map[string]interface{}, error) {
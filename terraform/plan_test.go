@@ -3,6 +3,7 @@ package terraform_test
 import (
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -12,6 +13,7 @@ import (
 	"github.com/cycloidio/terracost/mock"
 	"github.com/cycloidio/terracost/query"
 	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
 )
 
 func TestPlan_ExtractPlannedQueries(t *testing.T) {
@@ -52,6 +54,82 @@ func TestPlan_ExtractPlannedQueries(t *testing.T) {
 		require.Len(t, queries, 2)
 	})
 
+	t.Run("OpenTofu", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+
+		var regions []interface{}
+		plan := terraform.NewPlan(terraform.ProviderInitializer{
+			MatchNames: []string{"registry.terraform.io/hashicorp/aws"},
+			Provider: func(values map[string]interface{}) (terraform.Provider, error) {
+				regions = append(regions, values["region"])
+				return provider, nil
+			},
+		})
+
+		f, err := os.Open("../testdata/aws/opentofu-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = plan.Read(f)
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(2)
+
+		queries, err := plan.ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		assert.ElementsMatch(t, []interface{}{"us-east-1", "eu-west-3"}, regions)
+	})
+
+	t.Run("MultipleProviders", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		awsProvider := mock.NewTerraformProvider(ctrl)
+		azurermProvider := mock.NewTerraformProvider(ctrl)
+
+		plan := terraform.NewPlan(
+			terraform.ProviderInitializer{
+				MatchNames: []string{"aws"},
+				Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+					return awsProvider, nil
+				},
+			},
+			terraform.ProviderInitializer{
+				MatchNames: []string{"azurerm"},
+				Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+					return azurermProvider, nil
+				},
+			},
+		)
+
+		f, err := os.Open("../testdata/mixed/plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = plan.Read(f)
+		require.NoError(t, err)
+
+		awsProvider.EXPECT().Name().AnyTimes().Return("aws")
+		awsProvider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			assert.Equal(t, "aws_instance.web", res.Address)
+			return []query.Component{}
+		}).Times(1)
+		azurermProvider.EXPECT().Name().AnyTimes().Return("azurerm")
+		azurermProvider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			assert.Equal(t, "azurerm_managed_disk.data", res.Address)
+			return []query.Component{}
+		}).Times(1)
+
+		queries, err := plan.ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+	})
+
 	t.Run("BadProvider", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -103,10 +181,189 @@ func TestPlan_ExtractPlannedQueries(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, queries, 2)
 		assert.Contains(t, queries, query.Resource{
-			Address:  "module.instance.aws_instance.example",
-			Provider: "aws-test",
-			Type:     "aws_instance",
+			Address:      "module.instance.aws_instance.example",
+			Provider:     "aws-test",
+			Type:         "aws_instance",
+			DefaultUsage: true,
+		})
+	})
+}
+
+func TestPlan_ExtractPlannedQueries_DataSources(t *testing.T) {
+	readPlan := func(t *testing.T, provider terraform.Provider) *terraform.Plan {
+		plan := terraform.NewPlan(terraform.ProviderInitializer{
+			MatchNames: []string{"aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
+		})
+
+		f, err := os.Open("../testdata/aws/terraform-noprior-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = plan.Read(f)
+		require.NoError(t, err)
+		return plan
+	}
+
+	t.Run("Ignored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := dataSourcesProvider{mock.NewTerraformProvider(ctrl), []string{"aws_vpc"}}
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(3)
+
+		queries, err := readPlan(t, provider).ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 3)
+	})
+
+	t.Run("Included", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := dataSourcesProvider{mock.NewTerraformProvider(ctrl), []string{"aws_vpc"}}
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			if res.Mode == "data" {
+				assert.Equal(t, "module.nexus.data.aws_vpc.vpc", res.Address)
+				assert.Equal(t, "172.31.0.0/16", res.Values["cidr_block"])
+			}
+			return []query.Component{}
+		}).Times(5)
+
+		plan := readPlan(t, provider)
+		plan.SetDataSources(true)
+
+		// The data sources read while planning are only on the prior state,
+		// they are on both the prior and the planned queries
+		queries, err := plan.ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 4)
+
+		prior, err := plan.ExtractPriorQueries()
+		require.NoError(t, err)
+		require.Len(t, prior, 1)
+		assert.Equal(t, "module.nexus.data.aws_vpc.vpc", prior[0].Address)
+	})
+
+	t.Run("NotDataSourcesProvider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(3)
+
+		plan := readPlan(t, provider)
+		plan.SetDataSources(true)
+
+		queries, err := plan.ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 3)
+	})
+}
+
+// dataSourcesProvider is a Provider that implements the terraform.DataSourcesProvider
+type dataSourcesProvider struct {
+	*mock.TerraformProvider
+	types []string
+}
+
+func (p dataSourcesProvider) DataSources() []string { return p.types }
+
+// attributesProvider is a Provider that implements the terraform.AttributesProvider
+type attributesProvider struct {
+	*mock.TerraformProvider
+}
+
+func (attributesProvider) ResourceAttributes(rt string) []string {
+	return map[string][]string{
+		"aws_instance":   {"instance_type", "availability_zone"},
+		"aws_ebs_volume": {"size", "type", "availability_zone"},
+	}[rt]
+}
+
+func TestPlan_ExtractPlannedQueries_UnknownValues(t *testing.T) {
+	readPlan := func(t *testing.T, provider terraform.Provider) *terraform.Plan {
+		plan := terraform.NewPlan(terraform.ProviderInitializer{
+			MatchNames: []string{"aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
 		})
+
+		f, err := os.Open("../testdata/aws/unknown-values-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = plan.Read(f)
+		require.NoError(t, err)
+		return plan
+	}
+
+	t.Run("Indeterminate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := attributesProvider{mock.NewTerraformProvider(ctrl)}
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(2)
+
+		queries, err := readPlan(t, provider).ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		for _, q := range queries {
+			if q.Type == "aws_instance" {
+				assert.Contains(t, q.Indeterminate, "instance_type")
+			} else {
+				// The iops and throughput are unknown but not used
+				assert.Empty(t, q.Indeterminate, q.Address)
+			}
+		}
+	})
+
+	t.Run("PinnedByUsage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := attributesProvider{mock.NewTerraformProvider(ctrl)}
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			if res.Type == "aws_instance" {
+				assert.Equal(t, "t3.micro", res.Values["instance_type"])
+			}
+			return []query.Component{}
+		}).Times(2)
+
+		plan := readPlan(t, provider)
+		plan.SetUsage(usage.Usage{ResourceDefaultTypeUsage: map[string]interface{}{
+			"aws_instance": map[string]interface{}{"instance_type": "t3.micro"},
+		}})
+
+		queries, err := plan.ExtractPlannedQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		for _, q := range queries {
+			assert.Empty(t, q.Indeterminate, q.Address)
+		}
+	})
+
+	t.Run("NotAttributesProvider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(2)
+
+		queries, err := readPlan(t, provider).ExtractPlannedQueries()
+		require.NoError(t, err)
+		for _, q := range queries {
+			assert.Empty(t, q.Indeterminate, q.Address)
+		}
 	})
 }
 
@@ -143,6 +400,40 @@ func TestPlan_ExtractPriorQueries(t *testing.T) {
 		require.Len(t, queries, 1)
 	})
 
+	t.Run("RemovedFromConfiguration", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+
+		plan := terraform.NewPlan(terraform.ProviderInitializer{
+			MatchNames: []string{"aws", "registry.terraform.io/hashicorp/aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
+		})
+
+		f, err := os.Open("../testdata/aws/mixed-actions-plan.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = plan.Read(f)
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(3)
+
+		// The aws_instance.delete is only on the prior state, it's estimated
+		// with the Provider of its provider_name
+		queries, err := plan.ExtractPriorQueries()
+		require.NoError(t, err)
+		addresses := make([]string, 0, len(queries))
+		for _, q := range queries {
+			addresses = append(addresses, q.Address)
+		}
+		assert.ElementsMatch(t, []string{"aws_instance.update", "aws_instance.noop", "aws_instance.delete"}, addresses)
+	})
+
 	t.Run("BadProvider", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -194,9 +485,32 @@ func TestPlan_ExtractPriorQueries(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, queries, 1)
 		assert.Contains(t, queries, query.Resource{
-			Address:  "module.instance.aws_instance.example",
-			Provider: "aws-test",
-			Type:     "aws_instance",
+			Address:      "module.instance.aws_instance.example",
+			Provider:     "aws-test",
+			Type:         "aws_instance",
+			DefaultUsage: true,
 		})
 	})
 }
+
+func TestPlan_ChangedAddresses(t *testing.T) {
+	plan := terraform.NewPlan()
+	err := plan.Read(strings.NewReader(`{
+		"resource_changes": [
+			{"address": "aws_instance.unchanged", "mode": "managed", "type": "aws_instance", "change": {"actions": ["no-op"]}},
+			{"address": "aws_instance.updated", "mode": "managed", "type": "aws_instance", "change": {"actions": ["update"]}},
+			{"address": "aws_instance.replaced", "mode": "managed", "type": "aws_instance", "change": {"actions": ["delete", "create"]}},
+			{"address": "aws_lb.created", "mode": "managed", "type": "aws_lb", "change": {"actions": ["create"]}},
+			{"address": "aws_ebs_volume.deleted", "mode": "managed", "type": "aws_ebs_volume", "change": {"actions": ["delete"]}},
+			{"address": "data.aws_ami.ubuntu", "mode": "data", "type": "aws_ami", "change": {"actions": ["read"]}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]struct{}{
+		"aws_instance.updated":   {},
+		"aws_instance.replaced":  {},
+		"aws_lb.created":         {},
+		"aws_ebs_volume.deleted": {},
+	}, plan.ChangedAddresses())
+}
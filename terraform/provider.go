@@ -1,7 +1,11 @@
 package terraform
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/usage"
 )
 
 //go:generate mockgen -destination=../mock/terraform_provider.go -mock_names=Provider=TerraformProvider -package mock github.com/cycloidio/terracost/terraform Provider
@@ -18,6 +22,119 @@ type Provider interface {
 	ResourceComponents(rss map[string]Resource, res Resource) []query.Component
 }
 
+// AttributesProvider can be implemented by a Provider to report the attributes of a resource
+// type that are used to estimate it, so the values unknown on a plan ('known after apply')
+// that affect the estimation can be reported.
+type AttributesProvider interface {
+	ResourceAttributes(resourceType string) []string
+}
+
+// WarningsProvider can be implemented by a Provider to report the assumptions made while
+// extracting the components of the resource (ex: a default used for an unknown value). The
+// warnings are returned by each call so it can be used concurrently as ResourceComponents.
+type WarningsProvider interface {
+	ResourceComponentsWithWarnings(rss map[string]Resource, res Resource) ([]query.Component, []string)
+}
+
+// ResourceExample is a canonical Resource of a type supported by a Provider, with representative
+// values, and the References it needs to be estimated (ex: a launch template or a resource group).
+type ResourceExample struct {
+	Resource   Resource
+	References []Resource
+}
+
+// ExamplesProvider can be implemented by a Provider to give a ResourceExample of each resource type it
+// supports on the location (ex: a region), so it can be verified that the ingested pricing data can estimate
+// them. The examples are estimated by the Provider returned, which may not be the same if the location is
+// set on the provider (ex: the AWS region), if the location is empty the one of the Provider is used.
+type ExamplesProvider interface {
+	ResourceExamples(location string) (Provider, []ResourceExample, error)
+}
+
+// DataSourcesProvider can be implemented by a Provider to report the data source types it can estimate
+// with the same components than the managed resource of the same type (ex: the 'aws_instance' data source),
+// so the resources referenced but not managed by the configuration can be priced for informational purposes.
+// They are only estimated when requested (see Plan.SetDataSources and StateFile.SetDataSources).
+type DataSourcesProvider interface {
+	DataSources() []string
+}
+
+// isEstimated returns true if the res has to be estimated with the prov, the managed resources
+// always are and the data sources only if dataSources is set and the prov supports their type
+func isEstimated(res Resource, prov Provider, dataSources bool) bool {
+	switch res.Mode {
+	case "managed":
+		return true
+	case "data":
+		if !dataSources {
+			return false
+		}
+		dsp, ok := prov.(DataSourcesProvider)
+		if !ok {
+			return false
+		}
+		for _, t := range dsp.DataSources() {
+			if t == res.Type {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourceComponents returns the components of the res and, if p is a WarningsProvider, its warnings
+func resourceComponents(p Provider, rss map[string]Resource, res Resource) ([]query.Component, []string) {
+	wp, ok := p.(WarningsProvider)
+	if !ok {
+		return p.ResourceComponents(rss, res), nil
+	}
+	return wp.ResourceComponentsWithWarnings(rss, res)
+}
+
+// ValuesAttributes returns the top level attributes decoded by the values struct v from
+// its 'mapstructure' tags, the usage is not part of them. It's a helper to implement the
+// AttributesProvider from the values structs of the resources.
+func ValuesAttributes(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	attrs := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("mapstructure"), ",")[0]
+		if name == "" || name == "-" || name == usage.Key {
+			continue
+		}
+		attrs = append(attrs, name)
+	}
+	return attrs
+}
+
+// Registries hosting the providers, OpenTofu mirrors the providers of the Terraform
+// registry so the same provider can be referenced by both hostnames.
+const (
+	TerraformRegistry = "registry.terraform.io"
+	OpenTofuRegistry  = "registry.opentofu.org"
+)
+
+// providerInitializersByName returns the ProviderInitializer of each of their MatchNames, the names
+// of the TerraformRegistry also match the same provider on the OpenTofuRegistry
+func providerInitializersByName(providerInitializers []ProviderInitializer) map[string]ProviderInitializer {
+	piMap := make(map[string]ProviderInitializer)
+	for _, pi := range providerInitializers {
+		for _, name := range pi.MatchNames {
+			piMap[name] = pi
+			if strings.HasPrefix(name, TerraformRegistry+"/") {
+				piMap[OpenTofuRegistry+strings.TrimPrefix(name, TerraformRegistry)] = pi
+			}
+		}
+	}
+	return piMap
+}
+
 // ProviderInitializer is used to initialize a Provider for each provider name that matches one of the MatchNames.
 type ProviderInitializer struct {
 	// MatchNames contains the names that this ProviderInitializer will match. Most providers will only
@@ -3,6 +3,9 @@ package terraform
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+
+	"github.com/cycloidio/terracost/usage"
 )
 
 // ProviderConfigExpression is a single configuration variable of a ProviderConfig.
@@ -11,9 +14,11 @@ type ProviderConfigExpression struct {
 	References    []string `json:"references" mapstructure:"references"`
 }
 
-// ProviderConfig is configuration of a provider with the given Name.
+// ProviderConfig is configuration of a provider with the given Name,
+// the FullName is the address on the registry (ex: registry.terraform.io/hashicorp/aws).
 type ProviderConfig struct {
 	Name        string                              `json:"name"`
+	FullName    string                              `json:"full_name"`
 	Alias       string                              `json:"alias"`
 	Expressions map[string]ProviderConfigExpression `json:"expressions"`
 }
@@ -24,6 +29,7 @@ type ProviderConfig struct {
 func (cfg *ProviderConfig) UnmarshalJSON(b []byte) error {
 	var s struct {
 		Name        string                 `json:"name"`
+		FullName    string                 `json:"full_name"`
 		Alias       string                 `json:"alias"`
 		Expressions map[string]interface{} `json:"expressions"`
 	}
@@ -32,6 +38,7 @@ func (cfg *ProviderConfig) UnmarshalJSON(b []byte) error {
 	}
 
 	cfg.Name = s.Name
+	cfg.FullName = s.FullName
 	cfg.Alias = s.Alias
 	cfg.Expressions = make(map[string]ProviderConfigExpression)
 
@@ -88,6 +95,80 @@ type Resource struct {
 	Values       map[string]interface{} `json:"values"`
 }
 
+// tagsAttributes are the attributes holding the tags of a Resource by order of preference,
+// the 'tags_all' also has the AWS provider 'default_tags' and the 'labels' are the GCP ones
+var tagsAttributes = []string{"tags_all", "tags", "labels"}
+
+// Tags returns the tags of the Resource, only the ones with a string value are returned
+// and nil if the Resource has none.
+func (r Resource) Tags() map[string]string {
+	for _, attr := range tagsAttributes {
+		tv, ok := r.Values[attr].(map[string]interface{})
+		if !ok || len(tv) == 0 {
+			continue
+		}
+		tags := make(map[string]string, len(tv))
+		for k, v := range tv {
+			if sv, ok := v.(string); ok {
+				tags[k] = sv
+			}
+		}
+		return tags
+	}
+	return nil
+}
+
+// setUsage sets the usage of u on the Values of the Resource with the usage of its tags
+// (see usage.Usage.GetResourceUsage), the warnings of an invalid usage tag are returned
+func (r Resource) setUsage(u usage.Usage) []string {
+	us, err := u.GetResourceUsage(r.Type, r.Tags())
+	r.Values[usage.Key] = us
+	if err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// ResourceChange is the change planned for a single resource, the Actions
+// can be a combination of "no-op", "create", "read", "update" and "delete".
+type ResourceChange struct {
+	Address string `json:"address"`
+	Mode    string `json:"mode"`
+	Type    string `json:"type"`
+	Change  Change `json:"change"`
+}
+
+// Change holds the actions of a ResourceChange.
+type Change struct {
+	Actions []string `json:"actions"`
+
+	// AfterUnknown has the attributes that will only be
+	// known after apply set to true
+	AfterUnknown map[string]interface{} `json:"after_unknown"`
+}
+
+// UnknownAttributes returns the top level attributes that will only be known after apply, sorted.
+func (c Change) UnknownAttributes() []string {
+	attrs := make([]string, 0)
+	for k, v := range c.AfterUnknown {
+		if b, ok := v.(bool); ok && b {
+			attrs = append(attrs, k)
+		}
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+// IsNoOp returns true if no action is planned for the resource
+func (c Change) IsNoOp() bool {
+	for _, a := range c.Actions {
+		if a != "no-op" && a != "read" {
+			return false
+		}
+	}
+	return true
+}
+
 // Module is a collection of resources.
 type Module struct {
 	Address      string     `json:"address"`
@@ -46,3 +46,29 @@ func TestProviderConfigUnmarshalJSON(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, ex, pcfg)
 }
+
+func TestResource_Tags(t *testing.T) {
+	t.Run("TagsAll", func(t *testing.T) {
+		res := terraform.Resource{
+			Values: map[string]interface{}{
+				"tags":     map[string]interface{}{"team": "web"},
+				"tags_all": map[string]interface{}{"team": "web", "env": "prod"},
+			},
+		}
+		assert.Equal(t, map[string]string{"team": "web", "env": "prod"}, res.Tags())
+	})
+
+	t.Run("Labels", func(t *testing.T) {
+		res := terraform.Resource{
+			Values: map[string]interface{}{
+				"labels": map[string]interface{}{"team": "web", "count": 1},
+			},
+		}
+		assert.Equal(t, map[string]string{"team": "web"}, res.Tags())
+	})
+
+	t.Run("NoTags", func(t *testing.T) {
+		res := terraform.Resource{Values: map[string]interface{}{"tags": nil}}
+		assert.Nil(t, res.Tags())
+	})
+}
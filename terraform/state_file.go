@@ -0,0 +1,223 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/usage"
+)
+
+// StateFile is a representation of a Terraform state, it can be read from the raw
+// 'terraform.tfstate' file or from the output of 'terraform show -json'.
+// As the state has no provider configuration the provider values (ex: region)
+// are guessed from the attributes of each resource.
+type StateFile struct {
+	providerInitializers map[string]ProviderInitializer
+	usage                usage.Usage
+	dataSources          bool
+
+	// Values is set when the state is read from the output of 'terraform show -json'
+	Values *Values `json:"values"`
+
+	// Resources is set when the state is read from the raw 'terraform.tfstate'
+	Resources []StateResource `json:"resources"`
+}
+
+// StateResource is a single resource of the raw 'terraform.tfstate', it
+// holds all the instances created with 'count' or 'for_each'
+type StateResource struct {
+	Module    string          `json:"module"`
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Provider  string          `json:"provider"`
+	Instances []StateInstance `json:"instances"`
+}
+
+// StateInstance is a single instance of a StateResource
+type StateInstance struct {
+	// IndexKey can be an integer (count) or a string (for_each)
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// stateProviderRe extracts the provider name from the raw state
+// provider, ex: module.ec2.provider["registry.terraform.io/hashicorp/aws"].west
+// or provider["registry.opentofu.org/hashicorp/aws"] with OpenTofu
+var stateProviderRe = regexp.MustCompile(`provider\["([^"]+)"\]`)
+
+// NewStateFile returns an empty StateFile.
+func NewStateFile(providerInitializers ...ProviderInitializer) *StateFile {
+	return &StateFile{providerInitializers: providerInitializersByName(providerInitializers)}
+}
+
+// SetUsage will set the usage of the state
+func (s *StateFile) SetUsage(u usage.Usage) { s.usage = u }
+
+// SetDataSources sets if the data sources supported by the Providers (see DataSourcesProvider)
+// are estimated along the managed resources, by default they are ignored
+func (s *StateFile) SetDataSources(ds bool) { s.dataSources = ds }
+
+// Read reads the StateFile from the provided io.Reader.
+func (s *StateFile) Read(r io.Reader) error {
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ExtractQueries extracts a query.Resource slice from all the managed resources of the StateFile,
+// and the data sources if they are requested (see SetDataSources).
+func (s *StateFile) ExtractQueries() ([]query.Resource, error) {
+	rss := make(map[string]Resource)
+	if s.Values != nil {
+		flattenModuleResources(&s.Values.RootModule, rss, s.dataSources)
+	} else {
+		for _, sr := range s.Resources {
+			if sr.Mode != "managed" && (sr.Mode != "data" || !s.dataSources) {
+				continue
+			}
+			pn := sr.Provider
+			if m := stateProviderRe.FindStringSubmatch(sr.Provider); len(m) == 2 {
+				pn = m[1]
+			}
+			for _, si := range sr.Instances {
+				addr := fmt.Sprintf("%s.%s%s", sr.Type, sr.Name, indexKeySuffix(si.IndexKey))
+				if sr.Mode == "data" {
+					addr = "data." + addr
+				}
+				if sr.Module != "" {
+					addr = fmt.Sprintf("%s.%s", sr.Module, addr)
+				}
+				vals := si.Attributes
+				if vals == nil {
+					vals = make(map[string]interface{})
+				}
+				rss[addr] = Resource{
+					Address:      addr,
+					Index:        si.IndexKey,
+					Mode:         sr.Mode,
+					Type:         sr.Type,
+					Name:         sr.Name,
+					ProviderName: pn,
+					Values:       vals,
+				}
+			}
+		}
+	}
+
+	addresses := make([]string, 0, len(rss))
+	usageWarnings := make(map[string][]string)
+	for addr, rs := range rss {
+		usageWarnings[addr] = rs.setUsage(s.usage)
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+
+	providers := make(map[string]Provider)
+	result := make([]query.Resource, 0, len(rss))
+	for _, addr := range addresses {
+		rs := rss[addr]
+		pi, ok := s.providerInitializers[rs.ProviderName]
+		if !ok {
+			continue
+		}
+
+		values := stateProviderValues(rs.Values)
+		pk := fmt.Sprintf("%s/%v/%v", rs.ProviderName, values["region"], values["zone"])
+		prov, ok := providers[pk]
+		if !ok {
+			var err error
+			prov, err = pi.Provider(values)
+			if err != nil {
+				return nil, err
+			}
+			providers[pk] = prov
+		}
+		if prov == nil || !isEstimated(rs, prov, s.dataSources) {
+			continue
+		}
+
+		comps, warnings := resourceComponents(prov, rss, rs)
+		result = append(result, query.Resource{
+			Address:    rs.Address,
+			Provider:   prov.Name(),
+			Type:       rs.Type,
+			Tags:       rs.Tags(),
+			Components: comps,
+			Warnings:   append(usageWarnings[rs.Address], warnings...),
+
+			DefaultUsage: s.usage.IsDefault(rs.Type, rs.Tags()),
+		})
+	}
+
+	if len(providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	return result, nil
+}
+
+// flattenModuleResources adds all the managed resources of the module and its
+// descendants to the rss, and the data sources if dataSources is set
+func flattenModuleResources(module *Module, rss map[string]Resource, dataSources bool) {
+	for _, rs := range module.Resources {
+		if rs.Mode != "managed" && (rs.Mode != "data" || !dataSources) {
+			continue
+		}
+		if rs.Values == nil {
+			rs.Values = make(map[string]interface{})
+		}
+		rss[rs.Address] = rs
+	}
+	for _, child := range module.ChildModules {
+		flattenModuleResources(child, rss, dataSources)
+	}
+}
+
+// hclTemplateEscaper escapes the template sequences of a quoted HCL string
+var hclTemplateEscaper = strings.NewReplacer("${", "$${", "%{", "%%{")
+
+// indexKeySuffix returns the address suffix of the index key of an instance, ex: [0] or ["front"],
+// the string keys are quoted as on the Terraform addresses, with the template sequences
+// escaped as in HCL (ex: ["a$${b}"] for the key a${b})
+func indexKeySuffix(ik interface{}) string {
+	switch k := ik.(type) {
+	case float64:
+		return fmt.Sprintf("[%d]", int(k))
+	case string:
+		return "[" + hclTemplateEscaper.Replace(strconv.Quote(k)) + "]"
+	default:
+		return ""
+	}
+}
+
+// stateProviderValues guesses the provider configuration values from the
+// attributes of a resource, as they are not part of the state
+func stateProviderValues(attrs map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	if r, ok := attrs["region"].(string); ok && r != "" {
+		values["region"] = r
+	} else if arn, ok := attrs["arn"].(string); ok {
+		// arn:partition:service:region:account-id:resource
+		if parts := strings.Split(arn, ":"); len(parts) > 3 && parts[3] != "" {
+			values["region"] = parts[3]
+		}
+	}
+	if _, ok := values["region"]; !ok {
+		// The availability zone is the region with a letter at the end, ex: eu-west-3a
+		if az, ok := attrs["availability_zone"].(string); ok && len(az) > 1 {
+			values["region"] = az[:len(az)-1]
+		}
+	}
+	if z, ok := attrs["zone"].(string); ok && z != "" {
+		values["zone"] = z
+	}
+	return values
+}
@@ -0,0 +1,237 @@
+package terraform_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/mock"
+	"github.com/cycloidio/terracost/query"
+	"github.com/cycloidio/terracost/terraform"
+	"github.com/cycloidio/terracost/usage"
+)
+
+func TestStateFile_ExtractQueries(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+
+		var regions []interface{}
+		state := terraform.NewStateFile(terraform.ProviderInitializer{
+			MatchNames: []string{"aws", "registry.terraform.io/hashicorp/aws"},
+			Provider: func(values map[string]interface{}) (terraform.Provider, error) {
+				regions = append(regions, values["region"])
+				return provider, nil
+			},
+		})
+
+		f, err := os.Open("../testdata/aws/terraform-state.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = state.Read(f)
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			assert.Len(t, rss, 4)
+			switch res.Type {
+			case "aws_instance":
+				assert.True(t, strings.HasPrefix(res.Address, "module.instance.aws_instance.example["), res.Address)
+				assert.Equal(t, "t2.xlarge", res.Values["instance_type"])
+			case "aws_ebs_volume":
+				assert.Equal(t, `module.instance.aws_ebs_volume.data["logs"]`, res.Address)
+			default:
+				assert.Equal(t, "aws_lb.example", res.Address)
+				assert.Equal(t, "application", res.Values["load_balancer_type"])
+			}
+			return []query.Component{}
+		}).Times(4)
+
+		queries, err := state.ExtractQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 4)
+		assert.Equal(t, "aws_lb.example", queries[0].Address)
+		assert.Equal(t, []interface{}{"eu-west-3"}, regions)
+	})
+
+	t.Run("OpenTofu", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+
+		state := terraform.NewStateFile(terraform.ProviderInitializer{
+			MatchNames: []string{"aws", "registry.terraform.io/hashicorp/aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
+		})
+
+		f, err := os.Open("../testdata/aws/opentofu-state.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = state.Read(f)
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(4)
+
+		queries, err := state.ExtractQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 4)
+	})
+
+	t.Run("DataSources", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := dataSourcesProvider{mock.NewTerraformProvider(ctrl), []string{"aws_ami"}}
+
+		state := terraform.NewStateFile(terraform.ProviderInitializer{
+			MatchNames: []string{"registry.terraform.io/hashicorp/aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
+		})
+		state.SetDataSources(true)
+
+		f, err := os.Open("../testdata/aws/terraform-state.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = state.Read(f)
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(5)
+
+		queries, err := state.ExtractQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 5)
+		assert.Equal(t, "aws_lb.example", queries[0].Address)
+		assert.Equal(t, "data.aws_ami.ubuntu", queries[1].Address)
+	})
+
+	t.Run("UsageTag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+
+		state := terraform.NewStateFile(terraform.ProviderInitializer{
+			MatchNames: []string{"aws", "registry.terraform.io/hashicorp/aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
+		})
+		state.SetUsage(usage.Usage{
+			ResourceDefaultTypeUsage: map[string]interface{}{
+				"aws_instance": map[string]interface{}{"monthly_hours": 730, "monthly_cpu_credit_hours": 10},
+			},
+			UsageTag: "terracost_usage",
+		})
+
+		err := state.Read(strings.NewReader(`{
+			"version": 4,
+			"resources": [
+				{
+					"mode": "managed",
+					"type": "aws_instance",
+					"name": "tagged",
+					"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+					"instances": [{"attributes": {"instance_type": "t3.micro", "tags": {"terracost_usage": "{\"monthly_hours\": 200}"}}}]
+				},
+				{
+					"mode": "managed",
+					"type": "aws_instance",
+					"name": "invalid",
+					"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+					"instances": [{"attributes": {"instance_type": "t3.micro", "tags": {"terracost_usage": "200 hours"}}}]
+				}
+			]
+		}`))
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).DoAndReturn(func(rss map[string]terraform.Resource, res terraform.Resource) []query.Component {
+			us := res.Values[usage.Key].(map[string]interface{})
+			if res.Name == "tagged" {
+				assert.Equal(t, map[string]interface{}{"monthly_hours": float64(200), "monthly_cpu_credit_hours": 10}, us)
+			} else {
+				assert.Equal(t, map[string]interface{}{"monthly_hours": 730, "monthly_cpu_credit_hours": 10}, us)
+			}
+			return []query.Component{}
+		}).Times(2)
+
+		queries, err := state.ExtractQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		assert.Equal(t, "aws_instance.invalid", queries[0].Address)
+		require.Len(t, queries[0].Warnings, 1)
+		assert.Contains(t, queries[0].Warnings[0], `invalid usage on the tag "terracost_usage"`)
+		assert.Empty(t, queries[1].Warnings)
+	})
+
+	t.Run("IndexKeys", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := mock.NewTerraformProvider(ctrl)
+
+		state := terraform.NewStateFile(terraform.ProviderInitializer{
+			MatchNames: []string{"aws", "registry.terraform.io/hashicorp/aws"},
+			Provider: func(_ map[string]interface{}) (terraform.Provider, error) {
+				return provider, nil
+			},
+		})
+
+		err := state.Read(strings.NewReader(`{
+			"version": 4,
+			"resources": [
+				{
+					"mode": "managed",
+					"type": "aws_instance",
+					"name": "test",
+					"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+					"instances": [
+						{"index_key": 0, "attributes": {"instance_type": "t3.micro"}},
+						{"index_key": "a\"b${c}", "attributes": {"instance_type": "t3.micro"}}
+					]
+				}
+			]
+		}`))
+		require.NoError(t, err)
+
+		provider.EXPECT().Name().AnyTimes().Return("aws")
+		provider.EXPECT().ResourceComponents(gomock.Any(), gomock.Any()).Return([]query.Component{}).Times(2)
+
+		queries, err := state.ExtractQueries()
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		// The keys are quoted as on the Terraform addresses, with the HCL escaping of the template sequences
+		assert.Equal(t, `aws_instance.test["a\"b$${c}"]`, queries[0].Address)
+		assert.Equal(t, "aws_instance.test[0]", queries[1].Address)
+	})
+
+	t.Run("NoProviders", func(t *testing.T) {
+		state := terraform.NewStateFile()
+
+		f, err := os.Open("../testdata/aws/terraform-state.json")
+		require.NoError(t, err)
+		defer f.Close()
+
+		err = state.Read(f)
+		require.NoError(t, err)
+
+		_, err = state.ExtractQueries()
+		assert.Equal(t, terraform.ErrNoProviders, err)
+	})
+}
@@ -0,0 +1,9 @@
+variable "size" {
+  type    = number
+  default = 20
+}
+
+resource "aws_ebs_volume" "disk" {
+  availability_zone = "eu-west-3a"
+  size              = var.size
+}
@@ -0,0 +1,8 @@
+provider "aws" {
+  region = "eu-west-3"
+}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t3.micro"
+}
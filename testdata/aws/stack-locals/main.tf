@@ -0,0 +1,25 @@
+provider "aws" {
+  region = "eu-west-1"
+}
+
+variable "size" {
+  type    = string
+  default = "large"
+}
+
+locals {
+  # The locals are declared before the ones they reference on purpose
+  web_instance_type = local.instance_type
+  instance_type     = "${local.family}.${var.size}"
+  family            = "m5"
+}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = local.web_instance_type
+}
+
+resource "aws_instance" "interpolated" {
+  ami           = "some-ami"
+  instance_type = "t3.${var.size}"
+}
@@ -0,0 +1,23 @@
+provider "aws" {
+  region = "eu-west-1"
+}
+
+variable "instances" {
+  type = map(string)
+  default = {
+    front = "t3.small"
+    back  = "t3.medium"
+  }
+}
+
+resource "aws_instance" "count" {
+  count         = 3
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "for_each" {
+  for_each      = var.instances
+  ami           = "some-ami-${each.key}"
+  instance_type = each.value
+}
@@ -0,0 +1,8 @@
+variable "instance_type" {
+  type = string
+}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = var.instance_type
+}
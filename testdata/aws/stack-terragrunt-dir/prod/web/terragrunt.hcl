@@ -0,0 +1,11 @@
+include "root" {
+  path = find_in_parent_folders()
+}
+
+terraform {
+  source = "../../modules//web"
+}
+
+inputs = {
+  instance_type = "m5.large"
+}
@@ -0,0 +1,9 @@
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite_terragrunt"
+  contents  = <<EOF
+provider "aws" {
+  region = "eu-west-3"
+}
+EOF
+}
@@ -0,0 +1,21 @@
+provider "aws" {
+  region = "eu-west-3"
+}
+
+provider "azurerm" {
+  features {}
+}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t3.micro"
+}
+
+resource "azurerm_managed_disk" "data" {
+  name                 = "data"
+  location             = "francecentral"
+  resource_group_name  = "mixed"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = 128
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,6 +15,9 @@ const (
 	standardURL     = "/api/retail/prices?$filter=serviceName%20eq%20%27Virtual%20Machines%27%20and%20armRegionName%20eq%20%27francecentral%27"
 	azureURL        = "/api/retail/prices?$filter=serviceName%20eq%20%27Virtual%20Machines%27%20and%20%28armRegionName%20eq%20%27francecentral%27%20or%20armRegionName%20eq%20%27Global%27%20or%20armRegionName%20eq%20%27Zone%201%27%29"
 	azWithSwapperOr = "/api/retail/prices?$filter=serviceName%20eq%20%27Virtual%20Machines%27%20and%20%28armRegionName%20eq%20%27francecentral%27%20or%20armRegionName%20eq%20%27Zone%201%27%20or%20armRegionName%20eq%20%27Global%27%29"
+
+	// sinceFilter is appended to the URLs by the incremental ingestions
+	sinceFilter = "%20and%20effectiveStartDate%20ge%20"
 )
 
 // StartAzureServer starts a new test server for Azure API
@@ -26,7 +30,11 @@ func StartAzureServer(t *testing.T) *httptest.Server {
 
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var b []byte
-		switch r.URL.String() {
+		u := r.URL.String()
+		if i := strings.Index(u, sinceFilter); i != -1 {
+			u = u[:i]
+		}
+		switch u {
 		case standardURL, azureURL, azWithSwapperOr:
 			b = rp
 		default:
@@ -0,0 +1,157 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// hoursPerMonth is the average number of hours of a month, the same as cost.HoursPerMonth,
+	// it's only used by MonthlyHours as the providers use the RunningShare so the hourly
+	// prices are converted with the hours per month of the estimation (see cost.StateOptions)
+	hoursPerMonth float64 = 730
+	// hoursPerWeek is the number of hours of a week
+	hoursPerWeek float64 = 7 * 24
+)
+
+// weekdays are the days of a schedule in the order of a week starting on Monday
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// MonthlyHours returns the number of hours per month of a resource running
+// weeklyHours each week, with the average month of 730 hours
+func MonthlyHours(weeklyHours float64) float64 {
+	return weeklyHours * hoursPerMonth / hoursPerWeek
+}
+
+// ScheduledMonthlyHours returns the number of hours per month of a resource running weeklyHours each
+// week or, if it's not set, with the schedule (see ParseSchedule). It's 0 if none of them is set.
+// The month is the average one of 730 hours, see RunningShare to follow the month of the estimation.
+func ScheduledMonthlyHours(weeklyHours float64, schedule string) (float64, error) {
+	share, err := RunningShare(weeklyHours, schedule)
+	if err != nil {
+		return 0, err
+	}
+	return share * hoursPerMonth, nil
+}
+
+// RunningShare returns the share of the time (from 0 to 1) a resource running weeklyHours each week or,
+// if it's not set, with the schedule (see ParseSchedule) runs. It's 0 if none of them is set and an
+// error if weeklyHours is more than the hours of a week. Multiplying the hourly quantities by it keeps them hourly, so they are converted to monthly
+// ones with the hours per month of the estimation.
+func RunningShare(weeklyHours float64, schedule string) (float64, error) {
+	if weeklyHours > hoursPerWeek {
+		return 0, fmt.Errorf("invalid hours per week %v: it's more than the %v hours of a week", weeklyHours, hoursPerWeek)
+	}
+	if weeklyHours > 0 {
+		return weeklyHours / hoursPerWeek, nil
+	}
+	if schedule == "" {
+		return 0, nil
+	}
+	wh, err := ParseSchedule(schedule)
+	if err != nil {
+		return 0, err
+	}
+	return wh / hoursPerWeek, nil
+}
+
+// ParseSchedule returns the number of hours per week a resource runs with the schedule s. The schedule is
+// the days (ex: "Mon-Fri" or "Sat,Sun") followed by the time range it runs on those days (ex: "08:00-20:00"),
+// several can be separated by ";" (ex: "Mon-Fri 08:00-20:00; Sat 10:00-14:00"). A time range ending before
+// it starts runs over midnight (ex: "22:00-06:00") and "00:00-24:00" runs the whole day.
+func ParseSchedule(s string) (float64, error) {
+	var total float64
+	for _, p := range strings.Split(s, ";") {
+		fields := strings.Fields(p)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return 0, fmt.Errorf("invalid schedule %q: expected the days and the time range", p)
+		}
+
+		days, err := parseScheduleDays(fields[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid schedule %q: %w", p, err)
+		}
+		hours, err := parseScheduleHours(fields[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid schedule %q: %w", p, err)
+		}
+		total += float64(days) * hours
+	}
+
+	if total > hoursPerWeek {
+		return 0, fmt.Errorf("invalid schedule %q: it runs more than the %v hours of a week", s, hoursPerWeek)
+	}
+	return total, nil
+}
+
+// parseScheduleDays returns the number of days of the list of days or ranges of days ds (ex: "Mon-Wed,Fri")
+func parseScheduleDays(ds string) (int, error) {
+	var days int
+	for _, d := range strings.Split(ds, ",") {
+		from, to, isRange := strings.Cut(d, "-")
+		start, err := weekdayIndex(from)
+		if err != nil {
+			return 0, err
+		}
+		end := start
+		if isRange {
+			end, err = weekdayIndex(to)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if end < start {
+			return 0, fmt.Errorf("the days %q are not in the order of the week", d)
+		}
+		days += end - start + 1
+	}
+	return days, nil
+}
+
+// weekdayIndex returns the index of the day d (ex: Mon) in the weekdays
+func weekdayIndex(d string) (int, error) {
+	for i, wd := range weekdays {
+		if strings.EqualFold(d, wd) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", d)
+}
+
+// parseScheduleHours returns the number of hours of the time range tr (ex: "08:00-20:00")
+func parseScheduleHours(tr string) (float64, error) {
+	from, to, ok := strings.Cut(tr, "-")
+	if !ok {
+		return 0, fmt.Errorf("the time range %q has no end", tr)
+	}
+	start, err := parseScheduleTime(from)
+	if err != nil {
+		return 0, err
+	}
+	end, err := parseScheduleTime(to)
+	if err != nil {
+		return 0, err
+	}
+
+	d := end - start
+	if d <= 0 {
+		d += 24 * time.Hour
+	}
+	return d.Hours(), nil
+}
+
+// parseScheduleTime returns the duration since midnight of the time t (ex: 08:30), 24:00 is the end of the day
+func parseScheduleTime(t string) (time.Duration, error) {
+	if t == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	pt, err := time.Parse("15:04", t)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", t)
+	}
+	return time.Duration(pt.Hour())*time.Hour + time.Duration(pt.Minute())*time.Minute, nil
+}
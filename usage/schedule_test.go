@@ -0,0 +1,93 @@
+package usage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/usage"
+)
+
+func TestMonthlyHours(t *testing.T) {
+	assert.Equal(t, float64(730), usage.MonthlyHours(168))
+	assert.InDelta(t, 217.26, usage.MonthlyHours(50), 0.01)
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		schedule string
+		hours    float64
+	}{
+		{schedule: "Mon-Fri 08:00-18:00", hours: 50},
+		{schedule: "Mon-Fri 08:00-20:00; Sat 10:00-14:00", hours: 64},
+		{schedule: "mon,wed,fri 09:30-12:00", hours: 7.5},
+		{schedule: "Mon-Sun 00:00-24:00", hours: 168},
+		{schedule: "Fri 22:00-06:00", hours: 8},
+		{schedule: "", hours: 0},
+	} {
+		t.Run(tc.schedule, func(t *testing.T) {
+			hours, err := usage.ParseSchedule(tc.schedule)
+			require.NoError(t, err)
+			assert.Equal(t, tc.hours, hours)
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, s := range []string{
+			"Mon-Fri",
+			"Mon-Fri 08:00",
+			"Fri-Mon 08:00-18:00",
+			"Someday 08:00-18:00",
+			"Mon-Fri 8h-18h",
+			"Mon-Sun 00:00-24:00; Mon 08:00-10:00",
+		} {
+			_, err := usage.ParseSchedule(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestScheduledMonthlyHours(t *testing.T) {
+	t.Run("WeeklyHours", func(t *testing.T) {
+		hours, err := usage.ScheduledMonthlyHours(168, "Mon-Fri 08:00-18:00")
+		require.NoError(t, err)
+		assert.Equal(t, float64(730), hours)
+	})
+	t.Run("Schedule", func(t *testing.T) {
+		hours, err := usage.ScheduledMonthlyHours(0, "Mon-Sun 00:00-24:00")
+		require.NoError(t, err)
+		assert.Equal(t, float64(730), hours)
+	})
+	t.Run("None", func(t *testing.T) {
+		hours, err := usage.ScheduledMonthlyHours(0, "")
+		require.NoError(t, err)
+		assert.Zero(t, hours)
+	})
+}
+
+func TestRunningShare(t *testing.T) {
+	t.Run("WeeklyHours", func(t *testing.T) {
+		share, err := usage.RunningShare(84, "Mon-Fri 08:00-18:00")
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, share)
+	})
+	t.Run("Schedule", func(t *testing.T) {
+		share, err := usage.RunningShare(0, "Mon-Sun 00:00-24:00")
+		require.NoError(t, err)
+		assert.Equal(t, float64(1), share)
+	})
+	t.Run("None", func(t *testing.T) {
+		share, err := usage.RunningShare(0, "")
+		require.NoError(t, err)
+		assert.Zero(t, share)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := usage.RunningShare(0, "invalid")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidWeeklyHours", func(t *testing.T) {
+		_, err := usage.RunningShare(200, "")
+		assert.Error(t, err)
+	})
+}
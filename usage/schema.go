@@ -0,0 +1,111 @@
+package usage
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// SchemaURI is the JSON Schema version used by JSONSchema
+const SchemaURI = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns a JSON Schema describing the Usage with all the supported usage
+// fields. The resources are the values structs of the resources keyed by the resource type
+// (ex: aws_instance), the usage fields are read from the struct field with the Key
+// mapstructure tag and the resources without it are ignored.
+func JSONSchema(resources map[string]interface{}) ([]byte, error) {
+	rprops := make(map[string]interface{})
+	for rt, v := range resources {
+		ut, ok := usageType(reflect.TypeOf(v))
+		if !ok {
+			continue
+		}
+		rprops[rt] = typeSchema(ut)
+	}
+
+	schema := map[string]interface{}{
+		"$schema": SchemaURI,
+		"title":   "Terracost usage",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"resource_default_type_usage": map[string]interface{}{
+				"type":                 "object",
+				"properties":           rprops,
+				"additionalProperties": false,
+			},
+			"usage_tag": map[string]interface{}{
+				"type": "string",
+			},
+		},
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// usageType returns the type of the field tagged with the Key
+func usageType(t reflect.Type) (reflect.Type, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if tagName(f) == Key {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+// typeSchema returns the JSON Schema of the type t
+func typeSchema(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		props := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := tagName(f)
+			if name == "-" || f.PkgPath != "" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = typeSchema(f.Type)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// tagName returns the name of the mapstructure tag of the field
+func tagName(f reflect.StructField) string {
+	return strings.Split(f.Tag.Get("mapstructure"), ",")[0]
+}
@@ -0,0 +1,63 @@
+package usage_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracost/usage"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type withUsage struct {
+		Name  string `mapstructure:"name"`
+		Usage struct {
+			Hours    float64  `mapstructure:"monthly_hours"`
+			Requests int64    `mapstructure:"monthly_requests"`
+			Enabled  bool     `mapstructure:"enabled"`
+			Names    []string `mapstructure:"names"`
+		} `mapstructure:"tc_usage"`
+	}
+	type withoutUsage struct {
+		Name string `mapstructure:"name"`
+	}
+
+	b, err := usage.JSONSchema(map[string]interface{}{
+		"with_usage":    withUsage{},
+		"without_usage": withoutUsage{},
+	})
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &schema))
+
+	assert.Equal(t, map[string]interface{}{
+		"$schema": usage.SchemaURI,
+		"title":   "Terracost usage",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"resource_default_type_usage": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]interface{}{
+					"with_usage": map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"properties": map[string]interface{}{
+							"monthly_hours":    map[string]interface{}{"type": "number"},
+							"monthly_requests": map[string]interface{}{"type": "integer"},
+							"enabled":          map[string]interface{}{"type": "boolean"},
+							"names": map[string]interface{}{
+								"type":  "array",
+								"items": map[string]interface{}{"type": "string"},
+							},
+						},
+					},
+				},
+			},
+			"usage_tag": map[string]interface{}{"type": "string"},
+		},
+	}, schema)
+}
@@ -1,5 +1,11 @@
 package usage
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
 const (
 	// Key is the key used to set the usage
 	// on the values passed to the resources
@@ -10,6 +16,32 @@ const (
 var Default = Usage{
 	ResourceDefaultTypeUsage: map[string]interface{}{
 		// AWS
+		"aws_api_gateway_rest_api": map[string]interface{}{
+			"monthly_requests":         1000000,
+			"monthly_outbound_data_gb": 10,
+		},
+		"aws_apigatewayv2_api": map[string]interface{}{
+			"monthly_requests":           1000000,
+			"monthly_messages":           1000000,
+			"monthly_connection_minutes": 100000,
+			"monthly_outbound_data_gb":   10,
+		},
+		"aws_backup_vault": map[string]interface{}{
+			"resource_type":      "EFS",
+			"warm_storage_gb":    100,
+			"cold_storage_gb":    0,
+			"monthly_restore_gb": 0,
+		},
+		"aws_cloudfront_distribution": map[string]interface{}{
+			"monthly_data_transfer_to_internet_gb": map[string]interface{}{
+				"us":     100,
+				"europe": 100,
+			},
+			"monthly_https_requests": map[string]interface{}{
+				"us":     1000000,
+				"europe": 1000000,
+			},
+		},
 		"aws_cloudwatch_log_group": map[string]interface{}{
 			"storage_gb":                       200,
 			"monthly_data_ingested_gb":         10,
@@ -29,6 +61,8 @@ var Default = Usage{
 			"infrequent_access_storage_gb":       10,
 			"monthly_infrequent_access_read_gb":  20,
 			"monthly_infrequent_access_write_gb": 30,
+			"monthly_elastic_read_gb":            100,
+			"monthly_elastic_write_gb":           20,
 		},
 		"aws_fsx_openzfs_file_system": map[string]interface{}{
 			"backup_storage_gb": 1024,
@@ -42,6 +76,14 @@ var Default = Usage{
 		"aws_fsx_lustre_file_system": map[string]interface{}{
 			"backup_storage_gb": 1024,
 		},
+		"aws_kms_key": map[string]interface{}{
+			"monthly_requests": 100000,
+			"monthly_ecc_generate_data_key_pair_requests": 0,
+			"monthly_rsa_generate_data_key_pair_requests": 0,
+		},
+		"aws_mq_broker": map[string]interface{}{
+			"storage_gb": 20,
+		},
 		"aws_nat_gateway": map[string]interface{}{
 			"monthly_data_processed_gb": 10,
 		},
@@ -74,12 +116,29 @@ var Default = Usage{
 		"aws_secretsmanager_secret": map[string]interface{}{
 			"monthly_requests": 1000000,
 		},
+		"aws_sns_topic": map[string]interface{}{
+			"monthly_requests":           1000000,
+			"monthly_http_notifications": 1000000,
+		},
 		"aws_sqs_queue": map[string]interface{}{
 			"monthly_requests": 15000000,
 			"request_size_kb":  16,
 		},
+		"aws_transfer_server": map[string]interface{}{
+			"monthly_upload_gb":   10,
+			"monthly_download_gb": 10,
+		},
+		"aws_vpc_endpoint": map[string]interface{}{
+			"monthly_data_processed_gb": 10,
+		},
+		"aws_wafv2_web_acl": map[string]interface{}{
+			"monthly_requests": 1000000,
+		},
 
 		// Azure
+		"azurerm_application_gateway": map[string]interface{}{
+			"capacity_units": 10,
+		},
 		"azurerm_bastion_host": map[string]interface{}{
 			"monthly_outbound_data_gb": 40,
 		},
@@ -117,6 +176,18 @@ var Default = Usage{
 			"monthly_read_transactions":  1000000,
 			"monthly_other_transactions": 1000000,
 		},
+		"azurerm_cosmosdb_account": map[string]interface{}{
+			"storage_gb": 100,
+		},
+		"azurerm_container_registry": map[string]interface{}{
+			"storage_gb": 10,
+		},
+		"azurerm_container_app": map[string]interface{}{
+			"monthly_active_hours": 730, // Corresponds to a full month
+		},
+		"azurerm_lb": map[string]interface{}{
+			"monthly_data_processed_gb": 100,
+		},
 		"azurerm_public_ip": map[string]interface{}{
 			"monthly_hours": 730, // Corresponds to a full month
 		},
@@ -129,6 +200,11 @@ var Default = Usage{
 // Usage is the struct defining all the configure usages
 type Usage struct {
 	ResourceDefaultTypeUsage map[string]interface{} `json:"resource_default_type_usage" yaml:"resource_default_type_usage"`
+
+	// UsageTag is the key of the tag of the resources holding their own usage as a JSON
+	// object (ex: {"monthly_hours": 200}) merged over the usage of their type, so the usage
+	// can be set next to the resource. It's disabled if empty.
+	UsageTag string `json:"usage_tag,omitempty" yaml:"usage_tag,omitempty"`
 }
 
 // GetUsage will return the usage from the resource rt (ex: aws_instance)
@@ -140,3 +216,53 @@ func (u Usage) GetUsage(rt string) map[string]interface{} {
 
 	return nil
 }
+
+// IsDefault returns true if the usage of a resource of type rt (ex: aws_instance) with the tags is the one of
+// the Default usage or if it has none, so the estimation of its usage is a guess. A resource with its own usage
+// on the UsageTag is never the default one.
+func (u Usage) IsDefault(rt string, tags map[string]string) bool {
+	if _, ok := tags[u.UsageTag]; ok && u.UsageTag != "" {
+		return false
+	}
+	us := u.GetUsage(rt)
+	return us == nil || reflect.DeepEqual(us, Default.GetUsage(rt))
+}
+
+// GetResourceUsage returns the usage of a resource of type rt (ex: aws_instance) with the usage
+// of the UsageTag of its tags merged, if any. If the tag is not a JSON object the error is
+// returned with the usage of the type.
+func (u Usage) GetResourceUsage(rt string, tags map[string]string) (map[string]interface{}, error) {
+	us := u.GetUsage(rt)
+	if u.UsageTag == "" {
+		return us, nil
+	}
+	tv, ok := tags[u.UsageTag]
+	if !ok {
+		return us, nil
+	}
+
+	var tus map[string]interface{}
+	if err := json.Unmarshal([]byte(tv), &tus); err != nil {
+		return us, fmt.Errorf("invalid usage on the tag %q: %w", u.UsageTag, err)
+	}
+	return merge(us, tus), nil
+}
+
+// merge returns a new map with the values of src set over the ones of dst,
+// the maps present on both are merged
+func merge(dst, src map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		m[k] = v
+	}
+	for k, v := range src {
+		dv, dok := m[k].(map[string]interface{})
+		sv, sok := v.(map[string]interface{})
+		if dok && sok {
+			m[k] = merge(dv, sv)
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
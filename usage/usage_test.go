@@ -5,6 +5,7 @@ import (
 
 	"github.com/cycloidio/terracost/usage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetUsage(t *testing.T) {
@@ -21,3 +22,72 @@ func TestGetUsage(t *testing.T) {
 	assert.Equal(t, eu, ru)
 
 }
+
+func TestGetResourceUsage(t *testing.T) {
+	us := usage.Usage{
+		ResourceDefaultTypeUsage: map[string]interface{}{
+			"aws_instance": map[string]interface{}{
+				"monthly_hours": 730,
+				"os_disk": map[string]interface{}{
+					"monthly_disk_operations": 100,
+				},
+			},
+		},
+		UsageTag: "terracost_usage",
+	}
+	tags := map[string]string{
+		"Name":            "test",
+		"terracost_usage": `{"monthly_hours": 200, "os_disk": {"storage_gb": 10}}`,
+	}
+
+	t.Run("Merged", func(t *testing.T) {
+		ru, err := us.GetResourceUsage("aws_instance", tags)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"monthly_hours": float64(200),
+			"os_disk": map[string]interface{}{
+				"monthly_disk_operations": 100,
+				"storage_gb":              float64(10),
+			},
+		}, ru)
+
+		// The usage of the type is not modified
+		assert.Equal(t, 730, us.GetUsage("aws_instance")["monthly_hours"])
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		ru, err := us.GetResourceUsage("aws_ebs_volume", tags)
+		require.NoError(t, err)
+		assert.Equal(t, float64(200), ru["monthly_hours"])
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		ru, err := usage.Usage{ResourceDefaultTypeUsage: us.ResourceDefaultTypeUsage}.GetResourceUsage("aws_instance", tags)
+		require.NoError(t, err)
+		assert.Equal(t, us.GetUsage("aws_instance"), ru)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		ru, err := us.GetResourceUsage("aws_instance", map[string]string{"terracost_usage": "200 hours"})
+		assert.Error(t, err)
+		assert.Equal(t, us.GetUsage("aws_instance"), ru)
+	})
+}
+
+func TestIsDefault(t *testing.T) {
+	us := usage.Usage{
+		ResourceDefaultTypeUsage: map[string]interface{}{
+			"aws_nat_gateway": usage.Default.GetUsage("aws_nat_gateway"),
+			"aws_instance": map[string]interface{}{
+				"monthly_hours": 200,
+			},
+		},
+		UsageTag: "terracost_usage",
+	}
+
+	assert.True(t, us.IsDefault("aws_nat_gateway", nil))
+	assert.True(t, us.IsDefault("aws_sqs_queue", nil), "no usage")
+	assert.False(t, us.IsDefault("aws_instance", nil))
+	assert.False(t, us.IsDefault("aws_nat_gateway", map[string]string{"terracost_usage": `{"monthly_data_processed_gb": 50}`}))
+	assert.True(t, usage.Default.IsDefault("aws_nat_gateway", map[string]string{"terracost_usage": "{}"}), "usage tag disabled")
+}